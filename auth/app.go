@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/go-github/v89/github"
+	"golang.org/x/oauth2"
 )
 
 // JWT constants for GitHub App authentication.
@@ -145,37 +147,22 @@ func expandPath(path string) string {
 }
 
 // NewAppClient creates a GitHub client authenticated as a GitHub App installation.
-// The client uses an installation access token that is valid for 1 hour.
+// Installation access tokens are valid for 1 hour; the client requests a new
+// one shortly before the current token expires.
 func NewAppClient(ctx context.Context, cfg *AppConfig) (*github.Client, error) {
-	privateKey := cfg.PrivateKey
-	if len(privateKey) == 0 {
-		var err error
-		privateKey, err = os.ReadFile(cfg.PrivateKeyPath)
-		if err != nil {
-			return nil, fmt.Errorf("reading private key: %w", err)
-		}
-	}
-
-	// Create JWT for App authentication
-	token, err := createAppJWT(cfg.AppID, privateKey)
-	if err != nil {
-		return nil, fmt.Errorf("creating JWT: %w", err)
-	}
-
-	// Create client with JWT auth to get installation token
-	jwtClient, err := github.NewClient(github.WithAuthToken(token))
+	ts, err := NewInstallationTokenSource(ctx, cfg, nil)
 	if err != nil {
-		return nil, fmt.Errorf("creating github client: %w", err)
+		return nil, err
 	}
 
-	// Get installation access token
-	installToken, _, err := jwtClient.Apps.CreateInstallationToken(ctx, cfg.InstallationID, nil)
-	if err != nil {
-		return nil, fmt.Errorf("creating installation token: %w", err)
+	// Get the first token now so configuration errors surface here.
+	if _, err := ts.Token(); err != nil {
+		return nil, err
 	}
 
-	// Create client with installation token
-	client, err := github.NewClient(github.WithAuthToken(installToken.GetToken()))
+	client, err := github.NewClient(github.WithHTTPClient(&http.Client{
+		Transport: &oauth2.Transport{Source: ts},
+	}))
 	if err != nil {
 		return nil, fmt.Errorf("creating github client: %w", err)
 	}
@@ -212,13 +199,9 @@ type AppInstallation struct {
 // ListAppInstallations lists all installations of the GitHub App.
 // This requires authenticating as the App (using JWT), not as an installation.
 func ListAppInstallations(ctx context.Context, cfg *AppConfig) ([]AppInstallation, error) {
-	privateKey := cfg.PrivateKey
-	if len(privateKey) == 0 {
-		var err error
-		privateKey, err = os.ReadFile(cfg.PrivateKeyPath)
-		if err != nil {
-			return nil, fmt.Errorf("reading private key: %w", err)
-		}
+	privateKey, err := readPrivateKey(cfg)
+	if err != nil {
+		return nil, err
 	}
 
 	token, err := createAppJWT(cfg.AppID, privateKey)
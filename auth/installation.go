@@ -0,0 +1,186 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v89/github"
+	"golang.org/x/oauth2"
+)
+
+// DefaultTokenRefreshBefore is how long before expiry an installation token
+// is replaced when InstallationTokenOptions.RefreshBefore is zero.
+const DefaultTokenRefreshBefore = 5 * time.Minute
+
+// InstallationTokenOptions configures an InstallationTokenSource.
+type InstallationTokenOptions struct {
+	// Repositories restricts the token to these repository names, which
+	// must belong to the installation's account. Empty means every
+	// repository the installation can access.
+	Repositories []string
+
+	// RepositoryIDs restricts the token to these repository IDs.
+	RepositoryIDs []int64
+
+	// Permissions narrows the token's permissions, keyed by GitHub's
+	// permission names, e.g. {"contents": "read", "pull_requests": "write"}.
+	// Empty means all permissions granted to the installation.
+	Permissions map[string]string
+
+	// RefreshBefore is how long before expiry the token is replaced.
+	// Default: DefaultTokenRefreshBefore.
+	RefreshBefore time.Duration
+
+	// BaseURL is the GitHub API base URL (for GitHub Enterprise).
+	// Leave empty for github.com.
+	BaseURL string
+
+	// HTTPClient is used to exchange the App JWT for installation tokens.
+	// Default: http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// InstallationTokenSource is an oauth2.TokenSource that returns GitHub App
+// installation access tokens. Tokens are cached and replaced shortly before
+// they expire, so clients built on it keep working past the one hour
+// lifetime of a single token. It is safe for concurrent use.
+type InstallationTokenSource struct {
+	ctx            context.Context
+	appID          int64
+	installationID int64
+	privateKey     []byte
+	body           *github.InstallationTokenOptions
+	refreshBefore  time.Duration
+	httpClient     *http.Client
+	baseURL        string
+
+	mu    sync.Mutex
+	token *oauth2.Token
+	now   func() time.Time
+}
+
+// NewInstallationTokenSource creates a token source for the installation in
+// cfg. No token is requested until Token is called. ctx is used for token
+// requests and must outlive the source. opts may be nil.
+func NewInstallationTokenSource(ctx context.Context, cfg *AppConfig, opts *InstallationTokenOptions) (*InstallationTokenSource, error) {
+	if opts == nil {
+		opts = &InstallationTokenOptions{}
+	}
+	privateKey, err := readPrivateKey(cfg)
+	if err != nil {
+		return nil, err
+	}
+	perms, err := installationPermissions(opts.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &InstallationTokenSource{
+		ctx:            ctx,
+		appID:          cfg.AppID,
+		installationID: cfg.InstallationID,
+		privateKey:     privateKey,
+		refreshBefore:  opts.RefreshBefore,
+		httpClient:     opts.HTTPClient,
+		baseURL:        opts.BaseURL,
+		now:            time.Now,
+	}
+	if s.refreshBefore == 0 {
+		s.refreshBefore = DefaultTokenRefreshBefore
+	}
+	if len(opts.Repositories) > 0 || len(opts.RepositoryIDs) > 0 || perms != nil {
+		s.body = &github.InstallationTokenOptions{
+			Repositories:  slices.Clone(opts.Repositories),
+			RepositoryIDs: slices.Clone(opts.RepositoryIDs),
+			Permissions:   perms,
+		}
+	}
+	return s, nil
+}
+
+// Token returns the cached installation token, requesting a new one if
+// there is none or it expires within RefreshBefore. It implements
+// oauth2.TokenSource.
+func (s *InstallationTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != nil && s.now().Add(s.refreshBefore).Before(s.token.Expiry) {
+		return s.token, nil
+	}
+
+	jwtToken, err := createAppJWT(s.appID, s.privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("creating JWT: %w", err)
+	}
+	ghOpts := []github.ClientOptionsFunc{github.WithAuthToken(jwtToken)}
+	if s.httpClient != nil {
+		ghOpts = append(ghOpts, github.WithHTTPClient(s.httpClient))
+	}
+	if s.baseURL != "" {
+		ghOpts = append(ghOpts, github.WithEnterpriseURLs(s.baseURL, s.baseURL))
+	}
+	jwtClient, err := github.NewClient(ghOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating github client: %w", err)
+	}
+
+	it, _, err := jwtClient.Apps.CreateInstallationToken(s.ctx, s.installationID, s.body)
+	if err != nil {
+		return nil, &AuthError{Message: "creating installation token: " + err.Error(), Err: err}
+	}
+	s.token = &oauth2.Token{
+		AccessToken: it.GetToken(),
+		TokenType:   "Bearer",
+		Expiry:      it.GetExpiresAt().Time,
+	}
+	return s.token, nil
+}
+
+// installationPermissions converts permission names and access levels to
+// the API's permissions object, rejecting names it does not know.
+func installationPermissions(perms map[string]string) (*github.InstallationPermissions, error) {
+	if len(perms) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(perms)
+	if err != nil {
+		return nil, err
+	}
+	var p github.InstallationPermissions
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("invalid permissions: %w", err)
+	}
+	// Unknown names are dropped by Unmarshal; find them by round-tripping.
+	data, err = json.Marshal(&p)
+	if err != nil {
+		return nil, err
+	}
+	var known map[string]string
+	if err := json.Unmarshal(data, &known); err != nil {
+		return nil, err
+	}
+	for name := range perms {
+		if _, ok := known[name]; !ok {
+			return nil, fmt.Errorf("unknown permission %q", name)
+		}
+	}
+	return &p, nil
+}
+
+// readPrivateKey returns cfg.PrivateKey, or reads it from cfg.PrivateKeyPath.
+func readPrivateKey(cfg *AppConfig) ([]byte, error) {
+	if len(cfg.PrivateKey) > 0 {
+		return cfg.PrivateKey, nil
+	}
+	privateKey, err := os.ReadFile(cfg.PrivateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading private key: %w", err)
+	}
+	return privateKey, nil
+}
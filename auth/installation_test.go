@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testPrivateKey(t *testing.T) []byte {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+// tokenServer issues installation tokens that expire an hour after clock,
+// numbering them so refreshes can be told apart. It records the last
+// request body.
+func tokenServer(t *testing.T, clock func() time.Time) (srv *httptest.Server, issued *int32, lastBody *map[string]any) {
+	t.Helper()
+	issued, lastBody = new(int32), new(map[string]any)
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v3/app/installations/42/access_tokens" {
+			http.NotFound(w, r)
+			return
+		}
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ey") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		*lastBody = nil
+		_ = json.NewDecoder(r.Body).Decode(lastBody)
+		n := atomic.AddInt32(issued, 1)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"token":"ghs_%d","expires_at":%q}`, n, clock().Add(time.Hour).Format(time.RFC3339))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, issued, lastBody
+}
+
+func TestInstallationTokenSourceRefresh(t *testing.T) {
+	now := time.Now()
+	clock := func() time.Time { return now }
+	srv, issued, _ := tokenServer(t, clock)
+	cfg := &AppConfig{AppID: 1, InstallationID: 42, PrivateKey: testPrivateKey(t)}
+	ts, err := NewInstallationTokenSource(context.Background(), cfg, &InstallationTokenOptions{BaseURL: srv.URL})
+	if err != nil {
+		t.Fatalf("NewInstallationTokenSource() error = %v", err)
+	}
+	ts.now = clock
+
+	tests := []struct {
+		advance time.Duration
+		want    string
+	}{
+		{0, "ghs_1"},
+		{30 * time.Minute, "ghs_1"}, // cached
+		{26 * time.Minute, "ghs_2"}, // within DefaultTokenRefreshBefore of expiry
+		{time.Minute, "ghs_2"},      // cached again
+	}
+	for _, tt := range tests {
+		now = now.Add(tt.advance)
+		tok, err := ts.Token()
+		if err != nil {
+			t.Fatalf("Token() error = %v", err)
+		}
+		if tok.AccessToken != tt.want {
+			t.Errorf("Token() after %s = %q, want %q", tt.advance, tok.AccessToken, tt.want)
+		}
+	}
+	if *issued != 2 {
+		t.Errorf("tokens issued = %d, want 2", *issued)
+	}
+}
+
+func TestInstallationTokenSourceNarrowing(t *testing.T) {
+	srv, _, body := tokenServer(t, time.Now)
+	cfg := &AppConfig{AppID: 1, InstallationID: 42, PrivateKey: testPrivateKey(t)}
+	ts, err := NewInstallationTokenSource(context.Background(), cfg, &InstallationTokenOptions{
+		BaseURL:      srv.URL,
+		Repositories: []string{"hello-world"},
+		Permissions:  map[string]string{"contents": "read", "pull_requests": "write"},
+	})
+	if err != nil {
+		t.Fatalf("NewInstallationTokenSource() error = %v", err)
+	}
+	if _, err := ts.Token(); err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+
+	got, _ := json.Marshal(*body)
+	want := `{"permissions":{"contents":"read","pull_requests":"write"},"repositories":["hello-world"]}`
+	if string(got) != want {
+		t.Errorf("request body = %s, want %s", got, want)
+	}
+
+	_, err = NewInstallationTokenSource(context.Background(), cfg, &InstallationTokenOptions{
+		Permissions: map[string]string{"contentz": "read"},
+	})
+	if err == nil || !strings.Contains(err.Error(), `"contentz"`) {
+		t.Errorf("unknown permission error = %v, want error naming it", err)
+	}
+}
@@ -0,0 +1,80 @@
+package clientv1
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/grokify/gogithub/auth"
+)
+
+// AppInstallationOptions configures NewAppInstallationClient.
+type AppInstallationOptions struct {
+	// Repositories restricts the installation token to these repository
+	// names. Empty means every repository the installation can access.
+	Repositories []string
+
+	// RepositoryIDs restricts the installation token to these repository IDs.
+	RepositoryIDs []int64
+
+	// Permissions narrows the token's permissions, keyed by GitHub's
+	// permission names, e.g. {"contents": "read", "pull_requests": "write"}.
+	// Empty means all permissions granted to the installation.
+	Permissions map[string]string
+
+	// RefreshBefore is how long before expiry the installation token is
+	// replaced. Default: auth.DefaultTokenRefreshBefore.
+	RefreshBefore time.Duration
+
+	// Client configures the client as for NewClientWithOptions. Its Token
+	// field is ignored. Token requests use its BaseURL and go through its
+	// transports and middleware, like API calls.
+	Client ClientOptions
+}
+
+// NewAppInstallationClient creates a client authenticated as the GitHub App
+// installation in cfg. It signs a JWT with the App's private key, exchanges
+// it for an installation access token, and replaces the token shortly
+// before it expires, so long-running processes keep working past the one
+// hour token lifetime. ctx is used for token requests and must outlive the
+// client. opts may be nil.
+//
+// The first token is requested before NewAppInstallationClient returns, so
+// a bad key, App ID or installation ID is reported here.
+func NewAppInstallationClient(ctx context.Context, cfg *auth.AppConfig, opts *AppInstallationOptions) (Client, error) {
+	if opts == nil {
+		opts = &AppInstallationOptions{}
+	}
+	t, err := newTransport(ctx, opts.Client)
+	if err != nil {
+		return nil, err
+	}
+	// Token requests carry their own JWT authentication but otherwise go
+	// through the same transports and middleware as API calls.
+	ts, err := appTokenSource(ctx, cfg, opts, t.httpClient(opts.Client, func(next http.RoundTripper) http.RoundTripper {
+		return next
+	}))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ts.Token(); err != nil {
+		return nil, err
+	}
+	// The token source caches and refreshes tokens itself, so it is used
+	// directly rather than through oauth2.ReuseTokenSource, which would only
+	// refresh at the last moment.
+	return t.client(opts.Client, tokenAuth(ts))
+}
+
+// appTokenSource creates an installation token source narrowed by opts
+// that requests tokens through hc.
+func appTokenSource(ctx context.Context, cfg *auth.AppConfig, opts *AppInstallationOptions, hc *http.Client) (*auth.InstallationTokenSource, error) {
+	return auth.NewInstallationTokenSource(ctx, cfg, &auth.InstallationTokenOptions{
+		Repositories:  opts.Repositories,
+		RepositoryIDs: opts.RepositoryIDs,
+		Permissions:   opts.Permissions,
+		RefreshBefore: opts.RefreshBefore,
+		BaseURL:       opts.Client.BaseURL,
+		HTTPClient:    hc,
+	})
+}
@@ -0,0 +1,134 @@
+package clientv1
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/grokify/gogithub/auth"
+	"golang.org/x/oauth2"
+)
+
+func TestNewAppInstallationClient(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	var issued int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/app/installations/7/access_tokens":
+			n := atomic.AddInt32(&issued, 1)
+			w.WriteHeader(http.StatusCreated)
+			// Tokens expire within the refresh window, so each API call
+			// needs a new one.
+			fmt.Fprintf(w, `{"token":"ghs_%d","expires_at":%q}`, n, time.Now().Add(time.Minute).Format(time.RFC3339))
+		case "/api/v3/repos/octocat/hello-world":
+			if got, want := r.Header.Get("Authorization"), fmt.Sprintf("Bearer ghs_%d", atomic.LoadInt32(&issued)); got != want {
+				t.Errorf("Authorization = %q, want %q", got, want)
+			}
+			_, _ = io.WriteString(w, `{"id":1,"name":"hello-world","full_name":"octocat/hello-world"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	cfg := &auth.AppConfig{AppID: 1, InstallationID: 7, PrivateKey: pemKey}
+	c, err := NewAppInstallationClient(context.Background(), cfg, &AppInstallationOptions{
+		Repositories: []string{"hello-world"},
+		Client:       ClientOptions{BaseURL: srv.URL + "/", UploadURL: srv.URL + "/"},
+	})
+	if err != nil {
+		t.Fatalf("NewAppInstallationClient() error = %v", err)
+	}
+	for range 2 {
+		repo, err := c.GetRepository(context.Background(), "octocat", "hello-world")
+		if err != nil {
+			t.Fatalf("GetRepository() error = %v", err)
+		}
+		if repo.FullName != "octocat/hello-world" {
+			t.Errorf("FullName = %q, want %q", repo.FullName, "octocat/hello-world")
+		}
+	}
+	if issued != 3 {
+		t.Errorf("tokens issued = %d, want 3", issued)
+	}
+
+	cfg.InstallationID = 8
+	if _, err := NewAppInstallationClient(context.Background(), cfg, &AppInstallationOptions{
+		Client: ClientOptions{BaseURL: srv.URL + "/", UploadURL: srv.URL + "/"},
+	}); err == nil {
+		t.Error("NewAppInstallationClient() with unknown installation error = nil")
+	}
+}
+
+func TestNewAppInstallationClientTransport(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/app/installations/7/access_tokens":
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"token":"ghs_1","expires_at":%q}`, time.Now().Add(time.Hour).Format(time.RFC3339))
+		case "/api/v3/repos/octocat/hello-world":
+			_, _ = io.WriteString(w, `{"id":1,"name":"hello-world","full_name":"octocat/hello-world"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	// Both the ctx client's transport and the middleware must see the
+	// token request as well as the API call.
+	var base, middleware []string
+	hc := &http.Client{Transport: RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		base = append(base, req.Method+" "+req.URL.Path)
+		return http.DefaultTransport.RoundTrip(req)
+	})}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, hc)
+	cfg := &auth.AppConfig{AppID: 1, InstallationID: 7, PrivateKey: pemKey}
+	c, err := NewAppInstallationClient(ctx, cfg, &AppInstallationOptions{
+		Client: ClientOptions{
+			BaseURL:   srv.URL + "/",
+			UploadURL: srv.URL + "/",
+			Middleware: []Middleware{func(next http.RoundTripper) http.RoundTripper {
+				return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					middleware = append(middleware, req.Method+" "+req.URL.Path)
+					return next.RoundTrip(req)
+				})
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewAppInstallationClient() error = %v", err)
+	}
+	if _, err := c.GetRepository(ctx, "octocat", "hello-world"); err != nil {
+		t.Fatalf("GetRepository() error = %v", err)
+	}
+
+	want := []string{
+		"POST /api/v3/app/installations/7/access_tokens",
+		"GET /api/v3/repos/octocat/hello-world",
+	}
+	for name, got := range map[string][]string{"transport": base, "middleware": middleware} {
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("%s requests = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package clientv1
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// headerFromCache is set on responses served from the cache, either
+// directly or after a 304 revalidation.
+const headerFromCache = "X-From-Cache"
+
+// CacheTTL overrides the cache TTL for requests whose path matches Pattern.
+//
+// Pattern uses path.Match syntax and is compared with the trailing segments
+// of the request path, so "/repos/*/*/languages" matches on github.com and
+// on GitHub Enterprise ("/api/v3/repos/o/r/languages") alike.
+type CacheTTL struct {
+	Pattern string
+	// TTL is how long a cached response is served without revalidation.
+	// Zero always revalidates; a negative TTL disables caching for the
+	// endpoint.
+	TTL time.Duration
+}
+
+// CacheOptions configures the conditional-request cache.
+type CacheOptions struct {
+	// Store holds cached responses. Default: a new MemoryCacheStore.
+	Store CacheStore
+
+	// TTL is how long a cached response is served without contacting
+	// GitHub. Zero (the default) revalidates every request with
+	// If-None-Match/If-Modified-Since; 304 responses do not count against
+	// the rate limit.
+	TTL time.Duration
+
+	// TTLOverrides sets per-endpoint TTLs. The first matching pattern wins.
+	TTLOverrides []CacheTTL
+
+	// Bypass skips cache lookups. Responses are still stored, so a bypassed
+	// run refreshes the cache for later runs.
+	Bypass bool
+
+	// Purge empties the store when the client is created.
+	Purge bool
+}
+
+// CacheTransport is an http.RoundTripper that caches GET responses and
+// revalidates them with ETag and Last-Modified. Create one with
+// NewCacheTransport.
+type CacheTransport struct {
+	base http.RoundTripper
+	opts CacheOptions
+	now  func() time.Time
+}
+
+// NewCacheTransport wraps base with a response cache. If base is nil,
+// http.DefaultTransport is used. It returns an error only if opts.Purge is
+// set and the store cannot be purged.
+func NewCacheTransport(base http.RoundTripper, opts CacheOptions) (*CacheTransport, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if opts.Store == nil {
+		opts.Store = NewMemoryCacheStore()
+	}
+	if opts.Purge {
+		if err := opts.Store.Purge(); err != nil {
+			return nil, err
+		}
+	}
+	return &CacheTransport{base: base, opts: opts, now: time.Now}, nil
+}
+
+// Store returns the transport's cache store.
+func (t *CacheTransport) Store() CacheStore {
+	return t.opts.Store
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *CacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ttl := t.ttl(req.URL.Path)
+	if req.Method != http.MethodGet || ttl < 0 || hasConditionalHeaders(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	key := cacheKey(req)
+	var cached *CacheEntry
+	if !t.opts.Bypass {
+		if entry, ok, err := t.opts.Store.Get(key); err == nil && ok {
+			cached = entry
+		}
+	}
+
+	if cached != nil && ttl > 0 && t.now().Before(cached.StoredAt.Add(ttl)) {
+		resp := cached.response(req)
+		// Quota headers in a stored response are stale.
+		for k := range resp.Header {
+			if strings.HasPrefix(k, "X-Ratelimit-") {
+				resp.Header.Del(k)
+			}
+		}
+		return resp, nil
+	}
+
+	r := req
+	if cached != nil {
+		r = req.Clone(req.Context())
+		if cached.ETag != "" {
+			r.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			r.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		drainBody(resp)
+		cached.StoredAt = t.now()
+		for k, v := range resp.Header {
+			if strings.HasPrefix(k, "X-Ratelimit-") || k == "Date" {
+				cached.Header[k] = v
+			}
+		}
+		_ = t.opts.Store.Set(key, cached)
+		return cached.response(req), nil
+
+	case resp.StatusCode == http.StatusOK:
+		etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+		if etag == "" && lastModified == "" && ttl == 0 {
+			return resp, nil
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		_ = t.opts.Store.Set(key, &CacheEntry{
+			StatusCode:   resp.StatusCode,
+			Header:       resp.Header.Clone(),
+			Body:         body,
+			ETag:         etag,
+			LastModified: lastModified,
+			StoredAt:     t.now(),
+		})
+		return resp, nil
+
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		_ = t.opts.Store.Delete(key)
+	}
+	return resp, nil
+}
+
+// ttl returns the TTL that applies to a request path.
+func (t *CacheTransport) ttl(p string) time.Duration {
+	for _, o := range t.opts.TTLOverrides {
+		if matchTrailingPath(o.Pattern, p) {
+			return o.TTL
+		}
+	}
+	return t.opts.TTL
+}
+
+// matchTrailingPath reports whether pattern matches the last segments of
+// p, one pattern segment per path segment.
+func matchTrailingPath(pattern, p string) bool {
+	pattern = strings.Trim(pattern, "/")
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	n := strings.Count(pattern, "/") + 1
+	if n > len(segments) {
+		return false
+	}
+	ok, err := path.Match(pattern, strings.Join(segments[len(segments)-n:], "/"))
+	return err == nil && ok
+}
+
+// cacheKey identifies a response by URL, accepted media type and
+// credential. The credential is hashed so responses visible to one token
+// are never served to another, and no secret is stored.
+func cacheKey(req *http.Request) string {
+	h := sha256.New()
+	for _, s := range []string{
+		req.Method,
+		req.URL.String(),
+		req.Header.Get("Accept"),
+		req.Header.Get("Authorization"),
+	} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func hasConditionalHeaders(req *http.Request) bool {
+	return req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != ""
+}
+
+// response builds an http.Response for req from the cached entry.
+func (e *CacheEntry) response(req *http.Request) *http.Response {
+	header := e.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	header.Set(headerFromCache, "1")
+	header.Set("Content-Length", strconv.Itoa(len(e.Body)))
+	return &http.Response{
+		Status:        strconv.Itoa(e.StatusCode) + " " + http.StatusText(e.StatusCode),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,190 @@
+package clientv1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheEntry is a cached HTTP response.
+type CacheEntry struct {
+	StatusCode   int         `json:"statusCode"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"lastModified,omitempty"`
+	StoredAt     time.Time   `json:"storedAt"`
+}
+
+// CacheStore stores cached responses by key. Implementations must be safe
+// for concurrent use.
+type CacheStore interface {
+	// Get returns the entry for key. ok is false if there is none.
+	Get(key string) (entry *CacheEntry, ok bool, err error)
+	// Set stores entry under key, replacing any existing entry.
+	Set(key string, entry *CacheEntry) error
+	// Delete removes the entry for key, if any.
+	Delete(key string) error
+	// Purge removes all entries.
+	Purge() error
+}
+
+// MemoryCacheStore is a CacheStore held in memory for the life of the
+// process.
+type MemoryCacheStore struct {
+	mu      sync.RWMutex
+	entries map[string]*CacheEntry
+}
+
+// NewMemoryCacheStore creates an empty in-memory store.
+func NewMemoryCacheStore() *MemoryCacheStore {
+	return &MemoryCacheStore{entries: make(map[string]*CacheEntry)}
+}
+
+// Get implements CacheStore.
+func (s *MemoryCacheStore) Get(key string) (*CacheEntry, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	e, ok := s.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	cp := *e
+	cp.Header = e.Header.Clone()
+	return &cp, true, nil
+}
+
+// Set implements CacheStore.
+func (s *MemoryCacheStore) Set(key string, entry *CacheEntry) error {
+	cp := *entry
+	cp.Header = entry.Header.Clone()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = &cp
+	return nil
+}
+
+// Delete implements CacheStore.
+func (s *MemoryCacheStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
+
+// Purge implements CacheStore.
+func (s *MemoryCacheStore) Purge() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = make(map[string]*CacheEntry)
+	return nil
+}
+
+// Len returns the number of cached entries.
+func (s *MemoryCacheStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.entries)
+}
+
+// DirCacheStore is a CacheStore that keeps one JSON file per entry in a
+// directory, so the cache survives across runs. Keys are used as file
+// names; CacheTransport keys are hex digests.
+type DirCacheStore struct {
+	dir string
+}
+
+// dirCacheExt is the file extension of DirCacheStore entries. Purge only
+// removes files with this extension.
+const dirCacheExt = ".httpcache.json"
+
+// NewDirCacheStore creates a store in dir, creating the directory if
+// needed.
+func NewDirCacheStore(dir string) (*DirCacheStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create cache dir: %w", err)
+	}
+	return &DirCacheStore{dir: dir}, nil
+}
+
+// Dir returns the store's directory.
+func (s *DirCacheStore) Dir() string {
+	return s.dir
+}
+
+// Get implements CacheStore. Unreadable or corrupt entries are treated as
+// missing.
+func (s *DirCacheStore) Get(key string) (*CacheEntry, bool, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	var entry CacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false, nil
+	}
+	return &entry, true, nil
+}
+
+// Set implements CacheStore. Entries are written to a temporary file and
+// renamed into place so concurrent readers never see partial data.
+func (s *DirCacheStore) Set(key string, entry *CacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(key))
+}
+
+// Delete implements CacheStore.
+func (s *DirCacheStore) Delete(key string) error {
+	err := os.Remove(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// Purge implements CacheStore. Files not written by the store are left in
+// place.
+func (s *DirCacheStore) Purge() error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), dirCacheExt) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.dir, e.Name())); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *DirCacheStore) path(key string) string {
+	return filepath.Join(s.dir, key+dirCacheExt)
+}
@@ -0,0 +1,206 @@
+package clientv1
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// etagServer serves body with a fixed ETag, answering 304 when the client
+// already has it. It counts full and conditional responses.
+func etagServer(t *testing.T, body string) (srv *httptest.Server, full, notModified *int32) {
+	t.Helper()
+	full, notModified = new(int32), new(int32)
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		atomic.AddInt32(full, 1)
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, full, notModified
+}
+
+func get(t *testing.T, rt http.RoundTripper, url string) (*http.Response, string) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	return resp, string(body)
+}
+
+func TestCacheTransportRevalidates(t *testing.T) {
+	srv, full, notModified := etagServer(t, `{"Go":100}`)
+	ct, err := NewCacheTransport(nil, CacheOptions{})
+	if err != nil {
+		t.Fatalf("NewCacheTransport() error = %v", err)
+	}
+
+	for i := range 3 {
+		resp, body := get(t, ct, srv.URL+"/repos/o/r/languages")
+		if resp.StatusCode != http.StatusOK || body != `{"Go":100}` {
+			t.Errorf("request %d = %d %q, want 200 with body", i, resp.StatusCode, body)
+		}
+		if fromCache := resp.Header.Get(headerFromCache) != ""; fromCache != (i > 0) {
+			t.Errorf("request %d from cache = %v, want %v", i, fromCache, i > 0)
+		}
+	}
+	if *full != 1 || *notModified != 2 {
+		t.Errorf("full = %d, 304 = %d, want 1 and 2", *full, *notModified)
+	}
+}
+
+func TestCacheTransportTTL(t *testing.T) {
+	srv, full, notModified := etagServer(t, `[]`)
+	ct, err := NewCacheTransport(nil, CacheOptions{
+		TTLOverrides: []CacheTTL{
+			{Pattern: "/repos/*/*/languages", TTL: time.Hour},
+			{Pattern: "/user", TTL: -1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewCacheTransport() error = %v", err)
+	}
+	now := time.Now()
+	ct.now = func() time.Time { return now }
+
+	// Enterprise-style prefix still matches the override.
+	get(t, ct, srv.URL+"/api/v3/repos/o/r/languages")
+	resp, _ := get(t, ct, srv.URL+"/api/v3/repos/o/r/languages")
+	if resp.Header.Get("X-RateLimit-Remaining") != "" {
+		t.Error("fresh cache hit kept stale rate limit headers")
+	}
+	if *full != 1 || *notModified != 0 {
+		t.Errorf("within TTL: full = %d, 304 = %d, want 1 and 0", *full, *notModified)
+	}
+
+	now = now.Add(2 * time.Hour)
+	get(t, ct, srv.URL+"/api/v3/repos/o/r/languages")
+	if *notModified != 1 {
+		t.Errorf("after TTL: 304 = %d, want 1", *notModified)
+	}
+
+	// A negative TTL disables caching.
+	get(t, ct, srv.URL+"/user")
+	get(t, ct, srv.URL+"/user")
+	if *full != 3 {
+		t.Errorf("uncached endpoint: full = %d, want 3", *full)
+	}
+}
+
+func TestCacheTransportBypassAndPurge(t *testing.T) {
+	srv, full, _ := etagServer(t, `{}`)
+	store, err := NewDirCacheStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDirCacheStore() error = %v", err)
+	}
+
+	ct, _ := NewCacheTransport(nil, CacheOptions{Store: store, Bypass: true})
+	get(t, ct, srv.URL+"/orgs/o/repos")
+	get(t, ct, srv.URL+"/orgs/o/repos")
+	if *full != 2 {
+		t.Errorf("bypass: full = %d, want 2", *full)
+	}
+
+	// A later run reuses what the bypassed run stored.
+	ct, _ = NewCacheTransport(nil, CacheOptions{Store: store})
+	resp, body := get(t, ct, srv.URL+"/orgs/o/repos")
+	if *full != 2 || resp.Header.Get(headerFromCache) == "" || body != `{}` {
+		t.Errorf("after bypass: full = %d, body %q, want cached", *full, body)
+	}
+
+	ct, err = NewCacheTransport(nil, CacheOptions{Store: store, Purge: true})
+	if err != nil {
+		t.Fatalf("NewCacheTransport(Purge) error = %v", err)
+	}
+	get(t, ct, srv.URL+"/orgs/o/repos")
+	if *full != 3 {
+		t.Errorf("after purge: full = %d, want 3", *full)
+	}
+}
+
+func TestCacheKeySeparatesCredentials(t *testing.T) {
+	a, _ := http.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+	b := a.Clone(context.Background())
+	a.Header.Set("Authorization", "Bearer one")
+	b.Header.Set("Authorization", "Bearer two")
+	if cacheKey(a) == cacheKey(b) {
+		t.Error("cacheKey() equal for different credentials")
+	}
+}
+
+func TestMatchTrailingPath(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"/repos/*/*/languages", "/repos/o/r/languages", true},
+		{"/repos/*/*/languages", "/api/v3/repos/o/r/languages", true},
+		{"/repos/*/*/languages", "/repos/o/r/releases", false},
+		{"/repos/*/*", "/repos/o/r/languages", false},
+		{"/user", "/user", true},
+		{"/repos/*/*/languages", "/languages", false},
+	}
+	for _, tt := range tests {
+		if got := matchTrailingPath(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("matchTrailingPath(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestNewClientWithOptionsCache(t *testing.T) {
+	var full, notModified int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"u1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		atomic.AddInt32(&full, 1)
+		w.Header().Set("ETag", `"u1"`)
+		_, _ = io.WriteString(w, `{"login":"octocat","id":1}`)
+	}))
+	defer srv.Close()
+
+	c, err := NewClientWithOptions(context.Background(), ClientOptions{
+		Token:     "token",
+		BaseURL:   srv.URL + "/",
+		UploadURL: srv.URL + "/",
+		Cache:     &CacheOptions{},
+	})
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+	for range 2 {
+		user, err := c.GetUser(context.Background(), "octocat")
+		if err != nil {
+			t.Fatalf("GetUser() error = %v", err)
+		}
+		if user.Login != "octocat" {
+			t.Errorf("Login = %q, want %q", user.Login, "octocat")
+		}
+	}
+	if full != 1 || notModified != 1 {
+		t.Errorf("full = %d, 304 = %d, want 1 and 1", full, notModified)
+	}
+}
@@ -0,0 +1,356 @@
+package clientv1
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// CassetteMode selects whether a CassetteTransport records or replays.
+type CassetteMode int
+
+const (
+	// CassetteReplay serves responses from an existing cassette file.
+	CassetteReplay CassetteMode = iota
+	// CassetteRecord sends requests to GitHub and writes each request and
+	// response to a new cassette file, replacing any existing one.
+	CassetteRecord
+)
+
+// cassetteScrubHeaders are removed from recorded requests and responses.
+var cassetteScrubHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// CassetteOptions configures a CassetteTransport.
+type CassetteOptions struct {
+	// Path is the cassette file, e.g. "testdata/cassettes/TestName.json".
+	Path string
+
+	// Mode selects recording or replay. Default: CassetteReplay.
+	Mode CassetteMode
+
+	// Strict makes replay fail requests that have no unused recorded
+	// counterpart. Otherwise such requests replay the last matching
+	// interaction again or, if none matches, are sent to the network.
+	Strict bool
+
+	// Secrets are additional strings replaced with "REDACTED" in recorded
+	// URLs, headers and bodies. Authorization and cookie headers, the
+	// credentials in them, credential query parameters and GitHub-format
+	// tokens are always scrubbed, as in LoggingMiddleware.
+	Secrets []string
+}
+
+// CassetteInteraction is a recorded request and its response.
+type CassetteInteraction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CassetteRequest is a recorded request.
+type CassetteRequest struct {
+	Method   string      `json:"method"`
+	URL      string      `json:"url"`
+	Header   http.Header `json:"header,omitempty"`
+	Body     string      `json:"body,omitempty"`
+	Encoding string      `json:"encoding,omitempty"` // "base64" for binary bodies
+}
+
+// CassetteResponse is a recorded response.
+type CassetteResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	Encoding   string      `json:"encoding,omitempty"` // "base64" for binary bodies
+}
+
+// cassette is the file format of a cassette.
+type cassette struct {
+	RecordedAt   time.Time              `json:"recordedAt"`
+	Interactions []*CassetteInteraction `json:"interactions"`
+}
+
+// CassetteTransport is an http.RoundTripper that records HTTP traffic to a
+// cassette file or replays it from one, so tests written against the live
+// API can run without a network or token. Create one with
+// NewCassetteTransport. It is safe for concurrent use.
+//
+// Replayed requests are matched by method, path, query and body; headers
+// and host are ignored. Repeated identical requests are answered in the
+// order they were recorded.
+type CassetteTransport struct {
+	base http.RoundTripper
+	opts CassetteOptions
+
+	mu       sync.Mutex
+	cassette cassette
+	used     []bool
+}
+
+// NewCassetteTransport creates a transport that records through base or
+// replays from opts.Path. If base is nil, http.DefaultTransport is used. In
+// replay mode it returns an error wrapping fs.ErrNotExist if the cassette
+// does not exist.
+func NewCassetteTransport(base http.RoundTripper, opts CassetteOptions) (*CassetteTransport, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if opts.Path == "" {
+		return nil, fmt.Errorf("cassette: path is required")
+	}
+	t := &CassetteTransport{base: base, opts: opts}
+	switch opts.Mode {
+	case CassetteRecord:
+		t.cassette.RecordedAt = time.Now().UTC()
+		if err := t.save(); err != nil {
+			return nil, err
+		}
+	case CassetteReplay:
+		data, err := os.ReadFile(opts.Path)
+		if err != nil {
+			return nil, fmt.Errorf("cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &t.cassette); err != nil {
+			return nil, fmt.Errorf("cassette %s: %w", opts.Path, err)
+		}
+		t.used = make([]bool, len(t.cassette.Interactions))
+	default:
+		return nil, fmt.Errorf("cassette: unknown mode %d", opts.Mode)
+	}
+	return t, nil
+}
+
+// RecordedAt returns when the cassette was recorded. Tests that derive
+// request parameters from the current time should use it instead, so that
+// replayed requests match the recorded ones.
+func (t *CassetteTransport) RecordedAt() time.Time {
+	return t.cassette.RecordedAt
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *CassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if t.opts.Mode == CassetteRecord {
+		return t.record(req, body)
+	}
+	return t.replay(req, body)
+}
+
+func (t *CassetteTransport) record(req *http.Request, body []byte) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	resp, err := t.base.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	scrub := t.scrubber(req)
+	in := &CassetteInteraction{
+		Request: CassetteRequest{
+			Method: req.Method,
+			URL:    scrub(RedactURL(req.URL)),
+			Header: scrubHeader(req.Header, scrub),
+		},
+		Response: CassetteResponse{
+			StatusCode: resp.StatusCode,
+			Header:     scrubHeader(resp.Header, scrub),
+		},
+	}
+	in.Request.Body, in.Request.Encoding = encodeBody(body, scrub)
+	in.Response.Body, in.Response.Encoding = encodeBody(respBody, scrub)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cassette.Interactions = append(t.cassette.Interactions, in)
+	if err := t.save(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (t *CassetteTransport) replay(req *http.Request, body []byte) (*http.Response, error) {
+	t.mu.Lock()
+	match, last := -1, -1
+	for i, in := range t.cassette.Interactions {
+		if !in.Request.matches(req, body) {
+			continue
+		}
+		last = i
+		if !t.used[i] {
+			match = i
+			break
+		}
+	}
+	if match < 0 && !t.opts.Strict {
+		match = last
+	}
+	if match >= 0 {
+		t.used[match] = true
+	}
+	t.mu.Unlock()
+
+	switch {
+	case match >= 0:
+		return t.cassette.Interactions[match].Response.response(req)
+	case t.opts.Strict:
+		return nil, fmt.Errorf("cassette %s: no recorded response for %s %s", t.opts.Path, req.Method, req.URL.RequestURI())
+	default:
+		r := req.Clone(req.Context())
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		return t.base.RoundTrip(r)
+	}
+}
+
+// scrubber returns a function that redacts the credentials used by req
+// and opts.Secrets.
+func (t *CassetteTransport) scrubber(req *http.Request) func(string) string {
+	var secrets []string
+	for _, s := range t.opts.Secrets {
+		if s != "" {
+			secrets = append(secrets, s)
+		}
+	}
+	for _, h := range []string{"Authorization", "Proxy-Authorization"} {
+		if v := req.Header.Get(h); v != "" {
+			secrets = append(secrets, v)
+			if _, cred, ok := strings.Cut(v, " "); ok && cred != "" {
+				secrets = append(secrets, cred)
+			}
+		}
+	}
+	return func(s string) string {
+		for _, secret := range secrets {
+			s = strings.ReplaceAll(s, secret, redacted)
+		}
+		return redactSecrets(s)
+	}
+}
+
+// save writes the cassette to a temporary file and renames it into place.
+// t.mu must be held, or t not yet shared.
+func (t *CassetteTransport) save() error {
+	data, err := json.MarshalIndent(&t.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(t.opts.Path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create cassette dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), t.opts.Path)
+}
+
+// matches reports whether req, with body, is the recorded request.
+func (r *CassetteRequest) matches(req *http.Request, body []byte) bool {
+	if r.Method != req.Method {
+		return false
+	}
+	u, err := req.URL.Parse(r.URL)
+	if err != nil || u.Path != req.URL.Path || u.Query().Encode() != req.URL.Query().Encode() {
+		return false
+	}
+	recorded, err := decodeBody(r.Body, r.Encoding)
+	return err == nil && bytes.Equal(bytes.TrimSpace(recorded), bytes.TrimSpace(body))
+}
+
+// response builds the recorded response to req.
+func (r *CassetteResponse) response(req *http.Request) (*http.Response, error) {
+	body, err := decodeBody(r.Body, r.Encoding)
+	if err != nil {
+		return nil, fmt.Errorf("cassette: decode response body: %w", err)
+	}
+	header := r.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// readRequestBody reads req's body, leaving req.Body readable again.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// scrubHeader copies h without credential headers and with scrub applied
+// to the remaining values.
+func scrubHeader(h http.Header, scrub func(string) string) http.Header {
+	out := h.Clone()
+	for _, k := range cassetteScrubHeaders {
+		out.Del(k)
+	}
+	for k, vs := range out {
+		for i, v := range vs {
+			vs[i] = scrub(v)
+		}
+		out[k] = vs
+	}
+	return out
+}
+
+// encodeBody returns body as text, scrubbed, or base64 if it is binary.
+func encodeBody(body []byte, scrub func(string) string) (string, string) {
+	if utf8.Valid(body) {
+		return scrub(string(body)), ""
+	}
+	return base64.StdEncoding.EncodeToString(body), "base64"
+}
+
+func decodeBody(body, encoding string) ([]byte, error) {
+	if encoding == "base64" {
+		return base64.StdEncoding.DecodeString(body)
+	}
+	return []byte(body), nil
+}
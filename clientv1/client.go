@@ -2,6 +2,7 @@ package clientv1
 
 import (
 	"context"
+	"iter"
 	"time"
 
 	"github.com/grokify/gogithub"
@@ -32,9 +33,27 @@ type Client interface {
 	// only a collaborator or org member on).
 	ListUserReposWithOptions(ctx context.Context, user string, opts *ListUserReposOptions) ([]*gogithub.Repository, error)
 
+	// IterUserRepos iterates over a user's repositories, fetching pages
+	// lazily. opts filters as in ListUserReposWithOptions.
+	IterUserRepos(ctx context.Context, user string, opts *ListUserReposOptions, iterOpts *IterOptions) iter.Seq2[*gogithub.Repository, error]
+
 	// ListOrgRepos lists all repositories for an organization.
 	ListOrgRepos(ctx context.Context, org string) ([]*gogithub.Repository, error)
 
+	// IterOrgRepos iterates over an organization's repositories, fetching
+	// pages lazily.
+	IterOrgRepos(ctx context.Context, org string, iterOpts *IterOptions) iter.Seq2[*gogithub.Repository, error]
+
+	// Organizations
+
+	// ListOrgMembers lists the members of an organization. Members who
+	// keep their membership private are only listed to other members.
+	ListOrgMembers(ctx context.Context, org string) ([]*gogithub.User, error)
+
+	// ListTeamMembers lists the members of an organization's team, by
+	// team slug, including the members of its child teams.
+	ListTeamMembers(ctx context.Context, org, teamSlug string) ([]*gogithub.User, error)
+
 	// GetDefaultBranch returns the default branch name for a repository.
 	GetDefaultBranch(ctx context.Context, owner, repo string) (string, error)
 
@@ -103,11 +122,17 @@ type Client interface {
 	// ListBranches lists all branches in a repository.
 	ListBranches(ctx context.Context, owner, repo string) ([]*gogithub.Branch, error)
 
+	// IterBranches iterates over the branches in a repository.
+	IterBranches(ctx context.Context, owner, repo string, iterOpts *IterOptions) iter.Seq2[*gogithub.Branch, error]
+
 	// Tags
 
 	// ListTags lists all tags in a repository.
 	ListTags(ctx context.Context, owner, repo string) ([]*gogithub.Tag, error)
 
+	// IterTags iterates over the tags in a repository.
+	IterTags(ctx context.Context, owner, repo string, iterOpts *IterOptions) iter.Seq2[*gogithub.Tag, error]
+
 	// CreateTag creates an annotated tag.
 	CreateTag(ctx context.Context, owner, repo, tag, sha, message string) error
 
@@ -119,6 +144,14 @@ type Client interface {
 	// ListCommits lists commits in a repository.
 	ListCommits(ctx context.Context, owner, repo string, opts *ListCommitsOptions) ([]*gogithub.Commit, error)
 
+	// IterCommits iterates over commits in a repository.
+	IterCommits(ctx context.Context, owner, repo string, opts *ListCommitsOptions, iterOpts *IterOptions) iter.Seq2[*gogithub.Commit, error]
+
+	// GetGitCommit retrieves a commit object from the Git Data API. Unlike
+	// GetCommit it does not compute file diffs, so it is the cheaper way to
+	// read a commit's tree SHA and parents.
+	GetGitCommit(ctx context.Context, owner, repo, sha string) (*gogithub.Commit, error)
+
 	// CreateCommit creates a commit with the given tree and parent.
 	CreateCommit(ctx context.Context, owner, repo string, opts *CreateCommitOptions) (*gogithub.Commit, error)
 
@@ -127,7 +160,8 @@ type Client interface {
 	// GetTree retrieves a git tree by SHA.
 	GetTree(ctx context.Context, owner, repo, sha string, recursive bool) ([]*gogithub.TreeNode, error)
 
-	// CreateTree creates a git tree from file entries.
+	// CreateTree creates a git tree from file entries, layered on baseTree
+	// when it is non-empty. Entries with Delete set remove their path.
 	CreateTree(ctx context.Context, owner, repo, baseTree string, entries []TreeEntry) (string, error)
 
 	// Git Blobs
@@ -143,6 +177,9 @@ type Client interface {
 	// ListPullRequests lists pull requests in a repository.
 	ListPullRequests(ctx context.Context, owner, repo string, opts *ListPullRequestsOptions) ([]*gogithub.PullRequest, error)
 
+	// IterPullRequests iterates over pull requests in a repository.
+	IterPullRequests(ctx context.Context, owner, repo string, opts *ListPullRequestsOptions, iterOpts *IterOptions) iter.Seq2[*gogithub.PullRequest, error]
+
 	// CreatePullRequest creates a new pull request.
 	CreatePullRequest(ctx context.Context, owner, repo string, input *CreatePullRequestInput) (*gogithub.PullRequest, error)
 
@@ -155,6 +192,9 @@ type Client interface {
 	// ListPullRequestFiles lists files changed in a pull request.
 	ListPullRequestFiles(ctx context.Context, owner, repo string, number int) ([]*gogithub.CommitFile, error)
 
+	// IterPullRequestFiles iterates over the files changed in a pull request.
+	IterPullRequestFiles(ctx context.Context, owner, repo string, number int, iterOpts *IterOptions) iter.Seq2[*gogithub.CommitFile, error]
+
 	// GetPullRequestDiff gets the diff for a pull request.
 	GetPullRequestDiff(ctx context.Context, owner, repo string, number int) (string, error)
 
@@ -169,6 +209,9 @@ type Client interface {
 	// ListPullRequestReviews lists reviews on a pull request.
 	ListPullRequestReviews(ctx context.Context, owner, repo string, number int) ([]*gogithub.PullRequestReview, error)
 
+	// IterPullRequestReviews iterates over the reviews on a pull request.
+	IterPullRequestReviews(ctx context.Context, owner, repo string, number int, iterOpts *IterOptions) iter.Seq2[*gogithub.PullRequestReview, error]
+
 	// RequestReviewers requests reviewers for a pull request.
 	RequestReviewers(ctx context.Context, owner, repo string, number int, reviewers, teamReviewers []string) (*gogithub.PullRequest, error)
 
@@ -180,6 +223,9 @@ type Client interface {
 	// ListPullRequestComments lists comments on a pull request.
 	ListPullRequestComments(ctx context.Context, owner, repo string, number int) ([]*gogithub.PullRequestComment, error)
 
+	// IterPullRequestComments iterates over the comments on a pull request.
+	IterPullRequestComments(ctx context.Context, owner, repo string, number int, iterOpts *IterOptions) iter.Seq2[*gogithub.PullRequestComment, error]
+
 	// Issues
 
 	// GetIssue retrieves an issue by number.
@@ -188,6 +234,9 @@ type Client interface {
 	// ListIssues lists issues in a repository.
 	ListIssues(ctx context.Context, owner, repo string, opts *ListIssuesOptions) ([]*gogithub.Issue, error)
 
+	// IterIssues iterates over issues in a repository.
+	IterIssues(ctx context.Context, owner, repo string, opts *ListIssuesOptions, iterOpts *IterOptions) iter.Seq2[*gogithub.Issue, error]
+
 	// CreateIssue creates a new issue.
 	CreateIssue(ctx context.Context, owner, repo string, input *CreateIssueInput) (*gogithub.Issue, error)
 
@@ -203,6 +252,9 @@ type Client interface {
 	// ListIssueComments lists comments on an issue or pull request.
 	ListIssueComments(ctx context.Context, owner, repo string, number int) ([]*gogithub.IssueComment, error)
 
+	// IterIssueComments iterates over the comments on an issue or pull request.
+	IterIssueComments(ctx context.Context, owner, repo string, number int, iterOpts *IterOptions) iter.Seq2[*gogithub.IssueComment, error]
+
 	// Checks
 
 	// GetCheckRun retrieves a check run by ID.
@@ -211,9 +263,15 @@ type Client interface {
 	// ListCheckRuns lists check runs for a git reference.
 	ListCheckRuns(ctx context.Context, owner, repo, ref string) ([]*gogithub.CheckRun, error)
 
+	// IterCheckRuns iterates over check runs for a git reference.
+	IterCheckRuns(ctx context.Context, owner, repo, ref string, iterOpts *IterOptions) iter.Seq2[*gogithub.CheckRun, error]
+
 	// ListCheckSuites lists check suites for a git reference.
 	ListCheckSuites(ctx context.Context, owner, repo, ref string) ([]*gogithub.CheckSuite, error)
 
+	// IterCheckSuites iterates over check suites for a git reference.
+	IterCheckSuites(ctx context.Context, owner, repo, ref string, iterOpts *IterOptions) iter.Seq2[*gogithub.CheckSuite, error]
+
 	// Releases
 
 	// GetRelease retrieves a release by ID.
@@ -228,6 +286,9 @@ type Client interface {
 	// ListReleases lists all releases in a repository.
 	ListReleases(ctx context.Context, owner, repo string) ([]*gogithub.Release, error)
 
+	// IterReleases iterates over the releases in a repository.
+	IterReleases(ctx context.Context, owner, repo string, iterOpts *IterOptions) iter.Seq2[*gogithub.Release, error]
+
 	// CreateRelease creates a new release.
 	CreateRelease(ctx context.Context, owner, repo string, input *CreateReleaseInput) (*gogithub.Release, error)
 
@@ -240,6 +301,9 @@ type Client interface {
 	// ListReleaseAssets lists assets for a release.
 	ListReleaseAssets(ctx context.Context, owner, repo string, releaseID int64) ([]*gogithub.ReleaseAsset, error)
 
+	// IterReleaseAssets iterates over the assets of a release.
+	IterReleaseAssets(ctx context.Context, owner, repo string, releaseID int64, iterOpts *IterOptions) iter.Seq2[*gogithub.ReleaseAsset, error]
+
 	// Search
 
 	// SearchIssues searches for issues and pull requests.
@@ -265,23 +329,65 @@ type Client interface {
 	// timeline). GitHub's Events API only returns the most recent ~300 events.
 	ListUserEvents(ctx context.Context, username string, opts *ListUserEventsOptions) ([]*gogithub.Event, error)
 
+	// IterUserEvents iterates over activity events performed by a user.
+	IterUserEvents(ctx context.Context, username string, opts *ListUserEventsOptions, iterOpts *IterOptions) iter.Seq2[*gogithub.Event, error]
+
 	// Actions
 
 	// ListWorkflows lists the GitHub Actions workflows defined in a repository.
 	ListWorkflows(ctx context.Context, owner, repo string) ([]*gogithub.Workflow, error)
 
+	// IterWorkflows iterates over the GitHub Actions workflows defined in a
+	// repository.
+	IterWorkflows(ctx context.Context, owner, repo string, iterOpts *IterOptions) iter.Seq2[*gogithub.Workflow, error]
+
 	// ListWorkflowRuns lists runs of a workflow, most recent first. Unlike
 	// most List* methods, this does NOT paginate through all results — a
 	// workflow can accumulate thousands of runs, so it returns a single page
 	// per opts (PerPage defaults to GitHub's own default when opts is nil).
 	ListWorkflowRuns(ctx context.Context, owner, repo string, workflowID int64, opts *ListWorkflowRunsOptions) ([]*gogithub.WorkflowRun, error)
 
+	// IterWorkflowRuns iterates over all runs of a workflow, most recent
+	// first, starting from opts.Page when set. Pages are fetched lazily, so
+	// stopping early avoids walking a long history.
+	IterWorkflowRuns(ctx context.Context, owner, repo string, workflowID int64, opts *ListWorkflowRunsOptions, iterOpts *IterOptions) iter.Seq2[*gogithub.WorkflowRun, error]
+
+	// Code Scanning
+
+	// UploadSARIF uploads a SARIF analysis to Code Scanning. Processing is
+	// asynchronous; poll GetSARIFUpload with the returned ID.
+	UploadSARIF(ctx context.Context, owner, repo string, opts *UploadSARIFOptions) (*gogithub.SARIFUpload, error)
+
+	// GetSARIFUpload returns the processing status of a SARIF upload.
+	GetSARIFUpload(ctx context.Context, owner, repo, sarifID string) (*gogithub.SARIFUploadStatus, error)
+
+	// Generic Requests
+
+	// Do sends a request to an endpoint that has no dedicated method, such
+	// as Traffic or Pages. path is relative to the API base URL (e.g.
+	// "repos/octocat/hello-world/traffic/views") or an absolute URL such as
+	// a Response.NextURL. body, unless nil, is sent as JSON; the JSON
+	// response is decoded into out unless out is nil, or copied to out if
+	// it is an io.Writer. Errors are translated as by the other methods, and
+	// the response is returned with them when there is one. See DoAll for
+	// paginated endpoints.
+	Do(ctx context.Context, method, path string, body, out any) (*gogithub.Response, error)
+
 	// Raw returns the underlying go-github client for advanced use cases.
 	// WARNING: Using this couples your code to a specific go-github version.
 	// The returned value is *github.Client from the go-github package.
 	Raw() any
 }
 
+// IterOptions controls the Iter* methods. A nil *IterOptions uses the
+// defaults.
+type IterOptions struct {
+	// MaxItems stops iteration after this many items. Zero means no limit.
+	MaxItems int
+	// PerPage sets the page size. Default: 100, or MaxItems when smaller.
+	PerPage int
+}
+
 // ListCommitsOptions specifies options for listing commits.
 type ListCommitsOptions struct {
 	// SHA is the branch name or commit SHA to start from.
@@ -387,6 +493,7 @@ type TreeEntry struct {
 	Type    string // "blob", "tree", or "commit"
 	SHA     string // SHA of existing blob, or empty to use Content
 	Content string // File content (creates new blob)
+	Delete  bool   // Remove Path from the base tree; SHA and Content must be empty
 }
 
 // CreateReleaseInput specifies input for creating a release.
@@ -497,3 +604,20 @@ type ListWorkflowRunsOptions struct {
 	// Page selects which page of results to return. Default: 1.
 	Page int
 }
+
+// UploadSARIFOptions specifies a SARIF analysis to upload to Code Scanning.
+type UploadSARIFOptions struct {
+	// CommitSHA is the SHA of the analyzed commit. Required.
+	CommitSHA string
+	// Ref is the full Git reference of the analyzed commit, e.g.
+	// "refs/heads/main". Required.
+	Ref string
+	// Sarif is the gzip-compressed, base64-encoded SARIF document. Required.
+	Sarif string
+	// CheckoutURI is the URI of the repository checkout root. Optional.
+	CheckoutURI string
+	// ToolName overrides the tool name from the SARIF document. Optional.
+	ToolName string
+	// StartedAt is when the analysis started. Optional.
+	StartedAt *time.Time
+}
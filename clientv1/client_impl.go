@@ -3,10 +3,13 @@ package clientv1
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
+	"strings"
 
 	"github.com/google/go-github/v89/github"
 	"github.com/grokify/gogithub"
+	ghErrors "github.com/grokify/gogithub/errors"
 	"golang.org/x/oauth2"
 )
 
@@ -52,6 +55,20 @@ type ClientOptions struct {
 	// UploadURL is the GitHub upload URL (for GitHub Enterprise).
 	// Leave empty for github.com.
 	UploadURL string
+	// RateLimit, if set, installs a RateLimitTransport that waits out
+	// primary and secondary rate limits and retries transient failures.
+	RateLimit *RateLimitOptions
+	// Cache, if set, installs a CacheTransport that revalidates GET
+	// responses with ETag/Last-Modified and serves cached bodies on 304.
+	Cache *CacheOptions
+	// Middleware wraps the client's transport, outermost first. It runs
+	// above the rate limit and cache transports, so it sees each API call
+	// once, including time spent waiting and responses served from cache.
+	Middleware []Middleware
+	// Cassette, if set, installs a CassetteTransport beneath all other
+	// transports that records the client's HTTP traffic to a file or
+	// replays it from one.
+	Cassette *CassetteOptions
 }
 
 // NewClientWithOptions creates a new GitHub client with the given options.
@@ -60,13 +77,105 @@ func NewClientWithOptions(ctx context.Context, opts ClientOptions) (Client, erro
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: opts.Token},
 	)
-	tc := oauth2.NewClient(ctx, ts)
+	return newClient(ctx, opts, tokenAuth(ts))
+}
+
+// tokenAuth returns a middleware that authenticates requests with tokens
+// from ts.
+func tokenAuth(ts oauth2.TokenSource) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &oauth2.Transport{Source: ts, Base: next}
+	}
+}
+
+// newClient creates a client whose requests are authenticated by
+// authenticate. opts.Token is ignored. Like oauth2.NewClient, it sends
+// requests through the *http.Client in ctx under oauth2.HTTPClient, if any.
+func newClient(ctx context.Context, opts ClientOptions, authenticate Middleware) (Client, error) {
+	t, err := newTransport(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return t.client(opts, authenticate)
+}
+
+// transport is the unauthenticated part of a client's HTTP stack: the
+// *http.Client from ctx and the cassette and cache transports from opts.
+// Clients that make more than one kind of request, such as App
+// installation clients, share one transport so the cassette and cache are
+// opened once.
+type transport struct {
+	hc   *http.Client
+	base http.RoundTripper
+}
+
+// newTransport creates the transport for opts, based on the *http.Client in
+// ctx under oauth2.HTTPClient, if any.
+func newTransport(ctx context.Context, opts ClientOptions) (*transport, error) {
+	hc := contextHTTPClient(ctx)
+	base := hc.Transport
+	if opts.Cassette != nil {
+		ct, err := NewCassetteTransport(base, *opts.Cassette)
+		if err != nil {
+			return nil, err
+		}
+		base = ct
+	}
+	if opts.Cache != nil {
+		// The cache sits below authentication so its keys include the
+		// credential.
+		ct, err := NewCacheTransport(base, *opts.Cache)
+		if err != nil {
+			return nil, err
+		}
+		base = ct
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{hc: hc, base: base}, nil
+}
+
+// contextHTTPClient returns the *http.Client in ctx under
+// oauth2.HTTPClient, or http.DefaultClient.
+func contextHTTPClient(ctx context.Context) *http.Client {
+	if c, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok && c != nil {
+		return c
+	}
+	return http.DefaultClient
+}
+
+// httpClient returns an *http.Client that authenticates requests with
+// authenticate and sends them through t, opts' rate limit transport and
+// opts' middleware.
+func (t *transport) httpClient(opts ClientOptions, authenticate Middleware) *http.Client {
+	rt := authenticate(t.base)
+	if opts.RateLimit != nil {
+		rt = NewRateLimitTransport(rt, *opts.RateLimit)
+	}
+	if len(opts.Middleware) > 0 {
+		rt = Chain(rt, opts.Middleware...)
+	}
+	return &http.Client{
+		Transport:     rt,
+		CheckRedirect: t.hc.CheckRedirect,
+		Jar:           t.hc.Jar,
+		Timeout:       t.hc.Timeout,
+	}
+}
 
-	ghOpts := []github.ClientOptionsFunc{github.WithHTTPClient(tc)}
+// client creates a client whose requests are authenticated by
+// authenticate and sent through t.
+func (t *transport) client(opts ClientOptions, authenticate Middleware) (Client, error) {
+	ghOpts := []github.ClientOptionsFunc{github.WithHTTPClient(t.httpClient(opts, authenticate))}
+	if opts.RateLimit != nil {
+		// The transport waits instead of failing fast, so go-github must not
+		// reject requests pre-emptively once it has seen an exhausted quota.
+		ghOpts = append(ghOpts, github.WithDisableRateLimitCheck())
+	}
 	if opts.BaseURL != "" {
 		ghOpts = append(ghOpts, github.WithEnterpriseURLs(opts.BaseURL, opts.UploadURL))
 	}
-
 	gh, err := github.NewClient(ghOpts...)
 	if err != nil {
 		return nil, err
@@ -94,29 +203,42 @@ func (c *client) Raw() any {
 	return c.gh
 }
 
+// Do sends an API request and decodes its JSON response into out.
+func (c *client) Do(ctx context.Context, method, path string, body, out any) (*gogithub.Response, error) {
+	req, err := c.gh.NewRequest(ctx, method, strings.TrimPrefix(path, "/"), body)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	resp, err := c.gh.Do(req, out)
+	if err != nil {
+		return responseFromGitHub(resp), fmt.Errorf("%s %s: %w", method, path, ghErrors.Translate(err, resp))
+	}
+	return responseFromGitHub(resp), nil
+}
+
 // GetAuthenticatedUser returns the currently authenticated user.
 func (c *client) GetAuthenticatedUser(ctx context.Context) (*gogithub.User, error) {
-	u, _, err := c.gh.Users.Get(ctx, "")
+	u, resp, err := c.gh.Users.Get(ctx, "")
 	if err != nil {
-		return nil, err
+		return nil, ghErrors.Translate(err, resp)
 	}
 	return userFromGitHub(u), nil
 }
 
 // GetUser returns information about a specific user.
 func (c *client) GetUser(ctx context.Context, username string) (*gogithub.User, error) {
-	u, _, err := c.gh.Users.Get(ctx, username)
+	u, resp, err := c.gh.Users.Get(ctx, username)
 	if err != nil {
-		return nil, err
+		return nil, ghErrors.Translate(err, resp)
 	}
 	return userFromGitHub(u), nil
 }
 
 // GetRepository retrieves a repository by owner and name.
 func (c *client) GetRepository(ctx context.Context, owner, repo string) (*gogithub.Repository, error) {
-	r, _, err := c.gh.Repositories.Get(ctx, owner, repo)
+	r, resp, err := c.gh.Repositories.Get(ctx, owner, repo)
 	if err != nil {
-		return nil, err
+		return nil, ghErrors.Translate(err, resp)
 	}
 	return repositoryFromGitHub(r), nil
 }
@@ -128,48 +250,51 @@ func (c *client) ListUserRepos(ctx context.Context, user string) ([]*gogithub.Re
 
 // ListUserReposWithOptions lists repositories for a user, filtered by type.
 func (c *client) ListUserReposWithOptions(ctx context.Context, user string, opts *ListUserReposOptions) ([]*gogithub.Repository, error) {
-	repoType := "all"
+	return collect(c.IterUserRepos(ctx, user, opts, nil))
+}
+
+// IterUserRepos iterates over a user's repositories, filtered by type.
+func (c *client) IterUserRepos(ctx context.Context, user string, opts *ListUserReposOptions, iterOpts *IterOptions) iter.Seq2[*gogithub.Repository, error] {
+	listOpts := &github.RepositoryListByUserOptions{Type: "all"}
 	if opts != nil && opts.Type != "" {
-		repoType = opts.Type
-	}
-	var allRepos []*github.Repository
-	listOpts := &github.RepositoryListByUserOptions{
-		Type:        repoType,
-		ListOptions: github.ListOptions{PerPage: 100},
-	}
-	for {
-		repos, resp, err := c.gh.Repositories.ListByUser(ctx, user, listOpts)
-		if err != nil {
-			return nil, fmt.Errorf("list user repos: %w", err)
-		}
-		allRepos = append(allRepos, repos...)
-		if resp.NextPage == 0 {
-			break
-		}
-		listOpts.Page = resp.NextPage
+		listOpts.Type = opts.Type
 	}
-	return repositoriesFromGitHub(allRepos), nil
+	return pages(ctx, listOpts.ListOptions, iterOpts, "list user repos", func(lo *github.ListOptions) ([]*github.Repository, *github.Response, error) {
+		listOpts.ListOptions = *lo
+		return c.gh.Repositories.ListByUser(ctx, user, listOpts)
+	}, repositoryFromGitHub)
 }
 
 // ListOrgRepos lists all repositories for an organization.
 func (c *client) ListOrgRepos(ctx context.Context, org string) ([]*gogithub.Repository, error) {
-	var allRepos []*github.Repository
-	opts := &github.RepositoryListByOrgOptions{
-		Type:        "all",
-		ListOptions: github.ListOptions{PerPage: 100},
-	}
-	for {
-		repos, resp, err := c.gh.Repositories.ListByOrg(ctx, org, opts)
-		if err != nil {
-			return nil, fmt.Errorf("list org repos: %w", err)
-		}
-		allRepos = append(allRepos, repos...)
-		if resp.NextPage == 0 {
-			break
-		}
-		opts.Page = resp.NextPage
-	}
-	return repositoriesFromGitHub(allRepos), nil
+	return collect(c.IterOrgRepos(ctx, org, nil))
+}
+
+// IterOrgRepos iterates over an organization's repositories.
+func (c *client) IterOrgRepos(ctx context.Context, org string, iterOpts *IterOptions) iter.Seq2[*gogithub.Repository, error] {
+	listOpts := &github.RepositoryListByOrgOptions{Type: "all"}
+	return pages(ctx, listOpts.ListOptions, iterOpts, "list org repos", func(lo *github.ListOptions) ([]*github.Repository, *github.Response, error) {
+		listOpts.ListOptions = *lo
+		return c.gh.Repositories.ListByOrg(ctx, org, listOpts)
+	}, repositoryFromGitHub)
+}
+
+// ListOrgMembers lists the members of an organization.
+func (c *client) ListOrgMembers(ctx context.Context, org string) ([]*gogithub.User, error) {
+	listOpts := &github.ListMembersOptions{}
+	return collect(pages(ctx, listOpts.ListOptions, nil, "list org members", func(lo *github.ListOptions) ([]*github.User, *github.Response, error) {
+		listOpts.ListOptions = *lo
+		return c.gh.Organizations.ListMembers(ctx, org, listOpts)
+	}, userFromGitHub))
+}
+
+// ListTeamMembers lists the members of an organization's team.
+func (c *client) ListTeamMembers(ctx context.Context, org, teamSlug string) ([]*gogithub.User, error) {
+	listOpts := &github.TeamListTeamMembersOptions{}
+	return collect(pages(ctx, listOpts.ListOptions, nil, "list team members", func(lo *github.ListOptions) ([]*github.User, *github.Response, error) {
+		listOpts.ListOptions = *lo
+		return c.gh.Teams.ListTeamMembersBySlug(ctx, org, teamSlug, listOpts)
+	}, userFromGitHub))
 }
 
 // GetFileContent fetches a file's content from a repository.
@@ -181,9 +306,9 @@ func (c *client) GetFileContent(ctx context.Context, owner, repo, path string, o
 	content, _, resp, err := c.gh.Repositories.GetContents(ctx, owner, repo, path, getOpts)
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
-			return nil, fmt.Errorf("file not found: %s", path)
+			return nil, fmt.Errorf("file not found: %s: %w", path, ghErrors.Translate(err, resp))
 		}
-		return nil, fmt.Errorf("get file content %s: %w", path, err)
+		return nil, fmt.Errorf("get file content %s: %w", path, ghErrors.Translate(err, resp))
 	}
 	if content == nil {
 		return nil, fmt.Errorf("path is a directory, not a file: %s", path)
@@ -216,9 +341,9 @@ func (c *client) ListDirectory(ctx context.Context, owner, repo, path string, op
 	_, dirContents, resp, err := c.gh.Repositories.GetContents(ctx, owner, repo, path, getOpts)
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
-			return nil, fmt.Errorf("directory not found: %s", path)
+			return nil, fmt.Errorf("directory not found: %s: %w", path, ghErrors.Translate(err, resp))
 		}
-		return nil, fmt.Errorf("list directory %s: %w", path, err)
+		return nil, fmt.Errorf("list directory %s: %w", path, ghErrors.Translate(err, resp))
 	}
 	if dirContents == nil {
 		return nil, fmt.Errorf("path is a file, not a directory: %s", path)
@@ -237,7 +362,7 @@ func (c *client) FileExists(ctx context.Context, owner, repo, path string, opts
 		if resp != nil && resp.StatusCode == 404 {
 			return false, nil
 		}
-		return false, fmt.Errorf("check file exists %s: %w", path, err)
+		return false, fmt.Errorf("check file exists %s: %w", path, ghErrors.Translate(err, resp))
 	}
 	return content != nil && content.GetType() == "file", nil
 }
@@ -251,9 +376,9 @@ func (c *client) GetFileContentWithSHA(ctx context.Context, owner, repo, path st
 	content, _, resp, err := c.gh.Repositories.GetContents(ctx, owner, repo, path, getOpts)
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
-			return nil, "", fmt.Errorf("file not found: %s", path)
+			return nil, "", fmt.Errorf("file not found: %s: %w", path, ghErrors.Translate(err, resp))
 		}
-		return nil, "", fmt.Errorf("get file content %s: %w", path, err)
+		return nil, "", fmt.Errorf("get file content %s: %w", path, ghErrors.Translate(err, resp))
 	}
 	if content == nil {
 		return nil, "", fmt.Errorf("path is a directory, not a file: %s", path)
@@ -286,9 +411,9 @@ func (c *client) CreateFile(ctx context.Context, owner, repo, path string, opts
 			fileOpts.Author.Date = &github.Timestamp{Time: *opts.Author.Date}
 		}
 	}
-	content, _, err := c.gh.Repositories.CreateFile(ctx, owner, repo, path, fileOpts)
+	content, resp, err := c.gh.Repositories.CreateFile(ctx, owner, repo, path, fileOpts)
 	if err != nil {
-		return nil, fmt.Errorf("create file %s: %w", path, err)
+		return nil, fmt.Errorf("create file %s: %w", path, ghErrors.Translate(err, resp))
 	}
 	return createFileResultFromGitHub(content), nil
 }
@@ -312,9 +437,9 @@ func (c *client) UpdateFile(ctx context.Context, owner, repo, path string, opts
 			fileOpts.Author.Date = &github.Timestamp{Time: *opts.Author.Date}
 		}
 	}
-	content, _, err := c.gh.Repositories.UpdateFile(ctx, owner, repo, path, fileOpts)
+	content, resp, err := c.gh.Repositories.UpdateFile(ctx, owner, repo, path, fileOpts)
 	if err != nil {
-		return nil, fmt.Errorf("update file %s: %w", path, err)
+		return nil, fmt.Errorf("update file %s: %w", path, ghErrors.Translate(err, resp))
 	}
 	return createFileResultFromGitHub(content), nil
 }
@@ -339,18 +464,18 @@ func (c *client) DeleteFile(ctx context.Context, owner, repo, path, sha, message
 			}
 		}
 	}
-	resp, _, err := c.gh.Repositories.DeleteFile(ctx, owner, repo, path, fileOpts)
+	result, resp, err := c.gh.Repositories.DeleteFile(ctx, owner, repo, path, fileOpts)
 	if err != nil {
-		return nil, fmt.Errorf("delete file %s: %w", path, err)
+		return nil, fmt.Errorf("delete file %s: %w", path, ghErrors.Translate(err, resp))
 	}
-	return deleteFileResultFromGitHub(resp), nil
+	return deleteFileResultFromGitHub(result), nil
 }
 
 // GetRef retrieves a git reference by its full name.
 func (c *client) GetRef(ctx context.Context, owner, repo, ref string) (*gogithub.Reference, error) {
-	r, _, err := c.gh.Git.GetRef(ctx, owner, repo, ref)
+	r, resp, err := c.gh.Git.GetRef(ctx, owner, repo, ref)
 	if err != nil {
-		return nil, err
+		return nil, ghErrors.Translate(err, resp)
 	}
 	return referenceFromGitHub(r), nil
 }
@@ -381,38 +506,44 @@ func (c *client) GetTagSHA(ctx context.Context, owner, repo, tag string) (string
 
 // ListBranches lists all branches in a repository.
 func (c *client) ListBranches(ctx context.Context, owner, repo string) ([]*gogithub.Branch, error) {
-	var allBranches []*github.Branch
-	opts := &github.BranchListOptions{
-		ListOptions: github.ListOptions{PerPage: 100},
-	}
-	for {
-		branches, resp, err := c.gh.Repositories.ListBranches(ctx, owner, repo, opts)
-		if err != nil {
-			return nil, fmt.Errorf("list branches: %w", err)
-		}
-		allBranches = append(allBranches, branches...)
-		if resp.NextPage == 0 {
-			break
-		}
-		opts.Page = resp.NextPage
-	}
-	return branchesFromGitHub(allBranches), nil
+	return collect(c.IterBranches(ctx, owner, repo, nil))
+}
+
+// IterBranches iterates over the branches in a repository.
+func (c *client) IterBranches(ctx context.Context, owner, repo string, iterOpts *IterOptions) iter.Seq2[*gogithub.Branch, error] {
+	listOpts := &github.BranchListOptions{}
+	return pages(ctx, listOpts.ListOptions, iterOpts, "list branches", func(lo *github.ListOptions) ([]*github.Branch, *github.Response, error) {
+		listOpts.ListOptions = *lo
+		return c.gh.Repositories.ListBranches(ctx, owner, repo, listOpts)
+	}, branchFromGitHub)
 }
 
 // GetCommit retrieves a commit by SHA.
 func (c *client) GetCommit(ctx context.Context, owner, repo, sha string) (*gogithub.Commit, error) {
-	commit, _, err := c.gh.Repositories.GetCommit(ctx, owner, repo, sha, nil)
+	commit, resp, err := c.gh.Repositories.GetCommit(ctx, owner, repo, sha, nil)
 	if err != nil {
-		return nil, err
+		return nil, ghErrors.Translate(err, resp)
 	}
 	return commitFromGitHub(commit), nil
 }
 
+// GetGitCommit retrieves a commit object from the Git Data API.
+func (c *client) GetGitCommit(ctx context.Context, owner, repo, sha string) (*gogithub.Commit, error) {
+	commit, resp, err := c.gh.Git.GetCommit(ctx, owner, repo, sha)
+	if err != nil {
+		return nil, fmt.Errorf("get git commit: %w", ghErrors.Translate(err, resp))
+	}
+	return gitCommitToCommit(commit), nil
+}
+
 // ListCommits lists commits in a repository.
 func (c *client) ListCommits(ctx context.Context, owner, repo string, opts *ListCommitsOptions) ([]*gogithub.Commit, error) {
-	listOpts := &github.CommitsListOptions{
-		ListOptions: github.ListOptions{PerPage: 100},
-	}
+	return collect(c.IterCommits(ctx, owner, repo, opts, nil))
+}
+
+// IterCommits iterates over commits in a repository.
+func (c *client) IterCommits(ctx context.Context, owner, repo string, opts *ListCommitsOptions, iterOpts *IterOptions) iter.Seq2[*gogithub.Commit, error] {
+	listOpts := &github.CommitsListOptions{}
 	if opts != nil {
 		listOpts.SHA = opts.SHA
 		listOpts.Path = opts.Path
@@ -424,36 +555,29 @@ func (c *client) ListCommits(ctx context.Context, owner, repo string, opts *List
 			listOpts.Until = *opts.Until
 		}
 	}
-	var allCommits []*github.RepositoryCommit
-	for {
-		commits, resp, err := c.gh.Repositories.ListCommits(ctx, owner, repo, listOpts)
-		if err != nil {
-			return nil, fmt.Errorf("list commits: %w", err)
-		}
-		allCommits = append(allCommits, commits...)
-		if resp.NextPage == 0 {
-			break
-		}
-		listOpts.Page = resp.NextPage
-	}
-	return commitsFromGitHub(allCommits), nil
+	return pages(ctx, listOpts.ListOptions, iterOpts, "list commits", func(lo *github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+		listOpts.ListOptions = *lo
+		return c.gh.Repositories.ListCommits(ctx, owner, repo, listOpts)
+	}, commitFromGitHub)
 }
 
 // GetPullRequest retrieves a pull request by number.
 func (c *client) GetPullRequest(ctx context.Context, owner, repo string, number int) (*gogithub.PullRequest, error) {
-	pr, _, err := c.gh.PullRequests.Get(ctx, owner, repo, number)
+	pr, resp, err := c.gh.PullRequests.Get(ctx, owner, repo, number)
 	if err != nil {
-		return nil, err
+		return nil, ghErrors.Translate(err, resp)
 	}
 	return pullRequestFromGitHub(pr), nil
 }
 
 // ListPullRequests lists pull requests in a repository.
 func (c *client) ListPullRequests(ctx context.Context, owner, repo string, opts *ListPullRequestsOptions) ([]*gogithub.PullRequest, error) {
-	listOpts := &github.PullRequestListOptions{
-		State:       "open",
-		ListOptions: github.ListOptions{PerPage: 100},
-	}
+	return collect(c.IterPullRequests(ctx, owner, repo, opts, nil))
+}
+
+// IterPullRequests iterates over pull requests in a repository.
+func (c *client) IterPullRequests(ctx context.Context, owner, repo string, opts *ListPullRequestsOptions, iterOpts *IterOptions) iter.Seq2[*gogithub.PullRequest, error] {
+	listOpts := &github.PullRequestListOptions{State: "open"}
 	if opts != nil {
 		if opts.State != "" {
 			listOpts.State = opts.State
@@ -463,19 +587,10 @@ func (c *client) ListPullRequests(ctx context.Context, owner, repo string, opts
 		listOpts.Sort = opts.Sort
 		listOpts.Direction = opts.Direction
 	}
-	var allPRs []*github.PullRequest
-	for {
-		prs, resp, err := c.gh.PullRequests.List(ctx, owner, repo, listOpts)
-		if err != nil {
-			return nil, fmt.Errorf("list pull requests: %w", err)
-		}
-		allPRs = append(allPRs, prs...)
-		if resp.NextPage == 0 {
-			break
-		}
-		listOpts.Page = resp.NextPage
-	}
-	return pullRequestsFromGitHub(allPRs), nil
+	return pages(ctx, listOpts.ListOptions, iterOpts, "list pull requests", func(lo *github.ListOptions) ([]*github.PullRequest, *github.Response, error) {
+		listOpts.ListOptions = *lo
+		return c.gh.PullRequests.List(ctx, owner, repo, listOpts)
+	}, pullRequestFromGitHub)
 }
 
 // CreatePullRequest creates a new pull request.
@@ -488,74 +603,66 @@ func (c *client) CreatePullRequest(ctx context.Context, owner, repo string, inpu
 		Draft:               github.Ptr(input.Draft),
 		MaintainerCanModify: github.Ptr(input.MaintainerCanModify),
 	}
-	pr, _, err := c.gh.PullRequests.Create(ctx, owner, repo, newPR)
+	pr, resp, err := c.gh.PullRequests.Create(ctx, owner, repo, newPR)
 	if err != nil {
-		return nil, err
+		return nil, ghErrors.Translate(err, resp)
 	}
 	return pullRequestFromGitHub(pr), nil
 }
 
 // ListCheckRuns lists check runs for a git reference.
 func (c *client) ListCheckRuns(ctx context.Context, owner, repo, ref string) ([]*gogithub.CheckRun, error) {
-	var allRuns []*github.CheckRun
-	opts := &github.ListCheckRunsOptions{
-		ListOptions: github.ListOptions{PerPage: 100},
-	}
-	for {
-		result, resp, err := c.gh.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
+	return collect(c.IterCheckRuns(ctx, owner, repo, ref, nil))
+}
+
+// IterCheckRuns iterates over check runs for a git reference.
+func (c *client) IterCheckRuns(ctx context.Context, owner, repo, ref string, iterOpts *IterOptions) iter.Seq2[*gogithub.CheckRun, error] {
+	listOpts := &github.ListCheckRunsOptions{}
+	return pages(ctx, listOpts.ListOptions, iterOpts, "list check runs", func(lo *github.ListOptions) ([]*github.CheckRun, *github.Response, error) {
+		listOpts.ListOptions = *lo
+		result, resp, err := c.gh.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, listOpts)
 		if err != nil {
-			return nil, fmt.Errorf("list check runs: %w", err)
+			return nil, resp, err
 		}
-		allRuns = append(allRuns, result.CheckRuns...)
-		if resp.NextPage == 0 {
-			break
-		}
-		opts.Page = resp.NextPage
-	}
-	return checkRunsFromGitHub(allRuns), nil
+		return result.CheckRuns, resp, nil
+	}, checkRunFromGitHub)
 }
 
 // GetLatestRelease retrieves the latest release.
 func (c *client) GetLatestRelease(ctx context.Context, owner, repo string) (*gogithub.Release, error) {
-	release, _, err := c.gh.Repositories.GetLatestRelease(ctx, owner, repo)
+	release, resp, err := c.gh.Repositories.GetLatestRelease(ctx, owner, repo)
 	if err != nil {
-		return nil, err
+		return nil, ghErrors.Translate(err, resp)
 	}
 	return releaseFromGitHub(release), nil
 }
 
 // GetReleaseByTag retrieves a release by its tag name.
 func (c *client) GetReleaseByTag(ctx context.Context, owner, repo, tag string) (*gogithub.Release, error) {
-	release, _, err := c.gh.Repositories.GetReleaseByTag(ctx, owner, repo, tag)
+	release, resp, err := c.gh.Repositories.GetReleaseByTag(ctx, owner, repo, tag)
 	if err != nil {
-		return nil, err
+		return nil, ghErrors.Translate(err, resp)
 	}
 	return releaseFromGitHub(release), nil
 }
 
 // ListReleases lists all releases in a repository.
 func (c *client) ListReleases(ctx context.Context, owner, repo string) ([]*gogithub.Release, error) {
-	var allReleases []*github.RepositoryRelease
-	opts := &github.ListOptions{PerPage: 100}
-	for {
-		releases, resp, err := c.gh.Repositories.ListReleases(ctx, owner, repo, opts)
-		if err != nil {
-			return nil, fmt.Errorf("list releases: %w", err)
-		}
-		allReleases = append(allReleases, releases...)
-		if resp.NextPage == 0 {
-			break
-		}
-		opts.Page = resp.NextPage
-	}
-	return releasesFromGitHub(allReleases), nil
+	return collect(c.IterReleases(ctx, owner, repo, nil))
+}
+
+// IterReleases iterates over the releases in a repository.
+func (c *client) IterReleases(ctx context.Context, owner, repo string, iterOpts *IterOptions) iter.Seq2[*gogithub.Release, error] {
+	return pages(ctx, github.ListOptions{}, iterOpts, "list releases", func(lo *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error) {
+		return c.gh.Repositories.ListReleases(ctx, owner, repo, lo)
+	}, releaseFromGitHub)
 }
 
 // GetDefaultBranch returns the default branch name for a repository.
 func (c *client) GetDefaultBranch(ctx context.Context, owner, repo string) (string, error) {
-	r, _, err := c.gh.Repositories.Get(ctx, owner, repo)
+	r, resp, err := c.gh.Repositories.Get(ctx, owner, repo)
 	if err != nil {
-		return "", err
+		return "", ghErrors.Translate(err, resp)
 	}
 	return r.GetDefaultBranch(), nil
 }
@@ -568,7 +675,7 @@ func (c *client) GetBranchProtection(ctx context.Context, owner, repo, branch st
 		if resp != nil && resp.StatusCode == 404 {
 			return nil, nil
 		}
-		return nil, fmt.Errorf("get branch protection: %w", err)
+		return nil, fmt.Errorf("get branch protection: %w", ghErrors.Translate(err, resp))
 	}
 	return branchProtectionFromGitHub(p), nil
 }
@@ -576,9 +683,9 @@ func (c *client) GetBranchProtection(ctx context.Context, owner, repo, branch st
 // ListLanguages returns the languages used in a repository, mapped to bytes
 // of code written in that language.
 func (c *client) ListLanguages(ctx context.Context, owner, repo string) (map[string]int, error) {
-	langs, _, err := c.gh.Repositories.ListLanguages(ctx, owner, repo)
+	langs, resp, err := c.gh.Repositories.ListLanguages(ctx, owner, repo)
 	if err != nil {
-		return nil, fmt.Errorf("list languages: %w", err)
+		return nil, fmt.Errorf("list languages: %w", ghErrors.Translate(err, resp))
 	}
 	return langs, nil
 }
@@ -591,13 +698,13 @@ func (c *client) CreateFork(ctx context.Context, owner, repo string, opts *Creat
 		forkOpts.Name = opts.Name
 		forkOpts.DefaultBranchOnly = opts.DefaultBranch
 	}
-	fork, _, err := c.gh.Repositories.CreateFork(ctx, owner, repo, forkOpts)
+	fork, resp, err := c.gh.Repositories.CreateFork(ctx, owner, repo, forkOpts)
 	if err != nil {
 		// AcceptedError means fork is being created asynchronously
 		if _, ok := err.(*github.AcceptedError); ok {
 			return repositoryFromGitHub(fork), nil
 		}
-		return nil, fmt.Errorf("create fork: %w", err)
+		return nil, fmt.Errorf("create fork: %w", ghErrors.Translate(err, resp))
 	}
 	return repositoryFromGitHub(fork), nil
 }
@@ -608,9 +715,9 @@ func (c *client) CreateRef(ctx context.Context, owner, repo, ref, sha string) (*
 		Ref: ref,
 		SHA: sha,
 	}
-	r, _, err := c.gh.Git.CreateRef(ctx, owner, repo, newRef)
+	r, resp, err := c.gh.Git.CreateRef(ctx, owner, repo, newRef)
 	if err != nil {
-		return nil, fmt.Errorf("create ref: %w", err)
+		return nil, fmt.Errorf("create ref: %w", ghErrors.Translate(err, resp))
 	}
 	return referenceFromGitHub(r), nil
 }
@@ -621,35 +728,29 @@ func (c *client) UpdateRef(ctx context.Context, owner, repo, ref, sha string, fo
 		SHA:   sha,
 		Force: github.Ptr(force),
 	}
-	r, _, err := c.gh.Git.UpdateRef(ctx, owner, repo, ref, updateRef)
+	r, resp, err := c.gh.Git.UpdateRef(ctx, owner, repo, ref, updateRef)
 	if err != nil {
-		return nil, fmt.Errorf("update ref: %w", err)
+		return nil, fmt.Errorf("update ref: %w", ghErrors.Translate(err, resp))
 	}
 	return referenceFromGitHub(r), nil
 }
 
 // DeleteRef deletes a git reference.
 func (c *client) DeleteRef(ctx context.Context, owner, repo, ref string) error {
-	_, err := c.gh.Git.DeleteRef(ctx, owner, repo, ref)
-	return err
+	resp, err := c.gh.Git.DeleteRef(ctx, owner, repo, ref)
+	return ghErrors.Translate(err, resp)
 }
 
 // ListTags lists all tags in a repository.
 func (c *client) ListTags(ctx context.Context, owner, repo string) ([]*gogithub.Tag, error) {
-	var allTags []*github.RepositoryTag
-	opts := &github.ListOptions{PerPage: 100}
-	for {
-		tags, resp, err := c.gh.Repositories.ListTags(ctx, owner, repo, opts)
-		if err != nil {
-			return nil, fmt.Errorf("list tags: %w", err)
-		}
-		allTags = append(allTags, tags...)
-		if resp.NextPage == 0 {
-			break
-		}
-		opts.Page = resp.NextPage
-	}
-	return tagsFromGitHub(allTags), nil
+	return collect(c.IterTags(ctx, owner, repo, nil))
+}
+
+// IterTags iterates over the tags in a repository.
+func (c *client) IterTags(ctx context.Context, owner, repo string, iterOpts *IterOptions) iter.Seq2[*gogithub.Tag, error] {
+	return pages(ctx, github.ListOptions{}, iterOpts, "list tags", func(lo *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+		return c.gh.Repositories.ListTags(ctx, owner, repo, lo)
+	}, tagFromGitHub)
 }
 
 // CreateTag creates an annotated tag.
@@ -661,9 +762,9 @@ func (c *client) CreateTag(ctx context.Context, owner, repo, tag, sha, message s
 		Object:  sha,
 		Type:    "commit",
 	}
-	createdTag, _, err := c.gh.Git.CreateTag(ctx, owner, repo, tagObj)
+	createdTag, resp, err := c.gh.Git.CreateTag(ctx, owner, repo, tagObj)
 	if err != nil {
-		return fmt.Errorf("create tag object: %w", err)
+		return fmt.Errorf("create tag object: %w", ghErrors.Translate(err, resp))
 	}
 
 	// Create reference to tag
@@ -671,9 +772,9 @@ func (c *client) CreateTag(ctx context.Context, owner, repo, tag, sha, message s
 		Ref: "refs/tags/" + tag,
 		SHA: createdTag.GetSHA(),
 	}
-	_, _, err = c.gh.Git.CreateRef(ctx, owner, repo, ref)
+	_, resp, err = c.gh.Git.CreateRef(ctx, owner, repo, ref)
 	if err != nil {
-		return fmt.Errorf("create tag reference: %w", err)
+		return fmt.Errorf("create tag reference: %w", ghErrors.Translate(err, resp))
 	}
 	return nil
 }
@@ -700,9 +801,9 @@ func (c *client) CreateCommit(ctx context.Context, owner, repo string, opts *Cre
 			commit.Author.Date = &github.Timestamp{Time: *opts.Author.Date}
 		}
 	}
-	created, _, err := c.gh.Git.CreateCommit(ctx, owner, repo, *commit, nil)
+	created, resp, err := c.gh.Git.CreateCommit(ctx, owner, repo, *commit, nil)
 	if err != nil {
-		return nil, fmt.Errorf("create commit: %w", err)
+		return nil, fmt.Errorf("create commit: %w", ghErrors.Translate(err, resp))
 	}
 	return gitCommitToCommit(created), nil
 }
@@ -716,6 +817,14 @@ func (c *client) CreateTree(ctx context.Context, owner, repo, baseTree string, e
 			Mode: github.Ptr(e.Mode),
 			Type: github.Ptr(e.Type),
 		}
+		if e.Delete {
+			if e.SHA != "" || e.Content != "" {
+				return "", fmt.Errorf("create tree: delete entry %s must not set SHA or Content", e.Path)
+			}
+			// go-github sends "sha": null when both SHA and Content are
+			// nil, which removes the path from the base tree.
+			continue
+		}
 		if e.SHA != "" {
 			ghEntries[i].SHA = github.Ptr(e.SHA)
 		}
@@ -723,9 +832,9 @@ func (c *client) CreateTree(ctx context.Context, owner, repo, baseTree string, e
 			ghEntries[i].Content = github.Ptr(e.Content)
 		}
 	}
-	tree, _, err := c.gh.Git.CreateTree(ctx, owner, repo, baseTree, ghEntries)
+	tree, resp, err := c.gh.Git.CreateTree(ctx, owner, repo, baseTree, ghEntries)
 	if err != nil {
-		return "", fmt.Errorf("create tree: %w", err)
+		return "", fmt.Errorf("create tree: %w", ghErrors.Translate(err, resp))
 	}
 	return tree.GetSHA(), nil
 }
@@ -739,18 +848,18 @@ func (c *client) CreateBlob(ctx context.Context, owner, repo string, content []b
 		Content:  github.Ptr(string(content)),
 		Encoding: github.Ptr(encoding),
 	}
-	created, _, err := c.gh.Git.CreateBlob(ctx, owner, repo, blob)
+	created, resp, err := c.gh.Git.CreateBlob(ctx, owner, repo, blob)
 	if err != nil {
-		return "", fmt.Errorf("create blob: %w", err)
+		return "", fmt.Errorf("create blob: %w", ghErrors.Translate(err, resp))
 	}
 	return created.GetSHA(), nil
 }
 
 // GetTree retrieves a git tree by SHA.
 func (c *client) GetTree(ctx context.Context, owner, repo, sha string, recursive bool) ([]*gogithub.TreeNode, error) {
-	tree, _, err := c.gh.Git.GetTree(ctx, owner, repo, sha, recursive)
+	tree, resp, err := c.gh.Git.GetTree(ctx, owner, repo, sha, recursive)
 	if err != nil {
-		return nil, fmt.Errorf("get tree: %w", err)
+		return nil, fmt.Errorf("get tree: %w", ghErrors.Translate(err, resp))
 	}
 	return treeNodesFromGitHub(tree.Entries), nil
 }
@@ -773,9 +882,9 @@ func (c *client) UpdatePullRequest(ctx context.Context, owner, repo string, numb
 	if input.MaintainerCanModify != nil {
 		pr.MaintainerCanModify = input.MaintainerCanModify
 	}
-	updated, _, err := c.gh.PullRequests.Edit(ctx, owner, repo, number, pr)
+	updated, resp, err := c.gh.PullRequests.Edit(ctx, owner, repo, number, pr)
 	if err != nil {
-		return nil, err
+		return nil, ghErrors.Translate(err, resp)
 	}
 	return pullRequestFromGitHub(updated), nil
 }
@@ -792,9 +901,9 @@ func (c *client) MergePullRequest(ctx context.Context, owner, repo string, numbe
 			SHA:         opts.SHA,
 		}
 	}
-	result, _, err := c.gh.PullRequests.Merge(ctx, owner, repo, number, commitMsg, ghOpts)
+	result, resp, err := c.gh.PullRequests.Merge(ctx, owner, repo, number, commitMsg, ghOpts)
 	if err != nil {
-		return nil, err
+		return nil, ghErrors.Translate(err, resp)
 	}
 	return &gogithub.MergeResult{
 		SHA:     result.GetSHA(),
@@ -805,36 +914,30 @@ func (c *client) MergePullRequest(ctx context.Context, owner, repo string, numbe
 
 // ListPullRequestFiles lists files changed in a pull request.
 func (c *client) ListPullRequestFiles(ctx context.Context, owner, repo string, number int) ([]*gogithub.CommitFile, error) {
-	var allFiles []*github.CommitFile
-	opts := &github.ListOptions{PerPage: 100}
-	for {
-		files, resp, err := c.gh.PullRequests.ListFiles(ctx, owner, repo, number, opts)
-		if err != nil {
-			return nil, fmt.Errorf("list PR files: %w", err)
-		}
-		allFiles = append(allFiles, files...)
-		if resp.NextPage == 0 {
-			break
-		}
-		opts.Page = resp.NextPage
-	}
-	return commitFilesFromGitHub(allFiles), nil
+	return collect(c.IterPullRequestFiles(ctx, owner, repo, number, nil))
+}
+
+// IterPullRequestFiles iterates over the files changed in a pull request.
+func (c *client) IterPullRequestFiles(ctx context.Context, owner, repo string, number int, iterOpts *IterOptions) iter.Seq2[*gogithub.CommitFile, error] {
+	return pages(ctx, github.ListOptions{}, iterOpts, "list PR files", func(lo *github.ListOptions) ([]*github.CommitFile, *github.Response, error) {
+		return c.gh.PullRequests.ListFiles(ctx, owner, repo, number, lo)
+	}, commitFileFromGitHub)
 }
 
 // GetPullRequestDiff gets the diff for a pull request.
 func (c *client) GetPullRequestDiff(ctx context.Context, owner, repo string, number int) (string, error) {
-	diff, _, err := c.gh.PullRequests.GetRaw(ctx, owner, repo, number, github.RawOptions{Type: github.Diff})
+	diff, resp, err := c.gh.PullRequests.GetRaw(ctx, owner, repo, number, github.RawOptions{Type: github.Diff})
 	if err != nil {
-		return "", fmt.Errorf("get PR diff: %w", err)
+		return "", fmt.Errorf("get PR diff: %w", ghErrors.Translate(err, resp))
 	}
 	return diff, nil
 }
 
 // GetPullRequestPatch gets the patch for a pull request.
 func (c *client) GetPullRequestPatch(ctx context.Context, owner, repo string, number int) (string, error) {
-	patch, _, err := c.gh.PullRequests.GetRaw(ctx, owner, repo, number, github.RawOptions{Type: github.Patch})
+	patch, resp, err := c.gh.PullRequests.GetRaw(ctx, owner, repo, number, github.RawOptions{Type: github.Patch})
 	if err != nil {
-		return "", fmt.Errorf("get PR patch: %w", err)
+		return "", fmt.Errorf("get PR patch: %w", ghErrors.Translate(err, resp))
 	}
 	return patch, nil
 }
@@ -845,39 +948,33 @@ func (c *client) CreatePullRequestReview(ctx context.Context, owner, repo string
 		Event: github.Ptr(input.Event),
 		Body:  github.Ptr(input.Body),
 	}
-	result, _, err := c.gh.PullRequests.CreateReview(ctx, owner, repo, number, review)
+	result, resp, err := c.gh.PullRequests.CreateReview(ctx, owner, repo, number, review)
 	if err != nil {
-		return nil, err
+		return nil, ghErrors.Translate(err, resp)
 	}
 	return pullRequestReviewFromGitHub(result), nil
 }
 
 // ListPullRequestReviews lists reviews on a pull request.
 func (c *client) ListPullRequestReviews(ctx context.Context, owner, repo string, number int) ([]*gogithub.PullRequestReview, error) {
-	var allReviews []*github.PullRequestReview
-	opts := &github.ListOptions{PerPage: 100}
-	for {
-		reviews, resp, err := c.gh.PullRequests.ListReviews(ctx, owner, repo, number, opts)
-		if err != nil {
-			return nil, fmt.Errorf("list PR reviews: %w", err)
-		}
-		allReviews = append(allReviews, reviews...)
-		if resp.NextPage == 0 {
-			break
-		}
-		opts.Page = resp.NextPage
-	}
-	return pullRequestReviewsFromGitHub(allReviews), nil
+	return collect(c.IterPullRequestReviews(ctx, owner, repo, number, nil))
+}
+
+// IterPullRequestReviews iterates over the reviews on a pull request.
+func (c *client) IterPullRequestReviews(ctx context.Context, owner, repo string, number int, iterOpts *IterOptions) iter.Seq2[*gogithub.PullRequestReview, error] {
+	return pages(ctx, github.ListOptions{}, iterOpts, "list PR reviews", func(lo *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+		return c.gh.PullRequests.ListReviews(ctx, owner, repo, number, lo)
+	}, pullRequestReviewFromGitHub)
 }
 
 // RequestReviewers requests reviewers for a pull request.
 func (c *client) RequestReviewers(ctx context.Context, owner, repo string, number int, reviewers, teamReviewers []string) (*gogithub.PullRequest, error) {
-	pr, _, err := c.gh.PullRequests.RequestReviewers(ctx, owner, repo, number, github.ReviewersRequest{
+	pr, resp, err := c.gh.PullRequests.RequestReviewers(ctx, owner, repo, number, github.ReviewersRequest{
 		Reviewers:     reviewers,
 		TeamReviewers: teamReviewers,
 	})
 	if err != nil {
-		return nil, err
+		return nil, ghErrors.Translate(err, resp)
 	}
 	return pullRequestFromGitHub(pr), nil
 }
@@ -893,48 +990,45 @@ func (c *client) CreatePullRequestComment(ctx context.Context, owner, repo strin
 	if input.Side != "" {
 		comment.Side = github.Ptr(input.Side)
 	}
-	result, _, err := c.gh.PullRequests.CreateComment(ctx, owner, repo, number, comment)
+	result, resp, err := c.gh.PullRequests.CreateComment(ctx, owner, repo, number, comment)
 	if err != nil {
-		return nil, err
+		return nil, ghErrors.Translate(err, resp)
 	}
 	return pullRequestCommentFromGitHub(result), nil
 }
 
 // ListPullRequestComments lists comments on a pull request.
 func (c *client) ListPullRequestComments(ctx context.Context, owner, repo string, number int) ([]*gogithub.PullRequestComment, error) {
-	var allComments []*github.PullRequestComment
-	opts := &github.PullRequestListCommentsOptions{
-		ListOptions: github.ListOptions{PerPage: 100},
-	}
-	for {
-		comments, resp, err := c.gh.PullRequests.ListComments(ctx, owner, repo, number, opts)
-		if err != nil {
-			return nil, fmt.Errorf("list PR comments: %w", err)
-		}
-		allComments = append(allComments, comments...)
-		if resp.NextPage == 0 {
-			break
-		}
-		opts.Page = resp.NextPage
-	}
-	return pullRequestCommentsFromGitHub(allComments), nil
+	return collect(c.IterPullRequestComments(ctx, owner, repo, number, nil))
+}
+
+// IterPullRequestComments iterates over the review comments on a pull request.
+func (c *client) IterPullRequestComments(ctx context.Context, owner, repo string, number int, iterOpts *IterOptions) iter.Seq2[*gogithub.PullRequestComment, error] {
+	listOpts := &github.PullRequestListCommentsOptions{}
+	return pages(ctx, listOpts.ListOptions, iterOpts, "list PR comments", func(lo *github.ListOptions) ([]*github.PullRequestComment, *github.Response, error) {
+		listOpts.ListOptions = *lo
+		return c.gh.PullRequests.ListComments(ctx, owner, repo, number, listOpts)
+	}, pullRequestCommentFromGitHub)
 }
 
 // GetIssue retrieves an issue by number.
 func (c *client) GetIssue(ctx context.Context, owner, repo string, number int) (*gogithub.Issue, error) {
-	issue, _, err := c.gh.Issues.Get(ctx, owner, repo, number)
+	issue, resp, err := c.gh.Issues.Get(ctx, owner, repo, number)
 	if err != nil {
-		return nil, fmt.Errorf("get issue: %w", err)
+		return nil, fmt.Errorf("get issue: %w", ghErrors.Translate(err, resp))
 	}
 	return issueFromGitHub(issue), nil
 }
 
 // ListIssues lists issues in a repository.
 func (c *client) ListIssues(ctx context.Context, owner, repo string, opts *ListIssuesOptions) ([]*gogithub.Issue, error) {
-	listOpts := &github.IssueListByRepoOptions{
-		State:       "open",
-		ListOptions: github.ListOptions{PerPage: 100},
-	}
+	return collect(c.IterIssues(ctx, owner, repo, opts, nil))
+}
+
+// IterIssues iterates over issues in a repository, starting from
+// opts.Page when set.
+func (c *client) IterIssues(ctx context.Context, owner, repo string, opts *ListIssuesOptions, iterOpts *IterOptions) iter.Seq2[*gogithub.Issue, error] {
+	listOpts := &github.IssueListByRepoOptions{State: "open"}
 	if opts != nil {
 		if opts.State != "" {
 			listOpts.State = opts.State
@@ -945,26 +1039,13 @@ func (c *client) ListIssues(ctx context.Context, owner, repo string, opts *ListI
 		if opts.Since != nil {
 			listOpts.Since = *opts.Since
 		}
-		if opts.PerPage > 0 {
-			listOpts.ListOptions.PerPage = opts.PerPage
-		}
-		if opts.Page > 0 {
-			listOpts.ListOptions.Page = opts.Page
-		}
-	}
-	var allIssues []*github.Issue
-	for {
-		issues, resp, err := c.gh.Issues.ListByRepo(ctx, owner, repo, listOpts)
-		if err != nil {
-			return nil, fmt.Errorf("list issues: %w", err)
-		}
-		allIssues = append(allIssues, issues...)
-		if resp.NextPage == 0 {
-			break
-		}
-		listOpts.ListOptions.Page = resp.NextPage
+		listOpts.ListOptions.PerPage = opts.PerPage
+		listOpts.ListOptions.Page = opts.Page
 	}
-	return issuesFromGitHub(allIssues), nil
+	return pages(ctx, listOpts.ListOptions, iterOpts, "list issues", func(lo *github.ListOptions) ([]*github.Issue, *github.Response, error) {
+		listOpts.ListOptions = *lo
+		return c.gh.Issues.ListByRepo(ctx, owner, repo, listOpts)
+	}, issueFromGitHub)
 }
 
 // CreateIssue creates a new issue.
@@ -984,9 +1065,9 @@ func (c *client) CreateIssue(ctx context.Context, owner, repo string, input *Cre
 	if input.Milestone != nil {
 		req.Milestone = input.Milestone
 	}
-	issue, _, err := c.gh.Issues.Create(ctx, owner, repo, req)
+	issue, resp, err := c.gh.Issues.Create(ctx, owner, repo, req)
 	if err != nil {
-		return nil, fmt.Errorf("create issue: %w", err)
+		return nil, fmt.Errorf("create issue: %w", ghErrors.Translate(err, resp))
 	}
 	return issueFromGitHub(issue), nil
 }
@@ -1012,9 +1093,9 @@ func (c *client) UpdateIssue(ctx context.Context, owner, repo string, number int
 	if input.Milestone != nil {
 		req.Milestone = input.Milestone
 	}
-	issue, _, err := c.gh.Issues.Edit(ctx, owner, repo, number, req)
+	issue, resp, err := c.gh.Issues.Edit(ctx, owner, repo, number, req)
 	if err != nil {
-		return nil, fmt.Errorf("update issue: %w", err)
+		return nil, fmt.Errorf("update issue: %w", ghErrors.Translate(err, resp))
 	}
 	return issueFromGitHub(issue), nil
 }
@@ -1024,9 +1105,9 @@ func (c *client) CreateIssueComment(ctx context.Context, owner, repo string, num
 	comment := &github.IssueComment{
 		Body: github.Ptr(body),
 	}
-	result, _, err := c.gh.Issues.CreateComment(ctx, owner, repo, number, comment)
+	result, resp, err := c.gh.Issues.CreateComment(ctx, owner, repo, number, comment)
 	if err != nil {
-		return nil, err
+		return nil, ghErrors.Translate(err, resp)
 	}
 	return issueCommentFromGitHub(result), nil
 }
@@ -1036,65 +1117,59 @@ func (c *client) EditIssueComment(ctx context.Context, owner, repo string, comme
 	comment := &github.IssueComment{
 		Body: github.Ptr(body),
 	}
-	result, _, err := c.gh.Issues.EditComment(ctx, owner, repo, commentID, comment)
+	result, resp, err := c.gh.Issues.EditComment(ctx, owner, repo, commentID, comment)
 	if err != nil {
-		return nil, fmt.Errorf("edit issue comment: %w", err)
+		return nil, fmt.Errorf("edit issue comment: %w", ghErrors.Translate(err, resp))
 	}
 	return issueCommentFromGitHub(result), nil
 }
 
 // ListIssueComments lists comments on an issue or pull request.
 func (c *client) ListIssueComments(ctx context.Context, owner, repo string, number int) ([]*gogithub.IssueComment, error) {
-	listOpts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
-	var allComments []*github.IssueComment
-	for {
-		comments, resp, err := c.gh.Issues.ListComments(ctx, owner, repo, number, listOpts)
-		if err != nil {
-			return nil, fmt.Errorf("list issue comments: %w", err)
-		}
-		allComments = append(allComments, comments...)
-		if resp.NextPage == 0 {
-			break
-		}
-		listOpts.Page = resp.NextPage
-	}
-	return issueCommentsFromGitHub(allComments), nil
+	return collect(c.IterIssueComments(ctx, owner, repo, number, nil))
+}
+
+// IterIssueComments iterates over the comments on an issue or pull request.
+func (c *client) IterIssueComments(ctx context.Context, owner, repo string, number int, iterOpts *IterOptions) iter.Seq2[*gogithub.IssueComment, error] {
+	listOpts := &github.IssueListCommentsOptions{}
+	return pages(ctx, listOpts.ListOptions, iterOpts, "list issue comments", func(lo *github.ListOptions) ([]*github.IssueComment, *github.Response, error) {
+		listOpts.ListOptions = *lo
+		return c.gh.Issues.ListComments(ctx, owner, repo, number, listOpts)
+	}, issueCommentFromGitHub)
 }
 
 // GetCheckRun retrieves a check run by ID.
 func (c *client) GetCheckRun(ctx context.Context, owner, repo string, checkRunID int64) (*gogithub.CheckRun, error) {
-	check, _, err := c.gh.Checks.GetCheckRun(ctx, owner, repo, checkRunID)
+	check, resp, err := c.gh.Checks.GetCheckRun(ctx, owner, repo, checkRunID)
 	if err != nil {
-		return nil, err
+		return nil, ghErrors.Translate(err, resp)
 	}
 	return checkRunFromGitHub(check), nil
 }
 
 // ListCheckSuites lists check suites for a git reference.
 func (c *client) ListCheckSuites(ctx context.Context, owner, repo, ref string) ([]*gogithub.CheckSuite, error) {
-	var allSuites []*github.CheckSuite
-	opts := &github.ListCheckSuiteOptions{
-		ListOptions: github.ListOptions{PerPage: 100},
-	}
-	for {
-		result, resp, err := c.gh.Checks.ListCheckSuitesForRef(ctx, owner, repo, ref, opts)
+	return collect(c.IterCheckSuites(ctx, owner, repo, ref, nil))
+}
+
+// IterCheckSuites iterates over check suites for a git reference.
+func (c *client) IterCheckSuites(ctx context.Context, owner, repo, ref string, iterOpts *IterOptions) iter.Seq2[*gogithub.CheckSuite, error] {
+	listOpts := &github.ListCheckSuiteOptions{}
+	return pages(ctx, listOpts.ListOptions, iterOpts, "list check suites", func(lo *github.ListOptions) ([]*github.CheckSuite, *github.Response, error) {
+		listOpts.ListOptions = *lo
+		result, resp, err := c.gh.Checks.ListCheckSuitesForRef(ctx, owner, repo, ref, listOpts)
 		if err != nil {
-			return nil, fmt.Errorf("list check suites: %w", err)
-		}
-		allSuites = append(allSuites, result.CheckSuites...)
-		if resp.NextPage == 0 {
-			break
+			return nil, resp, err
 		}
-		opts.Page = resp.NextPage
-	}
-	return checkSuitesFromGitHub(allSuites), nil
+		return result.CheckSuites, resp, nil
+	}, checkSuiteFromGitHub)
 }
 
 // GetRelease retrieves a release by ID.
 func (c *client) GetRelease(ctx context.Context, owner, repo string, id int64) (*gogithub.Release, error) {
-	release, _, err := c.gh.Repositories.GetRelease(ctx, owner, repo, id)
+	release, resp, err := c.gh.Repositories.GetRelease(ctx, owner, repo, id)
 	if err != nil {
-		return nil, err
+		return nil, ghErrors.Translate(err, resp)
 	}
 	return releaseFromGitHub(release), nil
 }
@@ -1110,9 +1185,9 @@ func (c *client) CreateRelease(ctx context.Context, owner, repo string, input *C
 		Prerelease:           github.Ptr(input.Prerelease),
 		GenerateReleaseNotes: github.Ptr(input.GenerateReleaseNotes),
 	}
-	release, _, err := c.gh.Repositories.CreateRelease(ctx, owner, repo, req)
+	release, resp, err := c.gh.Repositories.CreateRelease(ctx, owner, repo, req)
 	if err != nil {
-		return nil, fmt.Errorf("create release: %w", err)
+		return nil, fmt.Errorf("create release: %w", ghErrors.Translate(err, resp))
 	}
 	return releaseFromGitHub(release), nil
 }
@@ -1127,35 +1202,29 @@ func (c *client) UpdateRelease(ctx context.Context, owner, repo string, id int64
 		Draft:           input.Draft,
 		Prerelease:      input.Prerelease,
 	}
-	release, _, err := c.gh.Repositories.UpdateRelease(ctx, owner, repo, id, req)
+	release, resp, err := c.gh.Repositories.UpdateRelease(ctx, owner, repo, id, req)
 	if err != nil {
-		return nil, fmt.Errorf("update release: %w", err)
+		return nil, fmt.Errorf("update release: %w", ghErrors.Translate(err, resp))
 	}
 	return releaseFromGitHub(release), nil
 }
 
 // DeleteRelease deletes a release.
 func (c *client) DeleteRelease(ctx context.Context, owner, repo string, id int64) error {
-	_, err := c.gh.Repositories.DeleteRelease(ctx, owner, repo, id)
-	return err
+	resp, err := c.gh.Repositories.DeleteRelease(ctx, owner, repo, id)
+	return ghErrors.Translate(err, resp)
 }
 
 // ListReleaseAssets lists assets for a release.
 func (c *client) ListReleaseAssets(ctx context.Context, owner, repo string, releaseID int64) ([]*gogithub.ReleaseAsset, error) {
-	var allAssets []*github.ReleaseAsset
-	opts := &github.ListOptions{PerPage: 100}
-	for {
-		assets, resp, err := c.gh.Repositories.ListReleaseAssets(ctx, owner, repo, releaseID, opts)
-		if err != nil {
-			return nil, fmt.Errorf("list release assets: %w", err)
-		}
-		allAssets = append(allAssets, assets...)
-		if resp.NextPage == 0 {
-			break
-		}
-		opts.Page = resp.NextPage
-	}
-	return releaseAssetsFromGitHub(allAssets), nil
+	return collect(c.IterReleaseAssets(ctx, owner, repo, releaseID, nil))
+}
+
+// IterReleaseAssets iterates over the assets of a release.
+func (c *client) IterReleaseAssets(ctx context.Context, owner, repo string, releaseID int64, iterOpts *IterOptions) iter.Seq2[*gogithub.ReleaseAsset, error] {
+	return pages(ctx, github.ListOptions{}, iterOpts, "list release assets", func(lo *github.ListOptions) ([]*github.ReleaseAsset, *github.Response, error) {
+		return c.gh.Repositories.ListReleaseAssets(ctx, owner, repo, releaseID, lo)
+	}, releaseAssetFromGitHub)
 }
 
 // SearchIssues searches for issues and pull requests.
@@ -1171,27 +1240,27 @@ func (c *client) SearchIssues(ctx context.Context, query string, opts *SearchOpt
 			searchOpts.ListOptions.Page = opts.Page
 		}
 	}
-	result, _, err := c.gh.Search.Issues(ctx, query, searchOpts)
+	result, resp, err := c.gh.Search.Issues(ctx, query, searchOpts)
 	if err != nil {
-		return nil, fmt.Errorf("search issues: %w", err)
+		return nil, fmt.Errorf("search issues: %w", ghErrors.Translate(err, resp))
 	}
 	return issueSearchResultFromGitHub(result), nil
 }
 
 // GetContributorStats gets contribution statistics for a repository.
 func (c *client) GetContributorStats(ctx context.Context, owner, repo string) ([]*gogithub.ContributorStats, error) {
-	stats, _, err := c.gh.Repositories.ListContributorsStats(ctx, owner, repo)
+	stats, resp, err := c.gh.Repositories.ListContributorsStats(ctx, owner, repo)
 	if err != nil {
-		return nil, fmt.Errorf("get contributor stats: %w", err)
+		return nil, fmt.Errorf("get contributor stats: %w", ghErrors.Translate(err, resp))
 	}
 	return contributorStatsFromGitHub(stats), nil
 }
 
 // GetRateLimit returns the core (non-search) API rate limit status.
 func (c *client) GetRateLimit(ctx context.Context) (*gogithub.RateLimit, error) {
-	limits, _, err := c.gh.RateLimit.Get(ctx)
+	limits, resp, err := c.gh.RateLimit.Get(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("get rate limit: %w", err)
+		return nil, fmt.Errorf("get rate limit: %w", ghErrors.Translate(err, resp))
 	}
 	core := limits.GetCore()
 	if core == nil {
@@ -1207,47 +1276,63 @@ func (c *client) GetRateLimit(ctx context.Context) (*gogithub.RateLimit, error)
 
 // ListUserEvents lists activity events performed by a user (their public timeline).
 func (c *client) ListUserEvents(ctx context.Context, username string, opts *ListUserEventsOptions) ([]*gogithub.Event, error) {
+	return collect(c.IterUserEvents(ctx, username, opts, nil))
+}
+
+// IterUserEvents iterates over activity events performed by a user.
+func (c *client) IterUserEvents(ctx context.Context, username string, opts *ListUserEventsOptions, iterOpts *IterOptions) iter.Seq2[*gogithub.Event, error] {
 	publicOnly := false
 	if opts != nil {
 		publicOnly = opts.PublicOnly
 	}
-	listOpts := &github.ListOptions{PerPage: 100}
-	var allEvents []*github.Event
-	for {
-		events, resp, err := c.gh.Activity.ListEventsPerformedByUser(ctx, username, publicOnly, listOpts)
-		if err != nil {
-			return nil, fmt.Errorf("list user events: %w", err)
-		}
-		allEvents = append(allEvents, events...)
-		if resp.NextPage == 0 {
-			break
-		}
-		listOpts.Page = resp.NextPage
-	}
-	return eventsFromGitHub(allEvents), nil
+	return pages(ctx, github.ListOptions{}, iterOpts, "list user events", func(lo *github.ListOptions) ([]*github.Event, *github.Response, error) {
+		return c.gh.Activity.ListEventsPerformedByUser(ctx, username, publicOnly, lo)
+	}, eventFromGitHub)
 }
 
 // ListWorkflows lists the GitHub Actions workflows defined in a repository.
 func (c *client) ListWorkflows(ctx context.Context, owner, repo string) ([]*gogithub.Workflow, error) {
-	listOpts := &github.ListOptions{PerPage: 100}
-	var allWorkflows []*github.Workflow
-	for {
-		workflows, resp, err := c.gh.Actions.ListWorkflows(ctx, owner, repo, listOpts)
+	return collect(c.IterWorkflows(ctx, owner, repo, nil))
+}
+
+// IterWorkflows iterates over the GitHub Actions workflows defined in a
+// repository.
+func (c *client) IterWorkflows(ctx context.Context, owner, repo string, iterOpts *IterOptions) iter.Seq2[*gogithub.Workflow, error] {
+	return pages(ctx, github.ListOptions{}, iterOpts, "list workflows", func(lo *github.ListOptions) ([]*github.Workflow, *github.Response, error) {
+		workflows, resp, err := c.gh.Actions.ListWorkflows(ctx, owner, repo, lo)
 		if err != nil {
-			return nil, fmt.Errorf("list workflows: %w", err)
-		}
-		allWorkflows = append(allWorkflows, workflows.Workflows...)
-		if resp.NextPage == 0 {
-			break
+			return nil, resp, err
 		}
-		listOpts.Page = resp.NextPage
-	}
-	return workflowsFromGitHub(allWorkflows), nil
+		return workflows.Workflows, resp, nil
+	}, workflowFromGitHub)
 }
 
 // ListWorkflowRuns lists runs of a workflow, most recent first. Unlike most
 // List* methods, this does not paginate through all results.
 func (c *client) ListWorkflowRuns(ctx context.Context, owner, repo string, workflowID int64, opts *ListWorkflowRunsOptions) ([]*gogithub.WorkflowRun, error) {
+	runOpts := workflowRunsListOptions(opts)
+	runs, resp, err := c.gh.Actions.ListWorkflowRunsByID(ctx, owner, repo, workflowID, runOpts)
+	if err != nil {
+		return nil, fmt.Errorf("list workflow runs: %w", ghErrors.Translate(err, resp))
+	}
+	return workflowRunsFromGitHub(runs.WorkflowRuns), nil
+}
+
+// IterWorkflowRuns iterates over all runs of a workflow, most recent first,
+// starting from opts.Page when set.
+func (c *client) IterWorkflowRuns(ctx context.Context, owner, repo string, workflowID int64, opts *ListWorkflowRunsOptions, iterOpts *IterOptions) iter.Seq2[*gogithub.WorkflowRun, error] {
+	runOpts := workflowRunsListOptions(opts)
+	return pages(ctx, runOpts.ListOptions, iterOpts, "list workflow runs", func(lo *github.ListOptions) ([]*github.WorkflowRun, *github.Response, error) {
+		runOpts.ListOptions = *lo
+		runs, resp, err := c.gh.Actions.ListWorkflowRunsByID(ctx, owner, repo, workflowID, runOpts)
+		if err != nil {
+			return nil, resp, err
+		}
+		return runs.WorkflowRuns, resp, nil
+	}, workflowRunFromGitHub)
+}
+
+func workflowRunsListOptions(opts *ListWorkflowRunsOptions) *github.ListWorkflowRunsOptions {
 	runOpts := &github.ListWorkflowRunsOptions{}
 	if opts != nil {
 		runOpts.Branch = opts.Branch
@@ -1259,11 +1344,7 @@ func (c *client) ListWorkflowRuns(ctx context.Context, owner, repo string, workf
 			runOpts.ListOptions.Page = opts.Page
 		}
 	}
-	runs, _, err := c.gh.Actions.ListWorkflowRunsByID(ctx, owner, repo, workflowID, runOpts)
-	if err != nil {
-		return nil, fmt.Errorf("list workflow runs: %w", err)
-	}
-	return workflowRunsFromGitHub(runs.WorkflowRuns), nil
+	return runOpts
 }
 
 // SearchCode searches for code in repositories.
@@ -1279,9 +1360,41 @@ func (c *client) SearchCode(ctx context.Context, query string, opts *SearchOptio
 			searchOpts.ListOptions.Page = opts.Page
 		}
 	}
-	result, _, err := c.gh.Search.Code(ctx, query, searchOpts)
+	result, resp, err := c.gh.Search.Code(ctx, query, searchOpts)
 	if err != nil {
-		return nil, fmt.Errorf("search code: %w", err)
+		return nil, fmt.Errorf("search code: %w", ghErrors.Translate(err, resp))
 	}
 	return codeSearchResultFromGitHub(result), nil
 }
+
+// UploadSARIF uploads a SARIF analysis to Code Scanning.
+func (c *client) UploadSARIF(ctx context.Context, owner, repo string, opts *UploadSARIFOptions) (*gogithub.SARIFUpload, error) {
+	analysis := &github.SarifAnalysis{
+		CommitSHA: github.Ptr(opts.CommitSHA),
+		Ref:       github.Ptr(opts.Ref),
+		Sarif:     github.Ptr(opts.Sarif),
+	}
+	if opts.CheckoutURI != "" {
+		analysis.CheckoutURI = github.Ptr(opts.CheckoutURI)
+	}
+	if opts.ToolName != "" {
+		analysis.ToolName = github.Ptr(opts.ToolName)
+	}
+	if opts.StartedAt != nil {
+		analysis.StartedAt = &github.Timestamp{Time: *opts.StartedAt}
+	}
+	id, resp, err := c.gh.CodeScanning.UploadSarif(ctx, owner, repo, analysis)
+	if err != nil {
+		return nil, fmt.Errorf("upload sarif: %w", ghErrors.Translate(err, resp))
+	}
+	return sarifUploadFromGitHub(id), nil
+}
+
+// GetSARIFUpload returns the processing status of a SARIF upload.
+func (c *client) GetSARIFUpload(ctx context.Context, owner, repo, sarifID string) (*gogithub.SARIFUploadStatus, error) {
+	upload, resp, err := c.gh.CodeScanning.GetSARIF(ctx, owner, repo, sarifID)
+	if err != nil {
+		return nil, fmt.Errorf("get sarif upload: %w", ghErrors.Translate(err, resp))
+	}
+	return sarifUploadStatusFromGitHub(upload), nil
+}
@@ -250,6 +250,34 @@ func TestWorkflowRunFromGitHub(t *testing.T) {
 	}
 }
 
+func TestSARIFUploadFromGitHub(t *testing.T) {
+	id := sarifUploadFromGitHub(&github.SarifID{
+		ID:  github.Ptr("47177e22-5596-11eb-80a1-c1e54ef945c6"),
+		URL: github.Ptr("https://api.github.com/repos/octocat/hello-world/code-scanning/sarifs/47177e22-5596-11eb-80a1-c1e54ef945c6"),
+	})
+	if id.ID != "47177e22-5596-11eb-80a1-c1e54ef945c6" {
+		t.Errorf("ID = %q, want %q", id.ID, "47177e22-5596-11eb-80a1-c1e54ef945c6")
+	}
+	if id.URL == "" {
+		t.Error("URL is empty")
+	}
+
+	status := sarifUploadStatusFromGitHub(&github.SARIFUpload{
+		ProcessingStatus: github.Ptr("complete"),
+		AnalysesURL:      github.Ptr("https://api.github.com/repos/octocat/hello-world/code-scanning/analyses?sarif_id=47177e22"),
+	})
+	if status.ProcessingStatus != "complete" {
+		t.Errorf("ProcessingStatus = %q, want %q", status.ProcessingStatus, "complete")
+	}
+	if status.AnalysesURL == "" {
+		t.Error("AnalysesURL is empty")
+	}
+
+	if sarifUploadFromGitHub(nil) != nil || sarifUploadStatusFromGitHub(nil) != nil {
+		t.Error("expected nil for nil input")
+	}
+}
+
 func TestNewClientFromRaw(t *testing.T) {
 	ghClient, err := github.NewClient()
 	if err != nil {
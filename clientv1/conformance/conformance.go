@@ -0,0 +1,282 @@
+// Package conformance provides a behavioral test suite for implementations
+// of clientv1.Client.
+//
+// Wrappers that add caching, logging or retries, and fakes that replace the
+// network, must keep the contract documented on clientv1.Client: for
+// example, GetBranchProtection returns (nil, nil) for an unprotected branch,
+// FileExists returns false rather than an error for a missing path, and
+// List methods return every page. Run checks such rules against a local
+// stand-in server, a githubtest.Server holding the Fixture:
+//
+//	func TestConformance(t *testing.T) {
+//		conformance.Run(t, func(t *testing.T, target conformance.Target) clientv1.Client {
+//			c, err := clientv1.NewClientWithOptions(context.Background(), clientv1.ClientOptions{
+//				Token:     target.Token,
+//				BaseURL:   target.BaseURL,
+//				UploadURL: target.UploadURL,
+//			})
+//			if err != nil {
+//				t.Fatal(err)
+//			}
+//			return mywrapper.New(c)
+//		})
+//	}
+//
+// Implementations that do not use HTTP can ignore the Target and instead
+// hold the state described by the Fixture constants, e.g. by starting from
+// NewFixture.
+package conformance
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/grokify/gogithub"
+	"github.com/grokify/gogithub/clientv1"
+	ghErrors "github.com/grokify/gogithub/errors"
+	"github.com/grokify/gogithub/githubtest"
+)
+
+// NewClientFunc returns the client under test, configured for target.
+type NewClientFunc func(t *testing.T, target Target) clientv1.Client
+
+// spec is one rule of the clientv1.Client contract.
+type spec struct {
+	name string
+	run  func(t *testing.T, c clientv1.Client)
+}
+
+// Target locates the stand-in server for the client under test.
+type Target struct {
+	// BaseURL and UploadURL are suitable for clientv1.ClientOptions.
+	BaseURL   string
+	UploadURL string
+	// Token authenticates with the server.
+	Token string
+}
+
+// Run runs the suite, one subtest per rule. Each subtest gets a new
+// stand-in server and a new client from newClient.
+func Run(t *testing.T, newClient NewClientFunc) {
+	t.Helper()
+	for _, s := range specs {
+		t.Run(s.name, func(t *testing.T) {
+			srv := githubtest.NewServerWithFake(NewFixture(t))
+			defer srv.Close()
+			opts := srv.ClientOptions()
+			c := newClient(t, Target{
+				BaseURL:   opts.BaseURL,
+				UploadURL: opts.UploadURL,
+				Token:     opts.Token,
+			})
+			s.run(t, c)
+		})
+	}
+}
+
+var specs = []spec{
+	{"GetAuthenticatedUser", func(t *testing.T, c clientv1.Client) {
+		u, err := c.GetAuthenticatedUser(context.Background())
+		if err != nil {
+			t.Fatalf("GetAuthenticatedUser() error = %v", err)
+		}
+		if u.Login != Owner {
+			t.Errorf("GetAuthenticatedUser().Login = %q, want %q", u.Login, Owner)
+		}
+	}},
+	{"GetUser/NotFound", func(t *testing.T, c clientv1.Client) {
+		_, err := c.GetUser(context.Background(), "no-such-user")
+		if !ghErrors.IsNotFound(err) {
+			t.Errorf("GetUser(missing) error = %v, want errors.IsNotFound", err)
+		}
+	}},
+	{"GetRepository", func(t *testing.T, c clientv1.Client) {
+		r, err := c.GetRepository(context.Background(), Owner, Repo)
+		if err != nil {
+			t.Fatalf("GetRepository() error = %v", err)
+		}
+		if r.FullName != Owner+"/"+Repo || r.DefaultBranch != DefaultBranch {
+			t.Errorf("GetRepository() = %q on %q, want %q on %q", r.FullName, r.DefaultBranch, Owner+"/"+Repo, DefaultBranch)
+		}
+	}},
+	{"GetRepository/NotFound", func(t *testing.T, c clientv1.Client) {
+		_, err := c.GetRepository(context.Background(), Owner, "no-such-repo")
+		if !ghErrors.IsNotFound(err) {
+			t.Errorf("GetRepository(missing) error = %v, want errors.IsNotFound", err)
+		}
+	}},
+	{"GetDefaultBranch", func(t *testing.T, c clientv1.Client) {
+		b, err := c.GetDefaultBranch(context.Background(), Owner, Repo)
+		if err != nil {
+			t.Fatalf("GetDefaultBranch() error = %v", err)
+		}
+		if b != DefaultBranch {
+			t.Errorf("GetDefaultBranch() = %q, want %q", b, DefaultBranch)
+		}
+	}},
+	{"GetBranchProtection/Protected", func(t *testing.T, c clientv1.Client) {
+		p, err := c.GetBranchProtection(context.Background(), Owner, Repo, DefaultBranch)
+		if err != nil {
+			t.Fatalf("GetBranchProtection() error = %v", err)
+		}
+		if p == nil || p.RequiredPullRequestReviews == nil || p.RequiredPullRequestReviews.RequiredApprovingReviewCount != 1 {
+			t.Errorf("GetBranchProtection() = %+v, want 1 required review", p)
+		}
+	}},
+	{"GetBranchProtection/Unprotected", func(t *testing.T, c clientv1.Client) {
+		p, err := c.GetBranchProtection(context.Background(), Owner, Repo, UnprotectedBranch)
+		if p != nil || err != nil {
+			t.Errorf("GetBranchProtection(unprotected) = %+v, %v, want nil, nil", p, err)
+		}
+	}},
+	{"FileExists", func(t *testing.T, c clientv1.Client) {
+		tests := []struct {
+			path string
+			want bool
+		}{
+			{ReadmePath, true},
+			{"no-such-file.txt", false},
+			{DocsDir, false},
+		}
+		for _, tt := range tests {
+			got, err := c.FileExists(context.Background(), Owner, Repo, tt.path, nil)
+			if err != nil {
+				t.Errorf("FileExists(%q) error = %v, want nil", tt.path, err)
+			}
+			if got != tt.want {
+				t.Errorf("FileExists(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		}
+	}},
+	{"GetFileContent", func(t *testing.T, c clientv1.Client) {
+		content, err := c.GetFileContent(context.Background(), Owner, Repo, ReadmePath, &gogithub.ContentOptions{Ref: DefaultBranch})
+		if err != nil {
+			t.Fatalf("GetFileContent() error = %v", err)
+		}
+		if string(content) != ReadmeContent {
+			t.Errorf("GetFileContent() = %q, want %q", content, ReadmeContent)
+		}
+	}},
+	{"GetFileContent/NotFound", func(t *testing.T, c clientv1.Client) {
+		_, err := c.GetFileContent(context.Background(), Owner, Repo, "no-such-file.txt", nil)
+		if !ghErrors.IsNotFound(err) {
+			t.Errorf("GetFileContent(missing) error = %v, want errors.IsNotFound", err)
+		}
+	}},
+	{"GetBranchSHA", func(t *testing.T, c clientv1.Client) {
+		for _, branch := range []string{DefaultBranch, UnprotectedBranch} {
+			sha, err := c.GetBranchSHA(context.Background(), Owner, Repo, branch)
+			if err != nil {
+				t.Fatalf("GetBranchSHA(%q) error = %v", branch, err)
+			}
+			if !isSHA(sha) {
+				t.Errorf("GetBranchSHA(%q) = %q, want a commit SHA", branch, sha)
+			}
+			ref, err := c.GetRef(context.Background(), Owner, Repo, "refs/heads/"+branch)
+			if err != nil {
+				t.Fatalf("GetRef(%q) error = %v", branch, err)
+			}
+			if ref.Object == nil || ref.Object.SHA != sha {
+				t.Errorf("GetRef(%q).Object = %+v, want SHA %q", branch, ref.Object, sha)
+			}
+		}
+		if _, err := c.GetBranchSHA(context.Background(), Owner, Repo, "no-such-branch"); !ghErrors.IsNotFound(err) {
+			t.Errorf("GetBranchSHA(missing) error = %v, want errors.IsNotFound", err)
+		}
+	}},
+	{"ListLanguages", func(t *testing.T, c clientv1.Client) {
+		langs, err := c.ListLanguages(context.Background(), Owner, Repo)
+		if err != nil {
+			t.Fatalf("ListLanguages() error = %v", err)
+		}
+		if len(langs) != len(Languages) {
+			t.Errorf("ListLanguages() = %v, want %v", langs, Languages)
+		}
+		for lang, n := range Languages {
+			if langs[lang] != n {
+				t.Errorf("ListLanguages()[%q] = %d, want %d", lang, langs[lang], n)
+			}
+		}
+	}},
+	{"ListOrgRepos/AllPages", func(t *testing.T, c clientv1.Client) {
+		repos, err := c.ListOrgRepos(context.Background(), PagedOrg)
+		if err != nil {
+			t.Fatalf("ListOrgRepos() error = %v", err)
+		}
+		if len(repos) != PagedOrgRepos {
+			t.Fatalf("len(ListOrgRepos()) = %d, want %d", len(repos), PagedOrgRepos)
+		}
+		seen := make(map[string]bool)
+		for _, r := range repos {
+			if seen[r.Name] {
+				t.Fatalf("ListOrgRepos() returned %q twice", r.Name)
+			}
+			seen[r.Name] = true
+		}
+	}},
+	{"IterOrgRepos/MaxItems", func(t *testing.T, c clientv1.Client) {
+		n := 0
+		for _, err := range c.IterOrgRepos(context.Background(), PagedOrg, &clientv1.IterOptions{MaxItems: 120}) {
+			if err != nil {
+				t.Fatalf("IterOrgRepos() error = %v", err)
+			}
+			n++
+		}
+		if n != 120 {
+			t.Errorf("IterOrgRepos(MaxItems: 120) yielded %d, want 120", n)
+		}
+	}},
+	{"IterOrgRepos/Break", func(t *testing.T, c clientv1.Client) {
+		n := 0
+		for _, err := range c.IterOrgRepos(context.Background(), PagedOrg, nil) {
+			if err != nil {
+				t.Fatalf("IterOrgRepos() error = %v", err)
+			}
+			n++
+			if n == 5 {
+				break
+			}
+		}
+		if n != 5 {
+			t.Errorf("IterOrgRepos() yielded %d before break, want 5", n)
+		}
+	}},
+	{"IterOrgRepos/Canceled", func(t *testing.T, c clientv1.Client) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var errs int
+		for _, err := range c.IterOrgRepos(ctx, PagedOrg, nil) {
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("IterOrgRepos(canceled) error = %v, want context.Canceled", err)
+			}
+			errs++
+		}
+		if errs != 1 {
+			t.Errorf("IterOrgRepos(canceled) yielded %d values, want 1 error", errs)
+		}
+	}},
+	{"GetRateLimit", func(t *testing.T, c clientv1.Client) {
+		rl, err := c.GetRateLimit(context.Background())
+		if err != nil {
+			t.Fatalf("GetRateLimit() error = %v", err)
+		}
+		if rl.Limit <= 0 || rl.Remaining < 0 || rl.Remaining > rl.Limit {
+			t.Errorf("GetRateLimit() = %+v, want 0 <= Remaining <= Limit", rl)
+		}
+	}},
+}
+
+// isSHA reports whether s is a full hex-encoded SHA-1 object ID.
+func isSHA(s string) bool {
+	if len(s) != 40 {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
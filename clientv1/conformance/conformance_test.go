@@ -0,0 +1,58 @@
+package conformance_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grokify/gogithub/clientv1"
+	"github.com/grokify/gogithub/clientv1/conformance"
+)
+
+func TestClient(t *testing.T) {
+	tests := []struct {
+		name string
+		opts clientv1.ClientOptions
+	}{
+		{"plain", clientv1.ClientOptions{}},
+		{"cache", clientv1.ClientOptions{Cache: &clientv1.CacheOptions{}}},
+		{"ratelimit", clientv1.ClientOptions{RateLimit: &clientv1.RateLimitOptions{}}},
+		{"middleware", clientv1.ClientOptions{Middleware: []clientv1.Middleware{
+			clientv1.TracingMiddleware(),
+			clientv1.NewMetrics().Middleware(),
+		}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conformance.Run(t, func(t *testing.T, target conformance.Target) clientv1.Client {
+				opts := tt.opts
+				opts.Token = target.Token
+				opts.BaseURL = target.BaseURL
+				opts.UploadURL = target.UploadURL
+				c, err := clientv1.NewClientWithOptions(context.Background(), opts)
+				if err != nil {
+					t.Fatalf("NewClientWithOptions() error = %v", err)
+				}
+				return c
+			})
+		})
+	}
+}
+
+func TestPoolClient(t *testing.T) {
+	conformance.Run(t, func(t *testing.T, target conformance.Target) clientv1.Client {
+		c, err := clientv1.NewPoolClient(context.Background(), []clientv1.Credential{
+			clientv1.TokenCredential("revoked", "not-"+target.Token),
+			clientv1.TokenCredential("valid", target.Token),
+		}, clientv1.ClientOptions{BaseURL: target.BaseURL, UploadURL: target.UploadURL})
+		if err != nil {
+			t.Fatalf("NewPoolClient() error = %v", err)
+		}
+		return c
+	})
+}
+
+func TestFake(t *testing.T) {
+	conformance.Run(t, func(t *testing.T, _ conformance.Target) clientv1.Client {
+		return conformance.NewFixture(t)
+	})
+}
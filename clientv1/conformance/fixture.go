@@ -0,0 +1,86 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/grokify/gogithub"
+	"github.com/grokify/gogithub/clientv1"
+	"github.com/grokify/gogithub/clientv1/fake"
+	"github.com/grokify/gogithub/githubtest"
+)
+
+// Fixture is the GitHub state the stand-in server serves. Implementations
+// that do not speak HTTP, such as in-memory fakes, must hold the same state
+// to pass the suite; NewFixture returns a fake that does.
+const (
+	// Token is the token the stand-in server accepts.
+	Token = githubtest.Token
+
+	// Owner is the login of the authenticated user and the owner of Repo.
+	Owner = "octocat"
+	// Repo is a repository of Owner with DefaultBranch and UnprotectedBranch.
+	Repo = "hello-world"
+	// DefaultBranch is Repo's default branch. It is protected, requiring
+	// one approving review.
+	DefaultBranch = "main"
+	// UnprotectedBranch is a branch of Repo without protection.
+	UnprotectedBranch = "dev"
+
+	// ReadmePath is a file on DefaultBranch with ReadmeContent.
+	ReadmePath    = "README.md"
+	ReadmeContent = "Hello World!\n"
+	// DocsDir is a directory on DefaultBranch.
+	DocsDir = "docs"
+
+	// PagedOrg is an organization with PagedOrgRepos repositories named
+	// "repo-001" through "repo-250", more than two pages' worth.
+	PagedOrg      = "big-org"
+	PagedOrgRepos = 250
+)
+
+// Languages is the language breakdown of Repo.
+var Languages = map[string]int{"Go": 12345, "Shell": 678}
+
+// NewFixture returns a fake holding the Fixture state. Run serves it with
+// a githubtest.Server.
+func NewFixture(t testing.TB) *fake.Client {
+	t.Helper()
+	ctx := context.Background()
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("conformance fixture: %v", err)
+		}
+	}
+
+	fc := fake.NewClient()
+	fc.SetAuthenticatedUser(Owner)
+	fc.AddRepositoryWith(&gogithub.Repository{
+		Owner:         &gogithub.User{Login: Owner},
+		Name:          Repo,
+		DefaultBranch: DefaultBranch,
+	})
+	_, err := fc.CreateFile(ctx, Owner, Repo, ReadmePath, &clientv1.CreateFileOptions{
+		Message: "Add README",
+		Content: []byte(ReadmeContent),
+	})
+	must(err)
+	_, err = fc.CreateFile(ctx, Owner, Repo, DocsDir+"/index.md", &clientv1.CreateFileOptions{
+		Message: "Add docs",
+	})
+	must(err)
+	sha, err := fc.GetBranchSHA(ctx, Owner, Repo, DefaultBranch)
+	must(err)
+	_, err = fc.CreateRef(ctx, Owner, Repo, "refs/heads/"+UnprotectedBranch, sha)
+	must(err)
+	must(fc.SetBranchProtection(Owner, Repo, DefaultBranch, &gogithub.BranchProtection{
+		RequiredPullRequestReviews: &gogithub.PullRequestReviewsEnforcement{RequiredApprovingReviewCount: 1},
+	}))
+	must(fc.SetLanguages(Owner, Repo, Languages))
+	for i := range PagedOrgRepos {
+		fc.AddRepository(PagedOrg, fmt.Sprintf("repo-%03d", i+1))
+	}
+	return fc
+}
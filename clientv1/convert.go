@@ -1,6 +1,9 @@
 package clientv1
 
 import (
+	"net/http"
+	"strings"
+
 	"github.com/google/go-github/v89/github"
 	"github.com/grokify/gogithub"
 )
@@ -960,3 +963,66 @@ func workflowRunsFromGitHub(runs []*github.WorkflowRun) []*gogithub.WorkflowRun
 	}
 	return result
 }
+
+// sarifUploadFromGitHub converts a go-github SarifID to a stable SARIFUpload.
+func sarifUploadFromGitHub(id *github.SarifID) *gogithub.SARIFUpload {
+	if id == nil {
+		return nil
+	}
+	return &gogithub.SARIFUpload{
+		ID:  id.GetID(),
+		URL: id.GetURL(),
+	}
+}
+
+// sarifUploadStatusFromGitHub converts a go-github SARIFUpload to a stable SARIFUploadStatus.
+func sarifUploadStatusFromGitHub(u *github.SARIFUpload) *gogithub.SARIFUploadStatus {
+	if u == nil {
+		return nil
+	}
+	return &gogithub.SARIFUploadStatus{
+		ProcessingStatus: u.GetProcessingStatus(),
+		AnalysesURL:      u.GetAnalysesURL(),
+	}
+}
+
+// responseFromGitHub converts a go-github Response to a stable Response.
+func responseFromGitHub(resp *github.Response) *gogithub.Response {
+	if resp == nil || resp.Response == nil {
+		return nil
+	}
+	r := &gogithub.Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		NextPage:   resp.NextPage,
+		PrevPage:   resp.PrevPage,
+		FirstPage:  resp.FirstPage,
+		LastPage:   resp.LastPage,
+		NextURL:    linkURL(resp.Header, "next"),
+	}
+	if resp.Header.Get(headerRateLimitLimit) != "" {
+		r.Rate = &gogithub.RateLimit{
+			Limit:     resp.Rate.Limit,
+			Remaining: resp.Rate.Remaining,
+			Used:      resp.Rate.Used,
+			Reset:     resp.Rate.Reset.Time,
+		}
+	}
+	return r
+}
+
+// linkURL returns the URL with relation rel in h's Link header, if any.
+func linkURL(h http.Header, rel string) string {
+	for link := range strings.SplitSeq(h.Get("Link"), ",") {
+		target, params, ok := strings.Cut(strings.TrimSpace(link), ";")
+		if !ok || !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+		for param := range strings.SplitSeq(params, ";") {
+			if strings.TrimSpace(param) == `rel="`+rel+`"` {
+				return target[1 : len(target)-1]
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,148 @@
+package clientv1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	ghErrors "github.com/grokify/gogithub/errors"
+)
+
+type view struct {
+	Timestamp string `json:"timestamp"`
+	Count     int    `json:"count"`
+}
+
+// doServer serves a traffic endpoint, an echo endpoint and 25 numbered
+// items paginated like GitHub, under the GitHub Enterprise API prefix.
+func doServer(t *testing.T) (*httptest.Server, Client) {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/o/r/traffic/views", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		w.Header().Set("X-RateLimit-Used", "1")
+		w.Header().Set("X-RateLimit-Reset", "1700000000")
+		fmt.Fprint(w, `{"count":3,"views":[{"timestamp":"2026-10-01T00:00:00Z","count":3}]}`)
+	})
+	mux.HandleFunc("POST /echo", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var v map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(v)
+	})
+	mux.HandleFunc("GET /items", func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		page = max(page, 1)
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		const total = 25
+		last := (total + perPage - 1) / perPage
+		if page < last {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s/api/v3/items?page=%d&per_page=%d>; rel="next", <http://%s/api/v3/items?page=%d&per_page=%d>; rel="last"`,
+				r.Host, page+1, perPage, r.Host, last, perPage))
+		}
+		var items []int
+		for i := (page-1)*perPage + 1; i <= min(page*perPage, total); i++ {
+			items = append(items, i)
+		}
+		_ = json.NewEncoder(w).Encode(items)
+	})
+	srv := httptest.NewServer(http.StripPrefix("/api/v3", mux))
+	t.Cleanup(srv.Close)
+
+	c, err := NewClientWithOptions(context.Background(), ClientOptions{BaseURL: srv.URL + "/", UploadURL: srv.URL + "/"})
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+	return srv, c
+}
+
+func TestDo(t *testing.T) {
+	ctx := context.Background()
+	_, c := doServer(t)
+
+	var views struct {
+		Count int    `json:"count"`
+		Views []view `json:"views"`
+	}
+	resp, err := c.Do(ctx, http.MethodGet, "/repos/o/r/traffic/views", nil, &views)
+	if err != nil {
+		t.Fatalf("Do(GET) error = %v", err)
+	}
+	if views.Count != 3 || len(views.Views) != 1 {
+		t.Errorf("Do(GET) decoded %+v", views)
+	}
+	if resp.StatusCode != http.StatusOK || resp.Rate == nil || resp.Rate.Remaining != 4999 || resp.Rate.Reset.Unix() != 1700000000 {
+		t.Errorf("Do(GET) response = %+v, rate %+v", resp, resp.Rate)
+	}
+
+	var echoed map[string]string
+	resp, err = c.Do(ctx, http.MethodPost, "echo", map[string]string{"name": "pages"}, &echoed)
+	if err != nil {
+		t.Fatalf("Do(POST) error = %v", err)
+	}
+	if resp.StatusCode != http.StatusCreated || echoed["name"] != "pages" || resp.Rate != nil {
+		t.Errorf("Do(POST) = %+v, %v", resp, echoed)
+	}
+
+	resp, err = c.Do(ctx, http.MethodGet, "repos/o/r/pages", nil, nil)
+	if !ghErrors.IsNotFound(err) {
+		t.Errorf("Do(missing) error = %v, want not found", err)
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Do(missing) response = %+v, want 404", resp)
+	}
+}
+
+func TestDoAll(t *testing.T) {
+	ctx := context.Background()
+	_, c := doServer(t)
+
+	resp, err := c.Do(ctx, http.MethodGet, "items?per_page=10", nil, nil)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if resp.NextPage != 2 || resp.LastPage != 3 || resp.NextURL == "" {
+		t.Errorf("Do() pagination = %+v", resp)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		opts *IterOptions
+		want int
+	}{
+		{"all", "items", nil, 25},
+		{"page size", "items", &IterOptions{PerPage: 7}, 25},
+		{"path page size", "items?per_page=4", &IterOptions{PerPage: 7}, 25},
+		{"max items", "items", &IterOptions{MaxItems: 12, PerPage: 5}, 12},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := DoAll[int](ctx, c, tt.path, tt.opts)
+			if err != nil {
+				t.Fatalf("DoAll() error = %v", err)
+			}
+			if len(items) != tt.want {
+				t.Fatalf("DoAll() returned %d items, want %d", len(items), tt.want)
+			}
+			for i, item := range items {
+				if item != i+1 {
+					t.Fatalf("DoAll()[%d] = %d, want %d", i, item, i+1)
+				}
+			}
+		})
+	}
+
+	if _, err := DoAll[int](ctx, c, "missing", nil); !ghErrors.IsNotFound(err) {
+		t.Errorf("DoAll(missing) error = %v, want not found", err)
+	}
+}
@@ -41,9 +41,15 @@
 //
 // # Escape Hatch
 //
-// For advanced use cases not yet wrapped, use [Client.Raw] to access the
-// underlying go-github client. Note: this couples your code to a specific
-// go-github version.
+// Endpoints without a wrapped method can be called with [Client.Do], and
+// paginated ones with [DoAll], which return the stable [gogithub.Response]:
+//
+//	var views struct{ Count int }
+//	resp, err := client.Do(ctx, http.MethodGet, "repos/o/r/traffic/views", nil, &views)
+//
+// For other advanced use cases, use [Client.Raw] to access the underlying
+// go-github client. Note: this couples your code to a specific go-github
+// version.
 //
 //	raw := client.Raw().(*github.Client)
 //	// Use raw go-github client directly
@@ -0,0 +1,77 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grokify/gogithub"
+)
+
+// Checks
+
+// GetCheckRun retrieves a check run by ID.
+func (c *Client) GetCheckRun(ctx context.Context, owner, repo string, checkRunID int64) (*gogithub.CheckRun, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	for _, run := range rs.checkRuns {
+		if run.ID == checkRunID {
+			cp := *run
+			return &cp, nil
+		}
+	}
+	return nil, notFound()
+}
+
+// ListCheckRuns lists check runs for the commit ref resolves to.
+func (c *Client) ListCheckRuns(ctx context.Context, owner, repo, ref string) ([]*gogithub.CheckRun, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, sha, err := c.checkRef(owner, repo, ref)
+	if err != nil {
+		return nil, fmt.Errorf("list check runs: %w", err)
+	}
+	runs := []*gogithub.CheckRun{}
+	for _, run := range rs.checkRuns {
+		if run.HeadSHA == sha {
+			cp := *run
+			runs = append(runs, &cp)
+		}
+	}
+	return runs, nil
+}
+
+// ListCheckSuites lists check suites for the commit ref resolves to.
+func (c *Client) ListCheckSuites(ctx context.Context, owner, repo, ref string) ([]*gogithub.CheckSuite, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, sha, err := c.checkRef(owner, repo, ref)
+	if err != nil {
+		return nil, fmt.Errorf("list check suites: %w", err)
+	}
+	suites := []*gogithub.CheckSuite{}
+	for _, suite := range rs.checkSuites {
+		if suite.HeadSHA == sha {
+			cp := *suite
+			suites = append(suites, &cp)
+		}
+	}
+	return suites, nil
+}
+
+// checkRef resolves a branch, tag or SHA to a commit SHA, answering 422 for
+// unknown refs as GitHub does. Callers must hold c.mu.
+func (c *Client) checkRef(owner, repo, ref string) (*repository, string, error) {
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, "", err
+	}
+	sha, ok := rs.resolveCommit(ref)
+	if !ok {
+		return nil, "", validationFailed("No commit found for SHA: " + ref)
+	}
+	return rs, sha, nil
+}
@@ -0,0 +1,100 @@
+package fake
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/grokify/gogithub"
+	"github.com/grokify/gogithub/clientv1"
+)
+
+// SetSARIFUploadStatus overrides the processing status of a SARIF upload,
+// e.g. to exercise pending or failed uploads. Uploads are otherwise
+// reported as complete.
+func (c *Client) SetSARIFUploadStatus(owner, repo, sarifID, status string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return err
+	}
+	upload, ok := rs.sarifUploads[sarifID]
+	if !ok {
+		return notFound()
+	}
+	upload.ProcessingStatus = status
+	return nil
+}
+
+// Code Scanning
+
+// UploadSARIF validates and records a SARIF upload. The commit must exist
+// and Sarif must be a gzip-compressed, base64-encoded JSON document.
+func (c *Client) UploadSARIF(ctx context.Context, owner, repo string, opts *clientv1.UploadSARIFOptions) (*gogithub.SARIFUpload, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("upload sarif: %w", err)
+	}
+	if _, ok := rs.objects.commits[opts.CommitSHA]; !ok {
+		return nil, fmt.Errorf("upload sarif: %w", validationFailed("commit_sha does not exist: "+opts.CommitSHA))
+	}
+	if !strings.HasPrefix(opts.Ref, "refs/") {
+		return nil, fmt.Errorf("upload sarif: %w", validationFailed("ref must be a full Git reference"))
+	}
+	if !validSARIF(opts.Sarif) {
+		return nil, fmt.Errorf("upload sarif: %w", apiError(http.StatusBadRequest, "Invalid SARIF upload"))
+	}
+
+	n := c.id()
+	id := fmt.Sprintf("%08x-0000-4000-8000-%012x", n, n)
+	url := rs.apiURL("/code-scanning/sarifs/" + id)
+	rs.sarifUploads[id] = &gogithub.SARIFUploadStatus{
+		ProcessingStatus: "complete",
+		AnalysesURL:      rs.apiURL("/code-scanning/analyses?sarif_id=" + id),
+	}
+	return &gogithub.SARIFUpload{ID: id, URL: url}, nil
+}
+
+// GetSARIFUpload returns the processing status of a SARIF upload.
+func (c *Client) GetSARIFUpload(ctx context.Context, owner, repo, sarifID string) (*gogithub.SARIFUploadStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("get sarif upload: %w", err)
+	}
+	upload, ok := rs.sarifUploads[sarifID]
+	if !ok {
+		return nil, fmt.Errorf("get sarif upload: %w", notFound())
+	}
+	status := *upload
+	if status.ProcessingStatus != "complete" {
+		status.AnalysesURL = ""
+	}
+	return &status, nil
+}
+
+// validSARIF reports whether encoded is base64-encoded gzip data holding a
+// JSON document.
+func validSARIF(encoded string) bool {
+	compressed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return false
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return false
+	}
+	defer gz.Close()
+	data, err := io.ReadAll(gz)
+	return err == nil && json.Valid(data)
+}
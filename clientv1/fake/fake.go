@@ -0,0 +1,482 @@
+// Package fake provides a stateful, in-memory implementation of
+// clientv1.Client for unit tests.
+//
+// The fake models the parts of GitHub that clientv1 exposes — users,
+// repositories, git refs, trees, blobs, commits, annotated tags, pull
+// requests, issues, comments, releases and check runs — so code written
+// against clientv1.Client can be exercised end to end without a network:
+//
+//	fc := fake.NewClient()
+//	fc.AddRepository("octocat", "hello-world")
+//
+//	sha, _ := repo.GetBranchSHA(ctx, fc, "octocat", "hello-world", "main")
+//	_ = repo.CreateBranch(ctx, fc, "octocat", "hello-world", "feature", sha)
+//
+// Failures are reported with the same translated errors the real client
+// produces for the corresponding GitHub responses (404 Not Found, 409
+// Conflict, 422 Validation Failed), so error-path tests can use
+// errors.IsNotFound, errors.IsConflict and errors.IsValidation.
+package fake
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // git object IDs are SHA-1
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/grokify/gogithub"
+	"github.com/grokify/gogithub/clientv1"
+	ghErrors "github.com/grokify/gogithub/errors"
+)
+
+const (
+	// DefaultLogin is the login of the authenticated user of a new Client.
+	DefaultLogin = "fake-user"
+
+	// DefaultBranch is the default branch of repositories created with
+	// AddRepository.
+	DefaultBranch = "main"
+
+	refHeadsPrefix = "refs/heads/"
+	refTagsPrefix  = "refs/tags/"
+)
+
+// Client is an in-memory implementation of clientv1.Client.
+// It is safe for concurrent use.
+type Client struct {
+	mu sync.Mutex
+
+	login  string
+	users  map[string]*gogithub.User
+	repos  map[string]*repository
+	events map[string][]*gogithub.Event
+
+	orgMembers map[string][]string // org -> member logins
+	teams      map[string][]string // "org/slug" -> member logins
+
+	rateLimit gogithub.RateLimit
+
+	nextID int64
+	now    func() time.Time
+}
+
+var _ clientv1.Client = (*Client)(nil)
+
+// NewClient creates an empty fake authenticated as DefaultLogin.
+func NewClient() *Client {
+	c := &Client{
+		login:  DefaultLogin,
+		users:  make(map[string]*gogithub.User),
+		repos:  make(map[string]*repository),
+		events: make(map[string][]*gogithub.Event),
+
+		orgMembers: make(map[string][]string),
+		teams:      make(map[string][]string),
+
+		nextID: 1000,
+		now:    func() time.Time { return time.Now().UTC() },
+	}
+	c.rateLimit = gogithub.RateLimit{
+		Limit:     5000,
+		Remaining: 5000,
+		Reset:     c.now().Add(time.Hour),
+	}
+	c.users[DefaultLogin] = c.newUser(DefaultLogin)
+	return c
+}
+
+// Raw returns nil; the fake has no underlying go-github client.
+func (c *Client) Raw() any {
+	return nil
+}
+
+// Do fails with a 404 Not Found error, as the fake models no endpoints
+// beyond the Client methods. Serve the fake with githubtest.Server to call
+// those through Do.
+func (c *Client) Do(ctx context.Context, method, path string, body, out any) (*gogithub.Response, error) {
+	return &gogithub.Response{StatusCode: http.StatusNotFound, Header: make(http.Header)}, fmt.Errorf("%s %s: %w", method, path, notFound())
+}
+
+// SetAuthenticatedUser makes login the authenticated user, creating the
+// user if it does not exist yet.
+func (c *Client) SetAuthenticatedUser(login string) *gogithub.User {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.login = login
+	return copyUser(c.ensureUser(login))
+}
+
+// AddUser adds or replaces a user. ID, HTMLURL and Type are filled in
+// when empty.
+func (c *Client) AddUser(u *gogithub.User) *gogithub.User {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stored := *u
+	if stored.ID == 0 {
+		stored.ID = c.id()
+	}
+	if stored.HTMLURL == "" {
+		stored.HTMLURL = gogithub.BaseURLRepoHTML + "/" + stored.Login
+	}
+	if stored.Type == "" {
+		stored.Type = "User"
+	}
+	c.users[strings.ToLower(stored.Login)] = &stored
+	return copyUser(&stored)
+}
+
+// AddRepository creates a repository with an initial empty commit on
+// DefaultBranch and returns it. If the repository already exists it is
+// returned unchanged.
+func (c *Client) AddRepository(owner, name string) *gogithub.Repository {
+	return c.AddRepositoryWith(&gogithub.Repository{
+		Owner: &gogithub.User{Login: owner},
+		Name:  name,
+	})
+}
+
+// AddRepositoryWith creates a repository from r, which must have Owner.Login
+// and Name set. Unset fields such as ID, URLs and DefaultBranch are filled
+// in, and an initial empty commit is created on the default branch.
+func (c *Client) AddRepositoryWith(r *gogithub.Repository) *gogithub.Repository {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if existing, ok := c.repos[repoKey(r.Owner.Login, r.Name)]; ok {
+		return copyRepository(existing.repo)
+	}
+	rs := c.newRepository(r)
+	tree := rs.writeTree(nil)
+	commit := rs.writeCommit(c, "Initial commit", tree, nil, nil)
+	rs.refs[refHeadsPrefix+rs.repo.DefaultBranch] = commit.SHA
+	return copyRepository(rs.repo)
+}
+
+// SetLanguages sets the languages reported by ListLanguages.
+func (c *Client) SetLanguages(owner, repo string, languages map[string]int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return err
+	}
+	rs.languages = make(map[string]int, len(languages))
+	for k, v := range languages {
+		rs.languages[k] = v
+	}
+	return nil
+}
+
+// SetBranchProtection sets the protection returned by GetBranchProtection.
+// A nil protection removes it.
+func (c *Client) SetBranchProtection(owner, repo, branch string, p *gogithub.BranchProtection) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		delete(rs.protection, branch)
+		return nil
+	}
+	stored := *p
+	rs.protection[branch] = &stored
+	return nil
+}
+
+// AddCheckRun records a check run for a commit. ID is assigned when zero.
+func (c *Client) AddCheckRun(owner, repo string, run *gogithub.CheckRun) (*gogithub.CheckRun, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	stored := *run
+	if stored.ID == 0 {
+		stored.ID = c.id()
+	}
+	if stored.Status == "" {
+		stored.Status = "queued"
+	}
+	if stored.HTMLURL == "" {
+		stored.HTMLURL = fmt.Sprintf("%s/runs/%d", rs.repo.HTMLURL, stored.ID)
+	}
+	rs.checkRuns = append(rs.checkRuns, &stored)
+	cp := stored
+	return &cp, nil
+}
+
+// AddCheckSuite records a check suite for a commit. ID is assigned when zero.
+func (c *Client) AddCheckSuite(owner, repo string, suite *gogithub.CheckSuite) (*gogithub.CheckSuite, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	stored := *suite
+	if stored.ID == 0 {
+		stored.ID = c.id()
+	}
+	if stored.Status == "" {
+		stored.Status = "queued"
+	}
+	if stored.CreatedAt.IsZero() {
+		stored.CreatedAt = c.now()
+		stored.UpdatedAt = stored.CreatedAt
+	}
+	rs.checkSuites = append(rs.checkSuites, &stored)
+	cp := stored
+	return &cp, nil
+}
+
+// AddContributorStats records contributor statistics for a repository.
+func (c *Client) AddContributorStats(owner, repo string, stats *gogithub.ContributorStats) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return err
+	}
+	stored := *stats
+	rs.contributors = append(rs.contributors, &stored)
+	return nil
+}
+
+// AddWorkflow records a GitHub Actions workflow. ID is assigned when zero.
+func (c *Client) AddWorkflow(owner, repo string, w *gogithub.Workflow) (*gogithub.Workflow, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	stored := *w
+	if stored.ID == 0 {
+		stored.ID = c.id()
+	}
+	if stored.State == "" {
+		stored.State = "active"
+	}
+	rs.workflows = append(rs.workflows, &stored)
+	cp := stored
+	return &cp, nil
+}
+
+// AddWorkflowRun records a run of a workflow. ID is assigned when zero.
+func (c *Client) AddWorkflowRun(owner, repo string, run *gogithub.WorkflowRun) (*gogithub.WorkflowRun, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	stored := *run
+	if stored.ID == 0 {
+		stored.ID = c.id()
+	}
+	if stored.CreatedAt.IsZero() {
+		stored.CreatedAt = c.now()
+		stored.UpdatedAt = stored.CreatedAt
+	}
+	rs.workflowRuns = append(rs.workflowRuns, &stored)
+	cp := stored
+	return &cp, nil
+}
+
+// AddEvent records an activity event performed by username.
+func (c *Client) AddEvent(username string, e *gogithub.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stored := *e
+	if stored.ID == "" {
+		stored.ID = fmt.Sprintf("%d", c.id())
+	}
+	if stored.CreatedAt.IsZero() {
+		stored.CreatedAt = c.now()
+	}
+	key := strings.ToLower(username)
+	c.events[key] = append(c.events[key], &stored)
+}
+
+// SetRateLimit sets the value returned by GetRateLimit.
+func (c *Client) SetRateLimit(rl gogithub.RateLimit) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rateLimit = rl
+}
+
+// Authentication
+
+// GetAuthenticatedUser returns the currently authenticated user.
+func (c *Client) GetAuthenticatedUser(ctx context.Context) (*gogithub.User, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return copyUser(c.ensureUser(c.login)), nil
+}
+
+// GetUser returns information about a specific user.
+func (c *Client) GetUser(ctx context.Context, username string) (*gogithub.User, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	u, ok := c.users[strings.ToLower(username)]
+	if !ok {
+		return nil, notFound()
+	}
+	return copyUser(u), nil
+}
+
+// Rate Limits
+
+// GetRateLimit returns the rate limit set with SetRateLimit.
+func (c *Client) GetRateLimit(ctx context.Context) (*gogithub.RateLimit, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rl := c.rateLimit
+	return &rl, nil
+}
+
+// Activity
+
+// ListUserEvents lists events recorded with AddEvent, most recent first.
+// Events are always public in the fake, so PublicOnly has no effect.
+func (c *Client) ListUserEvents(ctx context.Context, username string, opts *clientv1.ListUserEventsOptions) ([]*gogithub.Event, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.users[strings.ToLower(username)]; !ok {
+		return nil, fmt.Errorf("list user events: %w", notFound())
+	}
+	events := c.events[strings.ToLower(username)]
+	result := make([]*gogithub.Event, len(events))
+	for i, e := range events {
+		cp := *e
+		result[len(events)-1-i] = &cp
+	}
+	return result, nil
+}
+
+// helpers
+
+// id returns the next unique numeric ID. Callers must hold c.mu.
+func (c *Client) id() int64 {
+	c.nextID++
+	return c.nextID
+}
+
+// newUser builds a user record for login. Callers must hold c.mu.
+func (c *Client) newUser(login string) *gogithub.User {
+	now := c.now()
+	return &gogithub.User{
+		ID:        c.id(),
+		Login:     login,
+		HTMLURL:   gogithub.BaseURLRepoHTML + "/" + login,
+		AvatarURL: fmt.Sprintf("https://avatars.githubusercontent.com/u/%d", c.nextID),
+		Type:      "User",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// ensureUser returns the user for login, creating it if needed.
+// Callers must hold c.mu.
+func (c *Client) ensureUser(login string) *gogithub.User {
+	key := strings.ToLower(login)
+	if u, ok := c.users[key]; ok {
+		return u
+	}
+	u := c.newUser(login)
+	c.users[key] = u
+	return u
+}
+
+// repo looks up a repository. Callers must hold c.mu.
+func (c *Client) repo(owner, name string) (*repository, error) {
+	rs, ok := c.repos[repoKey(owner, name)]
+	if !ok {
+		return nil, notFound()
+	}
+	return rs, nil
+}
+
+func repoKey(owner, name string) string {
+	return strings.ToLower(owner + "/" + name)
+}
+
+// apiError builds the error the real client returns for a GitHub API
+// response with the given status code and message: a go-github
+// ErrorResponse passed through errors.Translate.
+func apiError(status int, message string) error {
+	return ghErrors.Translate(&github.ErrorResponse{
+		Response: &http.Response{StatusCode: status, Status: fmt.Sprintf("%d %s", status, http.StatusText(status))},
+		Message:  message,
+	}, nil)
+}
+
+// validationError builds a 422 Validation Failed error with a single
+// field error, as GitHub reports for invalid create and update requests.
+func validationError(resource, field, code, message string) error {
+	return ghErrors.Translate(&github.ErrorResponse{
+		Response: &http.Response{StatusCode: http.StatusUnprocessableEntity, Status: "422 Unprocessable Entity"},
+		Message:  "Validation Failed",
+		Errors: []github.Error{{
+			Resource: resource,
+			Field:    field,
+			Code:     code,
+			Message:  message,
+		}},
+	}, nil)
+}
+
+func notFound() error {
+	return apiError(http.StatusNotFound, "Not Found")
+}
+
+func validationFailed(message string) error {
+	return apiError(http.StatusUnprocessableEntity, message)
+}
+
+func conflict(message string) error {
+	return apiError(http.StatusConflict, message)
+}
+
+// hashObject returns the git object ID for content of the given type.
+func hashObject(typ string, content []byte) string {
+	h := sha1.New() //nolint:gosec // git object IDs are SHA-1
+	fmt.Fprintf(h, "%s %d\x00", typ, len(content))
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func copyUser(u *gogithub.User) *gogithub.User {
+	if u == nil {
+		return nil
+	}
+	cp := *u
+	return &cp
+}
+
+func copyRepository(r *gogithub.Repository) *gogithub.Repository {
+	if r == nil {
+		return nil
+	}
+	cp := *r
+	cp.Owner = copyUser(r.Owner)
+	cp.Topics = append([]string(nil), r.Topics...)
+	return &cp
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,387 @@
+package fake_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/grokify/gogithub/clientv1"
+	"github.com/grokify/gogithub/clientv1/fake"
+	"github.com/grokify/gogithub/errors"
+	"github.com/grokify/gogithub/pr"
+	"github.com/grokify/gogithub/release"
+	"github.com/grokify/gogithub/repo"
+	"github.com/grokify/gogithub/tag"
+)
+
+const (
+	testOwner = "octocat"
+	testRepo  = "hello-world"
+)
+
+func newTestClient(t *testing.T) *fake.Client {
+	t.Helper()
+	fc := fake.NewClient()
+	fc.AddRepository(testOwner, testRepo)
+	return fc
+}
+
+func TestCreateBranch(t *testing.T) {
+	ctx := context.Background()
+	fc := newTestClient(t)
+
+	sha, err := repo.GetBranchSHA(ctx, fc, testOwner, testRepo, fake.DefaultBranch)
+	if err != nil {
+		t.Fatalf("GetBranchSHA() error = %v", err)
+	}
+	if err := repo.CreateBranch(ctx, fc, testOwner, testRepo, "feature", sha); err != nil {
+		t.Fatalf("CreateBranch() error = %v", err)
+	}
+	// Creating an existing branch is not an error.
+	if err := repo.CreateBranch(ctx, fc, testOwner, testRepo, "feature", sha); err != nil {
+		t.Errorf("CreateBranch() existing branch error = %v, want nil", err)
+	}
+
+	got, err := repo.GetBranchSHA(ctx, fc, testOwner, testRepo, "feature")
+	if err != nil {
+		t.Fatalf("GetBranchSHA(feature) error = %v", err)
+	}
+	if got != sha {
+		t.Errorf("GetBranchSHA(feature) = %q, want %q", got, sha)
+	}
+
+	err = repo.CreateBranch(ctx, fc, testOwner, testRepo, "bad", strings.Repeat("0", 40))
+	if err == nil {
+		t.Error("CreateBranch() with unknown SHA error = nil, want error")
+	}
+
+	exists, err := repo.BranchExists(ctx, fc, testOwner, testRepo, "missing")
+	if err != nil {
+		t.Fatalf("BranchExists() error = %v", err)
+	}
+	if exists {
+		t.Error("BranchExists(missing) = true, want false")
+	}
+}
+
+func TestTagExists(t *testing.T) {
+	ctx := context.Background()
+	fc := newTestClient(t)
+
+	sha, err := fc.GetBranchSHA(ctx, testOwner, testRepo, fake.DefaultBranch)
+	if err != nil {
+		t.Fatalf("GetBranchSHA() error = %v", err)
+	}
+	if err := tag.CreateTag(ctx, fc, testOwner, testRepo, "v1.0.0", sha, "Release v1.0.0"); err != nil {
+		t.Fatalf("CreateTag() error = %v", err)
+	}
+
+	tests := []struct {
+		tag  string
+		want bool
+	}{
+		{"v1.0.0", true},
+		{"v2.0.0", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, err := tag.TagExists(ctx, fc, testOwner, testRepo, tt.tag)
+			if err != nil {
+				t.Fatalf("TagExists() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("TagExists(%q) = %v, want %v", tt.tag, got, tt.want)
+			}
+		})
+	}
+
+	// Annotated tag refs point at the tag object; ListTags peels to the commit.
+	tags, err := tag.ListTags(ctx, fc, testOwner, testRepo)
+	if err != nil {
+		t.Fatalf("ListTags() error = %v", err)
+	}
+	if len(tags) != 1 || tags[0].SHA != sha {
+		t.Errorf("ListTags() = %+v, want v1.0.0 at %q", tags, sha)
+	}
+}
+
+func TestCreatePR(t *testing.T) {
+	ctx := context.Background()
+	fc := newTestClient(t)
+	fc.SetAuthenticatedUser("contributor")
+
+	fork, err := fc.CreateFork(ctx, testOwner, testRepo, nil)
+	if err != nil {
+		t.Fatalf("CreateFork() error = %v", err)
+	}
+	forkOwner := fork.Owner.Login
+	if forkOwner != "contributor" {
+		t.Errorf("fork owner = %q, want %q", forkOwner, "contributor")
+	}
+
+	sha, err := repo.GetBranchSHA(ctx, fc, forkOwner, testRepo, fake.DefaultBranch)
+	if err != nil {
+		t.Fatalf("GetBranchSHA() error = %v", err)
+	}
+	if err := repo.CreateBranch(ctx, fc, forkOwner, testRepo, "fix", sha); err != nil {
+		t.Fatalf("CreateBranch() error = %v", err)
+	}
+
+	// A branch without commits cannot be proposed.
+	_, err = pr.CreatePR(ctx, fc, testOwner, testRepo, forkOwner, "fix", fake.DefaultBranch, "Fix", "")
+	if err == nil {
+		t.Fatal("CreatePR() without commits error = nil, want error")
+	}
+
+	_, err = fc.CreateFile(ctx, forkOwner, testRepo, "README.md", &clientv1.CreateFileOptions{
+		Content: []byte("# Hello\n"),
+		Message: "Add README",
+		Branch:  "fix",
+	})
+	if err != nil {
+		t.Fatalf("CreateFile() error = %v", err)
+	}
+
+	got, err := pr.CreatePR(ctx, fc, testOwner, testRepo, forkOwner, "fix", fake.DefaultBranch, "Fix", "Adds a README.")
+	if err != nil {
+		t.Fatalf("CreatePR() error = %v", err)
+	}
+	if got.Number != 1 || got.State != "open" || got.Commits != 1 {
+		t.Errorf("CreatePR() = #%d %s with %d commits, want #1 open with 1 commit", got.Number, got.State, got.Commits)
+	}
+
+	_, err = pr.CreatePR(ctx, fc, testOwner, testRepo, forkOwner, "fix", fake.DefaultBranch, "Fix again", "")
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("CreatePR() duplicate error = %v, want already exists", err)
+	}
+
+	files, err := pr.ListPRFiles(ctx, fc, testOwner, testRepo, got.Number)
+	if err != nil {
+		t.Fatalf("ListPRFiles() error = %v", err)
+	}
+	if len(files) != 1 || files[0].Filename != "README.md" || files[0].Status != "added" {
+		t.Errorf("ListPRFiles() = %+v, want README.md added", files)
+	}
+
+	fc.SetAuthenticatedUser(testOwner)
+	result, err := pr.MergePR(ctx, fc, testOwner, testRepo, got.Number, "", nil)
+	if err != nil {
+		t.Fatalf("MergePR() error = %v", err)
+	}
+	if !result.Merged {
+		t.Error("MergePR() Merged = false, want true")
+	}
+	exists, err := fc.FileExists(ctx, testOwner, testRepo, "README.md", nil)
+	if err != nil {
+		t.Fatalf("FileExists() error = %v", err)
+	}
+	if !exists {
+		t.Error("FileExists(README.md) after merge = false, want true")
+	}
+}
+
+func TestCreateRelease(t *testing.T) {
+	ctx := context.Background()
+	fc := newTestClient(t)
+
+	rel, err := release.CreateReleaseSimple(ctx, fc, testOwner, testRepo, "v1.0.0", "v1.0.0", "First release", false, false, false)
+	if err != nil {
+		t.Fatalf("CreateReleaseSimple() error = %v", err)
+	}
+	if rel.PublishedAt == nil {
+		t.Error("CreateReleaseSimple() PublishedAt = nil, want set")
+	}
+
+	// Publishing a release creates its tag.
+	exists, err := tag.TagExists(ctx, fc, testOwner, testRepo, "v1.0.0")
+	if err != nil {
+		t.Fatalf("TagExists() error = %v", err)
+	}
+	if !exists {
+		t.Error("TagExists(v1.0.0) = false, want true")
+	}
+
+	_, err = release.CreateReleaseSimple(ctx, fc, testOwner, testRepo, "v1.0.0", "again", "", false, false, false)
+	if err == nil || !strings.Contains(err.Error(), "already_exists") {
+		t.Errorf("CreateReleaseSimple() duplicate error = %v, want already_exists", err)
+	}
+
+	draft, err := release.CreateReleaseSimple(ctx, fc, testOwner, testRepo, "v2.0.0", "v2.0.0", "", true, false, false)
+	if err != nil {
+		t.Fatalf("CreateReleaseSimple() draft error = %v", err)
+	}
+	if exists, _ := tag.TagExists(ctx, fc, testOwner, testRepo, "v2.0.0"); exists {
+		t.Error("TagExists(v2.0.0) for draft = true, want false")
+	}
+
+	latest, err := release.GetLatestRelease(ctx, fc, testOwner, testRepo)
+	if err != nil {
+		t.Fatalf("GetLatestRelease() error = %v", err)
+	}
+	if latest.ID != rel.ID {
+		t.Errorf("GetLatestRelease() = %d, want %d", latest.ID, rel.ID)
+	}
+
+	if err := release.DeleteRelease(ctx, fc, testOwner, testRepo, draft.ID); err != nil {
+		t.Fatalf("DeleteRelease() error = %v", err)
+	}
+	if _, err := release.GetRelease(ctx, fc, testOwner, testRepo, draft.ID); !errors.IsNotFound(err) {
+		t.Errorf("GetRelease() after delete error = %v, want not found", err)
+	}
+}
+
+func TestFileConflicts(t *testing.T) {
+	ctx := context.Background()
+	fc := newTestClient(t)
+
+	created, err := fc.CreateFile(ctx, testOwner, testRepo, "docs/a.txt", &clientv1.CreateFileOptions{
+		Content: []byte("a"),
+		Message: "Add a",
+	})
+	if err != nil {
+		t.Fatalf("CreateFile() error = %v", err)
+	}
+	if _, err := fc.CreateFile(ctx, testOwner, testRepo, "docs/a.txt", &clientv1.CreateFileOptions{
+		Content: []byte("b"),
+		Message: "Add a again",
+	}); !errors.IsValidation(err) {
+		t.Errorf("CreateFile() existing file error = %v, want validation error", err)
+	}
+	if _, err := fc.UpdateFile(ctx, testOwner, testRepo, "docs/a.txt", &clientv1.UpdateFileOptions{
+		Content: []byte("b"),
+		Message: "Update a",
+		SHA:     "stale",
+	}); !errors.IsConflict(err) {
+		t.Errorf("UpdateFile() stale SHA error = %v, want conflict", err)
+	}
+	if _, err := fc.UpdateFile(ctx, testOwner, testRepo, "docs/a.txt", &clientv1.UpdateFileOptions{
+		Content: []byte("b"),
+		Message: "Update a",
+		SHA:     created.Content.SHA,
+	}); err != nil {
+		t.Errorf("UpdateFile() error = %v", err)
+	}
+
+	got, err := fc.GetFileContentString(ctx, testOwner, testRepo, "docs/a.txt", nil)
+	if err != nil {
+		t.Fatalf("GetFileContentString() error = %v", err)
+	}
+	if got != "b" {
+		t.Errorf("GetFileContentString() = %q, want %q", got, "b")
+	}
+
+	if _, err := fc.GetFileContent(ctx, testOwner, testRepo, "missing.txt", nil); !errors.IsNotFound(err) {
+		t.Errorf("GetFileContent(missing.txt) error = %v, want not found", err)
+	}
+	if _, err := fc.GetRepository(ctx, testOwner, "missing"); !errors.IsNotFound(err) {
+		t.Errorf("GetRepository(missing) error = %v, want not found", err)
+	}
+}
+
+func TestSearchIssues(t *testing.T) {
+	ctx := context.Background()
+	fc := newTestClient(t)
+
+	for _, title := range []string{"Crash on start", "Add dark mode"} {
+		if _, err := fc.CreateIssue(ctx, testOwner, testRepo, &clientv1.CreateIssueInput{Title: title}); err != nil {
+			t.Fatalf("CreateIssue() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"repo:octocat/hello-world is:issue", 2},
+		{"repo:octocat/hello-world crash", 1},
+		{"repo:octocat/hello-world is:pr", 0},
+		{"author:" + fake.DefaultLogin + " is:open", 2},
+		{"repo:other/repo", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := fc.SearchIssues(ctx, tt.query, nil)
+			if err != nil {
+				t.Fatalf("SearchIssues() error = %v", err)
+			}
+			if got.Total != tt.want {
+				t.Errorf("SearchIssues(%q).Total = %d, want %d", tt.query, got.Total, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateTreeDelete(t *testing.T) {
+	ctx := context.Background()
+	fc := newTestClient(t)
+
+	if _, err := fc.CreateFile(ctx, testOwner, testRepo, "a.txt", &clientv1.CreateFileOptions{
+		Content: []byte("a"),
+		Message: "Add a",
+	}); err != nil {
+		t.Fatalf("CreateFile() error = %v", err)
+	}
+	sha, err := fc.GetBranchSHA(ctx, testOwner, testRepo, fake.DefaultBranch)
+	if err != nil {
+		t.Fatalf("GetBranchSHA() error = %v", err)
+	}
+	commit, err := fc.GetGitCommit(ctx, testOwner, testRepo, sha)
+	if err != nil {
+		t.Fatalf("GetGitCommit() error = %v", err)
+	}
+
+	if _, err := fc.CreateTree(ctx, testOwner, testRepo, commit.Tree.SHA, []clientv1.TreeEntry{
+		{Path: "a.txt", Mode: "100644", Type: "blob", SHA: sha, Delete: true},
+	}); err == nil {
+		t.Error("CreateTree() delete with SHA error = nil, want error")
+	}
+
+	treeSHA, err := fc.CreateTree(ctx, testOwner, testRepo, commit.Tree.SHA, []clientv1.TreeEntry{
+		{Path: "a.txt", Mode: "100644", Type: "blob", Delete: true},
+	})
+	if err != nil {
+		t.Fatalf("CreateTree() error = %v", err)
+	}
+	nodes, err := fc.GetTree(ctx, testOwner, testRepo, treeSHA, true)
+	if err != nil {
+		t.Fatalf("GetTree() error = %v", err)
+	}
+	for _, n := range nodes {
+		if n.Path == "a.txt" {
+			t.Error("GetTree() still contains a.txt")
+		}
+	}
+
+	if _, err := fc.GetGitCommit(ctx, testOwner, testRepo, strings.Repeat("0", 40)); !errors.IsNotFound(err) {
+		t.Errorf("GetGitCommit(unknown) error = %v, want not found", err)
+	}
+}
+
+func TestIterIssues(t *testing.T) {
+	ctx := context.Background()
+	fc := newTestClient(t)
+
+	for i := range 5 {
+		if _, err := fc.CreateIssue(ctx, testOwner, testRepo, &clientv1.CreateIssueInput{Title: fmt.Sprintf("Issue %d", i+1)}); err != nil {
+			t.Fatalf("CreateIssue() error = %v", err)
+		}
+	}
+
+	var titles []string
+	for is, err := range fc.IterIssues(ctx, testOwner, testRepo, nil, &clientv1.IterOptions{MaxItems: 3}) {
+		if err != nil {
+			t.Fatalf("IterIssues() error = %v", err)
+		}
+		titles = append(titles, is.Title)
+	}
+	if len(titles) != 3 {
+		t.Errorf("IterIssues(MaxItems: 3) = %v, want 3 issues", titles)
+	}
+
+	for _, err := range fc.IterIssues(ctx, testOwner, "missing", nil, nil) {
+		if !errors.IsNotFound(err) {
+			t.Errorf("IterIssues(missing) error = %v, want not found", err)
+		}
+	}
+}
@@ -0,0 +1,805 @@
+package fake
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/grokify/gogithub"
+	"github.com/grokify/gogithub/clientv1"
+)
+
+// objectStore holds immutable git objects. Repositories in the same fork
+// network share one store, as they do on GitHub.
+type objectStore struct {
+	blobs   map[string][]byte
+	trees   map[string][]treeEntry
+	commits map[string]*gogithub.Commit
+	tags    map[string]*tagObject
+}
+
+func newObjectStore() *objectStore {
+	return &objectStore{
+		blobs:   make(map[string][]byte),
+		trees:   make(map[string][]treeEntry),
+		commits: make(map[string]*gogithub.Commit),
+		tags:    make(map[string]*tagObject),
+	}
+}
+
+// treeEntry is a single entry of a stored tree.
+type treeEntry struct {
+	Name string
+	Mode string
+	Type string
+	SHA  string
+}
+
+// tagObject is an annotated tag.
+type tagObject struct {
+	Name    string
+	Message string
+	Object  string
+}
+
+const (
+	modeFile = "100644"
+	modeTree = "040000"
+)
+
+// writeBlob stores content and returns its SHA.
+func (rs *repository) writeBlob(content []byte) string {
+	sha := hashObject("blob", content)
+	if _, ok := rs.objects.blobs[sha]; !ok {
+		rs.objects.blobs[sha] = append([]byte(nil), content...)
+	}
+	return sha
+}
+
+// writeTree stores a flat path -> entry map as a hierarchy of trees and
+// returns the root tree SHA.
+func (rs *repository) writeTree(flat map[string]treeEntry) string {
+	var entries []treeEntry
+	subdirs := make(map[string]map[string]treeEntry)
+	for p, e := range flat {
+		dir, rest, nested := strings.Cut(p, "/")
+		if !nested {
+			e.Name = p
+			entries = append(entries, e)
+			continue
+		}
+		if subdirs[dir] == nil {
+			subdirs[dir] = make(map[string]treeEntry)
+		}
+		subdirs[dir][rest] = e
+	}
+	for dir, sub := range subdirs {
+		entries = append(entries, treeEntry{
+			Name: dir,
+			Mode: modeTree,
+			Type: "tree",
+			SHA:  rs.writeTree(sub),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+
+	var buf bytes.Buffer
+	for _, e := range entries {
+		fmt.Fprintf(&buf, "%s %s\x00", strings.TrimPrefix(e.Mode, "0"), e.Name)
+		raw, _ := hex.DecodeString(e.SHA)
+		buf.Write(raw)
+	}
+	sha := hashObject("tree", buf.Bytes())
+	if _, ok := rs.objects.trees[sha]; !ok {
+		rs.objects.trees[sha] = entries
+	}
+	return sha
+}
+
+// flatten expands a stored tree into a path -> entry map of its blobs and
+// submodules.
+func (rs *repository) flatten(treeSHA string) map[string]treeEntry {
+	flat := make(map[string]treeEntry)
+	rs.flattenInto(flat, "", treeSHA)
+	return flat
+}
+
+func (rs *repository) flattenInto(flat map[string]treeEntry, prefix, treeSHA string) {
+	for _, e := range rs.objects.trees[treeSHA] {
+		p := path.Join(prefix, e.Name)
+		if e.Type == "tree" {
+			rs.flattenInto(flat, p, e.SHA)
+			continue
+		}
+		flat[p] = e
+	}
+}
+
+// writeCommit stores a new commit. author defaults to the authenticated user.
+func (rs *repository) writeCommit(c *Client, message, tree string, parents []string, author *clientv1.CommitAuthor) *gogithub.Commit {
+	now := c.now()
+	committer := &gogithub.CommitAuthor{
+		Name:  c.login,
+		Email: noreplyEmail(c.login),
+		Date:  now,
+	}
+	commitAuthor := *committer
+	if author != nil {
+		commitAuthor.Name = author.Name
+		commitAuthor.Email = author.Email
+		if author.Date != nil {
+			commitAuthor.Date = author.Date.UTC()
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "tree %s\n", tree)
+	for _, p := range parents {
+		fmt.Fprintf(&buf, "parent %s\n", p)
+	}
+	fmt.Fprintf(&buf, "author %s <%s> %d +0000\n", commitAuthor.Name, commitAuthor.Email, commitAuthor.Date.Unix())
+	fmt.Fprintf(&buf, "committer %s <%s> %d +0000\n\n%s", committer.Name, committer.Email, committer.Date.Unix(), message)
+	sha := hashObject("commit", buf.Bytes())
+
+	commit := &gogithub.Commit{
+		SHA:       sha,
+		Message:   message,
+		Author:    &commitAuthor,
+		Committer: committer,
+		HTMLURL:   rs.repo.HTMLURL + "/commit/" + sha,
+		Tree: &gogithub.GitObject{
+			Type: "tree",
+			SHA:  tree,
+			URL:  rs.apiURL("/git/trees/" + tree),
+		},
+	}
+	for _, p := range parents {
+		commit.Parents = append(commit.Parents, gogithub.CommitParent{
+			SHA: p,
+			URL: rs.apiURL("/git/commits/" + p),
+		})
+	}
+	if _, ok := rs.objects.commits[sha]; !ok {
+		rs.objects.commits[sha] = commit
+	}
+	return rs.objects.commits[sha]
+}
+
+// peel follows annotated tags to the object they point at.
+func (rs *repository) peel(sha string) string {
+	for {
+		t, ok := rs.objects.tags[sha]
+		if !ok {
+			return sha
+		}
+		sha = t.Object
+	}
+}
+
+// resolveCommit resolves a branch, tag, full ref or (abbreviated) commit
+// SHA to a commit SHA.
+func (rs *repository) resolveCommit(commitish string) (string, bool) {
+	if commitish == "" {
+		commitish = rs.repo.DefaultBranch
+	}
+	for _, ref := range []string{refHeadsPrefix + commitish, refTagsPrefix + commitish, commitish} {
+		if sha, ok := rs.refs[ref]; ok {
+			return rs.peel(sha), true
+		}
+	}
+	if _, ok := rs.objects.commits[commitish]; ok {
+		return commitish, true
+	}
+	if len(commitish) >= 7 {
+		var match string
+		for sha := range rs.objects.commits {
+			if strings.HasPrefix(sha, commitish) {
+				if match != "" {
+					return "", false
+				}
+				match = sha
+			}
+		}
+		if match != "" {
+			return match, true
+		}
+	}
+	return "", false
+}
+
+// resolveTree returns the flattened tree of the commit commitish resolves to.
+func (rs *repository) resolveTree(commitish string) (map[string]treeEntry, bool) {
+	sha, ok := rs.resolveCommit(commitish)
+	if !ok {
+		return nil, false
+	}
+	return rs.flatten(rs.objects.commits[sha].Tree.SHA), true
+}
+
+// ancestors returns the set of commits reachable from sha, including sha.
+func (rs *repository) ancestors(sha string) map[string]bool {
+	seen := make(map[string]bool)
+	queue := []string{sha}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if seen[cur] {
+			continue
+		}
+		seen[cur] = true
+		if commit, ok := rs.objects.commits[cur]; ok {
+			for _, p := range commit.Parents {
+				queue = append(queue, p.SHA)
+			}
+		}
+	}
+	return seen
+}
+
+// mergeBase returns the most recent common ancestor of a and b.
+func (rs *repository) mergeBase(a, b string) string {
+	inA := rs.ancestors(a)
+	queue := []string{b}
+	seen := make(map[string]bool)
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if seen[cur] {
+			continue
+		}
+		seen[cur] = true
+		if inA[cur] {
+			return cur
+		}
+		if commit, ok := rs.objects.commits[cur]; ok {
+			for _, p := range commit.Parents {
+				queue = append(queue, p.SHA)
+			}
+		}
+	}
+	return ""
+}
+
+// commitsBetween returns the commits reachable from head but not base.
+func (rs *repository) commitsBetween(base, head string) []string {
+	inBase := rs.ancestors(base)
+	var result []string
+	for sha := range rs.ancestors(head) {
+		if !inBase[sha] {
+			result = append(result, sha)
+		}
+	}
+	return result
+}
+
+// commitToBranch writes a commit that replaces the tree of branch and
+// advances the branch to it.
+func (rs *repository) commitToBranch(c *Client, branch, message string, flat map[string]treeEntry, author *clientv1.CommitAuthor) *gogithub.Commit {
+	parent := rs.refs[refHeadsPrefix+branch]
+	commit := rs.writeCommit(c, message, rs.writeTree(flat), []string{parent}, author)
+	rs.refs[refHeadsPrefix+branch] = commit.SHA
+	return commit
+}
+
+func (rs *repository) reference(ref string) *gogithub.Reference {
+	sha := rs.refs[ref]
+	objType, objPath := "commit", "/git/commits/"
+	if _, ok := rs.objects.tags[sha]; ok {
+		objType, objPath = "tag", "/git/tags/"
+	}
+	return &gogithub.Reference{
+		Ref: ref,
+		SHA: sha,
+		URL: rs.apiURL("/git/" + ref),
+		Object: &gogithub.GitObject{
+			Type: objType,
+			SHA:  sha,
+			URL:  rs.apiURL(objPath + sha),
+		},
+	}
+}
+
+// normalizeRef accepts both "refs/heads/main" and "heads/main".
+func normalizeRef(ref string) string {
+	if strings.HasPrefix(ref, "refs/") {
+		return ref
+	}
+	return "refs/" + ref
+}
+
+func noreplyEmail(login string) string {
+	return login + "@users.noreply.github.com"
+}
+
+func copyCommit(commit *gogithub.Commit) *gogithub.Commit {
+	if commit == nil {
+		return nil
+	}
+	cp := *commit
+	if commit.Author != nil {
+		a := *commit.Author
+		cp.Author = &a
+	}
+	if commit.Committer != nil {
+		a := *commit.Committer
+		cp.Committer = &a
+	}
+	if commit.Tree != nil {
+		t := *commit.Tree
+		cp.Tree = &t
+	}
+	cp.Parents = append([]gogithub.CommitParent(nil), commit.Parents...)
+	return &cp
+}
+
+// Git References
+
+// GetRef retrieves a git reference by its full name (e.g., "refs/heads/main").
+func (c *Client) GetRef(ctx context.Context, owner, repo, ref string) (*gogithub.Reference, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	ref = normalizeRef(ref)
+	if _, ok := rs.refs[ref]; !ok {
+		return nil, notFound()
+	}
+	return rs.reference(ref), nil
+}
+
+// CreateRef creates a git reference.
+func (c *Client) CreateRef(ctx context.Context, owner, repo, ref, sha string) (*gogithub.Reference, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("create ref: %w", err)
+	}
+	if !strings.HasPrefix(ref, "refs/") || strings.Count(ref, "/") < 2 {
+		return nil, fmt.Errorf("create ref: %w", validationFailed("Reference name must start with 'refs/' and have at least two slashes."))
+	}
+	if !rs.hasObject(sha) {
+		return nil, fmt.Errorf("create ref: %w", validationFailed("Object does not exist"))
+	}
+	if _, ok := rs.refs[ref]; ok {
+		return nil, fmt.Errorf("create ref: %w", validationFailed("Reference already exists"))
+	}
+	rs.refs[ref] = sha
+	return rs.reference(ref), nil
+}
+
+// UpdateRef updates a git reference to point to a new SHA. Without force,
+// the update must be a fast-forward.
+func (c *Client) UpdateRef(ctx context.Context, owner, repo, ref, sha string, force bool) (*gogithub.Reference, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("update ref: %w", err)
+	}
+	ref = normalizeRef(ref)
+	current, ok := rs.refs[ref]
+	if !ok {
+		return nil, fmt.Errorf("update ref: %w", validationFailed("Reference does not exist"))
+	}
+	if !rs.hasObject(sha) {
+		return nil, fmt.Errorf("update ref: %w", validationFailed("Object does not exist"))
+	}
+	if !force && !rs.ancestors(rs.peel(sha))[rs.peel(current)] {
+		return nil, fmt.Errorf("update ref: %w", validationFailed("Update is not a fast forward"))
+	}
+	rs.refs[ref] = sha
+	return rs.reference(ref), nil
+}
+
+// DeleteRef deletes a git reference.
+func (c *Client) DeleteRef(ctx context.Context, owner, repo, ref string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return err
+	}
+	ref = normalizeRef(ref)
+	if _, ok := rs.refs[ref]; !ok {
+		return validationFailed("Reference does not exist")
+	}
+	delete(rs.refs, ref)
+	return nil
+}
+
+// GetBranchSHA returns the commit SHA for a branch.
+func (c *Client) GetBranchSHA(ctx context.Context, owner, repo, branch string) (string, error) {
+	ref, err := c.GetRef(ctx, owner, repo, refHeadsPrefix+branch)
+	if err != nil {
+		return "", fmt.Errorf("get branch %s: %w", branch, err)
+	}
+	return ref.Object.SHA, nil
+}
+
+// GetTagSHA returns the SHA the tag reference points at: the commit for a
+// lightweight tag, or the tag object for an annotated tag.
+func (c *Client) GetTagSHA(ctx context.Context, owner, repo, tag string) (string, error) {
+	ref, err := c.GetRef(ctx, owner, repo, refTagsPrefix+tag)
+	if err != nil {
+		return "", fmt.Errorf("get tag %s: %w", tag, err)
+	}
+	return ref.Object.SHA, nil
+}
+
+// ListBranches lists all branches in a repository, sorted by name.
+func (c *Client) ListBranches(ctx context.Context, owner, repo string) ([]*gogithub.Branch, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("list branches: %w", err)
+	}
+	var branches []*gogithub.Branch
+	for _, ref := range sortedKeys(rs.refs) {
+		name, ok := strings.CutPrefix(ref, refHeadsPrefix)
+		if !ok {
+			continue
+		}
+		_, protected := rs.protection[name]
+		branches = append(branches, &gogithub.Branch{
+			Name:      name,
+			Protected: protected,
+			Commit:    copyCommit(rs.objects.commits[rs.refs[ref]]),
+		})
+	}
+	return branches, nil
+}
+
+// Tags
+
+// ListTags lists all tags in a repository, sorted by name in descending order.
+func (c *Client) ListTags(ctx context.Context, owner, repo string) ([]*gogithub.Tag, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+	var tags []*gogithub.Tag
+	for _, ref := range sortedKeys(rs.refs) {
+		name, ok := strings.CutPrefix(ref, refTagsPrefix)
+		if !ok {
+			continue
+		}
+		sha := rs.peel(rs.refs[ref])
+		tags = append(tags, &gogithub.Tag{
+			Name:   name,
+			SHA:    sha,
+			Commit: copyCommit(rs.objects.commits[sha]),
+		})
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].Name > tags[j].Name })
+	return tags, nil
+}
+
+// CreateTag creates an annotated tag object and a reference to it.
+func (c *Client) CreateTag(ctx context.Context, owner, repo, tag, sha, message string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return fmt.Errorf("create tag object: %w", err)
+	}
+	if _, ok := rs.objects.commits[sha]; !ok {
+		return fmt.Errorf("create tag object: %w", validationFailed("Object does not exist"))
+	}
+	content := fmt.Sprintf("object %s\ntype commit\ntag %s\ntagger %s <%s> %d +0000\n\n%s",
+		sha, tag, c.login, noreplyEmail(c.login), c.now().Unix(), message)
+	tagSHA := hashObject("tag", []byte(content))
+	rs.objects.tags[tagSHA] = &tagObject{Name: tag, Message: message, Object: sha}
+
+	ref := refTagsPrefix + tag
+	if _, ok := rs.refs[ref]; ok {
+		return fmt.Errorf("create tag reference: %w", validationFailed("Reference already exists"))
+	}
+	rs.refs[ref] = tagSHA
+	return nil
+}
+
+// Commits
+
+// GetCommit retrieves a commit by SHA. Like the GitHub API, branch and tag
+// names are also accepted.
+func (c *Client) GetCommit(ctx context.Context, owner, repo, sha string) (*gogithub.Commit, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	resolved, ok := rs.resolveCommit(sha)
+	if !ok || sha == "" {
+		return nil, validationFailed("No commit found for SHA: " + sha)
+	}
+	return copyCommit(rs.objects.commits[resolved]), nil
+}
+
+// GetGitCommit retrieves a commit object by its full SHA. Unlike
+// GetCommit, branch and tag names are not accepted.
+func (c *Client) GetGitCommit(ctx context.Context, owner, repo, sha string) (*gogithub.Commit, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("get git commit: %w", err)
+	}
+	commit, ok := rs.objects.commits[sha]
+	if !ok {
+		return nil, fmt.Errorf("get git commit: %w", notFound())
+	}
+	return copyCommit(commit), nil
+}
+
+// ListCommits lists commits reachable from opts.SHA (default: the default
+// branch), newest first.
+func (c *Client) ListCommits(ctx context.Context, owner, repo string, opts *clientv1.ListCommitsOptions) ([]*gogithub.Commit, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("list commits: %w", err)
+	}
+	if opts == nil {
+		opts = &clientv1.ListCommitsOptions{}
+	}
+	head, ok := rs.resolveCommit(opts.SHA)
+	if !ok {
+		return nil, fmt.Errorf("list commits: %w", notFound())
+	}
+
+	var commits []*gogithub.Commit
+	for sha := range rs.ancestors(head) {
+		commit := rs.objects.commits[sha]
+		if commit == nil || !rs.commitMatches(commit, opts) {
+			continue
+		}
+		commits = append(commits, copyCommit(commit))
+	}
+	sort.SliceStable(commits, func(i, j int) bool {
+		if !commits[i].Committer.Date.Equal(commits[j].Committer.Date) {
+			return commits[i].Committer.Date.After(commits[j].Committer.Date)
+		}
+		return rs.ancestors(commits[i].SHA)[commits[j].SHA]
+	})
+	return commits, nil
+}
+
+func (rs *repository) commitMatches(commit *gogithub.Commit, opts *clientv1.ListCommitsOptions) bool {
+	if opts.Since != nil && commit.Author.Date.Before(*opts.Since) {
+		return false
+	}
+	if opts.Until != nil && commit.Author.Date.After(*opts.Until) {
+		return false
+	}
+	if opts.Author != "" {
+		a := commit.Author
+		if !strings.EqualFold(opts.Author, a.Email) && !strings.EqualFold(opts.Author, a.Name) &&
+			!strings.EqualFold(noreplyEmail(opts.Author), a.Email) {
+			return false
+		}
+	}
+	if opts.Path != "" {
+		var parentTree map[string]treeEntry
+		if len(commit.Parents) > 0 {
+			if parent, ok := rs.objects.commits[commit.Parents[0].SHA]; ok {
+				parentTree = rs.flatten(parent.Tree.SHA)
+			}
+		}
+		if !touchesPath(parentTree, rs.flatten(commit.Tree.SHA), opts.Path) {
+			return false
+		}
+	}
+	return true
+}
+
+// touchesPath reports whether p, or anything under it, differs between trees.
+func touchesPath(before, after map[string]treeEntry, p string) bool {
+	p = strings.Trim(p, "/")
+	under := func(name string) bool { return name == p || strings.HasPrefix(name, p+"/") }
+	for name, e := range after {
+		if under(name) && before[name] != e {
+			return true
+		}
+	}
+	for name := range before {
+		if _, ok := after[name]; under(name) && !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateCommit creates a commit with the given tree and parents.
+func (c *Client) CreateCommit(ctx context.Context, owner, repo string, opts *clientv1.CreateCommitOptions) (*gogithub.Commit, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("create commit: %w", err)
+	}
+	if _, ok := rs.objects.trees[opts.Tree]; !ok {
+		return nil, fmt.Errorf("create commit: %w", validationFailed("Tree SHA does not exist"))
+	}
+	for _, p := range opts.Parents {
+		if _, ok := rs.objects.commits[p]; !ok {
+			return nil, fmt.Errorf("create commit: %w", validationFailed("Parent SHA does not exist or is not a commit object"))
+		}
+	}
+	return copyCommit(rs.writeCommit(c, opts.Message, opts.Tree, opts.Parents, opts.Author)), nil
+}
+
+// Git Trees
+
+// GetTree retrieves a git tree by SHA. Commit SHAs and branch names are
+// resolved to their root tree.
+func (c *Client) GetTree(ctx context.Context, owner, repo, sha string, recursive bool) ([]*gogithub.TreeNode, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("get tree: %w", err)
+	}
+	if _, ok := rs.objects.trees[sha]; !ok {
+		commit, ok := rs.resolveCommit(sha)
+		if !ok {
+			return nil, fmt.Errorf("get tree: %w", notFound())
+		}
+		sha = rs.objects.commits[commit].Tree.SHA
+	}
+	var nodes []*gogithub.TreeNode
+	rs.treeNodes(&nodes, "", sha, recursive)
+	return nodes, nil
+}
+
+func (rs *repository) treeNodes(nodes *[]*gogithub.TreeNode, prefix, sha string, recursive bool) {
+	for _, e := range rs.objects.trees[sha] {
+		node := &gogithub.TreeNode{
+			Path: path.Join(prefix, e.Name),
+			Mode: e.Mode,
+			Type: e.Type,
+			SHA:  e.SHA,
+		}
+		switch e.Type {
+		case "blob":
+			node.Size = len(rs.objects.blobs[e.SHA])
+			node.URL = rs.apiURL("/git/blobs/" + e.SHA)
+		case "tree":
+			node.URL = rs.apiURL("/git/trees/" + e.SHA)
+		}
+		*nodes = append(*nodes, node)
+		if recursive && e.Type == "tree" {
+			rs.treeNodes(nodes, node.Path, e.SHA, true)
+		}
+	}
+}
+
+// CreateTree creates a git tree from file entries. As with the real client,
+// an entry with Delete set, or with neither SHA nor Content, removes its
+// path from baseTree.
+func (c *Client) CreateTree(ctx context.Context, owner, repo, baseTree string, entries []clientv1.TreeEntry) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return "", fmt.Errorf("create tree: %w", err)
+	}
+	flat := make(map[string]treeEntry)
+	if baseTree != "" {
+		if _, ok := rs.objects.trees[baseTree]; !ok {
+			return "", fmt.Errorf("create tree: %w", validationFailed("Invalid tree info"))
+		}
+		flat = rs.flatten(baseTree)
+	}
+	for _, e := range entries {
+		p := strings.Trim(e.Path, "/")
+		if p == "" {
+			return "", fmt.Errorf("create tree: %w", validationFailed("Invalid tree info"))
+		}
+		if e.Delete && (e.SHA != "" || e.Content != "") {
+			return "", fmt.Errorf("create tree: delete entry %s must not set SHA or Content", e.Path)
+		}
+		if e.SHA == "" && e.Content == "" {
+			for name := range flat {
+				if name == p || strings.HasPrefix(name, p+"/") {
+					delete(flat, name)
+				}
+			}
+			continue
+		}
+		typ := e.Type
+		if typ == "" {
+			typ = "blob"
+		}
+		sha := e.SHA
+		if e.Content != "" {
+			sha = rs.writeBlob([]byte(e.Content))
+		}
+		switch typ {
+		case "tree":
+			if _, ok := rs.objects.trees[sha]; !ok {
+				return "", fmt.Errorf("create tree: %w", validationFailed("Invalid tree info"))
+			}
+			for name := range flat {
+				if name == p || strings.HasPrefix(name, p+"/") {
+					delete(flat, name)
+				}
+			}
+			for name, sub := range rs.flatten(sha) {
+				flat[path.Join(p, name)] = sub
+			}
+			continue
+		case "blob":
+			if _, ok := rs.objects.blobs[sha]; !ok {
+				return "", fmt.Errorf("create tree: %w", validationFailed("Invalid tree info"))
+			}
+		}
+		mode := e.Mode
+		if mode == "" {
+			mode = modeFile
+		}
+		flat[p] = treeEntry{Mode: mode, Type: typ, SHA: sha}
+	}
+	return rs.writeTree(flat), nil
+}
+
+// Git Blobs
+
+// CreateBlob creates a git blob with the given content. Encoding may be
+// "utf-8" (the default) or "base64".
+func (c *Client) CreateBlob(ctx context.Context, owner, repo string, content []byte, encoding string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return "", fmt.Errorf("create blob: %w", err)
+	}
+	switch encoding {
+	case "", "utf-8":
+	case "base64":
+		decoded, err := decodeBase64(content)
+		if err != nil {
+			return "", fmt.Errorf("create blob: %w", validationFailed("Invalid base64 content"))
+		}
+		content = decoded
+	default:
+		return "", fmt.Errorf("create blob: %w", validationFailed("encoding must be utf-8 or base64"))
+	}
+	return rs.writeBlob(content), nil
+}
+
+// Blob returns the content of a blob. The Client interface has no method
+// for reading blobs, so tests inspect them with this helper.
+func (c *Client) Blob(owner, repo, sha string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	content, ok := rs.objects.blobs[sha]
+	if !ok {
+		return nil, notFound()
+	}
+	return append([]byte(nil), content...), nil
+}
+
+// hasObject reports whether sha names a stored commit or tag object.
+func (rs *repository) hasObject(sha string) bool {
+	if _, ok := rs.objects.commits[sha]; ok {
+		return true
+	}
+	_, ok := rs.objects.tags[sha]
+	return ok
+}
@@ -0,0 +1,308 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grokify/gogithub"
+	"github.com/grokify/gogithub/clientv1"
+)
+
+// issue is the state of an issue. Every pull request also has an issue
+// record sharing its number, as on GitHub.
+type issue struct {
+	issue    *gogithub.Issue
+	comments []*gogithub.IssueComment
+}
+
+// Issues
+
+// GetIssue retrieves an issue or pull request by number.
+func (c *Client) GetIssue(ctx context.Context, owner, repo string, number int) (*gogithub.Issue, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, is, err := c.issue(owner, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("get issue: %w", err)
+	}
+	return is.copy(), nil
+}
+
+// ListIssues lists issues and pull requests in a repository.
+func (c *Client) ListIssues(ctx context.Context, owner, repo string, opts *clientv1.ListIssuesOptions) ([]*gogithub.Issue, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("list issues: %w", err)
+	}
+	if opts == nil {
+		opts = &clientv1.ListIssuesOptions{}
+	}
+	state := opts.State
+	if state == "" {
+		state = "open"
+	}
+	var issues []*gogithub.Issue
+	for _, number := range sortedNumbers(rs.issues) {
+		is := rs.issues[number]
+		if state != "all" && is.issue.State != state {
+			continue
+		}
+		if opts.Since != nil && is.issue.UpdatedAt.Before(*opts.Since) {
+			continue
+		}
+		if !hasLabels(is.issue.Labels, opts.Labels) {
+			continue
+		}
+		issues = append(issues, is.copy())
+	}
+	switch opts.Sort {
+	case "comments":
+		sort.SliceStable(issues, func(i, j int) bool {
+			if opts.Direction == "asc" {
+				return issues[i].Comments < issues[j].Comments
+			}
+			return issues[i].Comments > issues[j].Comments
+		})
+	default:
+		sortByTime(issues, opts.Direction, func(is *gogithub.Issue) time.Time {
+			if opts.Sort == "updated" {
+				return is.UpdatedAt
+			}
+			return is.CreatedAt
+		})
+	}
+	if opts.Page > 1 {
+		perPage := opts.PerPage
+		if perPage <= 0 {
+			perPage = 100
+		}
+		issues = issues[min((opts.Page-1)*perPage, len(issues)):]
+	}
+	return issues, nil
+}
+
+// CreateIssue creates a new issue. Assignees must be existing users.
+func (c *Client) CreateIssue(ctx context.Context, owner, repo string, input *clientv1.CreateIssueInput) (*gogithub.Issue, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("create issue: %w", err)
+	}
+	if input.Title == "" {
+		return nil, fmt.Errorf("create issue: %w", validationError("Issue", "title", "missing_field", ""))
+	}
+	assignees, err := c.assignees(input.Assignees)
+	if err != nil {
+		return nil, fmt.Errorf("create issue: %w", err)
+	}
+	number := rs.nextNumber
+	rs.nextNumber++
+	now := c.now()
+	is := &issue{issue: &gogithub.Issue{
+		ID:            c.id(),
+		Number:        number,
+		State:         "open",
+		Title:         input.Title,
+		Body:          input.Body,
+		HTMLURL:       fmt.Sprintf("%s/issues/%d", rs.repo.HTMLURL, number),
+		RepositoryURL: rs.apiURL(""),
+		User:          copyUser(c.ensureUser(c.login)),
+		Labels:        c.labels(rs, input.Labels),
+		Assignees:     assignees,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}}
+	rs.issues[number] = is
+	rs.repo.OpenIssuesCount++
+	return is.copy(), nil
+}
+
+// UpdateIssue updates an existing issue or the issue fields of a pull
+// request.
+func (c *Client) UpdateIssue(ctx context.Context, owner, repo string, number int, input *clientv1.UpdateIssueInput) (*gogithub.Issue, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, is, err := c.issue(owner, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("update issue: %w", err)
+	}
+	if input.State != nil && *input.State != "open" && *input.State != "closed" {
+		return nil, fmt.Errorf("update issue: %w", validationError("Issue", "state", "invalid", ""))
+	}
+	var assignees []*gogithub.User
+	if len(input.Assignees) > 0 {
+		if assignees, err = c.assignees(input.Assignees); err != nil {
+			return nil, fmt.Errorf("update issue: %w", err)
+		}
+	}
+
+	now := c.now()
+	if input.Title != nil {
+		is.issue.Title = *input.Title
+	}
+	if input.Body != nil {
+		is.issue.Body = *input.Body
+	}
+	if len(input.Labels) > 0 {
+		is.issue.Labels = c.labels(rs, input.Labels)
+	}
+	if len(input.Assignees) > 0 {
+		is.issue.Assignees = assignees
+	}
+	if input.State != nil {
+		if !is.issue.IsPullRequest && is.issue.State != *input.State {
+			if *input.State == "closed" {
+				rs.repo.OpenIssuesCount--
+			} else {
+				rs.repo.OpenIssuesCount++
+			}
+		}
+		setState(&is.issue.State, &is.issue.ClosedAt, *input.State, now)
+	}
+	is.issue.UpdatedAt = now
+	if p, ok := rs.pulls[number]; ok {
+		p.pr.Title = is.issue.Title
+		p.pr.Body = is.issue.Body
+		if !p.pr.Merged {
+			setState(&p.pr.State, &p.pr.ClosedAt, is.issue.State, now)
+		}
+		p.pr.UpdatedAt = now
+	}
+	return is.copy(), nil
+}
+
+// CreateIssueComment creates a comment on an issue or pull request.
+func (c *Client) CreateIssueComment(ctx context.Context, owner, repo string, number int, body string) (*gogithub.IssueComment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, is, err := c.issue(owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	if body == "" {
+		return nil, validationError("IssueComment", "body", "missing_field", "")
+	}
+	now := c.now()
+	comment := &gogithub.IssueComment{
+		ID:        c.id(),
+		User:      copyUser(c.ensureUser(c.login)),
+		Body:      body,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	comment.HTMLURL = fmt.Sprintf("%s#issuecomment-%d", is.issue.HTMLURL, comment.ID)
+	is.comments = append(is.comments, comment)
+	is.issue.Comments++
+	is.issue.UpdatedAt = now
+	cp := *comment
+	return &cp, nil
+}
+
+// EditIssueComment updates the body of an existing issue or pull request comment.
+func (c *Client) EditIssueComment(ctx context.Context, owner, repo string, commentID int64, body string) (*gogithub.IssueComment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("edit issue comment: %w", err)
+	}
+	for _, is := range rs.issues {
+		for _, comment := range is.comments {
+			if comment.ID != commentID {
+				continue
+			}
+			comment.Body = body
+			comment.UpdatedAt = c.now()
+			cp := *comment
+			return &cp, nil
+		}
+	}
+	return nil, fmt.Errorf("edit issue comment: %w", notFound())
+}
+
+// ListIssueComments lists comments on an issue or pull request, oldest first.
+func (c *Client) ListIssueComments(ctx context.Context, owner, repo string, number int) ([]*gogithub.IssueComment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, is, err := c.issue(owner, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("list issue comments: %w", err)
+	}
+	comments := make([]*gogithub.IssueComment, len(is.comments))
+	for i, comment := range is.comments {
+		cp := *comment
+		comments[i] = &cp
+	}
+	return comments, nil
+}
+
+// issue looks up an issue or pull request. Callers must hold c.mu.
+func (c *Client) issue(owner, repo string, number int) (*repository, *issue, error) {
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	is, ok := rs.issues[number]
+	if !ok {
+		return nil, nil, notFound()
+	}
+	return rs, is, nil
+}
+
+// labels returns the repository labels for names, creating any that do not
+// exist yet. Callers must hold c.mu.
+func (c *Client) labels(rs *repository, names []string) []gogithub.Label {
+	var labels []gogithub.Label
+	for _, name := range names {
+		key := strings.ToLower(name)
+		l, ok := rs.labels[key]
+		if !ok {
+			l = gogithub.Label{ID: c.id(), Name: name, Color: "ededed"}
+			rs.labels[key] = l
+		}
+		labels = append(labels, l)
+	}
+	return labels
+}
+
+// assignees looks up users to assign. Callers must hold c.mu.
+func (c *Client) assignees(logins []string) ([]*gogithub.User, error) {
+	var users []*gogithub.User
+	for _, login := range logins {
+		u, ok := c.users[strings.ToLower(login)]
+		if !ok {
+			return nil, validationError("Issue", "assignees", "invalid", "")
+		}
+		users = append(users, copyUser(u))
+	}
+	return users, nil
+}
+
+func (is *issue) copy() *gogithub.Issue {
+	cp := *is.issue
+	cp.User = copyUser(is.issue.User)
+	cp.Labels = append([]gogithub.Label(nil), is.issue.Labels...)
+	cp.Assignees = append([]*gogithub.User(nil), is.issue.Assignees...)
+	if is.issue.ClosedAt != nil {
+		t := *is.issue.ClosedAt
+		cp.ClosedAt = &t
+	}
+	return &cp
+}
+
+// hasLabels reports whether labels include every name in want.
+func hasLabels(labels []gogithub.Label, want []string) bool {
+	for _, name := range want {
+		if !slices.ContainsFunc(labels, func(l gogithub.Label) bool { return strings.EqualFold(l.Name, name) }) {
+			return false
+		}
+	}
+	return true
+}
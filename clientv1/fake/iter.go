@@ -0,0 +1,193 @@
+package fake
+
+import (
+	"context"
+	"iter"
+
+	"github.com/grokify/gogithub"
+	"github.com/grokify/gogithub/clientv1"
+)
+
+// Iterators
+//
+// The fake holds all state in memory, so each Iter* method runs the
+// matching List* method when iteration starts and streams its result,
+// applying IterOptions.MaxItems and checking ctx between items.
+
+// IterUserRepos iterates over a user's repositories.
+func (c *Client) IterUserRepos(ctx context.Context, user string, opts *clientv1.ListUserReposOptions, iterOpts *clientv1.IterOptions) iter.Seq2[*gogithub.Repository, error] {
+	return stream(ctx, iterOpts, func() ([]*gogithub.Repository, error) {
+		return c.ListUserReposWithOptions(ctx, user, opts)
+	})
+}
+
+// IterOrgRepos iterates over an organization's repositories.
+func (c *Client) IterOrgRepos(ctx context.Context, org string, iterOpts *clientv1.IterOptions) iter.Seq2[*gogithub.Repository, error] {
+	return stream(ctx, iterOpts, func() ([]*gogithub.Repository, error) {
+		return c.ListOrgRepos(ctx, org)
+	})
+}
+
+// IterBranches iterates over the branches in a repository.
+func (c *Client) IterBranches(ctx context.Context, owner, repo string, iterOpts *clientv1.IterOptions) iter.Seq2[*gogithub.Branch, error] {
+	return stream(ctx, iterOpts, func() ([]*gogithub.Branch, error) {
+		return c.ListBranches(ctx, owner, repo)
+	})
+}
+
+// IterTags iterates over the tags in a repository.
+func (c *Client) IterTags(ctx context.Context, owner, repo string, iterOpts *clientv1.IterOptions) iter.Seq2[*gogithub.Tag, error] {
+	return stream(ctx, iterOpts, func() ([]*gogithub.Tag, error) {
+		return c.ListTags(ctx, owner, repo)
+	})
+}
+
+// IterCommits iterates over commits in a repository.
+func (c *Client) IterCommits(ctx context.Context, owner, repo string, opts *clientv1.ListCommitsOptions, iterOpts *clientv1.IterOptions) iter.Seq2[*gogithub.Commit, error] {
+	return stream(ctx, iterOpts, func() ([]*gogithub.Commit, error) {
+		return c.ListCommits(ctx, owner, repo, opts)
+	})
+}
+
+// IterPullRequests iterates over pull requests in a repository.
+func (c *Client) IterPullRequests(ctx context.Context, owner, repo string, opts *clientv1.ListPullRequestsOptions, iterOpts *clientv1.IterOptions) iter.Seq2[*gogithub.PullRequest, error] {
+	return stream(ctx, iterOpts, func() ([]*gogithub.PullRequest, error) {
+		return c.ListPullRequests(ctx, owner, repo, opts)
+	})
+}
+
+// IterPullRequestFiles iterates over the files changed in a pull request.
+func (c *Client) IterPullRequestFiles(ctx context.Context, owner, repo string, number int, iterOpts *clientv1.IterOptions) iter.Seq2[*gogithub.CommitFile, error] {
+	return stream(ctx, iterOpts, func() ([]*gogithub.CommitFile, error) {
+		return c.ListPullRequestFiles(ctx, owner, repo, number)
+	})
+}
+
+// IterPullRequestReviews iterates over the reviews on a pull request.
+func (c *Client) IterPullRequestReviews(ctx context.Context, owner, repo string, number int, iterOpts *clientv1.IterOptions) iter.Seq2[*gogithub.PullRequestReview, error] {
+	return stream(ctx, iterOpts, func() ([]*gogithub.PullRequestReview, error) {
+		return c.ListPullRequestReviews(ctx, owner, repo, number)
+	})
+}
+
+// IterPullRequestComments iterates over the review comments on a pull
+// request.
+func (c *Client) IterPullRequestComments(ctx context.Context, owner, repo string, number int, iterOpts *clientv1.IterOptions) iter.Seq2[*gogithub.PullRequestComment, error] {
+	return stream(ctx, iterOpts, func() ([]*gogithub.PullRequestComment, error) {
+		return c.ListPullRequestComments(ctx, owner, repo, number)
+	})
+}
+
+// IterIssues iterates over issues in a repository.
+func (c *Client) IterIssues(ctx context.Context, owner, repo string, opts *clientv1.ListIssuesOptions, iterOpts *clientv1.IterOptions) iter.Seq2[*gogithub.Issue, error] {
+	return stream(ctx, iterOpts, func() ([]*gogithub.Issue, error) {
+		return c.ListIssues(ctx, owner, repo, opts)
+	})
+}
+
+// IterIssueComments iterates over the comments on an issue or pull request.
+func (c *Client) IterIssueComments(ctx context.Context, owner, repo string, number int, iterOpts *clientv1.IterOptions) iter.Seq2[*gogithub.IssueComment, error] {
+	return stream(ctx, iterOpts, func() ([]*gogithub.IssueComment, error) {
+		return c.ListIssueComments(ctx, owner, repo, number)
+	})
+}
+
+// IterCheckRuns iterates over check runs for the commit ref resolves to.
+func (c *Client) IterCheckRuns(ctx context.Context, owner, repo, ref string, iterOpts *clientv1.IterOptions) iter.Seq2[*gogithub.CheckRun, error] {
+	return stream(ctx, iterOpts, func() ([]*gogithub.CheckRun, error) {
+		return c.ListCheckRuns(ctx, owner, repo, ref)
+	})
+}
+
+// IterCheckSuites iterates over check suites for the commit ref resolves to.
+func (c *Client) IterCheckSuites(ctx context.Context, owner, repo, ref string, iterOpts *clientv1.IterOptions) iter.Seq2[*gogithub.CheckSuite, error] {
+	return stream(ctx, iterOpts, func() ([]*gogithub.CheckSuite, error) {
+		return c.ListCheckSuites(ctx, owner, repo, ref)
+	})
+}
+
+// IterReleases iterates over the releases in a repository.
+func (c *Client) IterReleases(ctx context.Context, owner, repo string, iterOpts *clientv1.IterOptions) iter.Seq2[*gogithub.Release, error] {
+	return stream(ctx, iterOpts, func() ([]*gogithub.Release, error) {
+		return c.ListReleases(ctx, owner, repo)
+	})
+}
+
+// IterReleaseAssets iterates over the assets of a release.
+func (c *Client) IterReleaseAssets(ctx context.Context, owner, repo string, releaseID int64, iterOpts *clientv1.IterOptions) iter.Seq2[*gogithub.ReleaseAsset, error] {
+	return stream(ctx, iterOpts, func() ([]*gogithub.ReleaseAsset, error) {
+		return c.ListReleaseAssets(ctx, owner, repo, releaseID)
+	})
+}
+
+// IterUserEvents iterates over activity events performed by a user.
+func (c *Client) IterUserEvents(ctx context.Context, username string, opts *clientv1.ListUserEventsOptions, iterOpts *clientv1.IterOptions) iter.Seq2[*gogithub.Event, error] {
+	return stream(ctx, iterOpts, func() ([]*gogithub.Event, error) {
+		return c.ListUserEvents(ctx, username, opts)
+	})
+}
+
+// IterWorkflows iterates over the workflows defined in a repository.
+func (c *Client) IterWorkflows(ctx context.Context, owner, repo string, iterOpts *clientv1.IterOptions) iter.Seq2[*gogithub.Workflow, error] {
+	return stream(ctx, iterOpts, func() ([]*gogithub.Workflow, error) {
+		return c.ListWorkflows(ctx, owner, repo)
+	})
+}
+
+// IterWorkflowRuns iterates over all runs of a workflow, most recent first,
+// starting from opts.Page when set.
+func (c *Client) IterWorkflowRuns(ctx context.Context, owner, repo string, workflowID int64, opts *clientv1.ListWorkflowRunsOptions, iterOpts *clientv1.IterOptions) iter.Seq2[*gogithub.WorkflowRun, error] {
+	return stream(ctx, iterOpts, func() ([]*gogithub.WorkflowRun, error) {
+		pageOpts := clientv1.ListWorkflowRunsOptions{PerPage: 100, Page: 1}
+		if opts != nil {
+			pageOpts = *opts
+			if pageOpts.PerPage <= 0 {
+				pageOpts.PerPage = 100
+			}
+			if pageOpts.Page <= 0 {
+				pageOpts.Page = 1
+			}
+		}
+		var runs []*gogithub.WorkflowRun
+		for {
+			page, err := c.ListWorkflowRuns(ctx, owner, repo, workflowID, &pageOpts)
+			if err != nil {
+				return nil, err
+			}
+			runs = append(runs, page...)
+			if len(page) < pageOpts.PerPage {
+				return runs, nil
+			}
+			pageOpts.Page++
+		}
+	})
+}
+
+// stream returns an iterator over the result of list, which runs when
+// iteration starts.
+func stream[T any](ctx context.Context, iterOpts *clientv1.IterOptions, list func() ([]T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		if err := ctx.Err(); err != nil {
+			yield(zero, err)
+			return
+		}
+		items, err := list()
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		for i, item := range items {
+			if iterOpts != nil && iterOpts.MaxItems > 0 && i >= iterOpts.MaxItems {
+				return
+			}
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/grokify/gogithub"
+)
+
+// AddOrgMember makes login a member of org, creating either if it does
+// not exist yet.
+func (c *Client) AddOrgMember(org, login string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.addOrgMember(org, login)
+}
+
+// AddTeamMember makes login a member of the team with teamSlug in org,
+// creating the team, and adding login to org, if needed.
+func (c *Client) AddTeamMember(org, teamSlug, login string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.addOrgMember(org, login)
+	key := teamKey(org, teamSlug)
+	if !slices.ContainsFunc(c.teams[key], func(m string) bool { return strings.EqualFold(m, login) }) {
+		c.teams[key] = append(c.teams[key], login)
+	}
+}
+
+// addOrgMember adds login to org. Callers must hold c.mu.
+func (c *Client) addOrgMember(org, login string) {
+	key := strings.ToLower(org)
+	if _, ok := c.users[key]; !ok {
+		c.ensureUser(org).Type = "Organization"
+	}
+	c.ensureUser(login)
+	if !slices.ContainsFunc(c.orgMembers[key], func(m string) bool { return strings.EqualFold(m, login) }) {
+		c.orgMembers[key] = append(c.orgMembers[key], login)
+	}
+}
+
+// Organizations
+
+// ListOrgMembers lists the members of an organization, sorted by login.
+func (c *Client) ListOrgMembers(ctx context.Context, org string) ([]*gogithub.User, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.users[strings.ToLower(org)]; !ok {
+		return nil, fmt.Errorf("list org members: %w", notFound())
+	}
+	return c.usersByLogin(c.orgMembers[strings.ToLower(org)]), nil
+}
+
+// ListTeamMembers lists the members of an organization's team, sorted by
+// login.
+func (c *Client) ListTeamMembers(ctx context.Context, org, teamSlug string) ([]*gogithub.User, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	members, ok := c.teams[teamKey(org, teamSlug)]
+	if !ok {
+		return nil, fmt.Errorf("list team members: %w", notFound())
+	}
+	return c.usersByLogin(members), nil
+}
+
+// usersByLogin returns copies of the users with logins, sorted by login.
+// Callers must hold c.mu.
+func (c *Client) usersByLogin(logins []string) []*gogithub.User {
+	users := make([]*gogithub.User, 0, len(logins))
+	for _, login := range logins {
+		users = append(users, copyUser(c.users[strings.ToLower(login)]))
+	}
+	slices.SortFunc(users, func(a, b *gogithub.User) int {
+		return strings.Compare(strings.ToLower(a.Login), strings.ToLower(b.Login))
+	})
+	return users
+}
+
+func teamKey(org, teamSlug string) string {
+	return strings.ToLower(org) + "/" + strings.ToLower(teamSlug)
+}
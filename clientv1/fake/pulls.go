@@ -0,0 +1,785 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grokify/gogithub"
+	"github.com/grokify/gogithub/clientv1"
+)
+
+// pull is the state of a pull request beyond its issue record.
+type pull struct {
+	pr        *gogithub.PullRequest
+	headRepo  *repository
+	reviews   []*gogithub.PullRequestReview
+	comments  []*gogithub.PullRequestComment
+	reviewers []string
+}
+
+// fileChange is a single file difference between two trees.
+type fileChange struct {
+	path   string
+	status string
+	before treeEntry
+	after  treeEntry
+}
+
+// Pull Requests
+
+// GetPullRequest retrieves a pull request by number.
+func (c *Client) GetPullRequest(ctx context.Context, owner, repo string, number int) (*gogithub.PullRequest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, p, err := c.pull(owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	return rs.pullRequest(p), nil
+}
+
+// ListPullRequests lists pull requests in a repository, newest first unless
+// opts specifies otherwise.
+func (c *Client) ListPullRequests(ctx context.Context, owner, repo string, opts *clientv1.ListPullRequestsOptions) ([]*gogithub.PullRequest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("list pull requests: %w", err)
+	}
+	if opts == nil {
+		opts = &clientv1.ListPullRequestsOptions{}
+	}
+	state := opts.State
+	if state == "" {
+		state = "open"
+	}
+	var prs []*gogithub.PullRequest
+	for _, number := range sortedNumbers(rs.pulls) {
+		pr := rs.pullRequest(rs.pulls[number])
+		if state != "all" && pr.State != state {
+			continue
+		}
+		if opts.Base != "" && pr.Base.Ref != opts.Base {
+			continue
+		}
+		if opts.Head != "" && pr.Head.Label != opts.Head && pr.Head.Ref != opts.Head {
+			continue
+		}
+		prs = append(prs, pr)
+	}
+	sortByTime(prs, opts.Direction, func(pr *gogithub.PullRequest) time.Time {
+		if opts.Sort == "updated" {
+			return pr.UpdatedAt
+		}
+		return pr.CreatedAt
+	})
+	return prs, nil
+}
+
+// CreatePullRequest creates a new pull request. Head may be "branch" or
+// "owner:branch" for a branch in a fork.
+func (c *Client) CreatePullRequest(ctx context.Context, owner, repo string, input *clientv1.CreatePullRequestInput) (*gogithub.PullRequest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	if input.Title == "" {
+		return nil, validationError("PullRequest", "title", "missing_field", "")
+	}
+	headRepo, headBranch, ok := rs.resolveHead(input.Head)
+	if !ok {
+		return nil, validationError("PullRequest", "head", "invalid", "")
+	}
+	if _, ok := rs.refs[refHeadsPrefix+input.Base]; !ok {
+		return nil, validationError("PullRequest", "base", "invalid", "")
+	}
+	headLabel := headRepo.repo.Owner.Login + ":" + headBranch
+	for _, p := range rs.pulls {
+		if p.pr.State == "open" && p.pr.Head.Label == headLabel && p.pr.Base.Ref == input.Base {
+			return nil, validationError("PullRequest", "", "custom", "A pull request already exists for "+headLabel+".")
+		}
+	}
+	headSHA := headRepo.refs[refHeadsPrefix+headBranch]
+	baseSHA := rs.refs[refHeadsPrefix+input.Base]
+	if len(rs.commitsBetween(baseSHA, headSHA)) == 0 {
+		return nil, validationError("PullRequest", "", "custom", "No commits between "+input.Base+" and "+headBranch)
+	}
+
+	number := rs.nextNumber
+	rs.nextNumber++
+	now := c.now()
+	author := copyUser(c.ensureUser(c.login))
+	pr := &gogithub.PullRequest{
+		ID:        c.id(),
+		Number:    number,
+		State:     "open",
+		Title:     input.Title,
+		Body:      input.Body,
+		HTMLURL:   fmt.Sprintf("%s/pull/%d", rs.repo.HTMLURL, number),
+		User:      author,
+		Draft:     input.Draft,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Head: &gogithub.PullRequestBranch{
+			Label: headLabel,
+			Ref:   headBranch,
+			User:  copyUser(headRepo.repo.Owner),
+			Repo:  copyRepository(headRepo.repo),
+		},
+		Base: &gogithub.PullRequestBranch{
+			Label: rs.repo.Owner.Login + ":" + input.Base,
+			Ref:   input.Base,
+			User:  copyUser(rs.repo.Owner),
+			Repo:  copyRepository(rs.repo),
+		},
+	}
+	p := &pull{pr: pr, headRepo: headRepo}
+	rs.pulls[number] = p
+	rs.issues[number] = &issue{issue: &gogithub.Issue{
+		ID:            c.id(),
+		Number:        number,
+		State:         "open",
+		Title:         input.Title,
+		Body:          input.Body,
+		HTMLURL:       pr.HTMLURL,
+		RepositoryURL: rs.apiURL(""),
+		User:          author,
+		IsPullRequest: true,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}}
+	return rs.pullRequest(p), nil
+}
+
+// UpdatePullRequest updates a pull request.
+func (c *Client) UpdatePullRequest(ctx context.Context, owner, repo string, number int, input *clientv1.UpdatePullRequestInput) (*gogithub.PullRequest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, p, err := c.pull(owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	if input.Base != nil {
+		if _, ok := rs.refs[refHeadsPrefix+*input.Base]; !ok {
+			return nil, validationError("PullRequest", "base", "invalid", "")
+		}
+	}
+	if input.State != nil && *input.State != "open" && *input.State != "closed" {
+		return nil, validationError("PullRequest", "state", "invalid", "")
+	}
+	if input.State != nil && *input.State == "open" && p.pr.Merged {
+		return nil, validationError("PullRequest", "state", "invalid", "")
+	}
+
+	is := rs.issues[number].issue
+	now := c.now()
+	if input.Title != nil {
+		p.pr.Title = *input.Title
+		is.Title = *input.Title
+	}
+	if input.Body != nil {
+		p.pr.Body = *input.Body
+		is.Body = *input.Body
+	}
+	if input.Base != nil {
+		p.pr.Base.Ref = *input.Base
+		p.pr.Base.Label = rs.repo.Owner.Login + ":" + *input.Base
+	}
+	if input.State != nil {
+		setState(&p.pr.State, &p.pr.ClosedAt, *input.State, now)
+		setState(&is.State, &is.ClosedAt, *input.State, now)
+	}
+	p.pr.UpdatedAt = now
+	is.UpdatedAt = now
+	return rs.pullRequest(p), nil
+}
+
+// MergePullRequest merges a pull request into its base branch using a
+// three-way merge of the changed paths.
+func (c *Client) MergePullRequest(ctx context.Context, owner, repo string, number int, opts *clientv1.MergePullRequestOptions) (*gogithub.MergeResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, p, err := c.pull(owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &clientv1.MergePullRequestOptions{}
+	}
+	pr := rs.pullRequest(p)
+	if pr.State != "open" || pr.Draft {
+		return nil, apiError(http.StatusMethodNotAllowed, "Pull Request is not mergeable")
+	}
+	if opts.SHA != "" && opts.SHA != pr.Head.SHA {
+		return nil, conflict("Head branch was modified. Review and try the merge again.")
+	}
+	merged, ok := rs.mergeTrees(pr.Base.SHA, pr.Head.SHA)
+	if !ok {
+		return nil, apiError(http.StatusMethodNotAllowed, "Pull Request is not mergeable")
+	}
+
+	title, message := opts.CommitTitle, opts.CommitMessage
+	var parents []string
+	switch opts.MergeMethod {
+	case "", "merge":
+		if title == "" {
+			title = fmt.Sprintf("Merge pull request #%d from %s", number, strings.Replace(pr.Head.Label, ":", "/", 1))
+		}
+		if message == "" {
+			message = pr.Title
+		}
+		parents = []string{pr.Base.SHA, pr.Head.SHA}
+	case "squash", "rebase":
+		if title == "" {
+			title = fmt.Sprintf("%s (#%d)", pr.Title, number)
+		}
+		parents = []string{pr.Base.SHA}
+	default:
+		return nil, validationError("PullRequest", "merge_method", "invalid", "")
+	}
+	if message != "" {
+		title += "\n\n" + message
+	}
+	commit := rs.writeCommit(c, title, rs.writeTree(merged), parents, nil)
+	rs.refs[refHeadsPrefix+pr.Base.Ref] = commit.SHA
+
+	now := c.now()
+	p.pr.Merged = true
+	p.pr.MergedAt = &now
+	p.pr.Head.SHA = pr.Head.SHA
+	setState(&p.pr.State, &p.pr.ClosedAt, "closed", now)
+	is := rs.issues[number].issue
+	setState(&is.State, &is.ClosedAt, "closed", now)
+	p.pr.UpdatedAt = now
+	is.UpdatedAt = now
+	return &gogithub.MergeResult{
+		SHA:     commit.SHA,
+		Merged:  true,
+		Message: "Pull Request successfully merged",
+	}, nil
+}
+
+// ListPullRequestFiles lists files changed in a pull request.
+func (c *Client) ListPullRequestFiles(ctx context.Context, owner, repo string, number int) ([]*gogithub.CommitFile, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, p, err := c.pull(owner, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("list PR files: %w", err)
+	}
+	pr := rs.pullRequest(p)
+	var files []*gogithub.CommitFile
+	for _, fc := range rs.diffCommits(rs.mergeBase(pr.Base.SHA, pr.Head.SHA), pr.Head.SHA) {
+		before, after := rs.lines(fc.before), rs.lines(fc.after)
+		adds, dels := countChanges(before, after)
+		sha := fc.after.SHA
+		if sha == "" {
+			sha = fc.before.SHA
+		}
+		files = append(files, &gogithub.CommitFile{
+			SHA:         sha,
+			Filename:    fc.path,
+			Status:      fc.status,
+			Additions:   adds,
+			Deletions:   dels,
+			Changes:     adds + dels,
+			Patch:       hunk(before, after),
+			BlobURL:     rs.repo.HTMLURL + "/blob/" + pr.Head.SHA + "/" + fc.path,
+			RawURL:      rs.repo.HTMLURL + "/raw/" + pr.Head.SHA + "/" + fc.path,
+			ContentsURL: rs.apiURL("/contents/" + fc.path + "?ref=" + pr.Head.SHA),
+		})
+	}
+	return files, nil
+}
+
+// GetPullRequestDiff gets the diff for a pull request. Each changed file is
+// rendered as a single hunk replacing the old content with the new.
+func (c *Client) GetPullRequestDiff(ctx context.Context, owner, repo string, number int) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, p, err := c.pull(owner, repo, number)
+	if err != nil {
+		return "", fmt.Errorf("get PR diff: %w", err)
+	}
+	pr := rs.pullRequest(p)
+	return rs.diff(rs.mergeBase(pr.Base.SHA, pr.Head.SHA), pr.Head.SHA), nil
+}
+
+// GetPullRequestPatch gets the patch for a pull request, one mail-formatted
+// patch per commit, oldest first.
+func (c *Client) GetPullRequestPatch(ctx context.Context, owner, repo string, number int) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, p, err := c.pull(owner, repo, number)
+	if err != nil {
+		return "", fmt.Errorf("get PR patch: %w", err)
+	}
+	pr := rs.pullRequest(p)
+	shas := rs.commitsBetween(pr.Base.SHA, pr.Head.SHA)
+	commits := make([]*gogithub.Commit, len(shas))
+	for i, sha := range shas {
+		commits[i] = rs.objects.commits[sha]
+	}
+	sort.SliceStable(commits, func(i, j int) bool {
+		return rs.ancestors(commits[j].SHA)[commits[i].SHA]
+	})
+	var sb strings.Builder
+	for i, commit := range commits {
+		subject, body, _ := strings.Cut(commit.Message, "\n")
+		parent := ""
+		if len(commit.Parents) > 0 {
+			parent = commit.Parents[0].SHA
+		}
+		fmt.Fprintf(&sb, "From %s Mon Sep 17 00:00:00 2001\n", commit.SHA)
+		fmt.Fprintf(&sb, "From: %s <%s>\n", commit.Author.Name, commit.Author.Email)
+		fmt.Fprintf(&sb, "Date: %s\n", commit.Author.Date.Format(time.RFC1123Z))
+		fmt.Fprintf(&sb, "Subject: [PATCH %d/%d] %s\n\n", i+1, len(commits), subject)
+		if body = strings.TrimSpace(body); body != "" {
+			sb.WriteString(body + "\n")
+		}
+		sb.WriteString("---\n")
+		sb.WriteString(rs.diff(parent, commit.SHA))
+		sb.WriteString("\n")
+	}
+	return sb.String(), nil
+}
+
+// Pull Request Reviews
+
+// CreatePullRequestReview creates a review on a pull request. As on GitHub,
+// authors cannot approve or request changes on their own pull requests.
+func (c *Client) CreatePullRequestReview(ctx context.Context, owner, repo string, number int, input *clientv1.CreateReviewInput) (*gogithub.PullRequestReview, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, p, err := c.pull(owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	var state string
+	switch input.Event {
+	case "APPROVE":
+		state = "APPROVED"
+	case "REQUEST_CHANGES":
+		state = "CHANGES_REQUESTED"
+	case "COMMENT":
+		state = "COMMENTED"
+	case "":
+		state = "PENDING"
+	default:
+		return nil, validationError("PullRequestReview", "event", "invalid", "")
+	}
+	own := strings.EqualFold(p.pr.User.Login, c.login)
+	switch {
+	case own && state == "APPROVED":
+		return nil, validationFailed("Unprocessable Entity: Can not approve your own pull request")
+	case own && state == "CHANGES_REQUESTED":
+		return nil, validationFailed("Unprocessable Entity: Can not request changes on your own pull request")
+	case input.Body == "" && (state == "CHANGES_REQUESTED" || state == "COMMENTED"):
+		return nil, validationFailed("Unprocessable Entity: Review Comment is missing")
+	}
+	pr := rs.pullRequest(p)
+	review := &gogithub.PullRequestReview{
+		ID:       c.id(),
+		User:     copyUser(c.ensureUser(c.login)),
+		Body:     input.Body,
+		State:    state,
+		CommitID: pr.Head.SHA,
+	}
+	review.HTMLURL = fmt.Sprintf("%s#pullrequestreview-%d", pr.HTMLURL, review.ID)
+	if state != "PENDING" {
+		now := c.now()
+		review.SubmittedAt = &now
+	}
+	p.reviews = append(p.reviews, review)
+	cp := *review
+	return &cp, nil
+}
+
+// ListPullRequestReviews lists reviews on a pull request, oldest first.
+func (c *Client) ListPullRequestReviews(ctx context.Context, owner, repo string, number int) ([]*gogithub.PullRequestReview, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, p, err := c.pull(owner, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("list PR reviews: %w", err)
+	}
+	reviews := make([]*gogithub.PullRequestReview, len(p.reviews))
+	for i, r := range p.reviews {
+		cp := *r
+		reviews[i] = &cp
+	}
+	return reviews, nil
+}
+
+// RequestReviewers requests reviewers for a pull request. Team reviewers
+// are accepted but not modelled.
+func (c *Client) RequestReviewers(ctx context.Context, owner, repo string, number int, reviewers, teamReviewers []string) (*gogithub.PullRequest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, p, err := c.pull(owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range reviewers {
+		if strings.EqualFold(r, p.pr.User.Login) {
+			return nil, validationFailed("Review cannot be requested from pull request author.")
+		}
+		if _, ok := c.users[strings.ToLower(r)]; !ok {
+			return nil, validationFailed("Reviews may only be requested from collaborators. One or more of the users or teams you specified is not a collaborator of the " + rs.repo.FullName + " repository.")
+		}
+	}
+	for _, r := range reviewers {
+		if !containsFold(p.reviewers, r) {
+			p.reviewers = append(p.reviewers, r)
+		}
+	}
+	return rs.pullRequest(p), nil
+}
+
+// Reviewers returns the logins of reviewers requested on a pull request.
+func (c *Client) Reviewers(owner, repo string, number int) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, p, err := c.pull(owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	return append([]string(nil), p.reviewers...), nil
+}
+
+// Pull Request Comments
+
+// CreatePullRequestComment creates a comment on a file changed by a pull
+// request.
+func (c *Client) CreatePullRequestComment(ctx context.Context, owner, repo string, number int, input *clientv1.CreatePRCommentInput) (*gogithub.PullRequestComment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, p, err := c.pull(owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := rs.objects.commits[input.CommitID]; !ok {
+		return nil, validationError("PullRequestReviewComment", "commit_id", "invalid", "")
+	}
+	pr := rs.pullRequest(p)
+	changed := false
+	for _, fc := range rs.diffCommits(rs.mergeBase(pr.Base.SHA, pr.Head.SHA), pr.Head.SHA) {
+		if fc.path == input.Path {
+			changed = true
+			break
+		}
+	}
+	if !changed || input.Line <= 0 {
+		return nil, validationError("PullRequestReviewComment", "pull_request_review_thread.path", "invalid", "")
+	}
+	side := input.Side
+	if side == "" {
+		side = "RIGHT"
+	}
+	now := c.now()
+	comment := &gogithub.PullRequestComment{
+		ID:        c.id(),
+		User:      copyUser(c.ensureUser(c.login)),
+		Body:      input.Body,
+		Path:      input.Path,
+		Line:      input.Line,
+		Side:      side,
+		CommitID:  input.CommitID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	comment.HTMLURL = fmt.Sprintf("%s#discussion_r%d", pr.HTMLURL, comment.ID)
+	p.comments = append(p.comments, comment)
+	cp := *comment
+	return &cp, nil
+}
+
+// ListPullRequestComments lists review comments on a pull request.
+func (c *Client) ListPullRequestComments(ctx context.Context, owner, repo string, number int) ([]*gogithub.PullRequestComment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, p, err := c.pull(owner, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("list PR comments: %w", err)
+	}
+	comments := make([]*gogithub.PullRequestComment, len(p.comments))
+	for i, cm := range p.comments {
+		cp := *cm
+		comments[i] = &cp
+	}
+	return comments, nil
+}
+
+// pull looks up a pull request. Callers must hold c.mu.
+func (c *Client) pull(owner, repo string, number int) (*repository, *pull, error) {
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	p, ok := rs.pulls[number]
+	if !ok {
+		return nil, nil, notFound()
+	}
+	return rs, p, nil
+}
+
+// resolveHead resolves a pull request head ("branch" or "owner:branch") to
+// the repository and branch it names.
+func (rs *repository) resolveHead(head string) (*repository, string, bool) {
+	headRepo := rs
+	branch := head
+	if owner, b, ok := strings.Cut(head, ":"); ok {
+		branch = b
+		headRepo = nil
+		for _, candidate := range append([]*repository{rs}, rs.forks...) {
+			if strings.EqualFold(candidate.repo.Owner.Login, owner) {
+				headRepo = candidate
+				break
+			}
+		}
+		if headRepo == nil {
+			return nil, "", false
+		}
+	}
+	if _, ok := headRepo.refs[refHeadsPrefix+branch]; !ok {
+		return nil, "", false
+	}
+	return headRepo, branch, true
+}
+
+// pullRequest returns a copy of the pull request with head, base and
+// change statistics refreshed from the current refs.
+func (rs *repository) pullRequest(p *pull) *gogithub.PullRequest {
+	pr := *p.pr
+	head := *p.pr.Head
+	base := *p.pr.Base
+	pr.Head, pr.Base = &head, &base
+	pr.User = copyUser(p.pr.User)
+	pr.Labels = append([]gogithub.Label(nil), rs.issues[pr.Number].issue.Labels...)
+	pr.Assignees = append([]*gogithub.User(nil), rs.issues[pr.Number].issue.Assignees...)
+	if p.pr.ClosedAt != nil {
+		t := *p.pr.ClosedAt
+		pr.ClosedAt = &t
+	}
+	if p.pr.MergedAt != nil {
+		t := *p.pr.MergedAt
+		pr.MergedAt = &t
+	}
+
+	base.SHA = rs.refs[refHeadsPrefix+base.Ref]
+	if !pr.Merged {
+		if sha, ok := p.headRepo.refs[refHeadsPrefix+head.Ref]; ok {
+			head.SHA = sha
+		}
+	}
+	if pr.Merged {
+		return &pr
+	}
+	mergeBase := rs.mergeBase(base.SHA, head.SHA)
+	pr.Commits = len(rs.commitsBetween(base.SHA, head.SHA))
+	pr.Additions, pr.Deletions = 0, 0
+	for _, fc := range rs.diffCommits(mergeBase, head.SHA) {
+		adds, dels := countChanges(rs.lines(fc.before), rs.lines(fc.after))
+		pr.Additions += adds
+		pr.Deletions += dels
+	}
+	if pr.State == "open" {
+		_, ok := rs.mergeTrees(base.SHA, head.SHA)
+		pr.Mergeable = &ok
+	}
+	return &pr
+}
+
+// mergeTrees three-way merges the trees of ours and theirs. It reports
+// false if both sides changed the same path differently.
+func (rs *repository) mergeTrees(ours, theirs string) (map[string]treeEntry, bool) {
+	baseTree := map[string]treeEntry{}
+	if mb := rs.mergeBase(ours, theirs); mb != "" {
+		baseTree = rs.flatten(rs.objects.commits[mb].Tree.SHA)
+	}
+	oursTree := rs.flatten(rs.objects.commits[ours].Tree.SHA)
+	theirsTree := rs.flatten(rs.objects.commits[theirs].Tree.SHA)
+
+	merged := make(map[string]treeEntry)
+	paths := make(map[string]bool)
+	for _, t := range []map[string]treeEntry{baseTree, oursTree, theirsTree} {
+		for p := range t {
+			paths[p] = true
+		}
+	}
+	for p := range paths {
+		b, o, t := baseTree[p], oursTree[p], theirsTree[p]
+		var result treeEntry
+		switch {
+		case o == t, t == b:
+			result = o
+		case o == b:
+			result = t
+		default:
+			return nil, false
+		}
+		if result.SHA != "" {
+			merged[p] = result
+		}
+	}
+	return merged, true
+}
+
+// diffCommits returns the file changes between two commits. An empty from
+// compares against an empty tree.
+func (rs *repository) diffCommits(from, to string) []fileChange {
+	before := map[string]treeEntry{}
+	if commit, ok := rs.objects.commits[from]; ok {
+		before = rs.flatten(commit.Tree.SHA)
+	}
+	after := map[string]treeEntry{}
+	if commit, ok := rs.objects.commits[to]; ok {
+		after = rs.flatten(commit.Tree.SHA)
+	}
+	var changes []fileChange
+	for _, p := range sortedKeys(after) {
+		b, existed := before[p]
+		switch {
+		case !existed:
+			changes = append(changes, fileChange{path: p, status: "added", after: after[p]})
+		case b != after[p]:
+			changes = append(changes, fileChange{path: p, status: "modified", before: b, after: after[p]})
+		}
+	}
+	for _, p := range sortedKeys(before) {
+		if _, ok := after[p]; !ok {
+			changes = append(changes, fileChange{path: p, status: "removed", before: before[p]})
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].path < changes[j].path })
+	return changes
+}
+
+// diff renders the changes between two commits as a unified diff.
+func (rs *repository) diff(from, to string) string {
+	var sb strings.Builder
+	for _, fc := range rs.diffCommits(from, to) {
+		fmt.Fprintf(&sb, "diff --git a/%s b/%s\n", fc.path, fc.path)
+		oldName, newName := "a/"+fc.path, "b/"+fc.path
+		switch fc.status {
+		case "added":
+			fmt.Fprintf(&sb, "new file mode %s\n", fc.after.Mode)
+			oldName = "/dev/null"
+		case "removed":
+			fmt.Fprintf(&sb, "deleted file mode %s\n", fc.before.Mode)
+			newName = "/dev/null"
+		}
+		fmt.Fprintf(&sb, "index %s..%s\n", shortSHA(fc.before.SHA), shortSHA(fc.after.SHA))
+		fmt.Fprintf(&sb, "--- %s\n+++ %s\n", oldName, newName)
+		sb.WriteString(hunk(rs.lines(fc.before), rs.lines(fc.after)))
+	}
+	return sb.String()
+}
+
+// lines returns the lines of a blob entry.
+func (rs *repository) lines(e treeEntry) []string {
+	if e.SHA == "" {
+		return nil
+	}
+	content := strings.TrimSuffix(string(rs.objects.blobs[e.SHA]), "\n")
+	if content == "" {
+		return nil
+	}
+	return strings.Split(content, "\n")
+}
+
+// hunk renders a single hunk replacing before with after.
+func hunk(before, after []string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(len(before)), hunkRange(len(after)))
+	for _, l := range before {
+		sb.WriteString("-" + l + "\n")
+	}
+	for _, l := range after {
+		sb.WriteString("+" + l + "\n")
+	}
+	return sb.String()
+}
+
+func hunkRange(n int) string {
+	if n == 0 {
+		return "0,0"
+	}
+	return fmt.Sprintf("1,%d", n)
+}
+
+// countChanges counts added and deleted lines, ignoring lines present in
+// both versions.
+func countChanges(before, after []string) (additions, deletions int) {
+	counts := make(map[string]int)
+	for _, l := range before {
+		counts[l]++
+	}
+	for _, l := range after {
+		if counts[l] > 0 {
+			counts[l]--
+			continue
+		}
+		additions++
+	}
+	for _, n := range counts {
+		deletions += n
+	}
+	return additions, deletions
+}
+
+func shortSHA(sha string) string {
+	if sha == "" {
+		return "0000000"
+	}
+	return sha[:7]
+}
+
+// setState sets an open/closed state and its closed timestamp.
+func setState(state *string, closedAt **time.Time, newState string, now time.Time) {
+	if *state == newState {
+		return
+	}
+	*state = newState
+	if newState == "closed" {
+		*closedAt = &now
+	} else {
+		*closedAt = nil
+	}
+}
+
+// sortByTime sorts items by key in direction ("asc" or "desc", default
+// "desc"). Items with equal keys keep their order.
+func sortByTime[T any](items []T, direction string, key func(T) time.Time) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if direction == "asc" {
+			return key(items[i]).Before(key(items[j]))
+		}
+		return key(items[i]).After(key(items[j]))
+	})
+}
+
+func sortedNumbers[V any](m map[int]V) []int {
+	numbers := make([]int, 0, len(m))
+	for n := range m {
+		numbers = append(numbers, n)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(numbers)))
+	return numbers
+}
+
+func containsFold(items []string, s string) bool {
+	for _, item := range items {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
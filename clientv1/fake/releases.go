@@ -0,0 +1,305 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/grokify/gogithub"
+	"github.com/grokify/gogithub/clientv1"
+)
+
+// release is the state of a release.
+type release struct {
+	rel *gogithub.Release
+}
+
+// AddReleaseAsset attaches an uploaded asset to a release. The Client
+// interface has no upload method, so tests seed assets with this helper.
+func (c *Client) AddReleaseAsset(owner, repo string, releaseID int64, upload *gogithub.ReleaseAssetUpload) (*gogithub.ReleaseAsset, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, r, err := c.release(owner, repo, releaseID)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range r.rel.Assets {
+		if a.Name == upload.Name {
+			return nil, validationError("ReleaseAsset", "name", "already_exists", "")
+		}
+	}
+	now := c.now()
+	contentType := upload.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	asset := gogithub.ReleaseAsset{
+		ID:                 c.id(),
+		Name:               upload.Name,
+		Label:              upload.Label,
+		State:              "uploaded",
+		ContentType:        contentType,
+		Size:               len(upload.Content),
+		BrowserDownloadURL: rs.repo.HTMLURL + "/releases/download/" + r.rel.TagName + "/" + upload.Name,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+	r.rel.Assets = append(r.rel.Assets, asset)
+	return &asset, nil
+}
+
+// Releases
+
+// GetRelease retrieves a release by ID.
+func (c *Client) GetRelease(ctx context.Context, owner, repo string, id int64) (*gogithub.Release, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, r, err := c.release(owner, repo, id)
+	if err != nil {
+		return nil, err
+	}
+	return r.copy(), nil
+}
+
+// GetLatestRelease retrieves the most recently published release that is
+// neither a draft nor a prerelease.
+func (c *Client) GetLatestRelease(ctx context.Context, owner, repo string) (*gogithub.Release, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	var latest *release
+	for _, r := range rs.releases {
+		if r.rel.Draft || r.rel.Prerelease {
+			continue
+		}
+		if latest == nil || !r.rel.PublishedAt.Before(*latest.rel.PublishedAt) {
+			latest = r
+		}
+	}
+	if latest == nil {
+		return nil, notFound()
+	}
+	return latest.copy(), nil
+}
+
+// GetReleaseByTag retrieves a published release by its tag name.
+func (c *Client) GetReleaseByTag(ctx context.Context, owner, repo, tag string) (*gogithub.Release, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rs.releases {
+		if r.rel.TagName == tag && !r.rel.Draft {
+			return r.copy(), nil
+		}
+	}
+	return nil, notFound()
+}
+
+// ListReleases lists all releases in a repository, newest first.
+func (c *Client) ListReleases(ctx context.Context, owner, repo string) ([]*gogithub.Release, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("list releases: %w", err)
+	}
+	releases := make([]*gogithub.Release, len(rs.releases))
+	for i, r := range rs.releases {
+		releases[len(rs.releases)-1-i] = r.copy()
+	}
+	sort.SliceStable(releases, func(i, j int) bool {
+		return releases[i].CreatedAt.After(releases[j].CreatedAt)
+	})
+	return releases, nil
+}
+
+// CreateRelease creates a new release. Publishing a release for a tag that
+// does not exist creates a lightweight tag at TargetCommitish (default: the
+// default branch).
+func (c *Client) CreateRelease(ctx context.Context, owner, repo string, input *clientv1.CreateReleaseInput) (*gogithub.Release, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("create release: %w", err)
+	}
+	if input.TagName == "" {
+		return nil, fmt.Errorf("create release: %w", validationError("Release", "tag_name", "missing_field", ""))
+	}
+	for _, r := range rs.releases {
+		if r.rel.TagName == input.TagName {
+			return nil, fmt.Errorf("create release: %w", validationError("Release", "tag_name", "already_exists", ""))
+		}
+	}
+	target := input.TargetCommitish
+	if target == "" {
+		target = rs.repo.DefaultBranch
+	}
+	targetSHA, ok := rs.resolveCommit(target)
+	if !ok {
+		return nil, fmt.Errorf("create release: %w", validationError("Release", "target_commitish", "invalid", ""))
+	}
+
+	now := c.now()
+	body := input.Body
+	if input.GenerateReleaseNotes && body == "" {
+		body = "**Full Changelog**: " + rs.repo.HTMLURL + "/commits/" + input.TagName
+	}
+	r := &release{rel: &gogithub.Release{
+		ID:              c.id(),
+		TagName:         input.TagName,
+		TargetCommitish: target,
+		Name:            input.Name,
+		Body:            body,
+		Draft:           input.Draft,
+		Prerelease:      input.Prerelease,
+		CreatedAt:       now,
+		Author:          copyUser(c.ensureUser(c.login)),
+	}}
+	r.setURLs(rs)
+	if !input.Draft {
+		r.rel.PublishedAt = &now
+		rs.ensureTag(input.TagName, targetSHA)
+	}
+	rs.releases = append(rs.releases, r)
+	return r.copy(), nil
+}
+
+// UpdateRelease updates a release. Publishing a draft creates its tag if
+// needed.
+func (c *Client) UpdateRelease(ctx context.Context, owner, repo string, id int64, input *clientv1.UpdateReleaseInput) (*gogithub.Release, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, r, err := c.release(owner, repo, id)
+	if err != nil {
+		return nil, fmt.Errorf("update release: %w", err)
+	}
+	if input.TagName != nil && *input.TagName != r.rel.TagName {
+		for _, other := range rs.releases {
+			if other.rel.TagName == *input.TagName {
+				return nil, fmt.Errorf("update release: %w", validationError("Release", "tag_name", "already_exists", ""))
+			}
+		}
+	}
+	target := r.rel.TargetCommitish
+	if input.TargetCommitish != nil {
+		target = *input.TargetCommitish
+	}
+	targetSHA, ok := rs.resolveCommit(target)
+	if !ok {
+		return nil, fmt.Errorf("update release: %w", validationError("Release", "target_commitish", "invalid", ""))
+	}
+
+	if input.TagName != nil {
+		r.rel.TagName = *input.TagName
+	}
+	r.rel.TargetCommitish = target
+	if input.Name != nil {
+		r.rel.Name = *input.Name
+	}
+	if input.Body != nil {
+		r.rel.Body = *input.Body
+	}
+	if input.Prerelease != nil {
+		r.rel.Prerelease = *input.Prerelease
+	}
+	if input.Draft != nil {
+		r.rel.Draft = *input.Draft
+	}
+	if r.rel.Draft {
+		r.rel.PublishedAt = nil
+	} else {
+		if r.rel.PublishedAt == nil {
+			now := c.now()
+			r.rel.PublishedAt = &now
+		}
+		rs.ensureTag(r.rel.TagName, targetSHA)
+	}
+	r.setURLs(rs)
+	return r.copy(), nil
+}
+
+// DeleteRelease deletes a release. As on GitHub, its tag is left in place.
+func (c *Client) DeleteRelease(ctx context.Context, owner, repo string, id int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, _, err := c.release(owner, repo, id)
+	if err != nil {
+		return err
+	}
+	for i, r := range rs.releases {
+		if r.rel.ID == id {
+			rs.releases = append(rs.releases[:i], rs.releases[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// ListReleaseAssets lists assets for a release.
+func (c *Client) ListReleaseAssets(ctx context.Context, owner, repo string, releaseID int64) ([]*gogithub.ReleaseAsset, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, r, err := c.release(owner, repo, releaseID)
+	if err != nil {
+		return nil, fmt.Errorf("list release assets: %w", err)
+	}
+	assets := make([]*gogithub.ReleaseAsset, len(r.rel.Assets))
+	for i := range r.rel.Assets {
+		a := r.rel.Assets[i]
+		assets[i] = &a
+	}
+	return assets, nil
+}
+
+// release looks up a release by ID. Callers must hold c.mu.
+func (c *Client) release(owner, repo string, id int64) (*repository, *release, error) {
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, r := range rs.releases {
+		if r.rel.ID == id {
+			return rs, r, nil
+		}
+	}
+	return nil, nil, notFound()
+}
+
+// ensureTag creates a lightweight tag at sha unless the tag exists.
+func (rs *repository) ensureTag(tag, sha string) {
+	if _, ok := rs.refs[refTagsPrefix+tag]; !ok {
+		rs.refs[refTagsPrefix+tag] = sha
+	}
+}
+
+func (r *release) setURLs(rs *repository) {
+	if r.rel.Draft {
+		r.rel.HTMLURL = fmt.Sprintf("%s/releases/tag/untagged-%d", rs.repo.HTMLURL, r.rel.ID)
+	} else {
+		r.rel.HTMLURL = rs.repo.HTMLURL + "/releases/tag/" + r.rel.TagName
+	}
+	r.rel.TarballURL = rs.apiURL("/tarball/" + r.rel.TagName)
+	r.rel.ZipballURL = rs.apiURL("/zipball/" + r.rel.TagName)
+	for i := range r.rel.Assets {
+		a := &r.rel.Assets[i]
+		a.BrowserDownloadURL = rs.repo.HTMLURL + "/releases/download/" + r.rel.TagName + "/" + a.Name
+	}
+}
+
+func (r *release) copy() *gogithub.Release {
+	cp := *r.rel
+	cp.Author = copyUser(r.rel.Author)
+	cp.Assets = append([]gogithub.ReleaseAsset(nil), r.rel.Assets...)
+	if r.rel.PublishedAt != nil {
+		t := *r.rel.PublishedAt
+		cp.PublishedAt = &t
+	}
+	return &cp
+}
@@ -0,0 +1,590 @@
+package fake
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/grokify/gogithub"
+	"github.com/grokify/gogithub/clientv1"
+)
+
+// repository is the in-memory state of a single repository.
+type repository struct {
+	repo    *gogithub.Repository
+	objects *objectStore
+	refs    map[string]string // full ref name -> object SHA
+	parent  *repository
+	forks   []*repository
+
+	nextNumber int
+	issues     map[int]*issue
+	pulls      map[int]*pull
+	labels     map[string]gogithub.Label
+	releases   []*release
+
+	checkRuns    []*gogithub.CheckRun
+	checkSuites  []*gogithub.CheckSuite
+	languages    map[string]int
+	protection   map[string]*gogithub.BranchProtection
+	contributors []*gogithub.ContributorStats
+	workflows    []*gogithub.Workflow
+	workflowRuns []*gogithub.WorkflowRun
+	sarifUploads map[string]*gogithub.SARIFUploadStatus
+}
+
+// newRepository registers a repository built from r. Callers must hold c.mu.
+func (c *Client) newRepository(r *gogithub.Repository) *repository {
+	stored := *r
+	stored.Owner = copyUser(c.ensureUser(r.Owner.Login))
+	if stored.ID == 0 {
+		stored.ID = c.id()
+	}
+	if stored.DefaultBranch == "" {
+		stored.DefaultBranch = DefaultBranch
+	}
+	if stored.Visibility == "" {
+		stored.Visibility = "public"
+		if stored.Private {
+			stored.Visibility = "private"
+		}
+	}
+	stored.FullName = stored.Owner.Login + "/" + stored.Name
+	if stored.HTMLURL == "" {
+		stored.HTMLURL = gogithub.BaseURLRepoHTML + "/" + stored.FullName
+	}
+	if stored.CloneURL == "" {
+		stored.CloneURL = stored.HTMLURL + ".git"
+	}
+	if stored.SSHURL == "" {
+		stored.SSHURL = "git@github.com:" + stored.FullName + ".git"
+	}
+	if stored.CreatedAt.IsZero() {
+		stored.CreatedAt = c.now()
+		stored.UpdatedAt = stored.CreatedAt
+		stored.PushedAt = stored.CreatedAt
+	}
+
+	rs := &repository{
+		repo:         &stored,
+		objects:      newObjectStore(),
+		refs:         make(map[string]string),
+		nextNumber:   1,
+		issues:       make(map[int]*issue),
+		pulls:        make(map[int]*pull),
+		labels:       make(map[string]gogithub.Label),
+		languages:    make(map[string]int),
+		protection:   make(map[string]*gogithub.BranchProtection),
+		sarifUploads: make(map[string]*gogithub.SARIFUploadStatus),
+	}
+	c.repos[repoKey(stored.Owner.Login, stored.Name)] = rs
+	return rs
+}
+
+// apiURL returns the REST API URL of a repository sub-resource.
+func (rs *repository) apiURL(suffix string) string {
+	return gogithub.BaseURLRepoAPI + "/" + rs.repo.FullName + suffix
+}
+
+// Repositories
+
+// GetRepository retrieves a repository by owner and name.
+func (c *Client) GetRepository(ctx context.Context, owner, repo string) (*gogithub.Repository, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	return copyRepository(rs.repo), nil
+}
+
+// ListUserRepos lists all repositories owned by user.
+func (c *Client) ListUserRepos(ctx context.Context, user string) ([]*gogithub.Repository, error) {
+	return c.ListUserReposWithOptions(ctx, user, nil)
+}
+
+// ListUserReposWithOptions lists repositories for a user. The fake has no
+// collaborator or membership model, so every Type lists owned repositories.
+func (c *Client) ListUserReposWithOptions(ctx context.Context, user string, opts *clientv1.ListUserReposOptions) ([]*gogithub.Repository, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.users[strings.ToLower(user)]; !ok {
+		return nil, fmt.Errorf("list user repos: %w", notFound())
+	}
+	return c.ownedRepos(user), nil
+}
+
+// ListOrgRepos lists all repositories for an organization.
+func (c *Client) ListOrgRepos(ctx context.Context, org string) ([]*gogithub.Repository, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.users[strings.ToLower(org)]; !ok {
+		return nil, fmt.Errorf("list org repos: %w", notFound())
+	}
+	return c.ownedRepos(org), nil
+}
+
+// ownedRepos returns copies of the repositories owned by login, sorted by
+// name. Callers must hold c.mu.
+func (c *Client) ownedRepos(login string) []*gogithub.Repository {
+	var repos []*gogithub.Repository
+	for _, key := range sortedKeys(c.repos) {
+		rs := c.repos[key]
+		if strings.EqualFold(rs.repo.Owner.Login, login) {
+			repos = append(repos, copyRepository(rs.repo))
+		}
+	}
+	return repos
+}
+
+// GetDefaultBranch returns the default branch name for a repository.
+func (c *Client) GetDefaultBranch(ctx context.Context, owner, repo string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return "", err
+	}
+	return rs.repo.DefaultBranch, nil
+}
+
+// GetBranchProtection returns the protection set with SetBranchProtection,
+// or (nil, nil) if the branch has none.
+func (c *Client) GetBranchProtection(ctx context.Context, owner, repo, branch string) (*gogithub.BranchProtection, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("get branch protection: %w", err)
+	}
+	if _, ok := rs.refs[refHeadsPrefix+branch]; !ok {
+		return nil, fmt.Errorf("get branch protection: %w", apiError(http.StatusNotFound, "Branch not found"))
+	}
+	p, ok := rs.protection[branch]
+	if !ok {
+		return nil, nil
+	}
+	cp := *p
+	return &cp, nil
+}
+
+// ListLanguages returns the languages set with SetLanguages.
+func (c *Client) ListLanguages(ctx context.Context, owner, repo string) (map[string]int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("list languages: %w", err)
+	}
+	langs := make(map[string]int, len(rs.languages))
+	for k, v := range rs.languages {
+		langs[k] = v
+	}
+	return langs, nil
+}
+
+// Forks
+
+// CreateFork creates a fork of a repository owned by the authenticated user
+// or opts.Organization. Like GitHub, forking a repository that has already
+// been forked to the same owner returns the existing fork.
+func (c *Client) CreateFork(ctx context.Context, owner, repo string, opts *clientv1.CreateForkOptions) (*gogithub.Repository, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("create fork: %w", err)
+	}
+	if opts == nil {
+		opts = &clientv1.CreateForkOptions{}
+	}
+	forkOwner := c.login
+	if opts.Organization != "" {
+		if _, ok := c.users[strings.ToLower(opts.Organization)]; !ok {
+			return nil, fmt.Errorf("create fork: %w", validationFailed("Validation Failed"))
+		}
+		forkOwner = opts.Organization
+	}
+	for _, f := range rs.forks {
+		if strings.EqualFold(f.repo.Owner.Login, forkOwner) {
+			return copyRepository(f.repo), nil
+		}
+	}
+	name := rs.repo.Name
+	if opts.Name != "" {
+		name = opts.Name
+	}
+	if _, exists := c.repos[repoKey(forkOwner, name)]; exists {
+		return nil, fmt.Errorf("create fork: %w", validationFailed("Name already exists on this account"))
+	}
+
+	fork := c.newRepository(&gogithub.Repository{
+		Owner:         &gogithub.User{Login: forkOwner},
+		Name:          name,
+		Description:   rs.repo.Description,
+		DefaultBranch: rs.repo.DefaultBranch,
+		Language:      rs.repo.Language,
+		Fork:          true,
+	})
+	fork.objects = rs.objects
+	fork.parent = rs
+	for ref, sha := range rs.refs {
+		if opts.DefaultBranch && strings.HasPrefix(ref, refHeadsPrefix) && ref != refHeadsPrefix+rs.repo.DefaultBranch {
+			continue
+		}
+		fork.refs[ref] = sha
+	}
+	rs.forks = append(rs.forks, fork)
+	rs.repo.ForksCount++
+	return copyRepository(fork.repo), nil
+}
+
+// Content
+
+// GetFileContent fetches a file's content from a repository.
+func (c *Client) GetFileContent(ctx context.Context, owner, repo, filePath string, opts *gogithub.ContentOptions) ([]byte, error) {
+	content, _, err := c.GetFileContentWithSHA(ctx, owner, repo, filePath, opts)
+	return content, err
+}
+
+// GetFileContentString fetches a file's content as a string.
+func (c *Client) GetFileContentString(ctx context.Context, owner, repo, filePath string, opts *gogithub.ContentOptions) (string, error) {
+	content, err := c.GetFileContent(ctx, owner, repo, filePath, opts)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// GetFileContentWithSHA fetches a file's content and returns its blob SHA.
+func (c *Client) GetFileContentWithSHA(ctx context.Context, owner, repo, filePath string, opts *gogithub.ContentOptions) ([]byte, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, "", fmt.Errorf("file not found: %s: %w", filePath, notFound())
+	}
+	tree, ok := rs.resolveTree(contentRef(opts))
+	if !ok {
+		return nil, "", fmt.Errorf("file not found: %s: %w", filePath, notFound())
+	}
+	p := strings.Trim(filePath, "/")
+	e, ok := tree[p]
+	if !ok {
+		if isDir(tree, p) {
+			return nil, "", fmt.Errorf("path is a directory, not a file: %s", filePath)
+		}
+		return nil, "", fmt.Errorf("file not found: %s: %w", filePath, notFound())
+	}
+	if e.Type != "blob" {
+		return nil, "", fmt.Errorf("path is not a file: %s (type: %s)", filePath, contentType(e))
+	}
+	return append([]byte(nil), rs.objects.blobs[e.SHA]...), e.SHA, nil
+}
+
+// ListDirectory lists the entries of a directory. An empty path lists the
+// repository root.
+func (c *Client) ListDirectory(ctx context.Context, owner, repo, dirPath string, opts *gogithub.ContentOptions) ([]*gogithub.FileContent, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("directory not found: %s: %w", dirPath, notFound())
+	}
+	tree, ok := rs.resolveTree(contentRef(opts))
+	if !ok {
+		return nil, fmt.Errorf("directory not found: %s: %w", dirPath, notFound())
+	}
+	p := strings.Trim(dirPath, "/")
+	if _, ok := tree[p]; ok && p != "" {
+		return nil, fmt.Errorf("path is a file, not a directory: %s", dirPath)
+	}
+	if p != "" && !isDir(tree, p) {
+		return nil, fmt.Errorf("directory not found: %s: %w", dirPath, notFound())
+	}
+
+	seen := make(map[string]bool)
+	var entries []*gogithub.FileContent
+	for _, name := range sortedKeys(tree) {
+		rel := name
+		if p != "" {
+			var ok bool
+			if rel, ok = strings.CutPrefix(name, p+"/"); !ok {
+				continue
+			}
+		}
+		child, _, nested := strings.Cut(rel, "/")
+		if seen[child] {
+			continue
+		}
+		seen[child] = true
+		full := path.Join(p, child)
+		if nested {
+			entries = append(entries, &gogithub.FileContent{Path: full, Name: child, Type: "dir"})
+			continue
+		}
+		e := tree[name]
+		entries = append(entries, &gogithub.FileContent{
+			Path:        full,
+			Name:        child,
+			SHA:         e.SHA,
+			Size:        len(rs.objects.blobs[e.SHA]),
+			Type:        contentType(e),
+			DownloadURL: rs.rawURL(full),
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries, nil
+}
+
+// FileExists checks if a file exists in a repository.
+func (c *Client) FileExists(ctx context.Context, owner, repo, filePath string, opts *gogithub.ContentOptions) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return false, nil
+	}
+	tree, ok := rs.resolveTree(contentRef(opts))
+	if !ok {
+		return false, nil
+	}
+	e, ok := tree[strings.Trim(filePath, "/")]
+	return ok && e.Type == "blob", nil
+}
+
+// CreateFile creates a new file in a repository with a single commit.
+func (c *Client) CreateFile(ctx context.Context, owner, repo, filePath string, opts *clientv1.CreateFileOptions) (*gogithub.CreateFileResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, tree, branch, err := c.branchTree(owner, repo, opts.Branch)
+	if err != nil {
+		return nil, fmt.Errorf("create file %s: %w", filePath, err)
+	}
+	p := strings.Trim(filePath, "/")
+	if _, ok := tree[p]; ok {
+		return nil, fmt.Errorf("create file %s: %w", filePath, validationFailed("Invalid request.\n\n\"sha\" wasn't supplied."))
+	}
+	return rs.writeFile(c, branch, p, tree, opts.Content, opts.Message, opts.Author), nil
+}
+
+// UpdateFile updates an existing file in a repository. opts.SHA must match
+// the file's current blob SHA.
+func (c *Client) UpdateFile(ctx context.Context, owner, repo, filePath string, opts *clientv1.UpdateFileOptions) (*gogithub.CreateFileResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, tree, branch, err := c.branchTree(owner, repo, opts.Branch)
+	if err != nil {
+		return nil, fmt.Errorf("update file %s: %w", filePath, err)
+	}
+	p := strings.Trim(filePath, "/")
+	if e, ok := tree[p]; ok && e.SHA != opts.SHA {
+		return nil, fmt.Errorf("update file %s: %w", filePath, conflict(fmt.Sprintf("%s does not match %s", p, opts.SHA)))
+	}
+	return rs.writeFile(c, branch, p, tree, opts.Content, opts.Message, opts.Author), nil
+}
+
+// DeleteFile deletes a file from a repository. sha must match the file's
+// current blob SHA.
+func (c *Client) DeleteFile(ctx context.Context, owner, repo, filePath, sha, message string, opts *clientv1.DeleteFileOptions) (*gogithub.DeleteFileResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if opts == nil {
+		opts = &clientv1.DeleteFileOptions{}
+	}
+	rs, tree, branch, err := c.branchTree(owner, repo, opts.Branch)
+	if err != nil {
+		return nil, fmt.Errorf("delete file %s: %w", filePath, err)
+	}
+	p := strings.Trim(filePath, "/")
+	e, ok := tree[p]
+	if !ok {
+		return nil, fmt.Errorf("delete file %s: %w", filePath, notFound())
+	}
+	if e.SHA != sha {
+		return nil, fmt.Errorf("delete file %s: %w", filePath, conflict(fmt.Sprintf("%s does not match %s", p, sha)))
+	}
+	delete(tree, p)
+	commit := rs.commitToBranch(c, branch, message, tree, opts.Author)
+	return &gogithub.DeleteFileResult{Commit: copyCommit(commit)}, nil
+}
+
+// branchTree returns the repository, the flattened tree at the head of
+// branch (default: the default branch) and the branch name.
+// Callers must hold c.mu.
+func (c *Client) branchTree(owner, repo, branch string) (*repository, map[string]treeEntry, string, error) {
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if branch == "" {
+		branch = rs.repo.DefaultBranch
+	}
+	sha, ok := rs.refs[refHeadsPrefix+branch]
+	if !ok {
+		return nil, nil, "", apiError(http.StatusNotFound, "Branch "+branch+" not found")
+	}
+	return rs, rs.flatten(rs.objects.commits[sha].Tree.SHA), branch, nil
+}
+
+// writeFile commits content at p on branch and returns the contents API
+// result.
+func (rs *repository) writeFile(c *Client, branch, p string, tree map[string]treeEntry, content []byte, message string, author *clientv1.CommitAuthor) *gogithub.CreateFileResult {
+	sha := rs.writeBlob(content)
+	mode := modeFile
+	if e, ok := tree[p]; ok {
+		mode = e.Mode
+	}
+	tree[p] = treeEntry{Mode: mode, Type: "blob", SHA: sha}
+	commit := rs.commitToBranch(c, branch, message, tree, author)
+	return &gogithub.CreateFileResult{
+		Content: &gogithub.FileContent{
+			Path:        p,
+			Name:        path.Base(p),
+			SHA:         sha,
+			Size:        len(content),
+			Type:        "file",
+			DownloadURL: rs.rawURL(p),
+		},
+		Commit: copyCommit(commit),
+	}
+}
+
+func (rs *repository) rawURL(p string) string {
+	return "https://raw.githubusercontent.com/" + rs.repo.FullName + "/" + rs.repo.DefaultBranch + "/" + p
+}
+
+func contentRef(opts *gogithub.ContentOptions) string {
+	if opts == nil {
+		return ""
+	}
+	return opts.Ref
+}
+
+// isDir reports whether p is a directory in a flattened tree.
+func isDir(tree map[string]treeEntry, p string) bool {
+	if p == "" {
+		return true
+	}
+	for name := range tree {
+		if strings.HasPrefix(name, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// contentType maps a tree entry to the contents API type.
+func contentType(e treeEntry) string {
+	switch {
+	case e.Type == "commit":
+		return "submodule"
+	case e.Mode == "120000":
+		return "symlink"
+	default:
+		return "file"
+	}
+}
+
+func decodeBase64(content []byte) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.TrimSpace(string(content)))
+}
+
+// Contributors
+
+// GetContributorStats returns the statistics added with AddContributorStats.
+func (c *Client) GetContributorStats(ctx context.Context, owner, repo string) ([]*gogithub.ContributorStats, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("get contributor stats: %w", err)
+	}
+	stats := make([]*gogithub.ContributorStats, len(rs.contributors))
+	for i, s := range rs.contributors {
+		cp := *s
+		cp.Weeks = append([]gogithub.WeeklyStats(nil), s.Weeks...)
+		stats[i] = &cp
+	}
+	return stats, nil
+}
+
+// Actions
+
+// ListWorkflows lists the workflows added with AddWorkflow.
+func (c *Client) ListWorkflows(ctx context.Context, owner, repo string) ([]*gogithub.Workflow, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("list workflows: %w", err)
+	}
+	workflows := make([]*gogithub.Workflow, len(rs.workflows))
+	for i, w := range rs.workflows {
+		cp := *w
+		workflows[i] = &cp
+	}
+	return workflows, nil
+}
+
+// ListWorkflowRuns lists runs of a workflow, most recent first, returning a
+// single page per opts.
+func (c *Client) ListWorkflowRuns(ctx context.Context, owner, repo string, workflowID int64, opts *clientv1.ListWorkflowRunsOptions) ([]*gogithub.WorkflowRun, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, err := c.repo(owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("list workflow runs: %w", err)
+	}
+	found := false
+	for _, w := range rs.workflows {
+		if w.ID == workflowID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("list workflow runs: %w", notFound())
+	}
+	if opts == nil {
+		opts = &clientv1.ListWorkflowRunsOptions{}
+	}
+	var runs []*gogithub.WorkflowRun
+	for i := len(rs.workflowRuns) - 1; i >= 0; i-- {
+		r := rs.workflowRuns[i]
+		if r.WorkflowID != workflowID {
+			continue
+		}
+		if opts.Branch != "" && r.HeadBranch != opts.Branch {
+			continue
+		}
+		if opts.Status != "" && r.Status != opts.Status && r.Conclusion != opts.Status {
+			continue
+		}
+		cp := *r
+		runs = append(runs, &cp)
+	}
+	return paginate(runs, opts.Page, opts.PerPage, 30), nil
+}
+
+// paginate returns one page of items. page is 1-based; zero values select
+// the first page and defaultPerPage.
+func paginate[T any](items []T, page, perPage, defaultPerPage int) []T {
+	if perPage <= 0 {
+		perPage = defaultPerPage
+	}
+	if page <= 0 {
+		page = 1
+	}
+	start := (page - 1) * perPage
+	if start >= len(items) {
+		return []T{}
+	}
+	end := min(start+perPage, len(items))
+	return items[start:end]
+}
@@ -0,0 +1,358 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grokify/gogithub"
+	"github.com/grokify/gogithub/clientv1"
+)
+
+// Search
+
+// SearchIssues searches issues and pull requests across all repositories.
+// It understands the repo:, org:, user:, is:, type:, state:, author:,
+// assignee:, label:, involves:, mentions:, created:, updated: and closed:
+// qualifiers; remaining terms must all appear in the title or body.
+func (c *Client) SearchIssues(ctx context.Context, query string, opts *clientv1.SearchOptions) (*gogithub.IssueSearchResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	q, err := parseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("search issues: %w", err)
+	}
+	if opts == nil {
+		opts = &clientv1.SearchOptions{}
+	}
+
+	var items []*gogithub.Issue
+	for _, key := range sortedKeys(c.repos) {
+		rs := c.repos[key]
+		if !q.matchesRepo(rs.repo) {
+			continue
+		}
+		for _, number := range sortedNumbers(rs.issues) {
+			is := rs.issues[number]
+			if q.matchesIssue(rs, is) {
+				items = append(items, is.copy())
+			}
+		}
+	}
+
+	switch opts.Sort {
+	case "comments":
+		sort.SliceStable(items, func(i, j int) bool {
+			if opts.Order == "asc" {
+				return items[i].Comments < items[j].Comments
+			}
+			return items[i].Comments > items[j].Comments
+		})
+	case "created", "updated":
+		sortByTime(items, opts.Order, func(is *gogithub.Issue) time.Time {
+			if opts.Sort == "updated" {
+				return is.UpdatedAt
+			}
+			return is.CreatedAt
+		})
+	}
+	return &gogithub.IssueSearchResult{
+		Total: len(items),
+		Items: paginate(items, opts.Page, opts.PerPage, 30),
+	}, nil
+}
+
+// SearchCode searches file contents on the default branch of every
+// repository. It understands the repo:, org:, user:, path:, filename: and
+// extension: qualifiers; remaining terms must all appear in the file.
+func (c *Client) SearchCode(ctx context.Context, query string, opts *clientv1.SearchOptions) (*gogithub.CodeSearchResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	q, err := parseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("search code: %w", err)
+	}
+	if opts == nil {
+		opts = &clientv1.SearchOptions{}
+	}
+
+	var items []*gogithub.CodeResult
+	for _, key := range sortedKeys(c.repos) {
+		rs := c.repos[key]
+		if !q.matchesRepo(rs.repo) {
+			continue
+		}
+		tree, ok := rs.resolveTree(rs.repo.DefaultBranch)
+		if !ok {
+			continue
+		}
+		for _, p := range sortedKeys(tree) {
+			e := tree[p]
+			if e.Type != "blob" || !q.matchesFile(p, string(rs.objects.blobs[e.SHA])) {
+				continue
+			}
+			items = append(items, &gogithub.CodeResult{
+				Name:       path.Base(p),
+				Path:       p,
+				SHA:        e.SHA,
+				HTMLURL:    rs.repo.HTMLURL + "/blob/" + rs.repo.DefaultBranch + "/" + p,
+				Repository: copyRepository(rs.repo),
+			})
+		}
+	}
+	return &gogithub.CodeSearchResult{
+		Total: len(items),
+		Items: paginate(items, opts.Page, opts.PerPage, 30),
+	}, nil
+}
+
+// searchQuery is a parsed search query: qualifiers by name plus free-text
+// terms.
+type searchQuery struct {
+	qualifiers map[string][]string
+	terms      []string
+}
+
+// parseQuery splits a search query into qualifiers and terms. Quoted
+// phrases are kept together.
+func parseQuery(query string) (*searchQuery, error) {
+	q := &searchQuery{qualifiers: make(map[string][]string)}
+	for _, tok := range tokenize(query) {
+		name, value, ok := strings.Cut(tok, ":")
+		if ok && name != "" && value != "" && !strings.ContainsAny(name, " \"") {
+			name = strings.ToLower(name)
+			q.qualifiers[name] = append(q.qualifiers[name], strings.Trim(value, `"`))
+			continue
+		}
+		q.terms = append(q.terms, strings.ToLower(strings.Trim(tok, `"`)))
+	}
+	if len(q.qualifiers) == 0 && len(q.terms) == 0 {
+		return nil, validationError("Search", "q", "missing", "")
+	}
+	for _, name := range []string{"created", "updated", "closed"} {
+		for _, v := range q.qualifiers[name] {
+			if _, err := parseDateRange(v); err != nil {
+				return nil, validationFailed(fmt.Sprintf("Invalid %s qualifier: %s", name, v))
+			}
+		}
+	}
+	return q, nil
+}
+
+func tokenize(query string) []string {
+	var (
+		tokens []string
+		b      strings.Builder
+		quoted bool
+	)
+	for _, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			b.WriteRune(r)
+		case r == ' ' && !quoted:
+			if b.Len() > 0 {
+				tokens = append(tokens, b.String())
+				b.Reset()
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() > 0 {
+		tokens = append(tokens, b.String())
+	}
+	return tokens
+}
+
+func (q *searchQuery) matchesRepo(r *gogithub.Repository) bool {
+	if repos := q.qualifiers["repo"]; len(repos) > 0 && !containsFold(repos, r.FullName) {
+		return false
+	}
+	owners := append(append([]string(nil), q.qualifiers["org"]...), q.qualifiers["user"]...)
+	if len(owners) > 0 && !containsFold(owners, r.Owner.Login) {
+		return false
+	}
+	return true
+}
+
+func (q *searchQuery) matchesIssue(rs *repository, is *issue) bool {
+	i := is.issue
+	for _, v := range append(append([]string(nil), q.qualifiers["is"]...), q.qualifiers["type"]...) {
+		switch strings.ToLower(v) {
+		case "issue":
+			if i.IsPullRequest {
+				return false
+			}
+		case "pr":
+			if !i.IsPullRequest {
+				return false
+			}
+		case "open", "closed":
+			if i.State != strings.ToLower(v) {
+				return false
+			}
+		case "merged", "unmerged":
+			p, ok := rs.pulls[i.Number]
+			if !ok || p.pr.Merged != (strings.ToLower(v) == "merged") {
+				return false
+			}
+		}
+	}
+	for _, v := range q.qualifiers["state"] {
+		if i.State != strings.ToLower(v) {
+			return false
+		}
+	}
+	for _, v := range q.qualifiers["author"] {
+		if i.User == nil || !strings.EqualFold(i.User.Login, v) {
+			return false
+		}
+	}
+	for _, v := range q.qualifiers["assignee"] {
+		if !containsFold(logins(i.Assignees), v) {
+			return false
+		}
+	}
+	if !hasLabels(i.Labels, q.qualifiers["label"]) {
+		return false
+	}
+	for _, v := range append(append([]string(nil), q.qualifiers["involves"]...), q.qualifiers["mentions"]...) {
+		if !is.involves(v) {
+			return false
+		}
+	}
+	for name, value := range map[string]func() *time.Time{
+		"created": func() *time.Time { return &i.CreatedAt },
+		"updated": func() *time.Time { return &i.UpdatedAt },
+		"closed":  func() *time.Time { return i.ClosedAt },
+	} {
+		for _, v := range q.qualifiers[name] {
+			in, _ := parseDateRange(v)
+			if t := value(); t == nil || !in(*t) {
+				return false
+			}
+		}
+	}
+	return matchesTerms(q.terms, i.Title+"\n"+i.Body)
+}
+
+func (q *searchQuery) matchesFile(p, content string) bool {
+	for _, v := range q.qualifiers["path"] {
+		v = strings.Trim(v, "/")
+		if p != v && !strings.HasPrefix(p, v+"/") {
+			return false
+		}
+	}
+	for _, v := range q.qualifiers["filename"] {
+		if !strings.EqualFold(path.Base(p), v) {
+			return false
+		}
+	}
+	for _, v := range q.qualifiers["extension"] {
+		if !strings.EqualFold(strings.TrimPrefix(path.Ext(p), "."), strings.TrimPrefix(v, ".")) {
+			return false
+		}
+	}
+	return matchesTerms(q.terms, content)
+}
+
+// involves reports whether login authored, is assigned to, commented on or
+// is @-mentioned in the issue.
+func (is *issue) involves(login string) bool {
+	if is.issue.User != nil && strings.EqualFold(is.issue.User.Login, login) {
+		return true
+	}
+	if containsFold(logins(is.issue.Assignees), login) {
+		return true
+	}
+	mention := "@" + strings.ToLower(login)
+	if strings.Contains(strings.ToLower(is.issue.Body), mention) {
+		return true
+	}
+	for _, comment := range is.comments {
+		if comment.User != nil && strings.EqualFold(comment.User.Login, login) {
+			return true
+		}
+		if strings.Contains(strings.ToLower(comment.Body), mention) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesTerms(terms []string, text string) bool {
+	text = strings.ToLower(text)
+	for _, term := range terms {
+		if !strings.Contains(text, term) {
+			return false
+		}
+	}
+	return true
+}
+
+func logins(users []*gogithub.User) []string {
+	var out []string
+	for _, u := range users {
+		if u != nil {
+			out = append(out, u.Login)
+		}
+	}
+	return out
+}
+
+// parseDateRange parses a date qualifier value such as "2024-01-01",
+// ">=2024-01-01", "<2024-02-01" or "2024-01-01..2024-01-31" into a
+// predicate. Bare dates match the whole day.
+func parseDateRange(v string) (func(time.Time) bool, error) {
+	if from, to, ok := strings.Cut(v, ".."); ok {
+		lo, err := parseSearchDate(from, "*")
+		if err != nil {
+			return nil, err
+		}
+		hi, err := parseSearchDate(to, "*")
+		if err != nil {
+			return nil, err
+		}
+		return func(t time.Time) bool {
+			return (lo.IsZero() || !t.Before(lo)) && (hi.IsZero() || t.Before(hi.AddDate(0, 0, 1)))
+		}, nil
+	}
+	for _, op := range []string{">=", "<=", ">", "<"} {
+		if !strings.HasPrefix(v, op) {
+			continue
+		}
+		d, err := parseSearchDate(strings.TrimPrefix(v, op), "")
+		if err != nil {
+			return nil, err
+		}
+		end := d.AddDate(0, 0, 1)
+		switch op {
+		case ">=":
+			return func(t time.Time) bool { return !t.Before(d) }, nil
+		case "<=":
+			return func(t time.Time) bool { return t.Before(end) }, nil
+		case ">":
+			return func(t time.Time) bool { return !t.Before(end) }, nil
+		default:
+			return func(t time.Time) bool { return t.Before(d) }, nil
+		}
+	}
+	d, err := parseSearchDate(v, "")
+	if err != nil {
+		return nil, err
+	}
+	return func(t time.Time) bool { return !t.Before(d) && t.Before(d.AddDate(0, 0, 1)) }, nil
+}
+
+// parseSearchDate parses a YYYY-MM-DD date. A value equal to open returns
+// the zero time, meaning unbounded.
+func parseSearchDate(v, open string) (time.Time, error) {
+	if open != "" && v == open {
+		return time.Time{}, nil
+	}
+	return time.Parse("2006-01-02", v)
+}
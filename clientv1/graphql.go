@@ -0,0 +1,62 @@
+package clientv1
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/shurcooL/githubv4"
+)
+
+// NewGraphQLClient returns a GitHub GraphQL client that sends its queries
+// through c's HTTP client, and so shares its authentication (token, App
+// installation or credential pool), middleware, rate limiting and
+// cassette. Its endpoint is derived from c's REST base URL:
+// https://api.github.com/graphql for github.com, and /api/graphql on the
+// host for GitHub Enterprise Server. If c implements GraphQLProvider, its
+// GraphQL client is returned instead. Otherwise c must be backed by
+// go-github, as the clients created by this package are; other
+// implementations, such as fakes, return an error.
+func NewGraphQLClient(c Client) (*githubv4.Client, error) {
+	if p, ok := c.(GraphQLProvider); ok {
+		return p.GraphQLClient()
+	}
+	gh, ok := c.Raw().(*github.Client)
+	if !ok || gh == nil {
+		return nil, fmt.Errorf("graphql: %T has no HTTP client", c)
+	}
+	endpoint, err := GraphQLURL(gh.BaseURL())
+	if err != nil {
+		return nil, err
+	}
+	return githubv4.NewEnterpriseClient(endpoint, gh.Client()), nil
+}
+
+// GraphQLProvider is implemented by Clients that supply their own GraphQL
+// client, such as wrappers of another Client or implementations not backed
+// by go-github. NewGraphQLClient prefers it to deriving a client from Raw.
+type GraphQLProvider interface {
+	GraphQLClient() (*githubv4.Client, error)
+}
+
+// GraphQLURL returns the GraphQL endpoint of the REST API at baseURL, such
+// as https://api.github.com/graphql for https://api.github.com/ and
+// https://github.example.com/api/graphql for
+// https://github.example.com/api/v3/.
+func GraphQLURL(baseURL string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("graphql: parse base URL: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("graphql: base URL %q is not absolute", baseURL)
+	}
+	path := strings.TrimSuffix(u.Path, "/")
+	if prefix, ok := strings.CutSuffix(path, "/v3"); ok {
+		path = prefix
+	}
+	u.Path = path + "/graphql"
+	u.RawQuery, u.Fragment = "", ""
+	return u.String(), nil
+}
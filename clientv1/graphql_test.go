@@ -0,0 +1,114 @@
+package clientv1
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shurcooL/githubv4"
+)
+
+func TestGraphQLURL(t *testing.T) {
+	tests := []struct {
+		baseURL string
+		want    string
+	}{
+		{"https://api.github.com/", "https://api.github.com/graphql"},
+		{"https://api.github.com", "https://api.github.com/graphql"},
+		{"https://github.example.com/api/v3/", "https://github.example.com/api/graphql"},
+		{"https://github.example.com/api/v3", "https://github.example.com/api/graphql"},
+		{"http://127.0.0.1:8080/", "http://127.0.0.1:8080/graphql"},
+	}
+	for _, tt := range tests {
+		got, err := GraphQLURL(tt.baseURL)
+		if err != nil || got != tt.want {
+			t.Errorf("GraphQLURL(%q) = %q, %v; want %q", tt.baseURL, got, err, tt.want)
+		}
+	}
+	if _, err := GraphQLURL("api/v3/"); err == nil {
+		t.Error("GraphQLURL(relative) error = nil, want error")
+	}
+}
+
+func TestNewGraphQLClient(t *testing.T) {
+	ctx := context.Background()
+	var gotPath, gotAuth, gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotPath, gotAuth, gotBody = r.URL.Path, r.Header.Get("Authorization"), string(body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"data":{"viewer":{"login":"octocat"}}}`)
+	}))
+	defer srv.Close()
+
+	var hooked int
+	c, err := NewClientWithOptions(ctx, ClientOptions{
+		Token:     "ghp_test",
+		BaseURL:   srv.URL + "/api/v3/",
+		UploadURL: srv.URL + "/api/uploads/",
+		Middleware: []Middleware{HooksMiddleware(Hooks{
+			Before: func(*http.Request) { hooked++ },
+		})},
+	})
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+	gql, err := NewGraphQLClient(c)
+	if err != nil {
+		t.Fatalf("NewGraphQLClient() error = %v", err)
+	}
+
+	var q struct {
+		Viewer struct {
+			Login githubv4.String
+		}
+	}
+	if err := gql.Query(ctx, &q, nil); err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if q.Viewer.Login != "octocat" {
+		t.Errorf("Viewer.Login = %q, want octocat", q.Viewer.Login)
+	}
+	if gotPath != "/api/graphql" {
+		t.Errorf("GraphQL path = %q, want /api/graphql", gotPath)
+	}
+	if gotAuth != "Bearer ghp_test" {
+		t.Errorf("Authorization = %q, want the REST client's token", gotAuth)
+	}
+	if !strings.Contains(gotBody, "viewer") {
+		t.Errorf("request body = %q, want viewer query", gotBody)
+	}
+	if hooked != 1 {
+		t.Errorf("middleware saw %d requests, want 1", hooked)
+	}
+}
+
+func TestNewGraphQLClientWithoutHTTP(t *testing.T) {
+	if _, err := NewGraphQLClient(rawOnly{}); err == nil {
+		t.Error("NewGraphQLClient(non-go-github client) error = nil, want error")
+	}
+}
+
+// rawOnly is a Client whose Raw returns no go-github client, like a fake.
+type rawOnly struct{ Client }
+
+func (rawOnly) Raw() any { return nil }
+
+func TestNewGraphQLClientProvider(t *testing.T) {
+	want := githubv4.NewClient(nil)
+	got, err := NewGraphQLClient(provider{gql: want})
+	if err != nil || got != want {
+		t.Errorf("NewGraphQLClient(GraphQLProvider) = %p, %v; want %p", got, err, want)
+	}
+}
+
+// provider is a Client that supplies its own GraphQL client.
+type provider struct {
+	rawOnly
+	gql *githubv4.Client
+}
+
+func (p provider) GraphQLClient() (*githubv4.Client, error) { return p.gql, nil }
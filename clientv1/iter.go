@@ -0,0 +1,127 @@
+package clientv1
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/go-github/v89/github"
+	ghErrors "github.com/grokify/gogithub/errors"
+)
+
+// defaultPerPage is the page size used by Iter* and List* methods; 100 is
+// the maximum GitHub allows.
+const defaultPerPage = 100
+
+// pages returns an iterator that fetches pages lazily, starting from
+// first.Page, and converts each item. fetch must send lo and return the
+// page's items. Iteration stops after the last page, when the consumer
+// stops, when ctx is done, or after opts.MaxItems items. Errors are
+// prefixed with op and yielded once, ending the iteration.
+func pages[G, T any](ctx context.Context, first github.ListOptions, opts *IterOptions, op string, fetch func(lo *github.ListOptions) ([]G, *github.Response, error), convert func(G) T) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		lo := first
+		if lo.PerPage <= 0 {
+			lo.PerPage = defaultPerPage
+		}
+		maxItems := 0
+		if opts != nil {
+			if opts.PerPage > 0 {
+				lo.PerPage = opts.PerPage
+			}
+			maxItems = opts.MaxItems
+		}
+		if maxItems > 0 && maxItems < lo.PerPage {
+			lo.PerPage = maxItems
+		}
+
+		n := 0
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			items, resp, err := fetch(&lo)
+			if err != nil {
+				yield(zero, fmt.Errorf("%s: %w", op, ghErrors.Translate(err, resp)))
+				return
+			}
+			for _, item := range items {
+				if err := ctx.Err(); err != nil {
+					yield(zero, err)
+					return
+				}
+				if !yield(convert(item), nil) {
+					return
+				}
+				n++
+				if maxItems > 0 && n >= maxItems {
+					return
+				}
+			}
+			if resp == nil || resp.NextPage == 0 {
+				return
+			}
+			lo.Page = resp.NextPage
+		}
+	}
+}
+
+// collect drains an iterator into a slice, returning the first error.
+func collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// DoAll sends GET requests with c.Do for path and each following page of a
+// paginated endpoint, whose pages must be JSON arrays of T, and returns the
+// items of every page. opts limits the number of items and sets the page
+// size as for the Iter* methods, unless path sets per_page itself.
+func DoAll[T any](ctx context.Context, c Client, path string, opts *IterOptions) ([]T, error) {
+	perPage, maxItems := defaultPerPage, 0
+	if opts != nil {
+		if opts.PerPage > 0 {
+			perPage = opts.PerPage
+		}
+		maxItems = opts.MaxItems
+	}
+	if maxItems > 0 && maxItems < perPage {
+		perPage = maxItems
+	}
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("GET %s: %w", path, err)
+	}
+	if q := u.Query(); !q.Has("per_page") {
+		q.Set("per_page", strconv.Itoa(perPage))
+		u.RawQuery = q.Encode()
+	}
+
+	var items []T
+	for next := u.String(); next != ""; {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var page []T
+		resp, err := c.Do(ctx, http.MethodGet, next, nil, &page)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+		if maxItems > 0 && len(items) >= maxItems {
+			return items[:maxItems], nil
+		}
+		next = resp.NextURL
+	}
+	return items, nil
+}
@@ -0,0 +1,185 @@
+package clientv1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	ghErrors "github.com/grokify/gogithub/errors"
+)
+
+// repoPagesServer serves total repositories for any org, paginated with
+// Link headers. It records the requested page sizes.
+func repoPagesServer(t *testing.T, total int) (Client, *int32, *[]int) {
+	t.Helper()
+	var calls int32
+	var perPages []int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if strings.Contains(r.URL.Path, "/orgs/missing/") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+			return
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		page = max(page, 1)
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		perPages = append(perPages, perPage)
+
+		start := (page - 1) * perPage
+		end := min(start+perPage, total)
+		if end < total {
+			next := fmt.Sprintf("%s%s?page=%d&per_page=%d", "http://"+r.Host, r.URL.Path, page+1, perPage)
+			w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next))
+		}
+		var items []string
+		for i := start; i < end; i++ {
+			items = append(items, fmt.Sprintf(`{"id":%d,"name":"repo-%d"}`, i+1, i+1))
+		}
+		_, _ = w.Write([]byte("[" + strings.Join(items, ",") + "]"))
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := NewClientWithOptions(context.Background(), ClientOptions{
+		BaseURL:   srv.URL + "/",
+		UploadURL: srv.URL + "/",
+	})
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+	return c, &calls, &perPages
+}
+
+func TestListOrgReposCollectsAllPages(t *testing.T) {
+	c, calls, _ := repoPagesServer(t, 250)
+
+	repos, err := c.ListOrgRepos(context.Background(), "octo")
+	if err != nil {
+		t.Fatalf("ListOrgRepos() error = %v", err)
+	}
+	if len(repos) != 250 {
+		t.Errorf("len(repos) = %d, want 250", len(repos))
+	}
+	if repos[249].Name != "repo-250" {
+		t.Errorf("last repo = %q, want %q", repos[249].Name, "repo-250")
+	}
+	if *calls != 3 {
+		t.Errorf("requests = %d, want 3", *calls)
+	}
+}
+
+func TestIterOrgReposStopsEarly(t *testing.T) {
+	c, calls, _ := repoPagesServer(t, 250)
+
+	n := 0
+	for repo, err := range c.IterOrgRepos(context.Background(), "octo", nil) {
+		if err != nil {
+			t.Fatalf("IterOrgRepos() error = %v", err)
+		}
+		n++
+		if repo.Name == "repo-10" {
+			break
+		}
+	}
+	if n != 10 {
+		t.Errorf("items = %d, want 10", n)
+	}
+	if *calls != 1 {
+		t.Errorf("requests = %d, want 1", *calls)
+	}
+}
+
+func TestIterOrgReposMaxItems(t *testing.T) {
+	c, calls, perPages := repoPagesServer(t, 250)
+
+	tests := []struct {
+		opts      IterOptions
+		wantItems int
+		wantCalls int32
+		wantPer   int
+	}{
+		{IterOptions{MaxItems: 5}, 5, 1, 5},
+		{IterOptions{MaxItems: 120}, 120, 2, 100},
+		{IterOptions{MaxItems: 25, PerPage: 10}, 25, 3, 10},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%+v", tt.opts), func(t *testing.T) {
+			atomic.StoreInt32(calls, 0)
+			*perPages = nil
+
+			n := 0
+			for _, err := range c.IterOrgRepos(context.Background(), "octo", &tt.opts) {
+				if err != nil {
+					t.Fatalf("IterOrgRepos() error = %v", err)
+				}
+				n++
+			}
+			if n != tt.wantItems {
+				t.Errorf("items = %d, want %d", n, tt.wantItems)
+			}
+			if *calls != tt.wantCalls {
+				t.Errorf("requests = %d, want %d", *calls, tt.wantCalls)
+			}
+			if len(*perPages) == 0 || (*perPages)[0] != tt.wantPer {
+				t.Errorf("per_page = %v, want %d", *perPages, tt.wantPer)
+			}
+		})
+	}
+}
+
+func TestIterOrgReposCanceled(t *testing.T) {
+	c, calls, _ := repoPagesServer(t, 250)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	n := 0
+	var gotErr error
+	for _, err := range c.IterOrgRepos(ctx, "octo", nil) {
+		if err != nil {
+			gotErr = err
+			break
+		}
+		n++
+		if n == 3 {
+			cancel()
+		}
+	}
+	if !errors.Is(gotErr, context.Canceled) {
+		t.Errorf("error = %v, want context.Canceled", gotErr)
+	}
+	if n != 3 || *calls != 1 {
+		t.Errorf("items = %d, requests = %d, want 3 and 1", n, *calls)
+	}
+}
+
+func TestIterOrgReposError(t *testing.T) {
+	c, _, _ := repoPagesServer(t, 0)
+
+	var errs int
+	for repo, err := range c.IterOrgRepos(context.Background(), "missing", nil) {
+		if err == nil {
+			t.Errorf("unexpected repo %+v", repo)
+			continue
+		}
+		errs++
+		if !ghErrors.IsNotFound(err) {
+			t.Errorf("error = %v, want not found", err)
+		}
+		if !strings.HasPrefix(err.Error(), "list org repos: ") {
+			t.Errorf("error = %q, want list org repos prefix", err)
+		}
+	}
+	if errs != 1 {
+		t.Errorf("errors yielded = %d, want 1", errs)
+	}
+
+	if _, err := c.ListOrgRepos(context.Background(), "missing"); !ghErrors.IsNotFound(err) {
+		t.Errorf("ListOrgRepos() error = %v, want not found", err)
+	}
+}
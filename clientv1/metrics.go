@@ -0,0 +1,138 @@
+package clientv1
+
+import (
+	"cmp"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets are the histogram bucket upper bounds used by
+// NewMetrics when none are given.
+var DefaultLatencyBuckets = []time.Duration{
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// Metrics counts requests and records latency histograms per method and
+// route template. It is safe for concurrent use. Install it with
+// Metrics.Middleware and read it with Snapshot.
+type Metrics struct {
+	buckets []time.Duration
+
+	mu     sync.Mutex
+	routes map[routeKey]*RouteMetrics
+}
+
+type routeKey struct {
+	method, route string
+}
+
+// RouteMetrics are the metrics recorded for one method and route.
+type RouteMetrics struct {
+	Method string
+	Route  string
+	// Count is the number of requests, including failed ones.
+	Count int64
+	// Errors counts requests that failed without a response or with a
+	// status of 400 or above.
+	Errors int64
+	// Statuses counts responses by status code; transport errors are
+	// counted under 0.
+	Statuses map[int]int64
+	// Buckets are the histogram upper bounds and BucketCounts the number
+	// of requests that took at most that long and longer than the previous
+	// bound. The last BucketCounts entry counts requests slower than every
+	// bound.
+	Buckets      []time.Duration
+	BucketCounts []int64
+	// TotalLatency is the sum of all request durations.
+	TotalLatency time.Duration
+}
+
+// MeanLatency returns the average request duration.
+func (m RouteMetrics) MeanLatency() time.Duration {
+	if m.Count == 0 {
+		return 0
+	}
+	return m.TotalLatency / time.Duration(m.Count)
+}
+
+// NewMetrics creates an empty Metrics with the given latency bucket upper
+// bounds, which must be in increasing order. With no buckets,
+// DefaultLatencyBuckets is used.
+func NewMetrics(buckets ...time.Duration) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	return &Metrics{
+		buckets: slices.Clone(buckets),
+		routes:  make(map[routeKey]*RouteMetrics),
+	}
+}
+
+// Middleware returns a middleware that records each request in m.
+func (m *Metrics) Middleware() Middleware {
+	return HooksMiddleware(Hooks{After: m.Observe})
+}
+
+// Observe records a completed request. It is exposed so callers can feed
+// Metrics from their own hooks.
+func (m *Metrics) Observe(info RequestInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	k := routeKey{info.Method, info.Route}
+	rm, ok := m.routes[k]
+	if !ok {
+		rm = &RouteMetrics{
+			Method:       info.Method,
+			Route:        info.Route,
+			Statuses:     make(map[int]int64),
+			Buckets:      m.buckets,
+			BucketCounts: make([]int64, len(m.buckets)+1),
+		}
+		m.routes[k] = rm
+	}
+	rm.Count++
+	if info.Err != nil || info.StatusCode >= http.StatusBadRequest {
+		rm.Errors++
+	}
+	rm.Statuses[info.StatusCode]++
+	i, _ := slices.BinarySearch(m.buckets, info.Duration)
+	rm.BucketCounts[i]++
+	rm.TotalLatency += info.Duration
+}
+
+// Snapshot returns a copy of the metrics recorded so far, sorted by route
+// and method.
+func (m *Metrics) Snapshot() []RouteMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]RouteMetrics, 0, len(m.routes))
+	for _, rm := range m.routes {
+		cp := *rm
+		cp.Statuses = maps.Clone(rm.Statuses)
+		cp.Buckets = slices.Clone(rm.Buckets)
+		cp.BucketCounts = slices.Clone(rm.BucketCounts)
+		out = append(out, cp)
+	}
+	slices.SortFunc(out, func(a, b RouteMetrics) int {
+		return cmp.Or(cmp.Compare(a.Route, b.Route), cmp.Compare(a.Method, b.Method))
+	})
+	return out
+}
+
+// Reset discards all recorded metrics.
+func (m *Metrics) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.routes = make(map[routeKey]*RouteMetrics)
+}
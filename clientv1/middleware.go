@@ -0,0 +1,247 @@
+package clientv1
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// headerGitHubRequestID identifies a request in GitHub's logs; include it
+// when reporting problems to GitHub support.
+const headerGitHubRequestID = "X-GitHub-Request-Id"
+
+// Middleware wraps an http.RoundTripper with additional behavior, such as
+// logging or metrics. Middleware must not modify the request it is given;
+// clone it first.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to the http.RoundTripper interface.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper.
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Chain wraps base with middlewares. The first middleware is the
+// outermost: it sees the request first and the response last. If base is
+// nil, http.DefaultTransport is used.
+func Chain(base http.RoundTripper, middlewares ...Middleware) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	rt := base
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		rt = middlewares[i](rt)
+	}
+	return rt
+}
+
+// RequestInfo describes a completed GitHub API call. It is passed to
+// Hooks.After and used by the built-in middlewares.
+type RequestInfo struct {
+	Method string
+	// URL is the request URL with credentials redacted.
+	URL string
+	// Route is the path template of the endpoint, such as
+	// "/repos/{owner}/{repo}/pulls". See RouteTemplate.
+	Route string
+	// StatusCode is the response status, or 0 if the request failed
+	// without a response.
+	StatusCode int
+	Duration   time.Duration
+	// RequestID is the X-GitHub-Request-Id response header.
+	RequestID string
+	// RateLimitRemaining is the remaining quota reported by the response,
+	// or -1 if the response had none.
+	RateLimitRemaining int
+	// RateLimitResource is the quota the request counted against, such as
+	// "core" or "search".
+	RateLimitResource string
+	// FromCache reports whether the response was served by a
+	// CacheTransport.
+	FromCache bool
+	// TraceID and SpanID identify the request's span when a
+	// TracingMiddleware runs before the middleware reporting it.
+	TraceID string
+	SpanID  string
+	// Err is the transport error, if any.
+	Err error
+}
+
+// newRequestInfo describes the outcome of sending req.
+func newRequestInfo(req *http.Request, resp *http.Response, err error, d time.Duration) RequestInfo {
+	info := RequestInfo{
+		Method:             req.Method,
+		URL:                RedactURL(req.URL),
+		Route:              RouteTemplate(req.URL.Path),
+		Duration:           d,
+		RateLimitRemaining: -1,
+		RateLimitResource:  rateLimitResource(req),
+		Err:                err,
+	}
+	if span, ok := SpanFromContext(req.Context()); ok {
+		info.TraceID = span.TraceID
+		info.SpanID = span.SpanID
+	}
+	if resp != nil {
+		info.StatusCode = resp.StatusCode
+		info.RequestID = resp.Header.Get(headerGitHubRequestID)
+		info.FromCache = resp.Header.Get(headerFromCache) != ""
+		if remaining, ok := headerInt(resp.Header, headerRateLimitRemaining); ok {
+			info.RateLimitRemaining = remaining
+		}
+		if r := resp.Header.Get(headerRateLimitResource); r != "" {
+			info.RateLimitResource = r
+		}
+	}
+	return info
+}
+
+// Hooks holds callbacks run around each request by HooksMiddleware.
+type Hooks struct {
+	// Before is called with a clone of the request before it is sent, and
+	// may add headers to it.
+	Before func(req *http.Request)
+	// After is called once the response headers arrive or the request
+	// fails.
+	After func(info RequestInfo)
+}
+
+// HooksMiddleware returns a middleware that runs h around each request.
+func HooksMiddleware(h Hooks) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if h.Before != nil {
+				req = req.Clone(req.Context())
+				h.Before(req)
+			}
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			if h.After != nil {
+				h.After(newRequestInfo(req, resp, err, time.Since(start)))
+			}
+			return resp, err
+		})
+	}
+}
+
+// LoggingMiddleware returns a middleware that logs each request to logger
+// with its route, status, latency, request ID and remaining rate limit.
+// Successful requests are logged at Info, error statuses at Warn and
+// transport errors at Error. Credentials in URLs and error messages are
+// redacted. If logger is nil, slog.Default is used.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return HooksMiddleware(Hooks{
+		After: func(info RequestInfo) {
+			l := logger
+			if l == nil {
+				l = slog.Default()
+			}
+			attrs := []slog.Attr{
+				slog.String("method", info.Method),
+				slog.String("route", info.Route),
+				slog.String("url", info.URL),
+				slog.Int("status", info.StatusCode),
+				slog.Duration("duration", info.Duration),
+			}
+			if info.RequestID != "" {
+				attrs = append(attrs, slog.String("request_id", info.RequestID))
+			}
+			if info.RateLimitRemaining >= 0 {
+				attrs = append(attrs,
+					slog.Int("ratelimit_remaining", info.RateLimitRemaining),
+					slog.String("ratelimit_resource", info.RateLimitResource))
+			}
+			if info.FromCache {
+				attrs = append(attrs, slog.Bool("from_cache", true))
+			}
+			if info.TraceID != "" {
+				attrs = append(attrs,
+					slog.String("trace_id", info.TraceID),
+					slog.String("span_id", info.SpanID))
+			}
+
+			level := slog.LevelInfo
+			switch {
+			case info.Err != nil:
+				level = slog.LevelError
+				attrs = append(attrs, slog.String("error", redactError(info.Err)))
+			case info.StatusCode >= http.StatusBadRequest:
+				level = slog.LevelWarn
+			}
+			l.LogAttrs(context.Background(), level, "github request", attrs...)
+		},
+	})
+}
+
+const redacted = "REDACTED"
+
+// sensitiveParams are query parameters that carry credentials.
+var sensitiveParams = map[string]bool{
+	"access_token":  true,
+	"client_secret": true,
+	"code":          true,
+	"jwt":           true,
+	"sig":           true,
+	"token":         true,
+}
+
+// secretPattern matches GitHub tokens and bearer credentials in free text.
+var secretPattern = regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})\b|((?i:bearer|token)\s+)[A-Za-z0-9._~+/=-]{8,}`)
+
+// RedactURL returns u as a string with any password and credential query
+// parameters replaced by "REDACTED".
+func RedactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	r := *u
+	if _, ok := r.User.Password(); ok {
+		r.User = url.UserPassword(r.User.Username(), redacted)
+	}
+	if r.RawQuery != "" {
+		q := r.Query()
+		changed := false
+		for k := range q {
+			if sensitiveParams[strings.ToLower(k)] {
+				q.Set(k, redacted)
+				changed = true
+			}
+		}
+		if changed {
+			r.RawQuery = q.Encode()
+		}
+	}
+	return redactSecrets(r.String())
+}
+
+// redactSecrets replaces GitHub tokens and bearer credentials in s.
+func redactSecrets(s string) string {
+	return secretPattern.ReplaceAllStringFunc(s, func(m string) string {
+		sub := secretPattern.FindStringSubmatch(m)
+		if sub[2] != "" {
+			return sub[2] + redacted
+		}
+		return redacted
+	})
+}
+
+// redactError returns err's message with credentials in its URL and text
+// redacted.
+func redactError(err error) string {
+	var uerr *url.Error
+	if errors.As(err, &uerr) {
+		if u, perr := url.Parse(uerr.URL); perr == nil {
+			cp := *uerr
+			cp.URL = RedactURL(u)
+			return redactSecrets(strings.Replace(err.Error(), uerr.Error(), cp.Error(), 1))
+		}
+	}
+	return redactSecrets(err.Error())
+}
@@ -0,0 +1,378 @@
+package clientv1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/grokify/gogithub/auth"
+	"golang.org/x/oauth2"
+)
+
+// errAllCredentialsRevoked is returned by a pooled client once GitHub has
+// rejected every credential.
+var errAllCredentialsRevoked = errors.New("credential pool: every credential was rejected")
+
+// Credential is one identity in a pooled client.
+type Credential struct {
+	// Name identifies the credential in usage reports. It must not be the
+	// token itself.
+	Name string
+	// Source supplies the credential's access tokens.
+	Source oauth2.TokenSource
+}
+
+// TokenCredential returns a credential for a personal access token.
+func TokenCredential(name, token string) Credential {
+	return Credential{
+		Name:   name,
+		Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}),
+	}
+}
+
+// AppCredential returns a credential for the GitHub App installation in
+// cfg. Its installation tokens are narrowed and refreshed as for
+// NewAppInstallationClient. Only opts.Client.BaseURL is used; token
+// requests are sent through the *http.Client in ctx under
+// oauth2.HTTPClient, if any. opts may be nil.
+func AppCredential(ctx context.Context, name string, cfg *auth.AppConfig, opts *AppInstallationOptions) (Credential, error) {
+	if opts == nil {
+		opts = &AppInstallationOptions{}
+	}
+	ts, err := appTokenSource(ctx, cfg, opts, contextHTTPClient(ctx))
+	if err != nil {
+		return Credential{}, err
+	}
+	return Credential{Name: name, Source: ts}, nil
+}
+
+// CredentialUsage reports how a pooled client has used one credential.
+type CredentialUsage struct {
+	Name string
+	// Requests is the number of requests sent with the credential.
+	Requests int64
+	// RateLimited counts responses that reported the credential's quota
+	// exhausted or a secondary rate limit.
+	RateLimited int64
+	// Revoked is set once GitHub rejects the credential with 401
+	// Unauthorized; it is not used again.
+	Revoked bool
+	// LastError is the most recent error obtaining a token, or the reason
+	// the credential was revoked.
+	LastError error
+	// Remaining and Reset are the quota last observed for each rate limit
+	// resource, such as "core" or "search".
+	Remaining map[string]int
+	Reset     map[string]time.Time
+}
+
+// PoolClient is a Client that spreads requests across several credentials.
+// Each request uses the credential with the most remaining quota for its
+// rate limit resource; when GitHub reports that credential exhausted or
+// rejects it, the request is retried with the next one.
+//
+// Responses report the pool's combined quota in their rate limit headers,
+// so a RateLimitTransport configured through ClientOptions.RateLimit waits
+// only once every credential is exhausted.
+type PoolClient struct {
+	Client
+	pool *credentialPool
+}
+
+// NewPoolClient creates a client that rotates across creds. Credential
+// names must be unique. opts configures the client as for
+// NewClientWithOptions; its Token field is ignored.
+func NewPoolClient(ctx context.Context, creds []Credential, opts ClientOptions) (*PoolClient, error) {
+	if len(creds) == 0 {
+		return nil, errors.New("credential pool: no credentials")
+	}
+	pool := &credentialPool{now: time.Now}
+	seen := make(map[string]bool)
+	for _, cred := range creds {
+		if cred.Source == nil {
+			return nil, fmt.Errorf("credential pool: credential %q has no token source", cred.Name)
+		}
+		if seen[cred.Name] {
+			return nil, fmt.Errorf("credential pool: duplicate credential name %q", cred.Name)
+		}
+		seen[cred.Name] = true
+		pool.creds = append(pool.creds, &pooledCredential{
+			Credential: cred,
+			limits:     make(map[string]*poolLimit),
+		})
+	}
+
+	c, err := newClient(ctx, opts, func(next http.RoundTripper) http.RoundTripper {
+		pool.base = next
+		return pool
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &PoolClient{Client: c, pool: pool}, nil
+}
+
+// Usage returns per-credential usage in the order the credentials were
+// given.
+func (c *PoolClient) Usage() []CredentialUsage {
+	return c.pool.usage()
+}
+
+// credentialPool is the http.RoundTripper behind PoolClient.
+type credentialPool struct {
+	base http.RoundTripper
+	now  func() time.Time
+
+	mu    sync.Mutex
+	creds []*pooledCredential
+}
+
+type pooledCredential struct {
+	Credential
+	requests    int64
+	rateLimited int64
+	revoked     bool
+	lastErr     error
+	limits      map[string]*poolLimit // keyed by rate limit resource
+}
+
+// poolLimit is the quota last observed for one credential and resource.
+type poolLimit struct {
+	limit     int
+	remaining int
+	reset     time.Time
+}
+
+// RoundTrip implements http.RoundTripper.
+func (p *credentialPool) RoundTrip(req *http.Request) (*http.Response, error) {
+	resource := rateLimitResource(req)
+	tried := make(map[*pooledCredential]bool)
+	var last *http.Response
+	var tokenErr error
+
+	for sent := 0; ; {
+		pc := p.pick(resource, tried)
+		if pc == nil {
+			break
+		}
+		tried[pc] = true
+		tok, err := pc.Source.Token()
+		if err != nil {
+			p.mu.Lock()
+			pc.requests--
+			pc.lastErr = err
+			p.mu.Unlock()
+			tokenErr = err
+			continue
+		}
+
+		r := req.Clone(req.Context())
+		if sent > 0 && req.Body != nil && req.Body != http.NoBody {
+			if r.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+		tok.SetAuthHeader(r)
+		sent++
+		resp, err := p.base.RoundTrip(r)
+		if err != nil {
+			if last != nil {
+				drainBody(last)
+			}
+			return nil, err
+		}
+
+		if !p.observe(pc, resource, resp) || !replayable(req) {
+			if last != nil {
+				drainBody(last)
+			}
+			p.setPoolQuota(resp, resource)
+			return resp, nil
+		}
+		if last != nil {
+			drainBody(last)
+		}
+		last = resp
+	}
+
+	switch {
+	case last != nil:
+		p.setPoolQuota(last, resource)
+		return last, nil
+	case tokenErr != nil:
+		return nil, fmt.Errorf("credential pool: %w", tokenErr)
+	default:
+		return nil, errAllCredentialsRevoked
+	}
+}
+
+// pick reserves the untried credential with the most remaining quota for
+// resource. Credentials whose quota is unknown are preferred so it can be
+// learned, and ties go to the least used. If no credential has quota and
+// none has been tried, the one that resets first is returned so GitHub
+// reports the limit. pick returns nil when there is nothing left to try.
+func (p *credentialPool) pick(resource string, tried map[*pooledCredential]bool) *pooledCredential {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+
+	var best, soonest *pooledCredential
+	bestScore := 0
+	for _, pc := range p.creds {
+		if pc.revoked || tried[pc] {
+			continue
+		}
+		score := math.MaxInt
+		l := pc.limits[resource]
+		if l != nil && now.Before(l.reset) {
+			score = l.remaining
+		}
+		if score <= 0 {
+			if soonest == nil || l.reset.Before(soonest.limits[resource].reset) {
+				soonest = pc
+			}
+			continue
+		}
+		if best == nil || score > bestScore || (score == bestScore && pc.requests < best.requests) {
+			best, bestScore = pc, score
+		}
+	}
+	if best == nil && len(tried) == 0 {
+		best = soonest
+	}
+	if best == nil {
+		return nil
+	}
+	best.requests++
+	if l := best.limits[resource]; l != nil && l.remaining > 0 {
+		l.remaining--
+	}
+	return best
+}
+
+// observe records the quota reported by resp and reports whether the
+// request should be retried with another credential.
+func (p *credentialPool) observe(pc *pooledCredential, resource string, resp *http.Response) bool {
+	if r := resp.Header.Get(headerRateLimitResource); r != "" {
+		resource = r
+	}
+	secondary := (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests) &&
+		resp.Header.Get(headerRateLimitRemaining) != "0" && isSecondaryRateLimit(resp)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+
+	limit := func() *poolLimit {
+		l := pc.limits[resource]
+		if l == nil {
+			l = &poolLimit{}
+			pc.limits[resource] = l
+		}
+		return l
+	}
+	if remaining, ok := headerInt(resp.Header, headerRateLimitRemaining); ok {
+		l := limit()
+		if reset, ok := rateLimitReset(resp, now); ok {
+			l.remaining, l.reset = remaining, reset
+		}
+		if n, ok := headerInt(resp.Header, headerRateLimitLimit); ok {
+			l.limit = n
+		}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		pc.revoked = true
+		pc.lastErr = fmt.Errorf("rejected by GitHub: %s", resp.Status)
+		return true
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		if retryAfter, ok := headerInt(resp.Header, headerRetryAfter); ok {
+			l := limit()
+			l.remaining, l.reset = 0, now.Add(time.Duration(retryAfter)*time.Second)
+		} else if secondary {
+			l := limit()
+			l.remaining, l.reset = 0, now.Add(DefaultRateLimitSecondaryDelay)
+		} else if resp.Header.Get(headerRateLimitRemaining) != "0" {
+			return false
+		}
+		pc.rateLimited++
+		return true
+	}
+	return false
+}
+
+// setPoolQuota replaces the rate limit headers of resp with the pool's
+// combined quota: the sum of every usable credential's remaining requests,
+// resetting when the first exhausted credential does. Credentials not yet
+// used are assumed to have the response's full limit.
+func (p *credentialPool) setPoolQuota(resp *http.Response, resource string) {
+	if resp.Header.Get(headerRateLimitRemaining) == "" {
+		return
+	}
+	if r := resp.Header.Get(headerRateLimitResource); r != "" {
+		resource = r
+	}
+	limit, _ := headerInt(resp.Header, headerRateLimitLimit)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+
+	remaining := 0
+	var reset time.Time
+	for _, pc := range p.creds {
+		if pc.revoked {
+			continue
+		}
+		l := pc.limits[resource]
+		switch {
+		case l == nil:
+			remaining += limit
+		case now.Before(l.reset):
+			remaining += l.remaining
+			if l.remaining == 0 && (reset.IsZero() || l.reset.Before(reset)) {
+				reset = l.reset
+			}
+		default:
+			remaining += l.limit
+		}
+	}
+	resp.Header.Set(headerRateLimitRemaining, strconv.Itoa(remaining))
+	if !reset.IsZero() {
+		// Express the reset on the server's clock, as GitHub does.
+		server := now
+		if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+			server = date
+		}
+		resp.Header.Set(headerRateLimitReset, strconv.FormatInt(server.Add(reset.Sub(now)).Unix(), 10))
+	}
+}
+
+func (p *credentialPool) usage() []CredentialUsage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]CredentialUsage, len(p.creds))
+	for i, pc := range p.creds {
+		u := CredentialUsage{
+			Name:        pc.Name,
+			Requests:    pc.requests,
+			RateLimited: pc.rateLimited,
+			Revoked:     pc.revoked,
+			LastError:   pc.lastErr,
+			Remaining:   make(map[string]int, len(pc.limits)),
+			Reset:       make(map[string]time.Time, len(pc.limits)),
+		}
+		for resource, l := range pc.limits {
+			u.Remaining[resource] = l.remaining
+			u.Reset[resource] = l.reset
+		}
+		out[i] = u
+	}
+	return out
+}
@@ -0,0 +1,160 @@
+package clientv1
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ghErrors "github.com/grokify/gogithub/errors"
+)
+
+var _ Client = (*PoolClient)(nil)
+
+// quotaServer tracks a quota per bearer token. Tokens not in quotas are
+// rejected with 401.
+type quotaServer struct {
+	mu     sync.Mutex
+	quotas map[string]int
+	seen   map[string]int
+}
+
+func (s *quotaServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seen[token]++
+	remaining, ok := s.quotas[token]
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = io.WriteString(w, `{"message":"Bad credentials"}`)
+		return
+	}
+	w.Header().Set(headerRateLimitLimit, "5000")
+	w.Header().Set(headerRateLimitReset, fmt.Sprint(time.Now().Add(time.Hour).Unix()))
+	w.Header().Set(headerRateLimitResource, "core")
+	if remaining == 0 {
+		w.Header().Set(headerRateLimitRemaining, "0")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = io.WriteString(w, `{"message":"API rate limit exceeded"}`)
+		return
+	}
+	s.quotas[token] = remaining - 1
+	w.Header().Set(headerRateLimitRemaining, fmt.Sprint(remaining-1))
+	_, _ = io.WriteString(w, `{"login":"octocat","id":1}`)
+}
+
+func TestPoolClientRotates(t *testing.T) {
+	qs := &quotaServer{
+		quotas: map[string]int{"low": 2, "high": 3},
+		seen:   make(map[string]int),
+	}
+	srv := httptest.NewServer(qs)
+	defer srv.Close()
+
+	var remaining []string
+	c, err := NewPoolClient(context.Background(), []Credential{
+		TokenCredential("revoked", "revoked"),
+		TokenCredential("low", "low"),
+		TokenCredential("high", "high"),
+	}, ClientOptions{
+		BaseURL:   srv.URL + "/",
+		UploadURL: srv.URL + "/",
+		// Give up rather than wait an hour for the quotas to reset.
+		RateLimit: &RateLimitOptions{MaxWait: time.Second},
+		Middleware: []Middleware{HooksMiddleware(Hooks{After: func(info RequestInfo) {
+			remaining = append(remaining, fmt.Sprint(info.RateLimitRemaining))
+		}})},
+	})
+	if err != nil {
+		t.Fatalf("NewPoolClient() error = %v", err)
+	}
+
+	// Five requests fit in the pool's combined quota.
+	for i := range 5 {
+		if _, err := c.GetUser(context.Background(), "octocat"); err != nil {
+			t.Fatalf("GetUser() #%d error = %v", i, err)
+		}
+	}
+	if _, err := c.GetUser(context.Background(), "octocat"); !ghErrors.IsRateLimited(err) {
+		t.Errorf("GetUser() with every quota exhausted error = %v, want rate limited", err)
+	}
+
+	if qs.seen["revoked"] != 1 {
+		t.Errorf("revoked credential used %d times, want 1", qs.seen["revoked"])
+	}
+	if qs.quotas["low"] != 0 || qs.quotas["high"] != 0 {
+		t.Errorf("quotas left = %v, want all spent", qs.quotas)
+	}
+	if last := remaining[len(remaining)-1]; last != "0" {
+		t.Errorf("pooled remaining after exhaustion = %s, want 0", last)
+	}
+
+	usage := c.Usage()
+	if len(usage) != 3 {
+		t.Fatalf("len(Usage()) = %d, want 3", len(usage))
+	}
+	if !usage[0].Revoked || usage[0].LastError == nil {
+		t.Errorf("usage[revoked] = %+v, want revoked with error", usage[0])
+	}
+	for _, u := range usage[1:] {
+		if u.Remaining["core"] != 0 {
+			t.Errorf("usage[%s].Remaining = %v, want exhausted", u.Name, u.Remaining)
+		}
+	}
+	// Once the pool knows every quota is spent, only one request is sent
+	// to let GitHub report the limit.
+	if got := usage[1].Requests + usage[2].Requests; got != 6 {
+		t.Errorf("requests sent = %d, want 6", got)
+	}
+	if got := usage[1].RateLimited + usage[2].RateLimited; got != 1 {
+		t.Errorf("rate limited = %d, want 1", got)
+	}
+}
+
+func TestPoolClientPrefersMostRemaining(t *testing.T) {
+	qs := &quotaServer{
+		quotas: map[string]int{"a": 10, "b": 100},
+		seen:   make(map[string]int),
+	}
+	srv := httptest.NewServer(qs)
+	defer srv.Close()
+
+	c, err := NewPoolClient(context.Background(), []Credential{
+		TokenCredential("a", "a"),
+		TokenCredential("b", "b"),
+	}, ClientOptions{BaseURL: srv.URL + "/", UploadURL: srv.URL + "/"})
+	if err != nil {
+		t.Fatalf("NewPoolClient() error = %v", err)
+	}
+	for range 20 {
+		if _, err := c.GetUser(context.Background(), "octocat"); err != nil {
+			t.Fatalf("GetUser() error = %v", err)
+		}
+	}
+	// Each credential is tried once to learn its quota; the rest go to b.
+	if qs.seen["a"] != 1 || qs.seen["b"] != 19 {
+		t.Errorf("requests = a:%d b:%d, want a:1 b:19", qs.seen["a"], qs.seen["b"])
+	}
+}
+
+func TestNewPoolClientValidates(t *testing.T) {
+	tests := []struct {
+		name  string
+		creds []Credential
+	}{
+		{"empty", nil},
+		{"duplicate", []Credential{TokenCredential("x", "1"), TokenCredential("x", "2")}},
+		{"no source", []Credential{{Name: "x"}}},
+	}
+	for _, tt := range tests {
+		if _, err := NewPoolClient(context.Background(), tt.creds, ClientOptions{}); err == nil {
+			t.Errorf("NewPoolClient(%s) error = nil", tt.name)
+		}
+	}
+}
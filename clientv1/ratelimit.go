@@ -0,0 +1,433 @@
+package clientv1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rate limit headers sent by GitHub on every API response.
+const (
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+	headerRateLimitResource  = "X-RateLimit-Resource"
+	headerRetryAfter         = "Retry-After"
+)
+
+// Default values used when the corresponding RateLimitOptions field is zero.
+const (
+	DefaultRateLimitMaxRetries     = 3
+	DefaultRateLimitBaseDelay      = time.Second
+	DefaultRateLimitMaxDelay       = time.Minute
+	DefaultRateLimitSecondaryDelay = time.Minute
+)
+
+// WaitReason describes why the rate limit transport paused a request.
+type WaitReason string
+
+const (
+	// WaitPrimaryLimit means the primary (hourly) rate limit is exhausted
+	// and the transport is waiting for it to reset.
+	WaitPrimaryLimit WaitReason = "primary rate limit"
+
+	// WaitSecondaryLimit means GitHub reported a secondary (abuse) rate
+	// limit and the transport is honoring Retry-After or backing off.
+	WaitSecondaryLimit WaitReason = "secondary rate limit"
+
+	// WaitBudgetFloor means the remaining quota fell to
+	// RateLimitOptions.MinRemaining and the transport is pausing until reset.
+	WaitBudgetFloor WaitReason = "rate limit budget"
+
+	// WaitRetry means an idempotent request failed with a transient error
+	// and is being retried after a backoff.
+	WaitRetry WaitReason = "retry"
+)
+
+// RateLimitWait describes a single pause taken by the rate limit transport.
+type RateLimitWait struct {
+	Reason    WaitReason
+	Duration  time.Duration
+	Attempt   int       // Retry attempt the wait precedes; 0 for pauses before the first attempt
+	Method    string    // HTTP method of the delayed request
+	URL       string    // URL of the delayed request
+	Resource  string    // Rate limit resource, e.g. "core", "search" or "graphql"
+	Remaining int       // Remaining quota when known, otherwise -1
+	Reset     time.Time // When the quota resets; zero if unknown
+}
+
+// String returns a short human-readable description such as
+// "waiting 42s for primary rate limit".
+func (w RateLimitWait) String() string {
+	return fmt.Sprintf("waiting %s for %s", w.Duration.Round(time.Second), w.Reason)
+}
+
+// RateLimitWaitFunc is called before the transport sleeps.
+type RateLimitWaitFunc func(wait RateLimitWait)
+
+// RateLimitOptions configures the rate limit aware transport.
+type RateLimitOptions struct {
+	// MinRemaining is the budget floor. When a response reports this many
+	// or fewer requests remaining, subsequent requests for the same
+	// resource wait until the limit resets. Zero waits only when the quota
+	// is exhausted.
+	MinRemaining int
+
+	// MaxRetries is the maximum number of times a request is retried after
+	// a rate limit or transient error. Default: DefaultRateLimitMaxRetries.
+	MaxRetries int
+
+	// BaseDelay is the initial backoff for transient errors; it doubles with
+	// each attempt. Default: DefaultRateLimitBaseDelay.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the exponential backoff. Default: DefaultRateLimitMaxDelay.
+	MaxDelay time.Duration
+
+	// SecondaryDelay is the minimum wait after a secondary rate limit that
+	// carries no Retry-After header. Default: DefaultRateLimitSecondaryDelay.
+	SecondaryDelay time.Duration
+
+	// MaxWait is the longest single wait the transport accepts. When a
+	// reset is further away, the rate-limited response is returned to the
+	// caller instead. Zero means no limit; the request context still applies.
+	MaxWait time.Duration
+
+	// OnWait, if set, is called before every wait.
+	OnWait RateLimitWaitFunc
+}
+
+// RateLimitTransport is an http.RoundTripper that waits out GitHub primary
+// and secondary rate limits and retries idempotent requests that fail with
+// transient errors. Create one with NewRateLimitTransport.
+type RateLimitTransport struct {
+	base http.RoundTripper
+	opts RateLimitOptions
+
+	mu     sync.Mutex
+	limits map[string]rateLimitState // keyed by rate limit resource
+
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// rateLimitState is the most recently observed quota for one resource.
+type rateLimitState struct {
+	remaining int
+	reset     time.Time
+}
+
+// NewRateLimitTransport wraps base with rate limit handling. If base is
+// nil, http.DefaultTransport is used.
+func NewRateLimitTransport(base http.RoundTripper, opts RateLimitOptions) *RateLimitTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = DefaultRateLimitMaxRetries
+	}
+	if opts.BaseDelay == 0 {
+		opts.BaseDelay = DefaultRateLimitBaseDelay
+	}
+	if opts.MaxDelay == 0 {
+		opts.MaxDelay = DefaultRateLimitMaxDelay
+	}
+	if opts.SecondaryDelay == 0 {
+		opts.SecondaryDelay = DefaultRateLimitSecondaryDelay
+	}
+	return &RateLimitTransport{
+		base:   base,
+		opts:   opts,
+		limits: make(map[string]rateLimitState),
+		now:    time.Now,
+		sleep:  sleepContext,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	resource := rateLimitResource(req)
+
+	if err := t.waitForBudget(ctx, req, resource); err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		r, err := rewindRequest(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := t.base.RoundTrip(r)
+		if resp != nil {
+			t.observe(resource, resp)
+		}
+
+		if attempt >= t.opts.MaxRetries || !replayable(req) {
+			return resp, err
+		}
+		wait, ok := t.retryWait(req, resp, err, resource, attempt)
+		if !ok || (t.opts.MaxWait > 0 && wait.Duration > t.opts.MaxWait) {
+			return resp, err
+		}
+
+		if resp != nil {
+			drainBody(resp)
+		}
+		wait.Attempt = attempt + 1
+		if err := t.wait(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// waitForBudget pauses before a request when the last observed quota for
+// the resource is at or below the budget floor and has not yet reset.
+func (t *RateLimitTransport) waitForBudget(ctx context.Context, req *http.Request, resource string) error {
+	t.mu.Lock()
+	state, ok := t.limits[resource]
+	t.mu.Unlock()
+	if !ok || state.remaining > t.opts.MinRemaining {
+		return nil
+	}
+	d := state.reset.Sub(t.now())
+	if d <= 0 {
+		return nil
+	}
+	if t.opts.MaxWait > 0 && d > t.opts.MaxWait {
+		// Let the request through; GitHub will answer with the limit.
+		return nil
+	}
+	reason := WaitBudgetFloor
+	if state.remaining == 0 {
+		reason = WaitPrimaryLimit
+	}
+	return t.wait(ctx, RateLimitWait{
+		Reason:    reason,
+		Duration:  d,
+		Method:    req.Method,
+		URL:       req.URL.String(),
+		Resource:  resource,
+		Remaining: state.remaining,
+		Reset:     state.reset,
+	})
+}
+
+// retryWait decides whether a response or transport error should be
+// retried and how long to wait first.
+func (t *RateLimitTransport) retryWait(req *http.Request, resp *http.Response, err error, resource string, attempt int) (RateLimitWait, bool) {
+	wait := RateLimitWait{
+		Method:    req.Method,
+		URL:       req.URL.String(),
+		Resource:  resource,
+		Remaining: -1,
+	}
+
+	if err != nil || resp == nil {
+		if !isIdempotent(req.Method) || req.Context().Err() != nil {
+			return wait, false
+		}
+		wait.Reason = WaitRetry
+		wait.Duration = t.backoff(attempt)
+		return wait, true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		// Rate-limited requests are rejected before GitHub acts on them, so
+		// they are safe to replay whatever the method.
+		if remaining, ok := headerInt(resp.Header, headerRateLimitRemaining); ok {
+			wait.Remaining = remaining
+		}
+		if retryAfter, ok := headerInt(resp.Header, headerRetryAfter); ok {
+			wait.Reason = WaitSecondaryLimit
+			wait.Duration = time.Duration(retryAfter) * time.Second
+			return wait, true
+		}
+		if wait.Remaining == 0 {
+			reset, ok := t.resetTime(resp)
+			if !ok {
+				return wait, false
+			}
+			wait.Reason = WaitPrimaryLimit
+			wait.Reset = reset
+			wait.Duration = max(reset.Sub(t.now()), 0) + time.Second
+			return wait, true
+		}
+		if isSecondaryRateLimit(resp) {
+			wait.Reason = WaitSecondaryLimit
+			wait.Duration = max(t.opts.SecondaryDelay, t.backoff(attempt))
+			return wait, true
+		}
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if isIdempotent(req.Method) {
+			wait.Reason = WaitRetry
+			wait.Duration = t.backoff(attempt)
+			if retryAfter, ok := headerInt(resp.Header, headerRetryAfter); ok {
+				wait.Duration = max(wait.Duration, time.Duration(retryAfter)*time.Second)
+			}
+			return wait, true
+		}
+	}
+	return wait, false
+}
+
+// observe records the rate limit headers of a response.
+func (t *RateLimitTransport) observe(resource string, resp *http.Response) {
+	remaining, ok := headerInt(resp.Header, headerRateLimitRemaining)
+	if !ok {
+		return
+	}
+	reset, ok := t.resetTime(resp)
+	if !ok {
+		return
+	}
+	if r := resp.Header.Get(headerRateLimitResource); r != "" {
+		resource = r
+	}
+	t.mu.Lock()
+	t.limits[resource] = rateLimitState{remaining: remaining, reset: reset}
+	t.mu.Unlock()
+}
+
+// resetTime returns the reset time from X-RateLimit-Reset on the local
+// clock.
+func (t *RateLimitTransport) resetTime(resp *http.Response) (time.Time, bool) {
+	return rateLimitReset(resp, t.now())
+}
+
+// backoff returns the jittered exponential delay for a retry attempt: a
+// random duration between half and all of BaseDelay*2^attempt, capped at
+// MaxDelay.
+func (t *RateLimitTransport) backoff(attempt int) time.Duration {
+	d := t.opts.BaseDelay << min(attempt, 30)
+	if d <= 0 || d > t.opts.MaxDelay {
+		d = t.opts.MaxDelay
+	}
+	half := d / 2
+	return half + rand.N(half+1)
+}
+
+func (t *RateLimitTransport) wait(ctx context.Context, w RateLimitWait) error {
+	if t.opts.OnWait != nil {
+		t.opts.OnWait(w)
+	}
+	return t.sleep(ctx, w.Duration)
+}
+
+// rateLimitResource guesses the rate limit resource a request counts
+// against, matching the X-RateLimit-Resource values GitHub reports.
+func rateLimitResource(req *http.Request) string {
+	path := req.URL.Path
+	switch {
+	case strings.HasSuffix(path, "/graphql"):
+		return "graphql"
+	case strings.Contains(path, "/search/code"):
+		return "code_search"
+	case strings.Contains(path, "/search/"):
+		return "search"
+	default:
+		return "core"
+	}
+}
+
+// isSecondaryRateLimit reports whether a 403 or 429 response without rate
+// limit headers is a secondary rate limit, judging by its message. The body
+// is restored for the caller.
+func isSecondaryRateLimit(resp *http.Response) bool {
+	if resp.Body == nil {
+		return false
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	msg := strings.ToLower(string(body))
+	return strings.Contains(msg, "secondary rate limit") || strings.Contains(msg, "abuse detection")
+}
+
+// rewindRequest returns the request to send for the given attempt. Retries
+// get a fresh body from GetBody.
+func rewindRequest(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 || req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	r := req.Clone(req.Context())
+	r.Body = body
+	return r, nil
+}
+
+// replayable reports whether the request body, if any, can be sent again.
+func replayable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// rateLimitReset returns the reset time from X-RateLimit-Reset, translated
+// to the local clock using the server's Date header to cancel out clock
+// skew. now is the local time the response arrived.
+func rateLimitReset(resp *http.Response, now time.Time) (time.Time, bool) {
+	epoch, ok := headerInt(resp.Header, headerRateLimitReset)
+	if !ok {
+		return time.Time{}, false
+	}
+	reset := time.Unix(int64(epoch), 0)
+	if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+		return now.Add(reset.Sub(date)), true
+	}
+	return reset, true
+}
+
+func headerInt(h http.Header, key string) (int, bool) {
+	v := h.Get(key)
+	if v == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+func drainBody(resp *http.Response) {
+	if resp.Body != nil {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+		resp.Body.Close()
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,288 @@
+package clientv1
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestRateLimitTransport returns a transport whose sleeps are recorded
+// instead of taken.
+func newTestRateLimitTransport(opts RateLimitOptions) (*RateLimitTransport, *[]RateLimitWait) {
+	var waits []RateLimitWait
+	opts.OnWait = func(w RateLimitWait) { waits = append(waits, w) }
+	t := NewRateLimitTransport(http.DefaultTransport, opts)
+	t.sleep = func(ctx context.Context, d time.Duration) error { return ctx.Err() }
+	return t, &waits
+}
+
+// sequenceServer answers each request with the next handler in order,
+// repeating the last one.
+func sequenceServer(t *testing.T, handlers ...http.HandlerFunc) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&calls, 1)) - 1
+		handlers[min(n, len(handlers)-1)](w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func respond(status int, headers map[string]string, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for k, v := range headers {
+			w.Header().Set(k, v)
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func TestRateLimitTransportRetries(t *testing.T) {
+	reset := strconv.FormatInt(time.Now().Add(30*time.Second).Unix(), 10)
+	ok := respond(http.StatusOK, nil, `{}`)
+
+	tests := []struct {
+		name       string
+		method     string
+		first      http.HandlerFunc
+		wantCalls  int32
+		wantStatus int
+		wantReason WaitReason
+	}{
+		{
+			name:       "secondary with Retry-After",
+			method:     http.MethodPost,
+			first:      respond(http.StatusForbidden, map[string]string{"Retry-After": "2"}, `{"message":"You have exceeded a secondary rate limit"}`),
+			wantCalls:  2,
+			wantStatus: http.StatusOK,
+			wantReason: WaitSecondaryLimit,
+		},
+		{
+			name:       "secondary without Retry-After",
+			method:     http.MethodGet,
+			first:      respond(http.StatusForbidden, nil, `{"message":"You have exceeded a secondary rate limit"}`),
+			wantCalls:  2,
+			wantStatus: http.StatusOK,
+			wantReason: WaitSecondaryLimit,
+		},
+		{
+			name:   "primary exhausted",
+			method: http.MethodGet,
+			first: respond(http.StatusForbidden, map[string]string{
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     reset,
+			}, `{"message":"API rate limit exceeded"}`),
+			wantCalls:  2,
+			wantStatus: http.StatusOK,
+			wantReason: WaitPrimaryLimit,
+		},
+		{
+			name:       "bad gateway on GET",
+			method:     http.MethodGet,
+			first:      respond(http.StatusBadGateway, nil, ``),
+			wantCalls:  2,
+			wantStatus: http.StatusOK,
+			wantReason: WaitRetry,
+		},
+		{
+			name:       "bad gateway on POST",
+			method:     http.MethodPost,
+			first:      respond(http.StatusBadGateway, nil, ``),
+			wantCalls:  1,
+			wantStatus: http.StatusBadGateway,
+		},
+		{
+			name:       "permission denied",
+			method:     http.MethodGet,
+			first:      respond(http.StatusForbidden, nil, `{"message":"Resource not accessible by integration"}`),
+			wantCalls:  1,
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, calls := sequenceServer(t, tt.first, ok)
+			transport, waits := newTestRateLimitTransport(RateLimitOptions{})
+
+			var body io.Reader
+			if tt.method == http.MethodPost {
+				body = strings.NewReader(`{"name":"x"}`)
+			}
+			req, err := http.NewRequest(tt.method, srv.URL+"/repos/o/r", body)
+			if err != nil {
+				t.Fatalf("NewRequest() error = %v", err)
+			}
+
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("RoundTrip() error = %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("StatusCode = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := atomic.LoadInt32(calls); got != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", got, tt.wantCalls)
+			}
+			if tt.wantReason == "" {
+				if len(*waits) != 0 {
+					t.Errorf("waits = %v, want none", *waits)
+				}
+				return
+			}
+			if len(*waits) != 1 || (*waits)[0].Reason != tt.wantReason {
+				t.Fatalf("waits = %v, want one %q", *waits, tt.wantReason)
+			}
+			if (*waits)[0].Attempt != 1 {
+				t.Errorf("Attempt = %d, want 1", (*waits)[0].Attempt)
+			}
+		})
+	}
+}
+
+func TestRateLimitTransportMaxRetries(t *testing.T) {
+	srv, calls := sequenceServer(t, respond(http.StatusServiceUnavailable, nil, ``))
+	transport, waits := newTestRateLimitTransport(RateLimitOptions{MaxRetries: 2})
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/user", nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("StatusCode = %d, want %d", resp.StatusCode, http.StatusServiceUnavailable)
+	}
+	if got := atomic.LoadInt32(calls); got != 3 {
+		t.Errorf("calls = %d, want 3", got)
+	}
+	if len(*waits) != 2 {
+		t.Errorf("waits = %d, want 2", len(*waits))
+	}
+}
+
+func TestRateLimitTransportMaxWait(t *testing.T) {
+	srv, calls := sequenceServer(t, respond(http.StatusTooManyRequests, map[string]string{"Retry-After": "3600"}, ``))
+	transport, waits := newTestRateLimitTransport(RateLimitOptions{MaxWait: time.Minute})
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/user", nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("StatusCode = %d, want %d", resp.StatusCode, http.StatusTooManyRequests)
+	}
+	if got := atomic.LoadInt32(calls); got != 1 {
+		t.Errorf("calls = %d, want 1", got)
+	}
+	if len(*waits) != 0 {
+		t.Errorf("waits = %v, want none", *waits)
+	}
+}
+
+func TestRateLimitTransportBudgetFloor(t *testing.T) {
+	reset := time.Now().Add(10 * time.Minute)
+	srv, _ := sequenceServer(t, respond(http.StatusOK, map[string]string{
+		"X-RateLimit-Remaining": "5",
+		"X-RateLimit-Reset":     strconv.FormatInt(reset.Unix(), 10),
+		"X-RateLimit-Resource":  "core",
+	}, `{}`))
+	transport, waits := newTestRateLimitTransport(RateLimitOptions{MinRemaining: 10})
+
+	for range 2 {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"/user", nil)
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("RoundTrip() error = %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	if len(*waits) != 1 {
+		t.Fatalf("waits = %v, want one", *waits)
+	}
+	w := (*waits)[0]
+	if w.Reason != WaitBudgetFloor || w.Remaining != 5 || w.Resource != "core" {
+		t.Errorf("wait = %+v, want budget floor on core with 5 remaining", w)
+	}
+	if w.Duration <= 9*time.Minute || w.Duration > 10*time.Minute {
+		t.Errorf("Duration = %v, want about 10m", w.Duration)
+	}
+
+	// Search has its own quota and is not held back by core.
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/search/issues?q=x", nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	resp.Body.Close()
+	if len(*waits) != 1 {
+		t.Errorf("waits after search = %d, want 1", len(*waits))
+	}
+}
+
+func TestRateLimitTransportCanceled(t *testing.T) {
+	srv, _ := sequenceServer(t, respond(http.StatusTooManyRequests, map[string]string{"Retry-After": "60"}, ``))
+	transport := NewRateLimitTransport(nil, RateLimitOptions{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	transport.opts.OnWait = func(RateLimitWait) { cancel() }
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/user", nil)
+	if _, err := transport.RoundTrip(req); err != context.Canceled {
+		t.Errorf("RoundTrip() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestRateLimitWaitString(t *testing.T) {
+	w := RateLimitWait{Reason: WaitPrimaryLimit, Duration: 41600 * time.Millisecond}
+	if got, want := w.String(), "waiting 42s for primary rate limit"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestNewClientWithOptionsRateLimit(t *testing.T) {
+	srv, calls := sequenceServer(t,
+		respond(http.StatusTooManyRequests, map[string]string{"Retry-After": "0"}, `{"message":"secondary rate limit"}`),
+		respond(http.StatusOK, map[string]string{"Content-Type": "application/json"}, `{"login":"octocat","id":1}`),
+	)
+
+	var waits int
+	c, err := NewClientWithOptions(context.Background(), ClientOptions{
+		Token:     "token",
+		BaseURL:   srv.URL + "/",
+		UploadURL: srv.URL + "/",
+		RateLimit: &RateLimitOptions{OnWait: func(RateLimitWait) { waits++ }},
+	})
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+
+	user, err := c.GetUser(context.Background(), "octocat")
+	if err != nil {
+		t.Fatalf("GetUser() error = %v", err)
+	}
+	if user.Login != "octocat" {
+		t.Errorf("Login = %q, want %q", user.Login, "octocat")
+	}
+	if got := atomic.LoadInt32(calls); got != 2 {
+		t.Errorf("calls = %d, want 2", got)
+	}
+	if waits != 1 {
+		t.Errorf("waits = %d, want 1", waits)
+	}
+}
@@ -0,0 +1,190 @@
+package clientv1
+
+import (
+	"strings"
+)
+
+// routeTemplates are the REST API endpoints RouteTemplate recognizes, using
+// the parameter names of GitHub's API reference. A final "{name...}"
+// segment matches one or more path segments.
+var routeTemplates = []string{
+	"/graphql",
+	"/rate_limit",
+	"/user",
+	"/user/repos",
+	"/users/{username}",
+	"/users/{username}/repos",
+	"/users/{username}/events",
+	"/users/{username}/events/public",
+	"/orgs/{org}",
+	"/orgs/{org}/repos",
+	"/orgs/{org}/members",
+	"/orgs/{org}/teams",
+	"/orgs/{org}/teams/{team_slug}",
+	"/orgs/{org}/teams/{team_slug}/members",
+	"/orgs/{org}/teams/{team_slug}/repos",
+	"/search/code",
+	"/search/commits",
+	"/search/issues",
+	"/search/repositories",
+	"/search/users",
+	"/repos/{owner}/{repo}",
+	"/repos/{owner}/{repo}/actions/runs",
+	"/repos/{owner}/{repo}/actions/runs/{run_id}",
+	"/repos/{owner}/{repo}/actions/workflows",
+	"/repos/{owner}/{repo}/actions/workflows/{workflow_id}",
+	"/repos/{owner}/{repo}/actions/workflows/{workflow_id}/runs",
+	"/repos/{owner}/{repo}/branches",
+	"/repos/{owner}/{repo}/branches/{branch}",
+	"/repos/{owner}/{repo}/branches/{branch}/protection",
+	"/repos/{owner}/{repo}/check-runs/{check_run_id}",
+	"/repos/{owner}/{repo}/code-scanning/sarifs",
+	"/repos/{owner}/{repo}/code-scanning/sarifs/{sarif_id}",
+	"/repos/{owner}/{repo}/commits",
+	"/repos/{owner}/{repo}/commits/{ref}",
+	"/repos/{owner}/{repo}/commits/{ref}/check-runs",
+	"/repos/{owner}/{repo}/commits/{ref}/check-suites",
+	"/repos/{owner}/{repo}/contents/{path...}",
+	"/repos/{owner}/{repo}/contributors",
+	"/repos/{owner}/{repo}/forks",
+	"/repos/{owner}/{repo}/git/blobs",
+	"/repos/{owner}/{repo}/git/blobs/{file_sha}",
+	"/repos/{owner}/{repo}/git/commits",
+	"/repos/{owner}/{repo}/git/commits/{commit_sha}",
+	"/repos/{owner}/{repo}/git/ref/{ref...}",
+	"/repos/{owner}/{repo}/git/refs",
+	"/repos/{owner}/{repo}/git/refs/{ref...}",
+	"/repos/{owner}/{repo}/git/tags",
+	"/repos/{owner}/{repo}/git/trees",
+	"/repos/{owner}/{repo}/git/trees/{tree_sha}",
+	"/repos/{owner}/{repo}/issues",
+	"/repos/{owner}/{repo}/issues/comments/{comment_id}",
+	"/repos/{owner}/{repo}/issues/{issue_number}",
+	"/repos/{owner}/{repo}/issues/{issue_number}/comments",
+	"/repos/{owner}/{repo}/languages",
+	"/repos/{owner}/{repo}/pulls",
+	"/repos/{owner}/{repo}/pulls/{pull_number}",
+	"/repos/{owner}/{repo}/pulls/{pull_number}/comments",
+	"/repos/{owner}/{repo}/pulls/{pull_number}/files",
+	"/repos/{owner}/{repo}/pulls/{pull_number}/merge",
+	"/repos/{owner}/{repo}/pulls/{pull_number}/requested_reviewers",
+	"/repos/{owner}/{repo}/pulls/{pull_number}/reviews",
+	"/repos/{owner}/{repo}/releases",
+	"/repos/{owner}/{repo}/releases/assets/{asset_id}",
+	"/repos/{owner}/{repo}/releases/latest",
+	"/repos/{owner}/{repo}/releases/tags/{tag}",
+	"/repos/{owner}/{repo}/releases/{release_id}",
+	"/repos/{owner}/{repo}/releases/{release_id}/assets",
+	"/repos/{owner}/{repo}/stats/code_frequency",
+	"/repos/{owner}/{repo}/stats/commit_activity",
+	"/repos/{owner}/{repo}/stats/contributors",
+	"/repos/{owner}/{repo}/stats/participation",
+	"/repos/{owner}/{repo}/stats/punch_card",
+	"/repos/{owner}/{repo}/tags",
+	"/repositories/{repository_id}",
+}
+
+// RouteTemplate returns the endpoint template for an API request path, such
+// as "/repos/{owner}/{repo}/pulls" for "/repos/octocat/hello/pulls", so
+// requests can be grouped without one series per repository. GitHub
+// Enterprise prefixes ("/api/v3", "/api/uploads") are removed.
+//
+// Paths of unknown endpoints keep their literal segments, except that
+// owner and repository names and numeric or SHA segments are replaced
+// with placeholders.
+func RouteTemplate(path string) string {
+	path = trimAPIPrefix(path)
+	segs := splitPath(path)
+
+	best, bestLiterals := "", -1
+	for _, tmpl := range routeTemplates {
+		if n, ok := matchRoute(splitPath(tmpl), segs); ok && n > bestLiterals {
+			best, bestLiterals = tmpl, n
+		}
+	}
+	if best != "" {
+		return best
+	}
+	return genericRoute(segs)
+}
+
+// matchRoute reports whether segs match the template segments and how many
+// literal segments matched, so the most specific template can be chosen.
+func matchRoute(tmpl, segs []string) (int, bool) {
+	literals := 0
+	for i, t := range tmpl {
+		if strings.HasSuffix(t, "...}") {
+			return literals, i == len(tmpl)-1 && len(segs) > i
+		}
+		if i >= len(segs) {
+			return 0, false
+		}
+		if strings.HasPrefix(t, "{") {
+			continue
+		}
+		if t != segs[i] {
+			return 0, false
+		}
+		literals++
+	}
+	return literals, len(tmpl) == len(segs)
+}
+
+// genericRoute templates a path no known route matched.
+func genericRoute(segs []string) string {
+	out := make([]string, len(segs))
+	for i, s := range segs {
+		switch {
+		case i > 0 && i <= 2 && segs[0] == "repos":
+			out[i] = [...]string{"", "{owner}", "{repo}"}[i]
+		case i == 1 && (segs[0] == "users" || segs[0] == "orgs"):
+			out[i] = "{" + strings.TrimSuffix(segs[0], "s") + "}"
+		case isNumeric(s):
+			out[i] = "{id}"
+		case len(s) == 40 && isHex(s):
+			out[i] = "{sha}"
+		default:
+			out[i] = s
+		}
+	}
+	return "/" + strings.Join(out, "/")
+}
+
+// trimAPIPrefix removes GitHub Enterprise API path prefixes.
+func trimAPIPrefix(path string) string {
+	for _, prefix := range []string{"/api/v3", "/api/uploads"} {
+		if rest, ok := strings.CutPrefix(path, prefix); ok && (rest == "" || rest[0] == '/') {
+			return rest
+		}
+	}
+	return path
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isHex(s string) bool {
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package clientv1
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+)
+
+// headerTraceparent carries the W3C trace context of an outgoing request.
+const headerTraceparent = "traceparent"
+
+// SpanContext identifies a span in a distributed trace. IDs are lowercase
+// hex strings in W3C Trace Context format: 32 characters for TraceID and 16
+// for SpanID.
+type SpanContext struct {
+	TraceID string
+	SpanID  string
+}
+
+// IsValid reports whether both IDs are set.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != "" && sc.SpanID != ""
+}
+
+type spanContextKey struct{}
+
+// ContextWithSpan returns a copy of ctx carrying sc. Requests made with the
+// returned context become children of sc when a TracingMiddleware is
+// installed.
+func ContextWithSpan(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// SpanFromContext returns the span carried by ctx, if any.
+func SpanFromContext(ctx context.Context) (SpanContext, bool) {
+	sc, ok := ctx.Value(spanContextKey{}).(SpanContext)
+	return sc, ok && sc.IsValid()
+}
+
+// TracingMiddleware returns a middleware that starts a span for each
+// request. The span continues the trace in the request context, or starts
+// a new trace if there is none. It is added to the context seen by later
+// middlewares and sent to GitHub in a traceparent header.
+//
+// Install it before LoggingMiddleware, HooksMiddleware or Metrics so they
+// report the request's trace and span IDs.
+func TracingMiddleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			sc := SpanContext{SpanID: randomHex(8)}
+			if parent, ok := SpanFromContext(req.Context()); ok {
+				sc.TraceID = parent.TraceID
+			} else {
+				sc.TraceID = randomHex(16)
+			}
+			req = req.Clone(ContextWithSpan(req.Context(), sc))
+			req.Header.Set(headerTraceparent, fmt.Sprintf("00-%s-%s-01", sc.TraceID, sc.SpanID))
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// randomHex returns n random bytes as a hex string.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
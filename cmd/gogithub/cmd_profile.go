@@ -21,26 +21,42 @@ import (
 )
 
 var (
-	profileUser             string
-	profileFrom             string
-	profileTo               string
-	profileFormat           string
-	profileOutput           string
-	profileOutputRaw        string
-	profileOutputAggregate  string
-	profileOutputMonthly    string
-	profileOutputMonthlyDir string
-	profileOutputReadme     string
-	profileReadmeConfig     string
-	profileOutputSVG        string
-	profileSVGTheme         string
-	profileSVGTitle         string
-	profileOutputChart      string
-	profileOutputChartJSON  string
-	profileInput            string
-	profileIncludeReleases  bool
-	profileReleaseOrgs      string
-	profileVisibility       string
+	profileUser              string
+	profileFrom              string
+	profileTo                string
+	profileFormat            string
+	profileOutput            string
+	profileOutputRaw         string
+	profileOutputAggregate   string
+	profileOutputMonthly     string
+	profileOutputMonthlyDir  string
+	profileOutputReadme      string
+	profileReadmeConfig      string
+	profileOutputSVG         string
+	profileSVGTheme          string
+	profileSVGTitle          string
+	profileOutputChart       string
+	profileOutputChartJSON   string
+	profileOutputCommitTypes string
+	profileOutputLanguages   string
+	profileIncludeLanguages  bool
+	profileLanguageWeight    string
+	profileOutputPunchcard   string
+	profileOutputCalendar    string
+	profileTimezone          string
+	profileInput             string
+	profileIncludeReleases   bool
+	profileReleaseOrgs       string
+	profileVisibility        string
+	profileConcurrency       int
+	profileCacheDir          string
+	profileMaxCost           int
+	profileMinRemaining      int
+	profileNoWait            bool
+	profileEstimate          bool
+	profileUsers             string
+	profileOrg               string
+	profileTeam              string
 )
 
 var profileCmd = &cobra.Command{
@@ -48,6 +64,7 @@ var profileCmd = &cobra.Command{
 	Short: "Fetch user contribution statistics",
 	Long: `Fetch comprehensive GitHub contribution statistics for a user.
 This provides data similar to what's shown on GitHub profile pages.
+With --users, --org or --team, it rolls up the statistics of a team.
 
 Examples:
   # Human-readable summary
@@ -64,6 +81,10 @@ Examples:
   gogithub profile --user grokify --from 2024-01-01 --to 2024-01-31 \
     --include-releases --output-raw raw.json
 
+  # Repository stats as a spreadsheet, or CSV
+  gogithub profile --user grokify --from 2024-01-01 --to 2024-12-31 --format xlsx --output repos.xlsx
+  gogithub profile --input raw.json --format csv --output repos.csv
+
   # Generate aggregate from existing raw file (no API calls)
   gogithub profile --input raw.json --output aggregate.json
 
@@ -85,9 +106,24 @@ Examples:
   # Generate monthly lines chart (JSON IR + SVG)
   gogithub profile --user grokify --output-chart-json chart.json --output-chart chart.svg
 
+  # Generate a GitHub-style contribution calendar SVG
+  gogithub profile --user grokify --output-calendar calendar.svg --svg-theme dark
+
   # Generate chart from existing raw JSON
   gogithub profile --input raw.json --output-chart lines.svg --svg-theme dark
 
+  # Classify commits by conventional commit type (JSON data, or SVG chart)
+  gogithub profile --user grokify --output-commit-types commit-types.json
+  gogithub profile --user grokify --output-commit-types commit-types.svg
+
+  # Language breakdown as a donut chart, weighted by lines added
+  gogithub profile --user grokify --include-languages --language-weight additions \
+    --output-languages languages.svg
+
+  # Punchcard of commits by weekday and hour, in New York time
+  gogithub profile --user grokify --timezone America/New_York \
+    --output-punchcard punchcard.svg
+
   # Generate monthly activity JSON (creates or merges with existing file)
   gogithub profile --user grokify --from 2024-01-01 --to 2024-01-31 \
     --output-monthly monthly.json
@@ -105,6 +141,18 @@ Examples:
     --include-releases --release-orgs grokify,plexusone \
     --output-monthly-dir ./stats/
 
+  # Cache fetched months, so a failed run resumes where it stopped
+  gogithub profile --user grokify --from 2015-01-01 --cache-dir ./cache/
+
+  # Estimate the API cost before fetching
+  gogithub profile --user grokify --from 2015-01-01 --include-releases --estimate
+
+  # Team rollup of a GitHub team's members
+  gogithub profile --org plexusone --team core --output-svg team.svg
+
+  # Team rollup of a list of users, as monthly files for stats-report
+  gogithub profile --users grokify,octocat --output-monthly-dir ./stats/
+
   # Public repos only
   gogithub profile --user grokify --visibility public --output-monthly monthly.json
 
@@ -121,7 +169,7 @@ func init() {
 	profileCmd.Flags().StringVarP(&profileUser, "user", "u", "", "GitHub username")
 	profileCmd.Flags().StringVarP(&profileFrom, "from", "f", "", "Start date (YYYY-MM-DD), defaults to 1 year ago")
 	profileCmd.Flags().StringVarP(&profileTo, "to", "t", "", "End date (YYYY-MM-DD), defaults to today")
-	profileCmd.Flags().StringVar(&profileFormat, "format", "summary", "Output format: summary, json")
+	profileCmd.Flags().StringVar(&profileFormat, "format", "summary", "Output format: summary, json, or xlsx, csv for repository stats (requires --output)")
 	profileCmd.Flags().StringVarP(&profileOutput, "output", "o", "", "Output file (defaults to stdout)")
 	profileCmd.Flags().StringVar(&profileOutputRaw, "output-raw", "", "Output raw JSON file (includes all per-repo data)")
 	profileCmd.Flags().StringVar(&profileOutputAggregate, "output-aggregate", "", "Output aggregate JSON file")
@@ -133,22 +181,48 @@ func init() {
 	profileCmd.Flags().StringVar(&profileSVGTitle, "svg-title", "", "Custom title for SVG card (default: username's GitHub Stats)")
 	profileCmd.Flags().StringVar(&profileOutputChart, "output-chart", "", "Output monthly lines chart SVG file")
 	profileCmd.Flags().StringVar(&profileOutputChartJSON, "output-chart-json", "", "Output monthly lines chart JSON IR file")
+	profileCmd.Flags().StringVar(&profileOutputCalendar, "output-calendar", "", "Output contribution calendar SVG file")
+	profileCmd.Flags().StringVar(&profileOutputCommitTypes, "output-commit-types", "", "Output commit types JSON file, or chart SVG file if it ends in .svg")
 	profileCmd.Flags().StringVarP(&profileInput, "input", "i", "", "Input raw JSON file (skips API calls)")
 	profileCmd.Flags().BoolVar(&profileIncludeReleases, "include-releases", false, "Fetch release counts for contributed repositories")
+	profileCmd.Flags().BoolVar(&profileIncludeLanguages, "include-languages", false, "Fetch languages of contributed repositories")
+	profileCmd.Flags().StringVar(&profileLanguageWeight, "language-weight", "commits", "Weight repository languages by: commits, additions")
+	profileCmd.Flags().StringVar(&profileOutputLanguages, "output-languages", "", "Output languages donut chart SVG file (requires --include-languages or --input with languages)")
+	profileCmd.Flags().StringVar(&profileOutputPunchcard, "output-punchcard", "", "Output commits by weekday and hour punchcard chart SVG file, or chart JSON IR file if it ends in .json")
+	profileCmd.Flags().StringVar(&profileTimezone, "timezone", "", "IANA time zone the punchcard counts commit hours in, e.g. America/New_York (default UTC)")
 	profileCmd.Flags().StringVar(&profileReleaseOrgs, "release-orgs", "", "Comma-separated list of orgs/owners to count releases for (e.g., grokify,plexusone)")
 	profileCmd.Flags().StringVar(&profileOutputMonthlyDir, "output-monthly-dir", "", "Output directory for individual monthly JSON files")
 	profileCmd.Flags().StringVar(&profileVisibility, "visibility", "all", "Repository visibility filter: all, public, private")
+	profileCmd.Flags().IntVar(&profileConcurrency, "concurrency", graphql.DefaultConcurrency, "Number of repositories to fetch commit histories and releases for at once")
+	profileCmd.Flags().StringVar(&profileCacheDir, "cache-dir", "", "Directory caching fetched months, so interrupted runs resume")
+	profileCmd.Flags().IntVar(&profileMaxCost, "max-cost", 0, "GraphQL points each repository's commit history may spend (0 means no limit)")
+	profileCmd.Flags().IntVar(&profileMinRemaining, "min-remaining", 0, "GraphQL points to leave unspent, waiting for the reset when fewer remain (0 disables)")
+	profileCmd.Flags().BoolVar(&profileNoWait, "no-wait", false, "Stop instead of waiting when fewer than --min-remaining points remain")
+	profileCmd.Flags().BoolVar(&profileEstimate, "estimate", false, "Estimate the API cost and duration without fetching the profile")
+	profileCmd.Flags().StringVar(&profileUsers, "users", "", "Comma-separated GitHub usernames to roll up as a team")
+	profileCmd.Flags().StringVar(&profileOrg, "org", "", "Roll up the members of this organization as a team")
+	profileCmd.Flags().StringVar(&profileTeam, "team", "", "Roll up the members of this team slug in --org instead")
 }
 
 func runProfile(cmd *cobra.Command, args []string) error {
+	if (profileFormat == "xlsx" || profileFormat == "csv") && profileOutput == "" {
+		return fmt.Errorf("--format %s requires --output", profileFormat)
+	}
+
 	// Mode 1: Read from input file
 	if profileInput != "" {
+		if profileOutputCommitTypes != "" {
+			return fmt.Errorf("--output-commit-types requires fetching from the API, not --input")
+		}
 		return runProfileFromInput()
 	}
 
 	// Mode 2: Fetch from API
-	if profileUser == "" {
-		return fmt.Errorf("--user is required when not using --input")
+	if profileTeam != "" && profileOrg == "" {
+		return fmt.Errorf("--team requires --org")
+	}
+	if profileUser == "" && !isTeamProfile() {
+		return fmt.Errorf("--user, --users or --org is required when not using --input")
 	}
 
 	return runProfileFromAPI()
@@ -174,6 +248,11 @@ func runProfileFromInput() error {
 	if err != nil {
 		return err
 	}
+	weighting, err := parseLanguageWeight(profileLanguageWeight)
+	if err != nil {
+		return err
+	}
+	p.Languages = profile.BuildLanguageBreakdown(p.RepoStats, weighting)
 	opts := &profile.Options{
 		Visibility:      visibility,
 		IncludeReleases: profileIncludeReleases,
@@ -201,6 +280,27 @@ func runProfileFromInput() error {
 		}
 	}
 
+	// Generate languages chart if requested
+	if profileOutputLanguages != "" {
+		if err := generateLanguagesSVG(p, profileOutputLanguages, profileSVGTheme); err != nil {
+			return err
+		}
+	}
+
+	// Generate punchcard chart if requested
+	if profileOutputPunchcard != "" {
+		if err := generatePunchcard(p, profileOutputPunchcard, profileSVGTheme); err != nil {
+			return err
+		}
+	}
+
+	// Generate calendar SVG if requested
+	if profileOutputCalendar != "" {
+		if err := generateCalendarSVG(p, profileOutputCalendar, profileSVGTheme); err != nil {
+			return err
+		}
+	}
+
 	// Generate README if requested
 	if profileOutputReadme != "" {
 		if err := generateReadme(p, profileOutputReadme, profileReadmeConfig); err != nil {
@@ -227,12 +327,17 @@ func runProfileFromInput() error {
 	}
 
 	// If specific outputs were requested, return early
-	if profileOutputSVG != "" || profileOutputChartJSON != "" || profileOutputChart != "" || profileOutputReadme != "" || profileOutputMonthly != "" || profileOutputMonthlyDir != "" {
+	if profileOutputSVG != "" || profileOutputChartJSON != "" || profileOutputChart != "" || profileOutputLanguages != "" || profileOutputPunchcard != "" || profileOutputCalendar != "" || profileOutputReadme != "" || profileOutputMonthly != "" || profileOutputMonthlyDir != "" {
 		if profileOutput == "" {
 			return nil
 		}
 	}
 
+	// Write repository stats as a spreadsheet if requested
+	if profileFormat == "xlsx" || profileFormat == "csv" {
+		return writeReposSpreadsheet(p, profileFormat, profileOutput)
+	}
+
 	// Generate aggregate from raw
 	aggregate := rawToAggregate(&raw)
 
@@ -256,15 +361,10 @@ func runProfileFromAPI() error {
 
 	ctx := context.Background()
 
-	// Create clients
-	restClient, err := clientv1.NewClient(ctx, token)
+	client, err := clientv1.NewClient(ctx, token)
 	if err != nil {
 		return fmt.Errorf("creating github client: %w", err)
 	}
-	gqlClient := graphql.NewClient(ctx, token)
-
-	fmt.Fprintf(os.Stderr, "Fetching profile for '%s' from %s to %s\n\n",
-		profileUser, from.Format("2006-01-02"), to.Format("2006-01-02"))
 
 	// Create progress renderer
 	renderer := progress.NewMultiStageRenderer(os.Stderr)
@@ -285,33 +385,116 @@ func runProfileFromAPI() error {
 	if err != nil {
 		return err
 	}
+	weighting, err := parseLanguageWeight(profileLanguageWeight)
+	if err != nil {
+		return err
+	}
 
 	opts := &profile.Options{
-		Visibility:      visibility,
-		IncludeReleases: profileIncludeReleases,
-		ReleaseOrgs:     parseCommaSeparated(profileReleaseOrgs),
-		Progress:        progressFunc,
+		Visibility:        visibility,
+		IncludeReleases:   profileIncludeReleases,
+		ReleaseOrgs:       parseCommaSeparated(profileReleaseOrgs),
+		IncludeLanguages:  profileIncludeLanguages,
+		LanguageWeighting: weighting,
+		Concurrency:       profileConcurrency,
+		Timezone:          profileTimezone,
+		CacheDir:          profileCacheDir,
+		Paginate:          paginateOptions(),
+		Progress:          progressFunc,
+	}
+
+	if isTeamProfile() {
+		return runTeamProfileFromAPI(ctx, client, from, to, opts)
+	}
+
+	if profileEstimate {
+		est, err := profile.EstimateUserProfile(ctx, client, profileUser, from, to, opts)
+		if err != nil {
+			return fmt.Errorf("estimate user profile: %w", err)
+		}
+		return writeOutput(formatEstimate(est), profileOutput, "output")
 	}
 
-	p, err := profile.GetUserProfile(ctx, restClient, gqlClient, profileUser, from, to, opts)
+	fmt.Fprintf(os.Stderr, "Fetching profile for '%s' from %s to %s\n\n",
+		profileUser, from.Format("2006-01-02"), to.Format("2006-01-02"))
+
+	p, err := profile.GetUserProfile(ctx, client, profileUser, from, to, opts)
 	if err != nil {
 		return fmt.Errorf("get user profile: %w", err)
 	}
 
+	if profileOutputCommitTypes != "" {
+		if err := generateCommitTypes(ctx, client, from, to, opts, profileOutputCommitTypes); err != nil {
+			return err
+		}
+	}
+
+	return outputProfile(p, opts, formatSummary)
+}
+
+// isTeamProfile reports whether the flags select a team rollup.
+func isTeamProfile() bool {
+	return profileUsers != "" || profileOrg != ""
+}
+
+func runTeamProfileFromAPI(ctx context.Context, client clientv1.Client, from, to time.Time, opts *profile.Options) error {
+	if profileEstimate {
+		return fmt.Errorf("--estimate is not supported for teams")
+	}
+	if profileOutputCommitTypes != "" {
+		return fmt.Errorf("--output-commit-types is not supported for teams")
+	}
+
+	team := profile.Team{
+		Usernames: parseCommaSeparated(profileUsers),
+		Org:       profileOrg,
+		TeamSlug:  profileTeam,
+	}
+	if profileUser != "" {
+		team.Usernames = append(team.Usernames, profileUser)
+	}
+
+	fmt.Fprintf(os.Stderr, "Fetching team profile from %s to %s\n",
+		from.Format("2006-01-02"), to.Format("2006-01-02"))
+
+	tp, err := profile.GetTeamProfile(ctx, client, team, from, to, &profile.TeamOptions{
+		Member: opts,
+		Progress: func(done, total int) {
+			fmt.Fprintf(os.Stderr, "  Fetched %d/%d members\n", done, total)
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("get team profile: %w", err)
+	}
+
+	return outputProfile(tp.Combined, opts, func(*profile.UserProfile) string {
+		return formatTeamSummary(tp)
+	})
+}
+
+// outputProfile writes the output files requested for p or, without any,
+// p as JSON or as formatted by summary.
+func outputProfile(p *profile.UserProfile, opts *profile.Options, summary func(*profile.UserProfile) string) error {
 	// Mode: Generate specific output files
-	if profileOutputRaw != "" || profileOutputAggregate != "" || profileOutputMonthly != "" || profileOutputMonthlyDir != "" || profileOutputReadme != "" || profileOutputSVG != "" || profileOutputChart != "" || profileOutputChartJSON != "" {
+	if profileOutputRaw != "" || profileOutputAggregate != "" || profileOutputMonthly != "" || profileOutputMonthlyDir != "" || profileOutputReadme != "" || profileOutputSVG != "" || profileOutputChart != "" || profileOutputChartJSON != "" || profileOutputLanguages != "" || profileOutputPunchcard != "" || profileOutputCalendar != "" {
 		return outputBothFormats(p, opts)
 	}
+	if profileOutputCommitTypes != "" && profileOutput == "" {
+		return nil
+	}
 
 	// Mode: Single output (legacy behavior)
 	var output string
+	var err error
 	switch profileFormat {
 	case "json":
 		output, err = formatAggregateJSON(p)
 	case "summary":
-		output = formatSummary(p)
+		output = summary(p)
+	case "xlsx", "csv":
+		return writeReposSpreadsheet(p, profileFormat, profileOutput)
 	default:
-		return fmt.Errorf("unknown format: %s (use 'summary' or 'json')", profileFormat)
+		return fmt.Errorf("unknown format: %s (use 'summary', 'json', 'xlsx' or 'csv')", profileFormat)
 	}
 	if err != nil {
 		return err
@@ -391,6 +574,43 @@ func outputBothFormats(p *profile.UserProfile, opts *profile.Options) error {
 		}
 	}
 
+	// Generate languages chart if requested
+	if profileOutputLanguages != "" {
+		if err := generateLanguagesSVG(p, profileOutputLanguages, profileSVGTheme); err != nil {
+			return err
+		}
+	}
+
+	// Generate punchcard chart if requested
+	if profileOutputPunchcard != "" {
+		if err := generatePunchcard(p, profileOutputPunchcard, profileSVGTheme); err != nil {
+			return err
+		}
+	}
+
+	// Generate calendar SVG if requested
+	if profileOutputCalendar != "" {
+		if err := generateCalendarSVG(p, profileOutputCalendar, profileSVGTheme); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeReposSpreadsheet writes the profile's repository stats as an xlsx or
+// csv file.
+func writeReposSpreadsheet(p *profile.UserProfile, format, outputPath string) error {
+	var err error
+	if format == "xlsx" {
+		err = p.WriteReposXLSX(outputPath)
+	} else {
+		err = p.WriteReposCSV(outputPath)
+	}
+	if err != nil {
+		return fmt.Errorf("write repo stats: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %s\n", outputPath)
 	return nil
 }
 
@@ -469,6 +689,9 @@ type RawJSON struct {
 
 	// Calendar data
 	Calendar *CalendarDataJSON `json:"calendar,omitempty"`
+
+	// Commits by weekday and hour
+	Punchcard *PunchcardJSON `json:"punchcard,omitempty"`
 }
 
 // AggregateJSON is the summarized output structure.
@@ -523,6 +746,12 @@ type CalendarDay struct {
 	Level             int    `json:"level"`
 }
 
+type PunchcardJSON struct {
+	Timezone string     `json:"timezone"`
+	Counts   [7][24]int `json:"counts"` // by weekday, Sunday first, and hour
+	Total    int        `json:"total"`
+}
+
 type CalendarStatsJSON struct {
 	TotalContributions    int `json:"totalContributions"`
 	DaysWithContributions int `json:"daysWithContributions"`
@@ -551,11 +780,13 @@ type MonthlyJSON struct {
 }
 
 type RepoJSON struct {
-	FullName  string `json:"fullName"`
-	IsPrivate bool   `json:"isPrivate"`
-	Commits   int    `json:"commits"`
-	Additions int    `json:"additions"`
-	Deletions int    `json:"deletions"`
+	FullName  string         `json:"fullName"`
+	URL       string         `json:"url,omitempty"`
+	IsPrivate bool           `json:"isPrivate"`
+	Commits   int            `json:"commits"`
+	Additions int            `json:"additions"`
+	Deletions int            `json:"deletions"`
+	Languages map[string]int `json:"languages,omitempty"`
 }
 
 // MonthlyOutputJSON is the output structure for --output-monthly.
@@ -710,10 +941,12 @@ func profileToRaw(p *profile.UserProfile) *RawJSON {
 	for _, r := range p.RepoStats {
 		raw.Repos = append(raw.Repos, RepoJSON{
 			FullName:  r.FullName,
+			URL:       r.URL,
 			IsPrivate: r.IsPrivate,
 			Commits:   r.Commits,
 			Additions: r.Additions,
 			Deletions: r.Deletions,
+			Languages: r.Languages,
 		})
 	}
 
@@ -767,6 +1000,15 @@ func profileToRaw(p *profile.UserProfile) *RawJSON {
 		}
 	}
 
+	// Punchcard
+	if p.Punchcard != nil {
+		raw.Punchcard = &PunchcardJSON{
+			Timezone: p.Punchcard.Timezone,
+			Counts:   p.Punchcard.Counts,
+			Total:    p.Punchcard.Total,
+		}
+	}
+
 	return raw
 }
 
@@ -941,6 +1183,9 @@ func generateReadme(p *profile.UserProfile, outputPath, configPath string) error
 	}
 
 	fmt.Fprintf(os.Stderr, "Wrote %s\n", outputPath)
+	if fp := readme.CalendarSVGPath(p, cfg, outputPath); fp != "" {
+		fmt.Fprintf(os.Stderr, "Wrote %s\n", fp)
+	}
 	return nil
 }
 
@@ -983,6 +1228,94 @@ func generateChartSVG(p *profile.UserProfile, outputPath, themeName, title strin
 	return nil
 }
 
+func generateLanguagesSVG(p *profile.UserProfile, outputPath, themeName string) error {
+	if len(p.Languages) == 0 {
+		return fmt.Errorf("no language data for --output-languages: use --include-languages")
+	}
+
+	svgContent := svg.LanguagesChartSVG(p, themeName, "")
+
+	if err := os.WriteFile(outputPath, []byte(svgContent), 0600); err != nil {
+		return fmt.Errorf("write languages SVG file: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Wrote %s\n", outputPath)
+	return nil
+}
+
+// generateCalendarSVG creates a contribution calendar SVG from profile data.
+func generateCalendarSVG(p *profile.UserProfile, outputPath, themeName string) error {
+	if p.Calendar == nil {
+		return fmt.Errorf("no calendar data for --output-calendar: use --input with a calendar, or fetch from the API")
+	}
+
+	svgContent := svg.GenerateCalendarSVG(p, themeName, "")
+
+	if err := os.WriteFile(outputPath, []byte(svgContent), 0600); err != nil {
+		return fmt.Errorf("write calendar SVG file: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Wrote %s\n", outputPath)
+	return nil
+}
+
+// generatePunchcard writes the profile's punchcard as a chart SVG or, for
+// a .json path, as chart JSON IR.
+func generatePunchcard(p *profile.UserProfile, outputPath, themeName string) error {
+	if p.Punchcard == nil {
+		return fmt.Errorf("no punchcard data for --output-punchcard: fetch from the API, or use --input with a punchcard")
+	}
+
+	var content []byte
+	if strings.HasSuffix(strings.ToLower(outputPath), ".json") {
+		data, err := svg.PunchcardChartJSON(p, themeName, "")
+		if err != nil {
+			return fmt.Errorf("marshal punchcard chart: %w", err)
+		}
+		content = append(data, '\n')
+	} else {
+		content = []byte(svg.PunchcardChartSVG(p, themeName, ""))
+	}
+
+	if err := os.WriteFile(outputPath, content, 0600); err != nil {
+		return fmt.Errorf("write punchcard file: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Wrote %s\n", outputPath)
+	return nil
+}
+
+// generateCommitTypes classifies the user's commits and writes the
+// result as JSON or, for a .svg path, as a commit types chart.
+func generateCommitTypes(ctx context.Context, client clientv1.Client, from, to time.Time, opts *profile.Options, outputPath string) error {
+	fmt.Fprintf(os.Stderr, "Fetching commit messages...\n")
+
+	typesOpts := *opts
+	typesOpts.Progress = nil
+	data, err := profile.GetCommitTypes(ctx, client, profileUser, from, to, &typesOpts)
+	if err != nil {
+		return fmt.Errorf("get commit types: %w", err)
+	}
+
+	var content []byte
+	if strings.HasSuffix(strings.ToLower(outputPath), ".svg") {
+		content = []byte(svg.CommitTypesByMonthChartSVG(data, profileSVGTheme, profileSVGTitle))
+	} else {
+		content, err = json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal commit types: %w", err)
+		}
+		content = append(content, '\n')
+	}
+
+	if err := os.WriteFile(outputPath, content, 0600); err != nil {
+		return fmt.Errorf("write commit types file: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Wrote %s\n", outputPath)
+	return nil
+}
+
 // rawToProfile converts RawJSON back to a UserProfile for README generation.
 func rawToProfile(raw *RawJSON) *profile.UserProfile {
 	p := &profile.UserProfile{
@@ -1005,10 +1338,12 @@ func rawToProfile(raw *RawJSON) *profile.UserProfile {
 	for _, r := range raw.Repos {
 		p.RepoStats = append(p.RepoStats, profile.RepoContribution{
 			FullName:  r.FullName,
+			URL:       r.URL,
 			IsPrivate: r.IsPrivate,
 			Commits:   r.Commits,
 			Additions: r.Additions,
 			Deletions: r.Deletions,
+			Languages: r.Languages,
 		})
 	}
 
@@ -1032,6 +1367,15 @@ func rawToProfile(raw *RawJSON) *profile.UserProfile {
 		p.Calendar = profile.NewCalendarFromDays(days)
 	}
 
+	// Convert punchcard
+	if raw.Punchcard != nil {
+		p.Punchcard = &profile.Punchcard{
+			Timezone: raw.Punchcard.Timezone,
+			Counts:   raw.Punchcard.Counts,
+			Total:    raw.Punchcard.Total,
+		}
+	}
+
 	// Convert monthly data to Activity
 	if len(raw.Monthly) > 0 {
 		p.Activity = &profile.ActivityTimeline{
@@ -1097,6 +1441,13 @@ func formatSummary(p *profile.UserProfile) string {
 		sb.WriteString("\n")
 	}
 
+	// Busiest time
+	if p.Punchcard != nil && p.Punchcard.Total > 0 {
+		day, hour, count := p.Punchcard.Peak()
+		sb.WriteString(fmt.Sprintf("Busiest Hour: %s %02d:00 %s (%d commits)\n", day, hour, p.Punchcard.Timezone, count))
+		sb.WriteString("\n")
+	}
+
 	// Top repos
 	if len(p.RepoStats) > 0 {
 		sb.WriteString("Top Repositories by Commits:\n")
@@ -1129,6 +1480,48 @@ func formatSummary(p *profile.UserProfile) string {
 	return sb.String()
 }
 
+func formatTeamSummary(tp *profile.TeamProfile) string {
+	var sb strings.Builder
+
+	sb.WriteString(formatSummary(tp.Combined))
+	sb.WriteString(fmt.Sprintf("Members (%d):\n", len(tp.Members)))
+	for _, m := range tp.Members {
+		sb.WriteString(fmt.Sprintf("  %s\n", m.Summary()))
+	}
+
+	return sb.String()
+}
+
+func formatEstimate(e *profile.Estimate) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("=== Estimate: %s ===\n", e.Username))
+	sb.WriteString(fmt.Sprintf("Period: %s to %s\n\n", e.From.Format("2006-01-02"), e.To.Format("2006-01-02")))
+
+	sb.WriteString("Discovery:\n")
+	sb.WriteString(fmt.Sprintf("  Repositories:         %d\n", e.Repos))
+	sb.WriteString(fmt.Sprintf("  Release repositories: %d\n", e.ReleaseRepos))
+	sb.WriteString(fmt.Sprintf("  Months:               %d (%d cached)\n", e.Months, e.CachedMonths))
+	sb.WriteString("\n")
+
+	sb.WriteString("Projected cost (lower bound):\n")
+	sb.WriteString(fmt.Sprintf("  GraphQL queries: %d\n", e.GraphQLQueries))
+	sb.WriteString(fmt.Sprintf("  GraphQL points:  at least %d of %d remaining (limit %d, resets %s)\n",
+		e.GraphQLPoints, e.GraphQLRate.Remaining, e.GraphQLRate.Limit, e.GraphQLRate.ResetAt.Local().Format(time.Kitchen)))
+	sb.WriteString(fmt.Sprintf("  REST requests:   %d of %d remaining (limit %d, resets %s)\n",
+		e.RESTRequests, e.RESTRate.Remaining, e.RESTRate.Limit, e.RESTRate.Reset.Local().Format(time.Kitchen)))
+	sb.WriteString(fmt.Sprintf("  Wall time:       ~%s\n", e.WallTime.Round(time.Second)))
+	sb.WriteString("\n")
+
+	if e.Fits() {
+		sb.WriteString("Fits in the current rate limits.\n")
+	} else {
+		sb.WriteString("Exceeds the current rate limits; use --cache-dir to resume after they reset.\n")
+	}
+
+	return sb.String()
+}
+
 // parseCommaSeparated splits a comma-separated string into a slice.
 // Returns nil for empty input.
 func parseCommaSeparated(s string) []string {
@@ -1150,6 +1543,30 @@ func parseCommaSeparated(s string) []string {
 }
 
 // parseVisibility converts a visibility string to graphql.Visibility.
+func parseLanguageWeight(s string) (profile.LanguageWeighting, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "commits", "":
+		return profile.WeightByCommits, nil
+	case "additions":
+		return profile.WeightByAdditions, nil
+	default:
+		return profile.WeightByCommits, fmt.Errorf("invalid language weight %q: use 'commits' or 'additions'", s)
+	}
+}
+
+// paginateOptions returns the pagination budget set by the --max-cost,
+// --min-remaining and --no-wait flags, or nil if none is set.
+func paginateOptions() *graphql.PaginateOptions {
+	if profileMaxCost <= 0 && profileMinRemaining <= 0 {
+		return nil
+	}
+	return &graphql.PaginateOptions{
+		MaxCost:      profileMaxCost,
+		MinRemaining: profileMinRemaining,
+		NoWait:       profileNoWait,
+	}
+}
+
 func parseVisibility(s string) (graphql.Visibility, error) {
 	switch strings.ToLower(strings.TrimSpace(s)) {
 	case "all", "":
@@ -15,6 +15,7 @@ import (
 var (
 	searchAccounts []string
 	searchOutfile  string
+	searchFormat   string
 )
 
 var searchPRsCmd = &cobra.Command{
@@ -23,7 +24,8 @@ var searchPRsCmd = &cobra.Command{
 	Long: `Search for open pull requests across GitHub for specified users.
 
 By default, results are displayed as an ASCII table to stdout.
-Use -o/--outfile to write to a file (format auto-detected from extension).
+Use -o/--outfile to write to a file (format auto-detected from extension,
+or set with --format).
 
 Supported formats:
   .xlsx  Excel spreadsheet
@@ -33,13 +35,15 @@ Supported formats:
 Examples:
   gogithub search-prs -a grokify                    # ASCII table to stdout
   gogithub search-prs -a grokify -o prs.xlsx        # Excel file
-  gogithub search-prs -a grokify,octocat -o prs.md  # Markdown file`,
+  gogithub search-prs -a grokify,octocat -o prs.md  # Markdown file
+  gogithub search-prs -a grokify --format csv -o prs.txt  # CSV file`,
 	RunE: runSearchPRs,
 }
 
 func init() {
 	searchPRsCmd.Flags().StringSliceVarP(&searchAccounts, "accounts", "a", nil, "GitHub accounts to search (required)")
 	searchPRsCmd.Flags().StringVarP(&searchOutfile, "outfile", "o", "", "Output file (format from extension: .xlsx, .md, .csv)")
+	searchPRsCmd.Flags().StringVar(&searchFormat, "format", "", "Output file format: xlsx, csv, md (default from the extension)")
 	_ = searchPRsCmd.MarkFlagRequired("accounts")
 }
 
@@ -50,6 +54,9 @@ func runSearchPRs(cmd *cobra.Command, args []string) error {
 	}
 
 	searchOutfile = strings.TrimSpace(searchOutfile)
+	if searchFormat != "" && searchOutfile == "" {
+		return fmt.Errorf("--format %s requires --outfile", searchFormat)
+	}
 
 	// Only print status messages when writing to file (not stdout)
 	if searchOutfile != "" {
@@ -83,18 +90,17 @@ func runSearchPRs(cmd *cobra.Command, args []string) error {
 		return tbl.Text(os.Stdout)
 	}
 
-	// Write to file based on extension
-	ext := strings.ToLower(filepath.Ext(searchOutfile))
-	switch ext {
-	case ".xlsx":
-		ts, err := ii.TableSet()
-		if err != nil {
-			return fmt.Errorf("create table: %w", err)
-		}
-		if err := ts.WriteXLSX(searchOutfile); err != nil {
+	// Write to file based on format, or else extension
+	format := strings.ToLower(searchFormat)
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(searchOutfile)), ".")
+	}
+	switch format {
+	case "xlsx":
+		if err := ii.WriteXLSX(searchOutfile); err != nil {
 			return fmt.Errorf("write xlsx: %w", err)
 		}
-	case ".md":
+	case "md":
 		tbl, err := ii.Table("Pull Requests")
 		if err != nil {
 			return fmt.Errorf("create table: %w", err)
@@ -102,16 +108,12 @@ func runSearchPRs(cmd *cobra.Command, args []string) error {
 		if err := tbl.WriteMarkdown(searchOutfile, 0644, "\n", true); err != nil {
 			return fmt.Errorf("write markdown: %w", err)
 		}
-	case ".csv":
-		tbl, err := ii.Table("Pull Requests")
-		if err != nil {
-			return fmt.Errorf("create table: %w", err)
-		}
-		if err := tbl.WriteCSV(searchOutfile); err != nil {
+	case "csv":
+		if err := ii.WriteCSV(searchOutfile); err != nil {
 			return fmt.Errorf("write csv: %w", err)
 		}
 	default:
-		return fmt.Errorf("unsupported file extension %q (use .xlsx, .md, or .csv)", ext)
+		return fmt.Errorf("unsupported format %q (use xlsx, md, or csv)", format)
 	}
 
 	fmt.Fprintf(os.Stderr, "Wrote %s\n", searchOutfile)
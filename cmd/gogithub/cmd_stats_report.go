@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/grokify/gogithub/profile"
 	"github.com/spf13/cobra"
@@ -18,6 +20,9 @@ var (
 	statsReportShowDetails   bool
 	statsReportDataURL       string
 	statsReportRegenerateCmd string
+	statsReportCompare       []string
+	statsReportFormat        string
+	statsReportOutput        string
 )
 
 var statsReportCmd = &cobra.Command{
@@ -36,6 +41,8 @@ The report can be output in multiple formats:
   - Markdown: Human-readable documentation format
   - HTML: Web-ready report with styling
   - Text: Plain text for terminal display
+  - XLSX: Spreadsheet with a sheet per year (--format xlsx)
+  - CSV: Spreadsheet rows for every year (--format csv)
 
 Examples:
   # Generate JSON report from monthly files
@@ -50,12 +57,26 @@ Examples:
     --output-md README.md \
     --output-html report.html
 
+  # Generate a spreadsheet with a sheet per year, or CSV
+  gogithub stats-report --input-dir ./stats/ --format xlsx --output report.xlsx
+  gogithub stats-report --input-dir ./stats/ --format csv --output report.csv
+
   # Customize the report
   gogithub stats-report --input-dir ./stats/ \
     --output-md README.md \
     --title "My GitHub Stats" \
     --show-details=false
 
+  # Compare the latest quarter with the previous quarter and a year earlier
+  gogithub stats-report --input-dir ./stats/ \
+    --output-md README.md \
+    --compare qoq --compare yoy
+
+  # Compare any two periods, quarters or years (CURRENT:PREVIOUS)
+  gogithub stats-report --input-dir ./stats/ \
+    --output-md README.md \
+    --compare 2025-Q3:2024-Q3 --compare 2025:2024
+
   # Include regeneration command in output
   gogithub stats-report --input-dir ./stats/ \
     --output-md README.md \
@@ -77,6 +98,9 @@ func init() {
 	statsReportCmd.Flags().BoolVar(&statsReportShowDetails, "show-details", true, "Include monthly detail sections")
 	statsReportCmd.Flags().StringVar(&statsReportDataURL, "data-url", "https://github.com/grokify/gogithub", "URL for data source attribution")
 	statsReportCmd.Flags().StringVar(&statsReportRegenerateCmd, "regenerate-cmd", "", "Command to regenerate the data (shown in output)")
+	statsReportCmd.Flags().StringVar(&statsReportFormat, "format", "", "Spreadsheet format of --output: xlsx, csv (default from the extension)")
+	statsReportCmd.Flags().StringVarP(&statsReportOutput, "output", "o", "", "Output spreadsheet file (see --format)")
+	statsReportCmd.Flags().StringSliceVar(&statsReportCompare, "compare", nil, "Period comparisons to include: qoq, yoy (latest quarter), or CURRENT:PREVIOUS, e.g. 2025-Q3:2024-Q3 (repeatable)")
 
 	if err := statsReportCmd.MarkFlagRequired("input-dir"); err != nil {
 		panic(err)
@@ -94,8 +118,23 @@ func runStatsReport(cmd *cobra.Command, args []string) error {
 	}
 
 	// Check that at least one output is specified
-	if statsReportOutputJSON == "" && statsReportOutputMD == "" && statsReportOutputHTML == "" && statsReportOutputText == "" {
-		return fmt.Errorf("at least one output format is required: --output-json, --output-md, --output-html, or --output-text")
+	if statsReportOutputJSON == "" && statsReportOutputMD == "" && statsReportOutputHTML == "" && statsReportOutputText == "" && statsReportOutput == "" {
+		return fmt.Errorf("at least one output format is required: --output-json, --output-md, --output-html, --output-text, or --output")
+	}
+
+	// Resolve the spreadsheet format
+	format := strings.ToLower(statsReportFormat)
+	if statsReportOutput == "" {
+		if format != "" {
+			return fmt.Errorf("--format %s requires --output", statsReportFormat)
+		}
+	} else {
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(statsReportOutput)), ".")
+		}
+		if format != "xlsx" && format != "csv" {
+			return fmt.Errorf("unsupported format %q for %s (use --format xlsx or csv)", format, statsReportOutput)
+		}
 	}
 
 	// Load monthly files
@@ -112,6 +151,15 @@ func runStatsReport(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("build stats report: %w", err)
 	}
 
+	// Add period comparisons
+	for _, spec := range statsReportCompare {
+		c, err := buildComparison(report, spec)
+		if err != nil {
+			return fmt.Errorf("compare %s: %w", spec, err)
+		}
+		report.Comparisons = append(report.Comparisons, *c)
+	}
+
 	// Build raw data file list for markdown
 	var rawDataFiles []string
 	for _, f := range files {
@@ -165,6 +213,19 @@ func runStatsReport(cmd *cobra.Command, args []string) error {
 		fmt.Fprintf(os.Stderr, "Wrote %s\n", statsReportOutputText)
 	}
 
+	if statsReportOutput != "" {
+		var err error
+		if format == "xlsx" {
+			err = report.WriteXLSX(statsReportOutput)
+		} else {
+			err = report.WriteCSV(statsReportOutput)
+		}
+		if err != nil {
+			return fmt.Errorf("write %s report: %w", format, err)
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %s\n", statsReportOutput)
+	}
+
 	// Print summary
 	total := report.TotalStats()
 	fmt.Fprintf(os.Stderr, "\nReport Summary:\n")
@@ -184,6 +245,32 @@ func runStatsReport(cmd *cobra.Command, args []string) error {
 	fmt.Fprintf(os.Stderr, "  Total commits: %d\n", total.Commits)
 	fmt.Fprintf(os.Stderr, "  Total releases: %d\n", total.Releases)
 	fmt.Fprintf(os.Stderr, "  Net additions: %+d\n", total.NetAdditions)
+	for _, c := range report.Comparisons {
+		commits := c.Deltas.Commits
+		fmt.Fprintf(os.Stderr, "  %s commits: %d vs %d (%+d)\n", c.Title(), commits.Current, commits.Previous, commits.Change)
+	}
 
 	return nil
 }
+
+// buildComparison returns the comparison for a --compare value: qoq or yoy
+// for the latest quarter, or two periods as CURRENT:PREVIOUS.
+func buildComparison(report *profile.StatsReport, spec string) (*profile.PeriodComparison, error) {
+	switch strings.ToLower(spec) {
+	case string(profile.ComparisonQoQ), string(profile.ComparisonYoY):
+		latest := report.GetLatestQuarter()
+		if latest == nil {
+			return nil, fmt.Errorf("report has no quarters")
+		}
+		if strings.EqualFold(spec, string(profile.ComparisonQoQ)) {
+			return report.CompareQoQ(latest.Year, latest.Quarter)
+		}
+		return report.CompareYoY(latest.Year, latest.Quarter)
+	}
+
+	current, previous, ok := strings.Cut(spec, ":")
+	if !ok {
+		return nil, fmt.Errorf("want qoq, yoy or CURRENT:PREVIOUS")
+	}
+	return report.ComparePeriods(current, previous)
+}
@@ -4,6 +4,7 @@ package main
 import (
 	"fmt"
 	"os"
+	_ "time/tzdata" // for --timezone where the system has no time zone database
 
 	"github.com/spf13/cobra"
 )
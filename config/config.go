@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/go-github/v89/github"
 	"github.com/grokify/gogithub/clientv1"
+	"github.com/shurcooL/githubv4"
 	"golang.org/x/oauth2"
 )
 
@@ -295,3 +296,15 @@ func (c *Config) MustNewClientV1(ctx context.Context) clientv1.Client {
 
 	return client
 }
+
+// NewGraphQLClient creates a GitHub GraphQL client from the configuration.
+// It shares the transport of the client created by NewClientV1, and its
+// endpoint is derived from BaseURL (see clientv1.NewGraphQLClient).
+// The config must be validated before calling this function.
+func (c *Config) NewGraphQLClient(ctx context.Context) (*githubv4.Client, error) {
+	client, err := c.NewClientV1(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return clientv1.NewGraphQLClient(client)
+}
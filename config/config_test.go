@@ -335,6 +335,24 @@ func TestNewClientEnterprise(t *testing.T) {
 	}
 }
 
+func TestNewGraphQLClientEnterprise(t *testing.T) {
+	cfg := Config{
+		Owner:     "owner",
+		Repo:      "repo",
+		Token:     "token",
+		BaseURL:   "https://enterprise.example.com/api/v3/",
+		UploadURL: "https://enterprise.example.com/uploads/",
+	}
+
+	client, err := cfg.NewGraphQLClient(context.Background())
+	if err != nil {
+		t.Fatalf("NewGraphQLClient() error = %v", err)
+	}
+	if client == nil {
+		t.Error("NewGraphQLClient() returned nil client")
+	}
+}
+
 func TestMustNewClientPanicsOnInvalidConfig(t *testing.T) {
 	cfg := Config{} // Invalid - missing required fields
 
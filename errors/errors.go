@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/google/go-github/v89/github"
 )
@@ -35,6 +36,12 @@ type APIError struct {
 	StatusCode int
 	Message    string
 	Err        error
+
+	// Cause is the error Translate was given when Err is one of the
+	// standard errors, such as a *github.RateLimitError whose Rate.Reset
+	// says when to retry. errors.As and errors.Is look through it as well
+	// as Err.
+	Cause error
 }
 
 func (e *APIError) Error() string {
@@ -53,79 +60,113 @@ func (e *APIError) Is(target error) bool {
 	if t, ok := target.(*APIError); ok {
 		return e.StatusCode == t.StatusCode
 	}
-	return errors.Is(e.Err, target)
+	return errors.Is(e.Err, target) || (e.Cause != nil && errors.Is(e.Cause, target))
+}
+
+// As finds the first error in Cause's chain that matches target, so the
+// original go-github error stays reachable after translation.
+func (e *APIError) As(target any) bool {
+	return e.Cause != nil && errors.As(e.Cause, target)
 }
 
 // Translate converts a GitHub API error to a standard error.
 // It examines both the response status code and the error type
 // to return an appropriate standard error.
 //
-// The returned error wraps the original error, so the caller can
-// use errors.Unwrap or errors.Is/As to access the original.
+// The message GitHub returned, including any field-level validation
+// details, is kept in APIError.Message. Errors that are already an
+// *APIError are returned unchanged, so Translate is safe to apply more
+// than once.
+//
+// Rate limit errors keep the status GitHub sent, 403 or 429, and the
+// go-github *RateLimitError or *AbuseRateLimitError remains reachable with
+// errors.As for its Rate.Reset or RetryAfter.
 func Translate(err error, resp *github.Response) error {
 	if err == nil {
 		return nil
 	}
 
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return err
+	}
+
 	statusCode := 0
-	if resp != nil {
+	if resp != nil && resp.Response != nil {
 		statusCode = resp.StatusCode
 	}
 
-	// Check response status code first
-	if statusCode != 0 {
-		switch statusCode {
-		case http.StatusNotFound:
-			return &APIError{StatusCode: statusCode, Err: ErrNotFound}
-		case http.StatusUnauthorized, http.StatusForbidden:
-			return &APIError{StatusCode: statusCode, Err: ErrPermissionDenied}
-		case http.StatusTooManyRequests:
-			return &APIError{StatusCode: statusCode, Err: ErrRateLimited}
-		case http.StatusConflict:
-			return &APIError{StatusCode: statusCode, Err: ErrConflict}
-		case http.StatusUnprocessableEntity:
-			return &APIError{StatusCode: statusCode, Err: ErrValidation}
-		case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable:
-			return &APIError{StatusCode: statusCode, Err: ErrServerError}
-		}
+	// Rate limit errors may arrive with a 403 status, so check them before
+	// the status code.
+	var rateLimitErr *github.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return &APIError{StatusCode: errorStatus(rateLimitErr.Response, statusCode), Message: rateLimitErr.Message, Err: ErrRateLimited, Cause: err}
+	}
+
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		return &APIError{StatusCode: errorStatus(abuseErr.Response, statusCode), Message: abuseErr.Message, Err: ErrRateLimited, Cause: err}
 	}
 
-	// Check error response type
+	message := ""
 	var errResp *github.ErrorResponse
 	if errors.As(err, &errResp) {
-		if errResp.Response != nil {
+		message = errorMessage(errResp)
+		if statusCode == 0 && errResp.Response != nil {
 			statusCode = errResp.Response.StatusCode
-			switch statusCode {
-			case http.StatusNotFound:
-				return &APIError{StatusCode: statusCode, Message: errResp.Message, Err: ErrNotFound}
-			case http.StatusUnauthorized, http.StatusForbidden:
-				return &APIError{StatusCode: statusCode, Message: errResp.Message, Err: ErrPermissionDenied}
-			case http.StatusTooManyRequests:
-				return &APIError{StatusCode: statusCode, Message: errResp.Message, Err: ErrRateLimited}
-			case http.StatusConflict:
-				return &APIError{StatusCode: statusCode, Message: errResp.Message, Err: ErrConflict}
-			case http.StatusUnprocessableEntity:
-				return &APIError{StatusCode: statusCode, Message: errResp.Message, Err: ErrValidation}
-			case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable:
-				return &APIError{StatusCode: statusCode, Message: errResp.Message, Err: ErrServerError}
-			}
 		}
 	}
 
-	// Check for rate limit error
-	var rateLimitErr *github.RateLimitError
-	if errors.As(err, &rateLimitErr) {
-		return &APIError{StatusCode: http.StatusTooManyRequests, Err: ErrRateLimited}
+	switch statusCode {
+	case http.StatusNotFound:
+		return &APIError{StatusCode: statusCode, Message: message, Err: ErrNotFound, Cause: err}
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return &APIError{StatusCode: statusCode, Message: message, Err: ErrPermissionDenied, Cause: err}
+	case http.StatusTooManyRequests:
+		return &APIError{StatusCode: statusCode, Message: message, Err: ErrRateLimited, Cause: err}
+	case http.StatusConflict:
+		return &APIError{StatusCode: statusCode, Message: message, Err: ErrConflict, Cause: err}
+	case http.StatusUnprocessableEntity:
+		return &APIError{StatusCode: statusCode, Message: message, Err: ErrValidation, Cause: err}
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable:
+		return &APIError{StatusCode: statusCode, Message: message, Err: ErrServerError, Cause: err}
 	}
 
-	// Check for abuse rate limit error
-	var abuseErr *github.AbuseRateLimitError
-	if errors.As(err, &abuseErr) {
-		return &APIError{StatusCode: http.StatusTooManyRequests, Err: ErrRateLimited}
+	// Return wrapped original error if no specific translation
+	return &APIError{StatusCode: statusCode, Message: message, Err: err}
+}
+
+// errorStatus returns the status code of r, the response a go-github
+// error was built from, or fallback if it has none.
+func errorStatus(r *http.Response, fallback int) int {
+	if r != nil {
+		return r.StatusCode
 	}
+	return fallback
+}
 
-	// Return wrapped original error if no specific translation
-	return &APIError{StatusCode: statusCode, Err: err}
+// errorMessage returns the message of a GitHub error response followed by
+// its field-level errors, e.g. "Validation Failed: A pull request already
+// exists for octocat:fix.".
+func errorMessage(errResp *github.ErrorResponse) string {
+	var details []string
+	for _, e := range errResp.Errors {
+		switch {
+		case e.Message != "":
+			details = append(details, e.Message)
+		case e.Field != "":
+			details = append(details, strings.TrimSpace(e.Resource+" "+e.Field+" "+e.Code))
+		case e.Code != "":
+			details = append(details, strings.TrimSpace(e.Resource+" "+e.Code))
+		}
+	}
+	if len(details) == 0 {
+		return errResp.Message
+	}
+	if errResp.Message == "" {
+		return strings.Join(details, "; ")
+	}
+	return errResp.Message + ": " + strings.Join(details, "; ")
 }
 
 // IsNotFound returns true if the error indicates a not found condition.
@@ -139,20 +180,23 @@ func IsPermissionDenied(err error) bool {
 }
 
 // IsRateLimited returns true if the error indicates rate limiting.
-// This only matches errors already translated by Translate; for a raw error
-// returned directly from a clientv1 call, use IsRateLimitError instead.
+// This matches errors translated by Translate, which clientv1 applies to
+// every error it returns.
 func IsRateLimited(err error) bool {
 	return errors.Is(err, ErrRateLimited)
 }
 
 // IsRateLimitError returns true if err is (or wraps) a go-github
-// *RateLimitError or *AbuseRateLimitError. Unlike IsRateLimited, this
-// matches raw errors returned directly from a clientv1 call, without
-// requiring Translate to be called first.
+// *RateLimitError or *AbuseRateLimitError, or is a rate limit error
+// produced by Translate. Unlike IsRateLimited, this also matches raw
+// go-github errors that have not been translated.
 func IsRateLimitError(err error) bool {
 	if err == nil {
 		return false
 	}
+	if errors.Is(err, ErrRateLimited) {
+		return true
+	}
 	var rateLimitErr *github.RateLimitError
 	if errors.As(err, &rateLimitErr) {
 		return true
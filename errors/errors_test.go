@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/go-github/v89/github"
 )
@@ -81,6 +82,165 @@ func TestIsRateLimitError(t *testing.T) {
 	}
 }
 
+func TestTranslateRateLimitForbidden(t *testing.T) {
+	// GitHub reports an exhausted primary rate limit as 403.
+	resp := &github.Response{Response: &http.Response{StatusCode: http.StatusForbidden}}
+	err := Translate(&github.RateLimitError{Message: "API rate limit exceeded"}, resp)
+
+	if !IsRateLimited(err) {
+		t.Error("expected IsRateLimited to return true")
+	}
+	if IsPermissionDenied(err) {
+		t.Error("expected IsPermissionDenied to return false")
+	}
+	if !IsRateLimitError(err) {
+		t.Error("expected IsRateLimitError to match a translated error")
+	}
+}
+
+func TestTranslateRateLimitErrors(t *testing.T) {
+	reset := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	retryAfter := 30 * time.Second
+	response := func(code int) *http.Response {
+		return &http.Response{StatusCode: code}
+	}
+	tests := []struct {
+		name       string
+		err        error
+		statusCode int
+	}{
+		{"primary 403", &github.RateLimitError{
+			Rate:     github.Rate{Limit: 5000, Reset: github.Timestamp{Time: reset}},
+			Response: response(http.StatusForbidden),
+			Message:  "API rate limit exceeded",
+		}, http.StatusForbidden},
+		{"primary 429", &github.RateLimitError{
+			Rate:     github.Rate{Limit: 5000, Reset: github.Timestamp{Time: reset}},
+			Response: response(http.StatusTooManyRequests),
+			Message:  "API rate limit exceeded",
+		}, http.StatusTooManyRequests},
+		{"secondary 403", &github.AbuseRateLimitError{
+			Response:   response(http.StatusForbidden),
+			Message:    "You have exceeded a secondary rate limit",
+			RetryAfter: &retryAfter,
+		}, http.StatusForbidden},
+		{"secondary 429", &github.AbuseRateLimitError{
+			Response:   response(http.StatusTooManyRequests),
+			Message:    "You have exceeded a secondary rate limit",
+			RetryAfter: &retryAfter,
+		}, http.StatusTooManyRequests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Translate(fmt.Errorf("list repos: %w", tt.err), &github.Response{Response: response(tt.statusCode)})
+
+			if !IsRateLimited(err) {
+				t.Error("expected IsRateLimited to return true")
+			}
+			if IsPermissionDenied(err) {
+				t.Error("expected IsPermissionDenied to return false")
+			}
+			if got := StatusCode(err); got != tt.statusCode {
+				t.Errorf("StatusCode() = %d, want %d", got, tt.statusCode)
+			}
+			switch tt.err.(type) {
+			case *github.RateLimitError:
+				var rateLimitErr *github.RateLimitError
+				if !errors.As(err, &rateLimitErr) {
+					t.Fatal("expected errors.As to find *github.RateLimitError")
+				}
+				if !rateLimitErr.Rate.Reset.Time.Equal(reset) {
+					t.Errorf("Rate.Reset = %v, want %v", rateLimitErr.Rate.Reset, reset)
+				}
+			case *github.AbuseRateLimitError:
+				var abuseErr *github.AbuseRateLimitError
+				if !errors.As(err, &abuseErr) {
+					t.Fatal("expected errors.As to find *github.AbuseRateLimitError")
+				}
+				if abuseErr.GetRetryAfter() != retryAfter {
+					t.Errorf("RetryAfter = %v, want %v", abuseErr.GetRetryAfter(), retryAfter)
+				}
+			}
+		})
+	}
+}
+
+func TestTranslateKeepsCause(t *testing.T) {
+	errResp := &github.ErrorResponse{
+		Response: &http.Response{StatusCode: http.StatusNotFound},
+		Message:  "Not Found",
+	}
+	err := Translate(errResp, nil)
+
+	if !IsNotFound(err) {
+		t.Error("expected IsNotFound to return true")
+	}
+	var got *github.ErrorResponse
+	if !errors.As(err, &got) || got != errResp {
+		t.Error("expected errors.As to find the original *github.ErrorResponse")
+	}
+}
+
+func TestTranslateMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		errResp *github.ErrorResponse
+		want    string
+	}{
+		{
+			name:    "message only",
+			errResp: &github.ErrorResponse{Message: "Reference already exists"},
+			want:    "Reference already exists",
+		},
+		{
+			name: "field error message",
+			errResp: &github.ErrorResponse{
+				Message: "Validation Failed",
+				Errors:  []github.Error{{Resource: "PullRequest", Code: "custom", Message: "A pull request already exists for octocat:fix."}},
+			},
+			want: "Validation Failed: A pull request already exists for octocat:fix.",
+		},
+		{
+			name: "field error code",
+			errResp: &github.ErrorResponse{
+				Message: "Validation Failed",
+				Errors:  []github.Error{{Resource: "Release", Field: "tag_name", Code: "already_exists"}},
+			},
+			want: "Validation Failed: Release tag_name already_exists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &github.Response{Response: &http.Response{StatusCode: http.StatusUnprocessableEntity}}
+			tt.errResp.Response = resp.Response
+			err := Translate(tt.errResp, resp)
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatal("expected APIError")
+			}
+			if apiErr.Message != tt.want {
+				t.Errorf("Message = %q, want %q", apiErr.Message, tt.want)
+			}
+			if !IsValidation(err) {
+				t.Error("expected IsValidation to return true")
+			}
+		})
+	}
+}
+
+func TestTranslateIdempotent(t *testing.T) {
+	resp := &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}
+	first := Translate(errors.New("original"), resp)
+	wrapped := fmt.Errorf("get branch main: %w", first)
+
+	if got := Translate(wrapped, nil); got != wrapped {
+		t.Errorf("Translate(translated) = %v, want %v", got, wrapped)
+	}
+}
+
 func TestTranslateConflict(t *testing.T) {
 	resp := &github.Response{Response: &http.Response{StatusCode: http.StatusConflict}}
 	err := Translate(errors.New("original"), resp)
@@ -0,0 +1,113 @@
+package githubtest
+
+import (
+	"net/http"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/grokify/gogithub/clientv1"
+)
+
+func (s *Server) routeChecks(mux *http.ServeMux) {
+	handle(mux, "GET /repos/{owner}/{repo}/check-runs/{id}", func(w http.ResponseWriter, r *http.Request) error {
+		id, err := pathInt(r, "id")
+		if err != nil {
+			return err
+		}
+		run, err := s.Fake.GetCheckRun(r.Context(), r.PathValue("owner"), r.PathValue("repo"), id)
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, checkRunToGitHub(run))
+		return nil
+	})
+	handle(mux, "GET /repos/{owner}/{repo}/commits/{ref}/check-runs", func(w http.ResponseWriter, r *http.Request) error {
+		runs, err := s.Fake.ListCheckRuns(r.Context(), r.PathValue("owner"), r.PathValue("repo"), r.PathValue("ref"))
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, &github.ListCheckRunsResults{
+			Total:     github.Ptr(len(runs)),
+			CheckRuns: convertAll(pageOf(w, r, runs), checkRunToGitHub),
+		})
+		return nil
+	})
+	handle(mux, "GET /repos/{owner}/{repo}/commits/{ref}/check-suites", func(w http.ResponseWriter, r *http.Request) error {
+		suites, err := s.Fake.ListCheckSuites(r.Context(), r.PathValue("owner"), r.PathValue("repo"), r.PathValue("ref"))
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, &github.ListCheckSuiteResults{
+			Total:       github.Ptr(len(suites)),
+			CheckSuites: convertAll(pageOf(w, r, suites), checkSuiteToGitHub),
+		})
+		return nil
+	})
+	handle(mux, "GET /repos/{owner}/{repo}/actions/workflows", func(w http.ResponseWriter, r *http.Request) error {
+		workflows, err := s.Fake.ListWorkflows(r.Context(), r.PathValue("owner"), r.PathValue("repo"))
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, &github.Workflows{
+			TotalCount: github.Ptr(len(workflows)),
+			Workflows:  convertAll(pageOf(w, r, workflows), workflowToGitHub),
+		})
+		return nil
+	})
+	handle(mux, "GET /repos/{owner}/{repo}/actions/workflows/{id}/runs", func(w http.ResponseWriter, r *http.Request) error {
+		id, err := pathInt(r, "id")
+		if err != nil {
+			return err
+		}
+		runs, err := s.Fake.ListWorkflowRuns(r.Context(), r.PathValue("owner"), r.PathValue("repo"), id, &clientv1.ListWorkflowRunsOptions{
+			Branch:  r.URL.Query().Get("branch"),
+			Status:  r.URL.Query().Get("status"),
+			PerPage: allItems,
+		})
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, &github.WorkflowRuns{
+			TotalCount:   github.Ptr(len(runs)),
+			WorkflowRuns: convertAll(pageOf(w, r, runs), workflowRunToGitHub),
+		})
+		return nil
+	})
+	handle(mux, "POST /repos/{owner}/{repo}/code-scanning/sarifs", func(w http.ResponseWriter, r *http.Request) error {
+		var req github.SarifAnalysis
+		if err := decode(r, &req); err != nil {
+			return err
+		}
+		opts := &clientv1.UploadSARIFOptions{
+			CommitSHA:   req.GetCommitSHA(),
+			Ref:         req.GetRef(),
+			Sarif:       req.GetSarif(),
+			CheckoutURI: req.GetCheckoutURI(),
+			ToolName:    req.GetToolName(),
+		}
+		if req.StartedAt != nil {
+			opts.StartedAt = &req.StartedAt.Time
+		}
+		upload, err := s.Fake.UploadSARIF(r.Context(), r.PathValue("owner"), r.PathValue("repo"), opts)
+		if err != nil {
+			return err
+		}
+		// SARIF uploads are processed asynchronously; GitHub answers 202.
+		writeJSON(w, http.StatusAccepted, &github.SarifID{
+			ID:  github.Ptr(upload.ID),
+			URL: github.Ptr(upload.URL),
+		})
+		return nil
+	})
+	handle(mux, "GET /repos/{owner}/{repo}/code-scanning/sarifs/{id}", func(w http.ResponseWriter, r *http.Request) error {
+		status, err := s.Fake.GetSARIFUpload(r.Context(), r.PathValue("owner"), r.PathValue("repo"), r.PathValue("id"))
+		if err != nil {
+			return err
+		}
+		upload := &github.SARIFUpload{ProcessingStatus: github.Ptr(status.ProcessingStatus)}
+		if status.AnalysesURL != "" {
+			upload.AnalysesURL = github.Ptr(status.AnalysesURL)
+		}
+		writeJSON(w, http.StatusOK, upload)
+		return nil
+	})
+}
@@ -0,0 +1,218 @@
+package githubtest
+
+import (
+	"encoding/base64"
+	"net/http"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/grokify/gogithub/clientv1"
+)
+
+func (s *Server) routeGit(mux *http.ServeMux) {
+	handle(mux, "GET /repos/{owner}/{repo}/git/ref/{ref...}", func(w http.ResponseWriter, r *http.Request) error {
+		ref, err := s.Fake.GetRef(r.Context(), r.PathValue("owner"), r.PathValue("repo"), "refs/"+r.PathValue("ref"))
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, referenceToGitHub(ref))
+		return nil
+	})
+	handle(mux, "POST /repos/{owner}/{repo}/git/refs", s.createRef)
+	handle(mux, "PATCH /repos/{owner}/{repo}/git/refs/{ref...}", func(w http.ResponseWriter, r *http.Request) error {
+		var req github.UpdateRef
+		if err := decode(r, &req); err != nil {
+			return err
+		}
+		ref, err := s.Fake.UpdateRef(r.Context(), r.PathValue("owner"), r.PathValue("repo"), "refs/"+r.PathValue("ref"), req.SHA, req.GetForce())
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, referenceToGitHub(ref))
+		return nil
+	})
+	handle(mux, "DELETE /repos/{owner}/{repo}/git/refs/{ref...}", func(w http.ResponseWriter, r *http.Request) error {
+		if err := s.Fake.DeleteRef(r.Context(), r.PathValue("owner"), r.PathValue("repo"), "refs/"+r.PathValue("ref")); err != nil {
+			return err
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	})
+	handle(mux, "POST /repos/{owner}/{repo}/git/tags", s.createTag)
+	handle(mux, "GET /repos/{owner}/{repo}/git/commits/{sha}", func(w http.ResponseWriter, r *http.Request) error {
+		commit, err := s.Fake.GetGitCommit(r.Context(), r.PathValue("owner"), r.PathValue("repo"), r.PathValue("sha"))
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, gitCommitToGitHub(commit))
+		return nil
+	})
+	handle(mux, "POST /repos/{owner}/{repo}/git/commits", func(w http.ResponseWriter, r *http.Request) error {
+		var req struct {
+			Message string               `json:"message"`
+			Tree    string               `json:"tree"`
+			Parents []string             `json:"parents"`
+			Author  *github.CommitAuthor `json:"author"`
+		}
+		if err := decode(r, &req); err != nil {
+			return err
+		}
+		commit, err := s.Fake.CreateCommit(r.Context(), r.PathValue("owner"), r.PathValue("repo"), &clientv1.CreateCommitOptions{
+			Message: req.Message,
+			Tree:    req.Tree,
+			Parents: req.Parents,
+			Author:  commitAuthorFromGitHub(req.Author),
+		})
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusCreated, gitCommitToGitHub(commit))
+		return nil
+	})
+	handle(mux, "GET /repos/{owner}/{repo}/git/trees/{sha}", func(w http.ResponseWriter, r *http.Request) error {
+		sha := r.PathValue("sha")
+		recursive := r.URL.Query().Get("recursive") != ""
+		nodes, err := s.Fake.GetTree(r.Context(), r.PathValue("owner"), r.PathValue("repo"), sha, recursive)
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, &github.Tree{
+			SHA:       github.Ptr(sha),
+			Entries:   convertAll(nodes, treeNodeToGitHub),
+			Truncated: github.Ptr(false),
+		})
+		return nil
+	})
+	handle(mux, "POST /repos/{owner}/{repo}/git/trees", s.createTree)
+	handle(mux, "POST /repos/{owner}/{repo}/git/blobs", func(w http.ResponseWriter, r *http.Request) error {
+		var req github.Blob
+		if err := decode(r, &req); err != nil {
+			return err
+		}
+		sha, err := s.Fake.CreateBlob(r.Context(), r.PathValue("owner"), r.PathValue("repo"), []byte(req.GetContent()), req.GetEncoding())
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusCreated, &github.Blob{SHA: github.Ptr(sha)})
+		return nil
+	})
+	handle(mux, "GET /repos/{owner}/{repo}/git/blobs/{sha}", func(w http.ResponseWriter, r *http.Request) error {
+		sha := r.PathValue("sha")
+		content, err := s.Fake.Blob(r.PathValue("owner"), r.PathValue("repo"), sha)
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, &github.Blob{
+			SHA:      github.Ptr(sha),
+			Content:  github.Ptr(base64.StdEncoding.EncodeToString(content)),
+			Encoding: github.Ptr("base64"),
+			Size:     github.Ptr(len(content)),
+		})
+		return nil
+	})
+}
+
+// createTag creates an annotated tag object. The fake creates the tag
+// object and its reference together, so the reference exists as soon as
+// this returns; the request that GitHub needs to create it afterwards is
+// answered by createRef from tagRefs.
+func (s *Server) createTag(w http.ResponseWriter, r *http.Request) error {
+	var req github.CreateTag
+	if err := decode(r, &req); err != nil {
+		return err
+	}
+	owner, repo := r.PathValue("owner"), r.PathValue("repo")
+	if err := s.Fake.CreateTag(r.Context(), owner, repo, req.Tag, req.Object, req.Message); err != nil {
+		return err
+	}
+	name := "refs/tags/" + req.Tag
+	ref, err := s.Fake.GetRef(r.Context(), owner, repo, name)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.tagRefs[owner+"/"+repo+" "+name] = ref.SHA
+	s.mu.Unlock()
+	writeJSON(w, http.StatusCreated, &github.Tag{
+		Tag:     github.Ptr(req.Tag),
+		SHA:     github.Ptr(ref.SHA),
+		Message: github.Ptr(req.Message),
+		Object:  &github.GitObject{Type: github.Ptr("commit"), SHA: github.Ptr(req.Object)},
+	})
+	return nil
+}
+
+func (s *Server) createRef(w http.ResponseWriter, r *http.Request) error {
+	var req github.CreateRef
+	if err := decode(r, &req); err != nil {
+		return err
+	}
+	owner, repo := r.PathValue("owner"), r.PathValue("repo")
+
+	key := owner + "/" + repo + " " + req.Ref
+	s.mu.Lock()
+	sha, pending := s.tagRefs[key]
+	delete(s.tagRefs, key)
+	s.mu.Unlock()
+	if pending && sha == req.SHA {
+		ref, err := s.Fake.GetRef(r.Context(), owner, repo, req.Ref)
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusCreated, referenceToGitHub(ref))
+		return nil
+	}
+
+	ref, err := s.Fake.CreateRef(r.Context(), owner, repo, req.Ref, req.SHA)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusCreated, referenceToGitHub(ref))
+	return nil
+}
+
+// createTree creates a tree. An entry with neither sha nor content removes
+// its path from the base tree.
+func (s *Server) createTree(w http.ResponseWriter, r *http.Request) error {
+	var req struct {
+		BaseTree string `json:"base_tree"`
+		Tree     []struct {
+			Path    string  `json:"path"`
+			Mode    string  `json:"mode"`
+			Type    string  `json:"type"`
+			SHA     *string `json:"sha"`
+			Content *string `json:"content"`
+		} `json:"tree"`
+	}
+	if err := decode(r, &req); err != nil {
+		return err
+	}
+	entries := make([]clientv1.TreeEntry, len(req.Tree))
+	for i, e := range req.Tree {
+		entries[i] = clientv1.TreeEntry{
+			Path:   e.Path,
+			Mode:   e.Mode,
+			Type:   e.Type,
+			Delete: e.SHA == nil && e.Content == nil,
+		}
+		if e.SHA != nil {
+			entries[i].SHA = *e.SHA
+		}
+		if e.Content != nil {
+			entries[i].Content = *e.Content
+		}
+	}
+	owner, repo := r.PathValue("owner"), r.PathValue("repo")
+	sha, err := s.Fake.CreateTree(r.Context(), owner, repo, req.BaseTree, entries)
+	if err != nil {
+		return err
+	}
+	nodes, err := s.Fake.GetTree(r.Context(), owner, repo, sha, false)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusCreated, &github.Tree{
+		SHA:     github.Ptr(sha),
+		Entries: convertAll(nodes, treeNodeToGitHub),
+	})
+	return nil
+}
@@ -0,0 +1,68 @@
+package githubtest
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grokify/gogithub/clientv1"
+	"golang.org/x/oauth2"
+)
+
+// RecordEnv is the environment variable that, set to a non-empty value
+// together with GITHUB_TOKEN, makes Integration record cassettes.
+const RecordEnv = "GOGITHUB_RECORD"
+
+// CassetteDir is the directory, relative to the package under test, that
+// Integration keeps cassettes in.
+const CassetteDir = "testdata/cassettes"
+
+// replayToken is the token used when replaying; cassettes never contain
+// the real one.
+const replayToken = "replay"
+
+// Integration prepares an integration test against the GitHub API, which
+// uses the cassette CassetteDir/<test name>.json as follows:
+//
+//   - with GITHUB_TOKEN and GOGITHUB_RECORD set, the test runs against
+//     GitHub and its traffic is recorded to the cassette;
+//   - with only GITHUB_TOKEN set, the test runs against GitHub;
+//   - otherwise the cassette is replayed in strict mode, and the test is
+//     skipped if there is none.
+//
+// Clients must take their HTTP client from the returned context, as
+// clientv1.NewClient and graphql.NewClient do. Tests should use now in
+// place of time.Now; it is the recording time when a cassette is in use,
+// so that time-derived requests match the recorded ones.
+func Integration(t testing.TB) (ctx context.Context, token string, now time.Time) {
+	t.Helper()
+	ctx = context.Background()
+	path := filepath.Join(CassetteDir, strings.ReplaceAll(t.Name(), "/", "_")+".json")
+
+	token = os.Getenv("GITHUB_TOKEN")
+	opts := clientv1.CassetteOptions{Path: path, Strict: true}
+	switch {
+	case token != "" && os.Getenv(RecordEnv) != "":
+		opts.Mode = clientv1.CassetteRecord
+	case token != "":
+		return ctx, token, time.Now()
+	default:
+		token = replayToken
+	}
+
+	ct, err := clientv1.NewCassetteTransport(nil, opts)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Skipf("GITHUB_TOKEN not set and no cassette %s, skipping integration test", path)
+	}
+	if err != nil {
+		t.Fatalf("failed to open cassette: %v", err)
+	}
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: ct})
+	return ctx, token, ct.RecordedAt()
+}
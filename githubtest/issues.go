@@ -0,0 +1,130 @@
+package githubtest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/grokify/gogithub/clientv1"
+)
+
+func (s *Server) routeIssues(mux *http.ServeMux) {
+	handle(mux, "GET /repos/{owner}/{repo}/issues", func(w http.ResponseWriter, r *http.Request) error {
+		q := r.URL.Query()
+		opts := &clientv1.ListIssuesOptions{
+			State:     q.Get("state"),
+			Sort:      q.Get("sort"),
+			Direction: q.Get("direction"),
+		}
+		if labels := q.Get("labels"); labels != "" {
+			opts.Labels = strings.Split(labels, ",")
+		}
+		var err error
+		if opts.Since, err = queryTime(r, "since"); err != nil {
+			return err
+		}
+		issues, err := s.Fake.ListIssues(r.Context(), r.PathValue("owner"), r.PathValue("repo"), opts)
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, convertAll(pageOf(w, r, issues), issueToGitHub))
+		return nil
+	})
+	handle(mux, "POST /repos/{owner}/{repo}/issues", func(w http.ResponseWriter, r *http.Request) error {
+		var req github.IssueRequest
+		if err := decode(r, &req); err != nil {
+			return err
+		}
+		issue, err := s.Fake.CreateIssue(r.Context(), r.PathValue("owner"), r.PathValue("repo"), &clientv1.CreateIssueInput{
+			Title:     req.GetTitle(),
+			Body:      req.GetBody(),
+			Labels:    req.GetLabels(),
+			Assignees: req.GetAssignees(),
+			Milestone: req.Milestone,
+		})
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusCreated, issueToGitHub(issue))
+		return nil
+	})
+	handle(mux, "GET /repos/{owner}/{repo}/issues/{number}", func(w http.ResponseWriter, r *http.Request) error {
+		number, err := pathInt(r, "number")
+		if err != nil {
+			return err
+		}
+		issue, err := s.Fake.GetIssue(r.Context(), r.PathValue("owner"), r.PathValue("repo"), int(number))
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, issueToGitHub(issue))
+		return nil
+	})
+	handle(mux, "PATCH /repos/{owner}/{repo}/issues/{number}", func(w http.ResponseWriter, r *http.Request) error {
+		number, err := pathInt(r, "number")
+		if err != nil {
+			return err
+		}
+		var req github.IssueRequest
+		if err := decode(r, &req); err != nil {
+			return err
+		}
+		issue, err := s.Fake.UpdateIssue(r.Context(), r.PathValue("owner"), r.PathValue("repo"), int(number), &clientv1.UpdateIssueInput{
+			Title:     req.Title,
+			Body:      req.Body,
+			State:     req.State,
+			Labels:    req.GetLabels(),
+			Assignees: req.GetAssignees(),
+			Milestone: req.Milestone,
+		})
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, issueToGitHub(issue))
+		return nil
+	})
+	handle(mux, "GET /repos/{owner}/{repo}/issues/{number}/comments", func(w http.ResponseWriter, r *http.Request) error {
+		number, err := pathInt(r, "number")
+		if err != nil {
+			return err
+		}
+		comments, err := s.Fake.ListIssueComments(r.Context(), r.PathValue("owner"), r.PathValue("repo"), int(number))
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, convertAll(pageOf(w, r, comments), issueCommentToGitHub))
+		return nil
+	})
+	handle(mux, "POST /repos/{owner}/{repo}/issues/{number}/comments", func(w http.ResponseWriter, r *http.Request) error {
+		number, err := pathInt(r, "number")
+		if err != nil {
+			return err
+		}
+		var req github.IssueComment
+		if err := decode(r, &req); err != nil {
+			return err
+		}
+		comment, err := s.Fake.CreateIssueComment(r.Context(), r.PathValue("owner"), r.PathValue("repo"), int(number), req.GetBody())
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusCreated, issueCommentToGitHub(comment))
+		return nil
+	})
+	handle(mux, "PATCH /repos/{owner}/{repo}/issues/comments/{id}", func(w http.ResponseWriter, r *http.Request) error {
+		id, err := pathInt(r, "id")
+		if err != nil {
+			return err
+		}
+		var req github.IssueComment
+		if err := decode(r, &req); err != nil {
+			return err
+		}
+		comment, err := s.Fake.EditIssueComment(r.Context(), r.PathValue("owner"), r.PathValue("repo"), id, req.GetBody())
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, issueCommentToGitHub(comment))
+		return nil
+	})
+}
@@ -0,0 +1,235 @@
+package githubtest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/grokify/gogithub/clientv1"
+)
+
+const (
+	mediaTypeDiff  = "application/vnd.github.v3.diff"
+	mediaTypePatch = "application/vnd.github.v3.patch"
+)
+
+func (s *Server) routePulls(mux *http.ServeMux) {
+	handle(mux, "GET /repos/{owner}/{repo}/pulls", func(w http.ResponseWriter, r *http.Request) error {
+		q := r.URL.Query()
+		prs, err := s.Fake.ListPullRequests(r.Context(), r.PathValue("owner"), r.PathValue("repo"), &clientv1.ListPullRequestsOptions{
+			State:     q.Get("state"),
+			Head:      q.Get("head"),
+			Base:      q.Get("base"),
+			Sort:      q.Get("sort"),
+			Direction: q.Get("direction"),
+		})
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, convertAll(pageOf(w, r, prs), pullRequestToGitHub))
+		return nil
+	})
+	handle(mux, "POST /repos/{owner}/{repo}/pulls", func(w http.ResponseWriter, r *http.Request) error {
+		var req github.NewPullRequest
+		if err := decode(r, &req); err != nil {
+			return err
+		}
+		pr, err := s.Fake.CreatePullRequest(r.Context(), r.PathValue("owner"), r.PathValue("repo"), &clientv1.CreatePullRequestInput{
+			Title:               req.GetTitle(),
+			Head:                req.GetHead(),
+			Base:                req.GetBase(),
+			Body:                req.GetBody(),
+			Draft:               req.GetDraft(),
+			MaintainerCanModify: req.GetMaintainerCanModify(),
+		})
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusCreated, pullRequestToGitHub(pr))
+		return nil
+	})
+	handle(mux, "GET /repos/{owner}/{repo}/pulls/{number}", s.getPullRequest)
+	handle(mux, "PATCH /repos/{owner}/{repo}/pulls/{number}", func(w http.ResponseWriter, r *http.Request) error {
+		number, err := pathInt(r, "number")
+		if err != nil {
+			return err
+		}
+		var req struct {
+			Title               *string `json:"title"`
+			Body                *string `json:"body"`
+			State               *string `json:"state"`
+			Base                *string `json:"base"`
+			MaintainerCanModify *bool   `json:"maintainer_can_modify"`
+		}
+		if err := decode(r, &req); err != nil {
+			return err
+		}
+		pr, err := s.Fake.UpdatePullRequest(r.Context(), r.PathValue("owner"), r.PathValue("repo"), int(number), &clientv1.UpdatePullRequestInput{
+			Title:               req.Title,
+			Body:                req.Body,
+			State:               req.State,
+			Base:                req.Base,
+			MaintainerCanModify: req.MaintainerCanModify,
+		})
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, pullRequestToGitHub(pr))
+		return nil
+	})
+	handle(mux, "PUT /repos/{owner}/{repo}/pulls/{number}/merge", func(w http.ResponseWriter, r *http.Request) error {
+		number, err := pathInt(r, "number")
+		if err != nil {
+			return err
+		}
+		var req struct {
+			CommitMessage string `json:"commit_message"`
+			CommitTitle   string `json:"commit_title"`
+			MergeMethod   string `json:"merge_method"`
+			SHA           string `json:"sha"`
+		}
+		if err := decode(r, &req); err != nil {
+			return err
+		}
+		result, err := s.Fake.MergePullRequest(r.Context(), r.PathValue("owner"), r.PathValue("repo"), int(number), &clientv1.MergePullRequestOptions{
+			CommitTitle:   req.CommitTitle,
+			CommitMessage: req.CommitMessage,
+			MergeMethod:   req.MergeMethod,
+			SHA:           req.SHA,
+		})
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, &github.PullRequestMergeResult{
+			SHA:     github.Ptr(result.SHA),
+			Merged:  github.Ptr(result.Merged),
+			Message: github.Ptr(result.Message),
+		})
+		return nil
+	})
+	handle(mux, "GET /repos/{owner}/{repo}/pulls/{number}/files", func(w http.ResponseWriter, r *http.Request) error {
+		number, err := pathInt(r, "number")
+		if err != nil {
+			return err
+		}
+		files, err := s.Fake.ListPullRequestFiles(r.Context(), r.PathValue("owner"), r.PathValue("repo"), int(number))
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, convertAll(pageOf(w, r, files), commitFileToGitHub))
+		return nil
+	})
+	handle(mux, "GET /repos/{owner}/{repo}/pulls/{number}/reviews", func(w http.ResponseWriter, r *http.Request) error {
+		number, err := pathInt(r, "number")
+		if err != nil {
+			return err
+		}
+		reviews, err := s.Fake.ListPullRequestReviews(r.Context(), r.PathValue("owner"), r.PathValue("repo"), int(number))
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, convertAll(pageOf(w, r, reviews), pullRequestReviewToGitHub))
+		return nil
+	})
+	handle(mux, "POST /repos/{owner}/{repo}/pulls/{number}/reviews", func(w http.ResponseWriter, r *http.Request) error {
+		number, err := pathInt(r, "number")
+		if err != nil {
+			return err
+		}
+		var req github.PullRequestReviewRequest
+		if err := decode(r, &req); err != nil {
+			return err
+		}
+		review, err := s.Fake.CreatePullRequestReview(r.Context(), r.PathValue("owner"), r.PathValue("repo"), int(number), &clientv1.CreateReviewInput{
+			Event: req.GetEvent(),
+			Body:  req.GetBody(),
+		})
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, pullRequestReviewToGitHub(review))
+		return nil
+	})
+	handle(mux, "POST /repos/{owner}/{repo}/pulls/{number}/requested_reviewers", func(w http.ResponseWriter, r *http.Request) error {
+		number, err := pathInt(r, "number")
+		if err != nil {
+			return err
+		}
+		var req github.ReviewersRequest
+		if err := decode(r, &req); err != nil {
+			return err
+		}
+		pr, err := s.Fake.RequestReviewers(r.Context(), r.PathValue("owner"), r.PathValue("repo"), int(number), req.Reviewers, req.TeamReviewers)
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusCreated, pullRequestToGitHub(pr))
+		return nil
+	})
+	handle(mux, "GET /repos/{owner}/{repo}/pulls/{number}/comments", func(w http.ResponseWriter, r *http.Request) error {
+		number, err := pathInt(r, "number")
+		if err != nil {
+			return err
+		}
+		comments, err := s.Fake.ListPullRequestComments(r.Context(), r.PathValue("owner"), r.PathValue("repo"), int(number))
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, convertAll(pageOf(w, r, comments), pullRequestCommentToGitHub))
+		return nil
+	})
+	handle(mux, "POST /repos/{owner}/{repo}/pulls/{number}/comments", func(w http.ResponseWriter, r *http.Request) error {
+		number, err := pathInt(r, "number")
+		if err != nil {
+			return err
+		}
+		var req github.PullRequestComment
+		if err := decode(r, &req); err != nil {
+			return err
+		}
+		comment, err := s.Fake.CreatePullRequestComment(r.Context(), r.PathValue("owner"), r.PathValue("repo"), int(number), &clientv1.CreatePRCommentInput{
+			Body:     req.GetBody(),
+			CommitID: req.GetCommitID(),
+			Path:     req.GetPath(),
+			Line:     req.GetLine(),
+			Side:     req.GetSide(),
+		})
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusCreated, pullRequestCommentToGitHub(comment))
+		return nil
+	})
+}
+
+// getPullRequest serves a pull request as JSON, or as a diff or patch when
+// the Accept header asks for one.
+func (s *Server) getPullRequest(w http.ResponseWriter, r *http.Request) error {
+	number, err := pathInt(r, "number")
+	if err != nil {
+		return err
+	}
+	owner, repo := r.PathValue("owner"), r.PathValue("repo")
+	accept := r.Header.Get("Accept")
+	switch {
+	case strings.Contains(accept, mediaTypeDiff):
+		diff, err := s.Fake.GetPullRequestDiff(r.Context(), owner, repo, int(number))
+		if err != nil {
+			return err
+		}
+		writeText(w, mediaTypeDiff, diff)
+	case strings.Contains(accept, mediaTypePatch):
+		patch, err := s.Fake.GetPullRequestPatch(r.Context(), owner, repo, int(number))
+		if err != nil {
+			return err
+		}
+		writeText(w, mediaTypePatch, patch)
+	default:
+		pr, err := s.Fake.GetPullRequest(r.Context(), owner, repo, int(number))
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, pullRequestToGitHub(pr))
+	}
+	return nil
+}
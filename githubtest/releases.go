@@ -0,0 +1,173 @@
+package githubtest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/grokify/gogithub"
+	"github.com/grokify/gogithub/clientv1"
+	ghErrors "github.com/grokify/gogithub/errors"
+)
+
+func (s *Server) routeReleases(mux *http.ServeMux) {
+	handle(mux, "GET /repos/{owner}/{repo}/releases", func(w http.ResponseWriter, r *http.Request) error {
+		releases, err := s.Fake.ListReleases(r.Context(), r.PathValue("owner"), r.PathValue("repo"))
+		if err != nil {
+			return err
+		}
+		page := pageOf(w, r, releases)
+		result := make([]*github.RepositoryRelease, len(page))
+		for i, rel := range page {
+			result[i] = releaseWithUploadURL(r, rel)
+		}
+		writeJSON(w, http.StatusOK, result)
+		return nil
+	})
+	handle(mux, "POST /repos/{owner}/{repo}/releases", func(w http.ResponseWriter, r *http.Request) error {
+		var req github.CreateReleaseRequest
+		if err := decode(r, &req); err != nil {
+			return err
+		}
+		rel, err := s.Fake.CreateRelease(r.Context(), r.PathValue("owner"), r.PathValue("repo"), &clientv1.CreateReleaseInput{
+			TagName:              req.TagName,
+			TargetCommitish:      req.GetTargetCommitish(),
+			Name:                 req.GetName(),
+			Body:                 req.GetBody(),
+			Draft:                req.GetDraft(),
+			Prerelease:           req.GetPrerelease(),
+			GenerateReleaseNotes: req.GetGenerateReleaseNotes(),
+		})
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusCreated, releaseWithUploadURL(r, rel))
+		return nil
+	})
+	handle(mux, "GET /repos/{owner}/{repo}/releases/latest", func(w http.ResponseWriter, r *http.Request) error {
+		rel, err := s.Fake.GetLatestRelease(r.Context(), r.PathValue("owner"), r.PathValue("repo"))
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, releaseWithUploadURL(r, rel))
+		return nil
+	})
+	handle(mux, "GET /repos/{owner}/{repo}/releases/{id}", func(w http.ResponseWriter, r *http.Request) error {
+		id, err := pathInt(r, "id")
+		if err != nil {
+			return err
+		}
+		rel, err := s.Fake.GetRelease(r.Context(), r.PathValue("owner"), r.PathValue("repo"), id)
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, releaseWithUploadURL(r, rel))
+		return nil
+	})
+	handle(mux, "PATCH /repos/{owner}/{repo}/releases/{id}", func(w http.ResponseWriter, r *http.Request) error {
+		id, err := pathInt(r, "id")
+		if err != nil {
+			return err
+		}
+		var req github.UpdateReleaseRequest
+		if err := decode(r, &req); err != nil {
+			return err
+		}
+		rel, err := s.Fake.UpdateRelease(r.Context(), r.PathValue("owner"), r.PathValue("repo"), id, &clientv1.UpdateReleaseInput{
+			TagName:         req.TagName,
+			TargetCommitish: req.TargetCommitish,
+			Name:            req.Name,
+			Body:            req.Body,
+			Draft:           req.Draft,
+			Prerelease:      req.Prerelease,
+		})
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, releaseWithUploadURL(r, rel))
+		return nil
+	})
+	handle(mux, "DELETE /repos/{owner}/{repo}/releases/{id}", func(w http.ResponseWriter, r *http.Request) error {
+		id, err := pathInt(r, "id")
+		if err != nil {
+			return err
+		}
+		if err := s.Fake.DeleteRelease(r.Context(), r.PathValue("owner"), r.PathValue("repo"), id); err != nil {
+			return err
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	})
+	// GET releases/tags/{tag} and GET releases/{id}/assets overlap as mux
+	// patterns, so one handler serves both.
+	handle(mux, "GET /repos/{owner}/{repo}/releases/{id}/{sub}", func(w http.ResponseWriter, r *http.Request) error {
+		if r.PathValue("id") == "tags" {
+			return s.getReleaseByTag(w, r, r.PathValue("sub"))
+		}
+		if r.PathValue("sub") != "assets" {
+			return notFound()
+		}
+		return s.listReleaseAssets(w, r)
+	})
+}
+
+func (s *Server) routeUploads(mux *http.ServeMux) {
+	handle(mux, "POST /repos/{owner}/{repo}/releases/{id}/assets", func(w http.ResponseWriter, r *http.Request) error {
+		id, err := pathInt(r, "id")
+		if err != nil {
+			return err
+		}
+		name := r.URL.Query().Get("name")
+		if name == "" {
+			return &ghErrors.APIError{StatusCode: http.StatusUnprocessableEntity, Message: "name is required", Err: ghErrors.ErrValidation}
+		}
+		content, err := io.ReadAll(r.Body)
+		if err != nil {
+			return &ghErrors.APIError{StatusCode: http.StatusBadRequest, Message: "Problems reading body", Err: err}
+		}
+		asset, err := s.Fake.AddReleaseAsset(r.PathValue("owner"), r.PathValue("repo"), id, &gogithub.ReleaseAssetUpload{
+			Name:        name,
+			Label:       r.URL.Query().Get("label"),
+			ContentType: r.Header.Get("Content-Type"),
+			Content:     content,
+		})
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusCreated, releaseAssetToGitHub(asset))
+		return nil
+	})
+}
+
+func (s *Server) getReleaseByTag(w http.ResponseWriter, r *http.Request, tag string) error {
+	rel, err := s.Fake.GetReleaseByTag(r.Context(), r.PathValue("owner"), r.PathValue("repo"), tag)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, releaseWithUploadURL(r, rel))
+	return nil
+}
+
+func (s *Server) listReleaseAssets(w http.ResponseWriter, r *http.Request) error {
+	id, err := pathInt(r, "id")
+	if err != nil {
+		return err
+	}
+	assets, err := s.Fake.ListReleaseAssets(r.Context(), r.PathValue("owner"), r.PathValue("repo"), id)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, convertAll(pageOf(w, r, assets), releaseAssetToGitHub))
+	return nil
+}
+
+// releaseWithUploadURL converts a release and points its upload URL at the
+// server's uploads endpoint, so that go-github's
+// UploadReleaseAssetFromRelease works against it.
+func releaseWithUploadURL(r *http.Request, rel *gogithub.Release) *github.RepositoryRelease {
+	release := releaseToGitHub(rel)
+	release.UploadURL = fmt.Sprintf("http://%s%s/repos/%s/%s/releases/%d/assets{?name,label}",
+		r.Host, uploadPrefix, r.PathValue("owner"), r.PathValue("repo"), rel.ID)
+	return release
+}
@@ -0,0 +1,321 @@
+package githubtest
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/grokify/gogithub"
+	"github.com/grokify/gogithub/clientv1"
+	ghErrors "github.com/grokify/gogithub/errors"
+)
+
+func (s *Server) routeUsers(mux *http.ServeMux) {
+	handle(mux, "GET /user", func(w http.ResponseWriter, r *http.Request) error {
+		if err := requireUser(r); err != nil {
+			return err
+		}
+		u, err := s.Fake.GetAuthenticatedUser(r.Context())
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, userToGitHub(u))
+		return nil
+	})
+	handle(mux, "GET /users/{username}", func(w http.ResponseWriter, r *http.Request) error {
+		u, err := s.Fake.GetUser(r.Context(), r.PathValue("username"))
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, userToGitHub(u))
+		return nil
+	})
+	handle(mux, "GET /users/{username}/repos", func(w http.ResponseWriter, r *http.Request) error {
+		repos, err := s.Fake.ListUserReposWithOptions(r.Context(), r.PathValue("username"), &clientv1.ListUserReposOptions{
+			Type: r.URL.Query().Get("type"),
+		})
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, convertAll(pageOf(w, r, repos), repositoryToGitHub))
+		return nil
+	})
+	handle(mux, "GET /orgs/{org}/repos", func(w http.ResponseWriter, r *http.Request) error {
+		repos, err := s.Fake.ListOrgRepos(r.Context(), r.PathValue("org"))
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, convertAll(pageOf(w, r, repos), repositoryToGitHub))
+		return nil
+	})
+	handle(mux, "GET /orgs/{org}/members", func(w http.ResponseWriter, r *http.Request) error {
+		users, err := s.Fake.ListOrgMembers(r.Context(), r.PathValue("org"))
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, convertAll(pageOf(w, r, users), userToGitHub))
+		return nil
+	})
+	handle(mux, "GET /orgs/{org}/teams/{team}/members", func(w http.ResponseWriter, r *http.Request) error {
+		users, err := s.Fake.ListTeamMembers(r.Context(), r.PathValue("org"), r.PathValue("team"))
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, convertAll(pageOf(w, r, users), userToGitHub))
+		return nil
+	})
+	handle(mux, "GET /users/{username}/events", func(w http.ResponseWriter, r *http.Request) error {
+		return s.listUserEvents(w, r, false)
+	})
+	handle(mux, "GET /users/{username}/events/public", func(w http.ResponseWriter, r *http.Request) error {
+		return s.listUserEvents(w, r, true)
+	})
+	handle(mux, "GET /rate_limit", func(w http.ResponseWriter, r *http.Request) error {
+		rl, err := s.Fake.GetRateLimit(r.Context())
+		if err != nil {
+			return err
+		}
+		core := &github.Rate{
+			Limit:     rl.Limit,
+			Remaining: rl.Remaining,
+			Used:      rl.Used,
+			Reset:     github.Timestamp{Time: rl.Reset},
+			Resource:  "core",
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"resources": &github.RateLimits{Core: core},
+			"rate":      core,
+		})
+		return nil
+	})
+}
+
+// listUserEvents serves a user's events. Private events are only listed
+// for the authenticated user's own timeline.
+func (s *Server) listUserEvents(w http.ResponseWriter, r *http.Request, publicOnly bool) error {
+	username := r.PathValue("username")
+	events, err := s.Fake.ListUserEvents(r.Context(), username, &clientv1.ListUserEventsOptions{PublicOnly: publicOnly})
+	if err != nil {
+		return err
+	}
+	if !publicOnly {
+		me, err := s.Fake.GetAuthenticatedUser(r.Context())
+		if err != nil {
+			return err
+		}
+		publicOnly = r.Header.Get("Authorization") == "" || !strings.EqualFold(me.Login, username)
+	}
+	if publicOnly {
+		public := events[:0]
+		for _, e := range events {
+			if e.Public {
+				public = append(public, e)
+			}
+		}
+		events = public
+	}
+	writeJSON(w, http.StatusOK, convertAll(pageOf(w, r, events), eventToGitHub))
+	return nil
+}
+
+func (s *Server) routeRepos(mux *http.ServeMux) {
+	handle(mux, "GET /repos/{owner}/{repo}", func(w http.ResponseWriter, r *http.Request) error {
+		repo, err := s.Fake.GetRepository(r.Context(), r.PathValue("owner"), r.PathValue("repo"))
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, repositoryToGitHub(repo))
+		return nil
+	})
+	handle(mux, "GET /repos/{owner}/{repo}/languages", func(w http.ResponseWriter, r *http.Request) error {
+		langs, err := s.Fake.ListLanguages(r.Context(), r.PathValue("owner"), r.PathValue("repo"))
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, langs)
+		return nil
+	})
+	handle(mux, "GET /repos/{owner}/{repo}/branches", func(w http.ResponseWriter, r *http.Request) error {
+		branches, err := s.Fake.ListBranches(r.Context(), r.PathValue("owner"), r.PathValue("repo"))
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, convertAll(pageOf(w, r, branches), branchToGitHub))
+		return nil
+	})
+	handle(mux, "GET /repos/{owner}/{repo}/branches/{branch}/protection", func(w http.ResponseWriter, r *http.Request) error {
+		p, err := s.Fake.GetBranchProtection(r.Context(), r.PathValue("owner"), r.PathValue("repo"), r.PathValue("branch"))
+		if err != nil {
+			return err
+		}
+		if p == nil {
+			return &ghErrors.APIError{StatusCode: http.StatusNotFound, Message: "Branch not protected", Err: ghErrors.ErrNotFound}
+		}
+		writeJSON(w, http.StatusOK, branchProtectionToGitHub(p))
+		return nil
+	})
+	handle(mux, "GET /repos/{owner}/{repo}/tags", func(w http.ResponseWriter, r *http.Request) error {
+		tags, err := s.Fake.ListTags(r.Context(), r.PathValue("owner"), r.PathValue("repo"))
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, convertAll(pageOf(w, r, tags), tagToGitHub))
+		return nil
+	})
+	handle(mux, "POST /repos/{owner}/{repo}/forks", func(w http.ResponseWriter, r *http.Request) error {
+		var req github.RepositoryCreateForkOptions
+		if err := decode(r, &req); err != nil {
+			return err
+		}
+		fork, err := s.Fake.CreateFork(r.Context(), r.PathValue("owner"), r.PathValue("repo"), &clientv1.CreateForkOptions{
+			Organization:  req.Organization,
+			Name:          req.Name,
+			DefaultBranch: req.DefaultBranchOnly,
+		})
+		if err != nil {
+			return err
+		}
+		// GitHub creates forks asynchronously and answers 202 Accepted.
+		writeJSON(w, http.StatusAccepted, repositoryToGitHub(fork))
+		return nil
+	})
+	handle(mux, "GET /repos/{owner}/{repo}/contents/{path...}", s.getContents)
+	handle(mux, "PUT /repos/{owner}/{repo}/contents/{path...}", s.putContents)
+	handle(mux, "DELETE /repos/{owner}/{repo}/contents/{path...}", s.deleteContents)
+	handle(mux, "GET /repos/{owner}/{repo}/commits", func(w http.ResponseWriter, r *http.Request) error {
+		q := r.URL.Query()
+		opts := &clientv1.ListCommitsOptions{
+			SHA:    q.Get("sha"),
+			Path:   q.Get("path"),
+			Author: q.Get("author"),
+		}
+		var err error
+		if opts.Since, err = queryTime(r, "since"); err != nil {
+			return err
+		}
+		if opts.Until, err = queryTime(r, "until"); err != nil {
+			return err
+		}
+		commits, err := s.Fake.ListCommits(r.Context(), r.PathValue("owner"), r.PathValue("repo"), opts)
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, convertAll(pageOf(w, r, commits), commitToGitHub))
+		return nil
+	})
+	handle(mux, "GET /repos/{owner}/{repo}/commits/{sha}", func(w http.ResponseWriter, r *http.Request) error {
+		commit, err := s.Fake.GetCommit(r.Context(), r.PathValue("owner"), r.PathValue("repo"), r.PathValue("sha"))
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, commitToGitHub(commit))
+		return nil
+	})
+	handle(mux, "GET /repos/{owner}/{repo}/stats/contributors", func(w http.ResponseWriter, r *http.Request) error {
+		stats, err := s.Fake.GetContributorStats(r.Context(), r.PathValue("owner"), r.PathValue("repo"))
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, convertAll(stats, contributorStatsToGitHub))
+		return nil
+	})
+}
+
+// getContents serves a file as an object with base64 content and a
+// directory as an array of entries, as GitHub's contents API does.
+func (s *Server) getContents(w http.ResponseWriter, r *http.Request) error {
+	owner, repo := r.PathValue("owner"), r.PathValue("repo")
+	p := strings.Trim(r.PathValue("path"), "/")
+	opts := &gogithub.ContentOptions{Ref: r.URL.Query().Get("ref")}
+
+	// The type of p is found by listing its parent directory.
+	if p != "" {
+		parent := path.Dir(p)
+		if parent == "." {
+			parent = ""
+		}
+		siblings, err := s.Fake.ListDirectory(r.Context(), owner, repo, parent, opts)
+		if err != nil {
+			return notFound()
+		}
+		var entry *gogithub.FileContent
+		for _, e := range siblings {
+			if e.Path == p {
+				entry = e
+			}
+		}
+		switch {
+		case entry == nil:
+			return notFound()
+		case entry.Type == "file":
+			content, _, err := s.Fake.GetFileContentWithSHA(r.Context(), owner, repo, p, opts)
+			if err != nil {
+				return err
+			}
+			entry.Content = content
+			fallthrough
+		case entry.Type != "dir":
+			writeJSON(w, http.StatusOK, fileContentToGitHub(entry))
+			return nil
+		}
+	}
+	entries, err := s.Fake.ListDirectory(r.Context(), owner, repo, p, opts)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, convertAll(entries, fileContentToGitHub))
+	return nil
+}
+
+// putContents creates a file, or updates it when the request names the
+// file's current SHA.
+func (s *Server) putContents(w http.ResponseWriter, r *http.Request) error {
+	var req github.RepositoryContentFileOptions
+	if err := decode(r, &req); err != nil {
+		return err
+	}
+	owner, repo, p := r.PathValue("owner"), r.PathValue("repo"), r.PathValue("path")
+	if req.SHA == nil {
+		result, err := s.Fake.CreateFile(r.Context(), owner, repo, p, &clientv1.CreateFileOptions{
+			Content: req.Content,
+			Message: req.GetMessage(),
+			Branch:  req.GetBranch(),
+			Author:  commitAuthorFromGitHub(req.Author),
+		})
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusCreated, contentResponseToGitHub(result.Content, result.Commit))
+		return nil
+	}
+	result, err := s.Fake.UpdateFile(r.Context(), owner, repo, p, &clientv1.UpdateFileOptions{
+		Content: req.Content,
+		SHA:     req.GetSHA(),
+		Message: req.GetMessage(),
+		Branch:  req.GetBranch(),
+		Author:  commitAuthorFromGitHub(req.Author),
+	})
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, contentResponseToGitHub(result.Content, result.Commit))
+	return nil
+}
+
+func (s *Server) deleteContents(w http.ResponseWriter, r *http.Request) error {
+	var req github.RepositoryContentFileOptions
+	if err := decode(r, &req); err != nil {
+		return err
+	}
+	result, err := s.Fake.DeleteFile(r.Context(), r.PathValue("owner"), r.PathValue("repo"), r.PathValue("path"),
+		req.GetSHA(), req.GetMessage(), &clientv1.DeleteFileOptions{
+			Branch: req.GetBranch(),
+			Author: commitAuthorFromGitHub(req.Author),
+		})
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, contentResponseToGitHub(nil, result.Commit))
+	return nil
+}
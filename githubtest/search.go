@@ -0,0 +1,45 @@
+package githubtest
+
+import (
+	"net/http"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/grokify/gogithub/clientv1"
+)
+
+func (s *Server) routeSearch(mux *http.ServeMux) {
+	handle(mux, "GET /search/issues", func(w http.ResponseWriter, r *http.Request) error {
+		result, err := s.Fake.SearchIssues(r.Context(), r.URL.Query().Get("q"), searchOptions(r))
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, &github.IssuesSearchResult{
+			Total:             github.Ptr(result.Total),
+			IncompleteResults: github.Ptr(result.IncompleteResults),
+			Issues:            convertAll(pageOf(w, r, result.Items), issueToGitHub),
+		})
+		return nil
+	})
+	handle(mux, "GET /search/code", func(w http.ResponseWriter, r *http.Request) error {
+		result, err := s.Fake.SearchCode(r.Context(), r.URL.Query().Get("q"), searchOptions(r))
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, &github.CodeSearchResult{
+			Total:             github.Ptr(result.Total),
+			IncompleteResults: github.Ptr(result.IncompleteResults),
+			CodeResults:       convertAll(pageOf(w, r, result.Items), codeResultToGitHub),
+		})
+		return nil
+	})
+}
+
+// searchOptions returns the sort order of a search request. The fake is
+// asked for every result; the server paginates them.
+func searchOptions(r *http.Request) *clientv1.SearchOptions {
+	return &clientv1.SearchOptions{
+		Sort:    r.URL.Query().Get("sort"),
+		Order:   r.URL.Query().Get("order"),
+		PerPage: allItems,
+	}
+}
@@ -0,0 +1,318 @@
+// Package githubtest provides a local emulator of the GitHub REST API for
+// integration tests.
+//
+// A Server is an httptest.Server that answers the REST endpoints used by
+// clientv1 — users, organization and team members, repositories,
+// contents, git refs, trees, blobs and commits, pull requests, issues,
+// releases and release asset uploads, check runs and search — from
+// in-memory state. Pointing the real client
+// at it exercises the go-github code paths, request encoding and response
+// decoding end to end without a network:
+//
+//	srv := githubtest.NewServer()
+//	defer srv.Close()
+//	srv.Fake.AddRepository("octocat", "hello-world")
+//
+//	c, err := clientv1.NewClientWithOptions(ctx, srv.ClientOptions())
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	sha, err := c.GetBranchSHA(ctx, "octocat", "hello-world", "main")
+//
+// The state is held by a fake.Client, so it behaves exactly as the
+// in-memory fake does, and tests seed and inspect it with the fake's
+// helpers through Server.Fake. Errors reach the client as the HTTP status
+// codes and messages GitHub uses, and list endpoints are paginated with
+// Link headers.
+package githubtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grokify/gogithub/clientv1"
+	"github.com/grokify/gogithub/clientv1/fake"
+	ghErrors "github.com/grokify/gogithub/errors"
+)
+
+const (
+	// Token is the access token the server accepts. Requests with another
+	// token are rejected with 401 Bad credentials; requests without one are
+	// served anonymously, except those that need an authenticated user.
+	Token = "githubtest-token"
+
+	// apiPrefix and uploadPrefix are the GitHub Enterprise paths go-github
+	// uses when given a custom base URL.
+	apiPrefix    = "/api/v3"
+	uploadPrefix = "/api/uploads"
+
+	defaultPerPage = 30
+	maxPerPage     = 100
+	// allItems is a page size that makes fake methods which paginate
+	// themselves return every item, so the server can paginate instead.
+	allItems = math.MaxInt32
+
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitUsed      = "X-RateLimit-Used"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+	headerRateLimitResource  = "X-RateLimit-Resource"
+)
+
+// Server emulates the GitHub REST API. It is safe for concurrent use.
+type Server struct {
+	// URL is the root URL of the server, e.g. "http://127.0.0.1:54321".
+	URL string
+
+	// Fake holds the emulated GitHub state. Seed it before making requests,
+	// e.g. with Fake.AddRepository, and inspect it afterwards.
+	Fake *fake.Client
+
+	srv *httptest.Server
+
+	mu sync.Mutex
+	// tagRefs records the refs created along with annotated tag objects;
+	// see createTag.
+	tagRefs map[string]string
+}
+
+// NewServer starts a server with an empty fake.Client.
+func NewServer() *Server {
+	return NewServerWithFake(fake.NewClient())
+}
+
+// NewServerWithFake starts a server that serves the state in fc.
+func NewServerWithFake(fc *fake.Client) *Server {
+	s := &Server{
+		Fake:    fc,
+		tagRefs: make(map[string]string),
+	}
+	api := http.NewServeMux()
+	s.routeUsers(api)
+	s.routeRepos(api)
+	s.routeGit(api)
+	s.routePulls(api)
+	s.routeIssues(api)
+	s.routeReleases(api)
+	s.routeChecks(api)
+	s.routeSearch(api)
+
+	uploads := http.NewServeMux()
+	s.routeUploads(uploads)
+
+	root := http.NewServeMux()
+	root.Handle(apiPrefix+"/", http.StripPrefix(apiPrefix, s.authenticate(api)))
+	root.Handle(uploadPrefix+"/", http.StripPrefix(uploadPrefix, s.authenticate(uploads)))
+	s.srv = httptest.NewServer(root)
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// ClientOptions returns options that point a clientv1 client at the server
+// and authenticate it with Token.
+func (s *Server) ClientOptions() clientv1.ClientOptions {
+	return clientv1.ClientOptions{
+		Token:     Token,
+		BaseURL:   s.URL + "/",
+		UploadURL: s.URL + "/",
+	}
+}
+
+// NewClient returns a clientv1 client for the server.
+func (s *Server) NewClient(ctx context.Context) (clientv1.Client, error) {
+	return clientv1.NewClientWithOptions(ctx, s.ClientOptions())
+}
+
+// authenticate checks the request's token and reports the fake's rate
+// limit in the response headers. Once the limit is exhausted, requests
+// other than GET /rate_limit fail with 403, as on GitHub.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			scheme, token, _ := strings.Cut(auth, " ")
+			if (!strings.EqualFold(scheme, "bearer") && !strings.EqualFold(scheme, "token")) || token != Token {
+				writeMessage(w, http.StatusUnauthorized, "Bad credentials")
+				return
+			}
+		}
+
+		rl, err := s.Fake.GetRateLimit(r.Context())
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		resource := "core"
+		if strings.HasPrefix(r.URL.Path, "/search/") {
+			resource = "search"
+		}
+		w.Header().Set(headerRateLimitLimit, strconv.Itoa(rl.Limit))
+		w.Header().Set(headerRateLimitRemaining, strconv.Itoa(rl.Remaining))
+		w.Header().Set(headerRateLimitUsed, strconv.Itoa(rl.Used))
+		w.Header().Set(headerRateLimitReset, strconv.FormatInt(rl.Reset.Unix(), 10))
+		w.Header().Set(headerRateLimitResource, resource)
+		if rl.Remaining <= 0 && time.Now().Before(rl.Reset) && r.URL.Path != "/rate_limit" {
+			writeMessage(w, http.StatusForbidden, "API rate limit exceeded")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requireUser rejects anonymous requests to endpoints that act as the
+// authenticated user.
+func requireUser(r *http.Request) error {
+	if r.Header.Get("Authorization") == "" {
+		return &ghErrors.APIError{StatusCode: http.StatusUnauthorized, Message: "Requires authentication"}
+	}
+	return nil
+}
+
+// handlerFunc serves one endpoint. A returned error is written as a GitHub
+// error response.
+type handlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// handle registers h for pattern on mux.
+func handle(mux *http.ServeMux, pattern string, h handlerFunc) {
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		if err := h(w, r); err != nil {
+			writeError(w, err)
+		}
+	})
+}
+
+// writeJSON writes v as the JSON response body.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeText writes body with the given media type.
+func writeText(w http.ResponseWriter, mediaType, body string) {
+	w.Header().Set("Content-Type", mediaType+"; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.WriteString(w, body)
+}
+
+// writeMessage writes a GitHub error body.
+func writeMessage(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"message": message})
+}
+
+// writeError writes err with the status code of the GitHub response it
+// stands for. The fake reports malformed input with untyped errors; GitHub
+// rejects such requests with 422 Unprocessable Entity.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusUnprocessableEntity
+	message := err.Error()
+	var apiErr *ghErrors.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode != 0 {
+		status = apiErr.StatusCode
+		message = apiErr.Message
+	}
+	if message == "" {
+		message = http.StatusText(status)
+	}
+	writeMessage(w, status, message)
+}
+
+// notFound is the error for a missing resource or an unparsable path.
+func notFound() error {
+	return &ghErrors.APIError{StatusCode: http.StatusNotFound, Message: "Not Found", Err: ghErrors.ErrNotFound}
+}
+
+// decode reads the JSON request body into v.
+func decode(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return &ghErrors.APIError{StatusCode: http.StatusBadRequest, Message: "Problems parsing JSON", Err: err}
+	}
+	return nil
+}
+
+// pathInt parses the path parameter name as an integer.
+func pathInt(r *http.Request, name string) (int64, error) {
+	n, err := strconv.ParseInt(r.PathValue(name), 10, 64)
+	if err != nil {
+		return 0, notFound()
+	}
+	return n, nil
+}
+
+// queryTime parses the query parameter name as an ISO 8601 timestamp. It
+// returns nil when the parameter is absent.
+func queryTime(r *http.Request, name string) (*time.Time, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, &ghErrors.APIError{StatusCode: http.StatusUnprocessableEntity, Message: fmt.Sprintf("Invalid %s: %q", name, v)}
+	}
+	return &t, nil
+}
+
+// pagination returns the page and per_page query parameters with GitHub's
+// defaults applied.
+func pagination(r *http.Request) (page, perPage int) {
+	page, _ = strconv.Atoi(r.URL.Query().Get("page"))
+	page = max(page, 1)
+	perPage, _ = strconv.Atoi(r.URL.Query().Get("per_page"))
+	if perPage <= 0 {
+		perPage = defaultPerPage
+	}
+	return page, min(perPage, maxPerPage)
+}
+
+// pageOf returns the requested page of items and sets the Link header for
+// the other pages.
+func pageOf[T any](w http.ResponseWriter, r *http.Request, items []T) []T {
+	page, perPage := pagination(r)
+	setLink(w, r, page, perPage, len(items))
+	start := min((page-1)*perPage, len(items))
+	end := min(start+perPage, len(items))
+	return items[start:end]
+}
+
+// setLink sets the Link header for a paginated response of total items.
+func setLink(w http.ResponseWriter, r *http.Request, page, perPage, total int) {
+	last := max((total+perPage-1)/perPage, 1)
+	u, err := url.Parse(r.RequestURI)
+	if err != nil {
+		return
+	}
+	u.Scheme, u.Host = "http", r.Host
+	link := func(p int, rel string) string {
+		q := u.Query()
+		q.Set("page", strconv.Itoa(p))
+		q.Set("per_page", strconv.Itoa(perPage))
+		u.RawQuery = q.Encode()
+		return fmt.Sprintf("<%s>; rel=%q", u, rel)
+	}
+	var links []string
+	if page < last {
+		links = append(links, link(page+1, "next"), link(last, "last"))
+	}
+	if page > 1 {
+		links = append(links, link(1, "first"), link(min(page-1, last), "prev"))
+	}
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+}
@@ -0,0 +1,472 @@
+package githubtest_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/grokify/gogithub"
+	"github.com/grokify/gogithub/clientv1"
+	"github.com/grokify/gogithub/clientv1/conformance"
+	"github.com/grokify/gogithub/errors"
+	"github.com/grokify/gogithub/githubtest"
+)
+
+const (
+	testOwner = "octocat"
+	testRepo  = "hello-world"
+)
+
+// newTestServer starts a server with testOwner/testRepo and returns a
+// client for it.
+func newTestServer(t *testing.T) (*githubtest.Server, clientv1.Client) {
+	t.Helper()
+	srv := githubtest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.Fake.SetAuthenticatedUser(testOwner)
+	srv.Fake.AddRepository(testOwner, testRepo)
+	c, err := srv.NewClient(context.Background())
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return srv, c
+}
+
+func TestConformance(t *testing.T) {
+	conformance.Run(t, func(t *testing.T, _ conformance.Target) clientv1.Client {
+		srv := githubtest.NewServerWithFake(conformance.NewFixture(t))
+		t.Cleanup(srv.Close)
+		c, err := srv.NewClient(context.Background())
+		if err != nil {
+			t.Fatalf("NewClient() error = %v", err)
+		}
+		return c
+	})
+}
+
+func TestContents(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestServer(t)
+
+	created, err := c.CreateFile(ctx, testOwner, testRepo, "docs/guide.md", &clientv1.CreateFileOptions{
+		Message: "Add guide",
+		Content: []byte("# Guide\n"),
+	})
+	if err != nil {
+		t.Fatalf("CreateFile() error = %v", err)
+	}
+	content, sha, err := c.GetFileContentWithSHA(ctx, testOwner, testRepo, "docs/guide.md", nil)
+	if err != nil {
+		t.Fatalf("GetFileContentWithSHA() error = %v", err)
+	}
+	if string(content) != "# Guide\n" || sha != created.Content.SHA {
+		t.Errorf("GetFileContentWithSHA() = %q, %q; want %q, %q", content, sha, "# Guide\n", created.Content.SHA)
+	}
+
+	if _, err := c.UpdateFile(ctx, testOwner, testRepo, "docs/guide.md", &clientv1.UpdateFileOptions{
+		Message: "Update guide",
+		Content: []byte("# Guide v2\n"),
+		SHA:     "0000000000000000000000000000000000000000",
+	}); !errors.IsConflict(err) {
+		t.Errorf("UpdateFile() with stale SHA error = %v, want conflict", err)
+	}
+	updated, err := c.UpdateFile(ctx, testOwner, testRepo, "docs/guide.md", &clientv1.UpdateFileOptions{
+		Message: "Update guide",
+		Content: []byte("# Guide v2\n"),
+		SHA:     sha,
+	})
+	if err != nil {
+		t.Fatalf("UpdateFile() error = %v", err)
+	}
+
+	entries, err := c.ListDirectory(ctx, testOwner, testRepo, "docs", nil)
+	if err != nil {
+		t.Fatalf("ListDirectory() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Path != "docs/guide.md" || entries[0].Type != "file" {
+		t.Errorf("ListDirectory() = %+v, want docs/guide.md", entries)
+	}
+
+	if _, err := c.DeleteFile(ctx, testOwner, testRepo, "docs/guide.md", updated.Content.SHA, "Remove guide", nil); err != nil {
+		t.Fatalf("DeleteFile() error = %v", err)
+	}
+	exists, err := c.FileExists(ctx, testOwner, testRepo, "docs/guide.md", nil)
+	if err != nil {
+		t.Fatalf("FileExists() error = %v", err)
+	}
+	if exists {
+		t.Error("FileExists() after delete = true, want false")
+	}
+}
+
+func TestGitData(t *testing.T) {
+	ctx := context.Background()
+	srv, c := newTestServer(t)
+
+	head, err := c.GetBranchSHA(ctx, testOwner, testRepo, "main")
+	if err != nil {
+		t.Fatalf("GetBranchSHA() error = %v", err)
+	}
+	base, err := c.GetGitCommit(ctx, testOwner, testRepo, head)
+	if err != nil {
+		t.Fatalf("GetGitCommit() error = %v", err)
+	}
+
+	blob, err := c.CreateBlob(ctx, testOwner, testRepo, []byte("package main\n"), "utf-8")
+	if err != nil {
+		t.Fatalf("CreateBlob() error = %v", err)
+	}
+	if got, err := srv.Fake.Blob(testOwner, testRepo, blob); err != nil || string(got) != "package main\n" {
+		t.Errorf("Blob() = %q, %v; want %q", got, err, "package main\n")
+	}
+	tree, err := c.CreateTree(ctx, testOwner, testRepo, base.Tree.SHA, []clientv1.TreeEntry{
+		{Path: "cmd/main.go", Mode: "100644", Type: "blob", SHA: blob},
+		{Path: "VERSION", Mode: "100644", Type: "blob", Content: "1.0.0\n"},
+	})
+	if err != nil {
+		t.Fatalf("CreateTree() error = %v", err)
+	}
+	commit, err := c.CreateCommit(ctx, testOwner, testRepo, &clientv1.CreateCommitOptions{
+		Message: "Add main",
+		Tree:    tree,
+		Parents: []string{head},
+	})
+	if err != nil {
+		t.Fatalf("CreateCommit() error = %v", err)
+	}
+	if _, err := c.UpdateRef(ctx, testOwner, testRepo, "refs/heads/main", commit.SHA, false); err != nil {
+		t.Fatalf("UpdateRef() error = %v", err)
+	}
+
+	nodes, err := c.GetTree(ctx, testOwner, testRepo, commit.SHA, true)
+	if err != nil {
+		t.Fatalf("GetTree() error = %v", err)
+	}
+	paths := make(map[string]bool)
+	for _, n := range nodes {
+		paths[n.Path] = true
+	}
+	for _, p := range []string{"cmd", "cmd/main.go", "VERSION"} {
+		if !paths[p] {
+			t.Errorf("GetTree() missing %q", p)
+		}
+	}
+
+	// An entry without SHA or content deletes its path.
+	tree, err = c.CreateTree(ctx, testOwner, testRepo, tree, []clientv1.TreeEntry{{Path: "VERSION", Delete: true}})
+	if err != nil {
+		t.Fatalf("CreateTree() delete error = %v", err)
+	}
+	nodes, err = c.GetTree(ctx, testOwner, testRepo, tree, false)
+	if err != nil {
+		t.Fatalf("GetTree() error = %v", err)
+	}
+	for _, n := range nodes {
+		if n.Path == "VERSION" {
+			t.Error("GetTree() after delete still has VERSION")
+		}
+	}
+
+	if err := c.CreateTag(ctx, testOwner, testRepo, "v1.0.0", commit.SHA, "Release v1.0.0"); err != nil {
+		t.Fatalf("CreateTag() error = %v", err)
+	}
+	ref, err := c.GetRef(ctx, testOwner, testRepo, "refs/tags/v1.0.0")
+	if err != nil {
+		t.Fatalf("GetRef() error = %v", err)
+	}
+	if ref.Object.Type != "tag" {
+		t.Errorf("GetRef().Object.Type = %q, want tag", ref.Object.Type)
+	}
+	if err := c.CreateTag(ctx, testOwner, testRepo, "v1.0.0", commit.SHA, "again"); err == nil {
+		t.Error("CreateTag() existing tag error = nil, want error")
+	}
+
+	if err := c.DeleteRef(ctx, testOwner, testRepo, "refs/tags/v1.0.0"); err != nil {
+		t.Fatalf("DeleteRef() error = %v", err)
+	}
+	if _, err := c.GetRef(ctx, testOwner, testRepo, "refs/tags/v1.0.0"); !errors.IsNotFound(err) {
+		t.Errorf("GetRef() after delete error = %v, want not found", err)
+	}
+}
+
+func TestPullRequests(t *testing.T) {
+	ctx := context.Background()
+	srv, c := newTestServer(t)
+	srv.Fake.AddUser(&gogithub.User{Login: "hubot"})
+
+	head, err := c.GetBranchSHA(ctx, testOwner, testRepo, "main")
+	if err != nil {
+		t.Fatalf("GetBranchSHA() error = %v", err)
+	}
+	if _, err := c.CreateRef(ctx, testOwner, testRepo, "refs/heads/feature", head); err != nil {
+		t.Fatalf("CreateRef() error = %v", err)
+	}
+	if _, err := c.CreateFile(ctx, testOwner, testRepo, "feature.txt", &clientv1.CreateFileOptions{
+		Message: "Add feature",
+		Content: []byte("feature\n"),
+		Branch:  "feature",
+	}); err != nil {
+		t.Fatalf("CreateFile() error = %v", err)
+	}
+
+	pr, err := c.CreatePullRequest(ctx, testOwner, testRepo, &clientv1.CreatePullRequestInput{
+		Title: "Add feature",
+		Head:  "feature",
+		Base:  "main",
+	})
+	if err != nil {
+		t.Fatalf("CreatePullRequest() error = %v", err)
+	}
+	if pr.Number != 1 || pr.State != "open" || pr.Head.Ref != "feature" {
+		t.Errorf("CreatePullRequest() = #%d %s head %s, want #1 open head feature", pr.Number, pr.State, pr.Head.Ref)
+	}
+
+	diff, err := c.GetPullRequestDiff(ctx, testOwner, testRepo, pr.Number)
+	if err != nil {
+		t.Fatalf("GetPullRequestDiff() error = %v", err)
+	}
+	if !strings.Contains(diff, "+feature") {
+		t.Errorf("GetPullRequestDiff() = %q, want added line", diff)
+	}
+	files, err := c.ListPullRequestFiles(ctx, testOwner, testRepo, pr.Number)
+	if err != nil {
+		t.Fatalf("ListPullRequestFiles() error = %v", err)
+	}
+	if len(files) != 1 || files[0].Filename != "feature.txt" || files[0].Status != "added" {
+		t.Errorf("ListPullRequestFiles() = %+v, want added feature.txt", files)
+	}
+
+	if _, err := c.RequestReviewers(ctx, testOwner, testRepo, pr.Number, []string{"hubot"}, nil); err != nil {
+		t.Fatalf("RequestReviewers() error = %v", err)
+	}
+	if reviewers, _ := srv.Fake.Reviewers(testOwner, testRepo, pr.Number); len(reviewers) != 1 || reviewers[0] != "hubot" {
+		t.Errorf("Reviewers() = %v, want [hubot]", reviewers)
+	}
+	if _, err := c.CreatePullRequestReview(ctx, testOwner, testRepo, pr.Number, &clientv1.CreateReviewInput{
+		Event: "COMMENT",
+		Body:  "Looks good",
+	}); err != nil {
+		t.Fatalf("CreatePullRequestReview() error = %v", err)
+	}
+
+	result, err := c.MergePullRequest(ctx, testOwner, testRepo, pr.Number, &clientv1.MergePullRequestOptions{MergeMethod: "squash"})
+	if err != nil {
+		t.Fatalf("MergePullRequest() error = %v", err)
+	}
+	if !result.Merged {
+		t.Error("MergePullRequest().Merged = false, want true")
+	}
+	if _, err := c.MergePullRequest(ctx, testOwner, testRepo, pr.Number, nil); err == nil {
+		t.Error("MergePullRequest() twice error = nil, want error")
+	}
+	got, err := c.GetFileContentString(ctx, testOwner, testRepo, "feature.txt", nil)
+	if err != nil || got != "feature\n" {
+		t.Errorf("GetFileContentString() after merge = %q, %v; want %q", got, err, "feature\n")
+	}
+}
+
+func TestIssues(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestServer(t)
+
+	// More than two pages at the client's page size.
+	const n = 250
+	for i := range n {
+		input := &clientv1.CreateIssueInput{Title: fmt.Sprintf("Issue %d", i+1)}
+		if i%2 == 0 {
+			input.Labels = []string{"bug"}
+		}
+		if _, err := c.CreateIssue(ctx, testOwner, testRepo, input); err != nil {
+			t.Fatalf("CreateIssue() error = %v", err)
+		}
+	}
+
+	issues, err := c.ListIssues(ctx, testOwner, testRepo, nil)
+	if err != nil {
+		t.Fatalf("ListIssues() error = %v", err)
+	}
+	if len(issues) != n {
+		t.Errorf("len(ListIssues()) = %d, want %d", len(issues), n)
+	}
+	bugs, err := c.ListIssues(ctx, testOwner, testRepo, &clientv1.ListIssuesOptions{Labels: []string{"bug"}})
+	if err != nil {
+		t.Fatalf("ListIssues(bug) error = %v", err)
+	}
+	if len(bugs) != n/2 {
+		t.Errorf("len(ListIssues(bug)) = %d, want %d", len(bugs), n/2)
+	}
+
+	closed := "closed"
+	if _, err := c.UpdateIssue(ctx, testOwner, testRepo, 1, &clientv1.UpdateIssueInput{State: &closed}); err != nil {
+		t.Fatalf("UpdateIssue() error = %v", err)
+	}
+	comment, err := c.CreateIssueComment(ctx, testOwner, testRepo, 2, "first")
+	if err != nil {
+		t.Fatalf("CreateIssueComment() error = %v", err)
+	}
+	if _, err := c.EditIssueComment(ctx, testOwner, testRepo, comment.ID, "edited"); err != nil {
+		t.Fatalf("EditIssueComment() error = %v", err)
+	}
+	comments, err := c.ListIssueComments(ctx, testOwner, testRepo, 2)
+	if err != nil {
+		t.Fatalf("ListIssueComments() error = %v", err)
+	}
+	if len(comments) != 1 || comments[0].Body != "edited" {
+		t.Errorf("ListIssueComments() = %+v, want one edited comment", comments)
+	}
+
+	result, err := c.SearchIssues(ctx, "repo:octocat/hello-world is:closed", nil)
+	if err != nil {
+		t.Fatalf("SearchIssues() error = %v", err)
+	}
+	if result.Total != 1 || len(result.Items) != 1 || result.Items[0].Number != 1 {
+		t.Errorf("SearchIssues(is:closed) = %d items (total %d), want #1", len(result.Items), result.Total)
+	}
+	result, err = c.SearchIssues(ctx, "repo:octocat/hello-world label:bug", &clientv1.SearchOptions{PerPage: 50, Page: 3})
+	if err != nil {
+		t.Fatalf("SearchIssues(label:bug) error = %v", err)
+	}
+	if result.Total != n/2 || len(result.Items) != 25 {
+		t.Errorf("SearchIssues(label:bug) page 3 = %d items (total %d), want 25 (total %d)", len(result.Items), result.Total, n/2)
+	}
+}
+
+func TestReleaseAssets(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestServer(t)
+
+	rel, err := c.CreateRelease(ctx, testOwner, testRepo, &clientv1.CreateReleaseInput{
+		TagName: "v1.0.0",
+		Name:    "v1.0.0",
+	})
+	if err != nil {
+		t.Fatalf("CreateRelease() error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "checksums.txt")
+	if err := os.WriteFile(path, []byte("abc123  app.tar.gz\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gh := c.Raw().(*github.Client)
+	if _, _, err := gh.Repositories.UploadReleaseAsset(ctx, testOwner, testRepo, rel.ID, &github.UploadOptions{
+		Name:  "checksums.txt",
+		Label: "Checksums",
+	}, f); err != nil {
+		t.Fatalf("UploadReleaseAsset() error = %v", err)
+	}
+
+	assets, err := c.ListReleaseAssets(ctx, testOwner, testRepo, rel.ID)
+	if err != nil {
+		t.Fatalf("ListReleaseAssets() error = %v", err)
+	}
+	if len(assets) != 1 {
+		t.Fatalf("len(ListReleaseAssets()) = %d, want 1", len(assets))
+	}
+	if a := assets[0]; a.Name != "checksums.txt" || a.Label != "Checksums" || a.Size != 19 || !strings.HasPrefix(a.ContentType, "text/plain") {
+		t.Errorf("ListReleaseAssets()[0] = %+v, want checksums.txt text/plain 19 bytes", a)
+	}
+
+	latest, err := c.GetLatestRelease(ctx, testOwner, testRepo)
+	if err != nil {
+		t.Fatalf("GetLatestRelease() error = %v", err)
+	}
+	if latest.ID != rel.ID || len(latest.Assets) != 1 {
+		t.Errorf("GetLatestRelease() = %d with %d assets, want %d with 1", latest.ID, len(latest.Assets), rel.ID)
+	}
+	if err := c.DeleteRelease(ctx, testOwner, testRepo, rel.ID); err != nil {
+		t.Fatalf("DeleteRelease() error = %v", err)
+	}
+	if _, err := c.GetRelease(ctx, testOwner, testRepo, rel.ID); !errors.IsNotFound(err) {
+		t.Errorf("GetRelease() after delete error = %v, want not found", err)
+	}
+}
+
+func TestChecks(t *testing.T) {
+	ctx := context.Background()
+	srv, c := newTestServer(t)
+
+	sha, err := c.GetBranchSHA(ctx, testOwner, testRepo, "main")
+	if err != nil {
+		t.Fatalf("GetBranchSHA() error = %v", err)
+	}
+	for _, name := range []string{"build", "lint"} {
+		if _, err := srv.Fake.AddCheckRun(testOwner, testRepo, &gogithub.CheckRun{
+			Name:       name,
+			HeadSHA:    sha,
+			Status:     "completed",
+			Conclusion: "success",
+		}); err != nil {
+			t.Fatalf("AddCheckRun() error = %v", err)
+		}
+	}
+
+	runs, err := c.ListCheckRuns(ctx, testOwner, testRepo, "main")
+	if err != nil {
+		t.Fatalf("ListCheckRuns() error = %v", err)
+	}
+	if len(runs) != 2 {
+		t.Fatalf("len(ListCheckRuns()) = %d, want 2", len(runs))
+	}
+	run, err := c.GetCheckRun(ctx, testOwner, testRepo, runs[0].ID)
+	if err != nil {
+		t.Fatalf("GetCheckRun() error = %v", err)
+	}
+	if run.Conclusion != "success" {
+		t.Errorf("GetCheckRun().Conclusion = %q, want success", run.Conclusion)
+	}
+}
+
+func TestMembers(t *testing.T) {
+	ctx := context.Background()
+	srv, c := newTestServer(t)
+	for i := range 120 {
+		srv.Fake.AddOrgMember("acme", fmt.Sprintf("dev%03d", i))
+	}
+	srv.Fake.AddTeamMember("acme", "platform", "dev007")
+	srv.Fake.AddTeamMember("acme", "platform", "dev003")
+
+	members, err := c.ListOrgMembers(ctx, "acme")
+	if err != nil || len(members) != 120 {
+		t.Fatalf("ListOrgMembers() = %d members, %v; want 120", len(members), err)
+	}
+	team, err := c.ListTeamMembers(ctx, "acme", "platform")
+	if err != nil || len(team) != 2 || team[0].Login != "dev003" {
+		t.Errorf("ListTeamMembers() = %v, %v; want dev003, dev007", team, err)
+	}
+	if _, err := c.ListTeamMembers(ctx, "acme", "missing"); !errors.IsNotFound(err) {
+		t.Errorf("ListTeamMembers(missing) error = %v, want not found", err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	ctx := context.Background()
+	srv, c := newTestServer(t)
+
+	if _, err := c.GetRepository(ctx, testOwner, "missing"); !errors.IsNotFound(err) {
+		t.Errorf("GetRepository(missing) error = %v, want not found", err)
+	}
+	if _, err := c.GetPullRequest(ctx, testOwner, testRepo, 42); !errors.IsNotFound(err) {
+		t.Errorf("GetPullRequest(42) error = %v, want not found", err)
+	}
+
+	opts := srv.ClientOptions()
+	opts.Token = "wrong"
+	bad, err := clientv1.NewClientWithOptions(ctx, opts)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+	_, err = bad.GetRepository(ctx, testOwner, testRepo)
+	if code := errors.StatusCode(err); code != http.StatusUnauthorized {
+		t.Errorf("GetRepository() with bad token status = %d (%v), want 401", code, err)
+	}
+}
@@ -0,0 +1,537 @@
+package githubtest
+
+import (
+	"encoding/base64"
+	"time"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/grokify/gogithub"
+	"github.com/grokify/gogithub/clientv1"
+)
+
+// The functions in this file are the inverse of clientv1's conversions:
+// they turn the fake's stable types back into the go-github types whose
+// JSON encoding is GitHub's wire format.
+
+// timestamp returns nil for the zero time, which GitHub omits.
+func timestamp(t time.Time) *github.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return &github.Timestamp{Time: t}
+}
+
+func timestampPtr(t *time.Time) *github.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamp(*t)
+}
+
+// convertAll converts a slice with fn.
+func convertAll[T, U any](items []T, fn func(T) U) []U {
+	result := make([]U, len(items))
+	for i, item := range items {
+		result[i] = fn(item)
+	}
+	return result
+}
+
+func userToGitHub(u *gogithub.User) *github.User {
+	if u == nil {
+		return nil
+	}
+	return &github.User{
+		ID:        github.Ptr(u.ID),
+		Login:     github.Ptr(u.Login),
+		Name:      github.Ptr(u.Name),
+		Email:     github.Ptr(u.Email),
+		AvatarURL: github.Ptr(u.AvatarURL),
+		HTMLURL:   github.Ptr(u.HTMLURL),
+		Type:      github.Ptr(u.Type),
+		Bio:       github.Ptr(u.Bio),
+		Company:   github.Ptr(u.Company),
+		Location:  github.Ptr(u.Location),
+		Blog:      github.Ptr(u.Blog),
+		Followers: github.Ptr(u.Followers),
+		Following: github.Ptr(u.Following),
+		CreatedAt: timestamp(u.CreatedAt),
+		UpdatedAt: timestamp(u.UpdatedAt),
+	}
+}
+
+func repositoryToGitHub(r *gogithub.Repository) *github.Repository {
+	if r == nil {
+		return nil
+	}
+	return &github.Repository{
+		ID:              github.Ptr(r.ID),
+		Owner:           userToGitHub(r.Owner),
+		Name:            github.Ptr(r.Name),
+		FullName:        github.Ptr(r.FullName),
+		Description:     github.Ptr(r.Description),
+		HTMLURL:         github.Ptr(r.HTMLURL),
+		CloneURL:        github.Ptr(r.CloneURL),
+		SSHURL:          github.Ptr(r.SSHURL),
+		DefaultBranch:   github.Ptr(r.DefaultBranch),
+		Private:         github.Ptr(r.Private),
+		Visibility:      github.Ptr(r.Visibility),
+		Fork:            github.Ptr(r.Fork),
+		Archived:        github.Ptr(r.Archived),
+		Disabled:        github.Ptr(r.Disabled),
+		Language:        github.Ptr(r.Language),
+		Topics:          r.Topics,
+		ForksCount:      github.Ptr(r.ForksCount),
+		StargazersCount: github.Ptr(r.StargazersCount),
+		WatchersCount:   github.Ptr(r.WatchersCount),
+		OpenIssuesCount: github.Ptr(r.OpenIssuesCount),
+		Size:            github.Ptr(r.Size),
+		CreatedAt:       timestamp(r.CreatedAt),
+		UpdatedAt:       timestamp(r.UpdatedAt),
+		PushedAt:        timestamp(r.PushedAt),
+	}
+}
+
+func referenceToGitHub(r *gogithub.Reference) *github.Reference {
+	ref := &github.Reference{
+		Ref: github.Ptr(r.Ref),
+		URL: github.Ptr(r.URL),
+	}
+	if r.Object != nil {
+		ref.Object = &github.GitObject{
+			Type: github.Ptr(r.Object.Type),
+			SHA:  github.Ptr(r.Object.SHA),
+			URL:  github.Ptr(r.Object.URL),
+		}
+	}
+	return ref
+}
+
+func commitAuthorToGitHub(a *gogithub.CommitAuthor) *github.CommitAuthor {
+	if a == nil {
+		return nil
+	}
+	return &github.CommitAuthor{
+		Name:  github.Ptr(a.Name),
+		Email: github.Ptr(a.Email),
+		Date:  timestamp(a.Date),
+	}
+}
+
+// gitCommitToGitHub converts a commit to a Git Data API commit object.
+func gitCommitToGitHub(c *gogithub.Commit) *github.Commit {
+	if c == nil {
+		return nil
+	}
+	commit := &github.Commit{
+		SHA:       github.Ptr(c.SHA),
+		Message:   github.Ptr(c.Message),
+		Author:    commitAuthorToGitHub(c.Author),
+		Committer: commitAuthorToGitHub(c.Committer),
+		HTMLURL:   github.Ptr(c.HTMLURL),
+	}
+	if c.Tree != nil {
+		commit.Tree = &github.Tree{SHA: github.Ptr(c.Tree.SHA)}
+	}
+	for _, p := range c.Parents {
+		commit.Parents = append(commit.Parents, &github.Commit{
+			SHA: github.Ptr(p.SHA),
+			URL: github.Ptr(p.URL),
+		})
+	}
+	return commit
+}
+
+// commitToGitHub converts a commit to a REST API repository commit, which
+// wraps the git commit object.
+func commitToGitHub(c *gogithub.Commit) *github.RepositoryCommit {
+	if c == nil {
+		return nil
+	}
+	gc := gitCommitToGitHub(c)
+	return &github.RepositoryCommit{
+		SHA:     gc.SHA,
+		HTMLURL: gc.HTMLURL,
+		Commit:  gc,
+		Parents: gc.Parents,
+	}
+}
+
+func branchToGitHub(b *gogithub.Branch) *github.Branch {
+	return &github.Branch{
+		Name:      github.Ptr(b.Name),
+		Protected: github.Ptr(b.Protected),
+		Commit:    commitToGitHub(b.Commit),
+	}
+}
+
+func tagToGitHub(t *gogithub.Tag) *github.RepositoryTag {
+	tag := &github.RepositoryTag{Name: github.Ptr(t.Name)}
+	if t.SHA != "" {
+		tag.Commit = &github.Commit{SHA: github.Ptr(t.SHA)}
+	}
+	return tag
+}
+
+func labelsToGitHub(labels []gogithub.Label) []*github.Label {
+	result := make([]*github.Label, len(labels))
+	for i, l := range labels {
+		result[i] = &github.Label{
+			ID:          github.Ptr(l.ID),
+			Name:        github.Ptr(l.Name),
+			Description: github.Ptr(l.Description),
+			Color:       github.Ptr(l.Color),
+		}
+	}
+	return result
+}
+
+func pullRequestBranchToGitHub(b *gogithub.PullRequestBranch) *github.PullRequestBranch {
+	if b == nil {
+		return nil
+	}
+	return &github.PullRequestBranch{
+		Label: github.Ptr(b.Label),
+		Ref:   github.Ptr(b.Ref),
+		SHA:   github.Ptr(b.SHA),
+		User:  userToGitHub(b.User),
+		Repo:  repositoryToGitHub(b.Repo),
+	}
+}
+
+func pullRequestToGitHub(pr *gogithub.PullRequest) *github.PullRequest {
+	return &github.PullRequest{
+		ID:        github.Ptr(pr.ID),
+		Number:    github.Ptr(pr.Number),
+		State:     github.Ptr(pr.State),
+		Title:     github.Ptr(pr.Title),
+		Body:      github.Ptr(pr.Body),
+		HTMLURL:   github.Ptr(pr.HTMLURL),
+		User:      userToGitHub(pr.User),
+		Head:      pullRequestBranchToGitHub(pr.Head),
+		Base:      pullRequestBranchToGitHub(pr.Base),
+		Labels:    labelsToGitHub(pr.Labels),
+		Assignees: convertAll(pr.Assignees, userToGitHub),
+		Merged:    github.Ptr(pr.Merged),
+		Mergeable: pr.Mergeable,
+		Draft:     github.Ptr(pr.Draft),
+		Additions: github.Ptr(pr.Additions),
+		Deletions: github.Ptr(pr.Deletions),
+		Commits:   github.Ptr(pr.Commits),
+		CreatedAt: timestamp(pr.CreatedAt),
+		UpdatedAt: timestamp(pr.UpdatedAt),
+		ClosedAt:  timestampPtr(pr.ClosedAt),
+		MergedAt:  timestampPtr(pr.MergedAt),
+	}
+}
+
+func commitFileToGitHub(f *gogithub.CommitFile) *github.CommitFile {
+	return &github.CommitFile{
+		SHA:              github.Ptr(f.SHA),
+		Filename:         github.Ptr(f.Filename),
+		Status:           github.Ptr(f.Status),
+		Additions:        github.Ptr(f.Additions),
+		Deletions:        github.Ptr(f.Deletions),
+		Changes:          github.Ptr(f.Changes),
+		Patch:            github.Ptr(f.Patch),
+		BlobURL:          github.Ptr(f.BlobURL),
+		RawURL:           github.Ptr(f.RawURL),
+		ContentsURL:      github.Ptr(f.ContentsURL),
+		PreviousFilename: github.Ptr(f.Previous),
+	}
+}
+
+func pullRequestReviewToGitHub(r *gogithub.PullRequestReview) *github.PullRequestReview {
+	return &github.PullRequestReview{
+		ID:          github.Ptr(r.ID),
+		User:        userToGitHub(r.User),
+		Body:        github.Ptr(r.Body),
+		State:       github.Ptr(r.State),
+		HTMLURL:     github.Ptr(r.HTMLURL),
+		CommitID:    github.Ptr(r.CommitID),
+		SubmittedAt: timestampPtr(r.SubmittedAt),
+	}
+}
+
+func pullRequestCommentToGitHub(c *gogithub.PullRequestComment) *github.PullRequestComment {
+	return &github.PullRequestComment{
+		ID:        github.Ptr(c.ID),
+		User:      userToGitHub(c.User),
+		Body:      github.Ptr(c.Body),
+		Path:      github.Ptr(c.Path),
+		Line:      github.Ptr(c.Line),
+		Side:      github.Ptr(c.Side),
+		CommitID:  github.Ptr(c.CommitID),
+		HTMLURL:   github.Ptr(c.HTMLURL),
+		CreatedAt: timestamp(c.CreatedAt),
+		UpdatedAt: timestamp(c.UpdatedAt),
+	}
+}
+
+func issueToGitHub(i *gogithub.Issue) *github.Issue {
+	issue := &github.Issue{
+		ID:            github.Ptr(i.ID),
+		Number:        github.Ptr(i.Number),
+		State:         github.Ptr(i.State),
+		Title:         github.Ptr(i.Title),
+		Body:          github.Ptr(i.Body),
+		HTMLURL:       github.Ptr(i.HTMLURL),
+		RepositoryURL: github.Ptr(i.RepositoryURL),
+		User:          userToGitHub(i.User),
+		Labels:        labelsToGitHub(i.Labels),
+		Assignees:     convertAll(i.Assignees, userToGitHub),
+		Comments:      github.Ptr(i.Comments),
+		CreatedAt:     timestamp(i.CreatedAt),
+		UpdatedAt:     timestamp(i.UpdatedAt),
+		ClosedAt:      timestampPtr(i.ClosedAt),
+	}
+	if i.IsPullRequest {
+		issue.PullRequestLinks = &github.PullRequestLinks{HTMLURL: github.Ptr(i.HTMLURL)}
+	}
+	return issue
+}
+
+func issueCommentToGitHub(c *gogithub.IssueComment) *github.IssueComment {
+	return &github.IssueComment{
+		ID:        github.Ptr(c.ID),
+		User:      userToGitHub(c.User),
+		Body:      github.Ptr(c.Body),
+		HTMLURL:   github.Ptr(c.HTMLURL),
+		CreatedAt: timestamp(c.CreatedAt),
+		UpdatedAt: timestamp(c.UpdatedAt),
+	}
+}
+
+func checkRunToGitHub(cr *gogithub.CheckRun) *github.CheckRun {
+	return &github.CheckRun{
+		ID:          github.Ptr(cr.ID),
+		HeadSHA:     github.Ptr(cr.HeadSHA),
+		Status:      github.Ptr(cr.Status),
+		Conclusion:  github.Ptr(cr.Conclusion),
+		Name:        github.Ptr(cr.Name),
+		HTMLURL:     github.Ptr(cr.HTMLURL),
+		StartedAt:   timestampPtr(cr.StartedAt),
+		CompletedAt: timestampPtr(cr.CompletedAt),
+	}
+}
+
+func checkSuiteToGitHub(cs *gogithub.CheckSuite) *github.CheckSuite {
+	suite := &github.CheckSuite{
+		ID:         github.Ptr(cs.ID),
+		HeadBranch: github.Ptr(cs.HeadBranch),
+		HeadSHA:    github.Ptr(cs.HeadSHA),
+		Status:     github.Ptr(cs.Status),
+		Conclusion: github.Ptr(cs.Conclusion),
+		URL:        github.Ptr(cs.URL),
+		CreatedAt:  timestamp(cs.CreatedAt),
+		UpdatedAt:  timestamp(cs.UpdatedAt),
+	}
+	if cs.App != nil {
+		suite.App = &github.App{
+			ID:          github.Ptr(cs.App.ID),
+			Slug:        github.Ptr(cs.App.Slug),
+			Name:        github.Ptr(cs.App.Name),
+			Description: github.Ptr(cs.App.Description),
+			HTMLURL:     github.Ptr(cs.App.HTMLURL),
+		}
+	}
+	return suite
+}
+
+func releaseAssetToGitHub(a *gogithub.ReleaseAsset) *github.ReleaseAsset {
+	return &github.ReleaseAsset{
+		ID:                 github.Ptr(a.ID),
+		Name:               github.Ptr(a.Name),
+		Label:              github.Ptr(a.Label),
+		State:              github.Ptr(a.State),
+		ContentType:        github.Ptr(a.ContentType),
+		Size:               github.Ptr(a.Size),
+		DownloadCount:      github.Ptr(a.DownloadCount),
+		BrowserDownloadURL: github.Ptr(a.BrowserDownloadURL),
+		CreatedAt:          timestamp(a.CreatedAt),
+		UpdatedAt:          timestamp(a.UpdatedAt),
+	}
+}
+
+func releaseToGitHub(r *gogithub.Release) *github.RepositoryRelease {
+	release := &github.RepositoryRelease{
+		ID:              r.ID,
+		TagName:         r.TagName,
+		TargetCommitish: r.TargetCommitish,
+		Name:            github.Ptr(r.Name),
+		Body:            github.Ptr(r.Body),
+		Draft:           r.Draft,
+		Prerelease:      r.Prerelease,
+		HTMLURL:         r.HTMLURL,
+		TarballURL:      github.Ptr(r.TarballURL),
+		ZipballURL:      github.Ptr(r.ZipballURL),
+		CreatedAt:       github.Timestamp{Time: r.CreatedAt},
+		PublishedAt:     timestampPtr(r.PublishedAt),
+		Author:          userToGitHub(r.Author),
+	}
+	for i := range r.Assets {
+		release.Assets = append(release.Assets, releaseAssetToGitHub(&r.Assets[i]))
+	}
+	return release
+}
+
+// fileContentToGitHub converts a file or directory entry. File content is
+// base64-encoded, as in GitHub's contents API.
+func fileContentToGitHub(c *gogithub.FileContent) *github.RepositoryContent {
+	if c == nil {
+		return nil
+	}
+	content := &github.RepositoryContent{
+		Type:        github.Ptr(c.Type),
+		Name:        github.Ptr(c.Name),
+		Path:        github.Ptr(c.Path),
+		SHA:         github.Ptr(c.SHA),
+		Size:        github.Ptr(c.Size),
+		DownloadURL: github.Ptr(c.DownloadURL),
+	}
+	if c.Content != nil {
+		content.Encoding = github.Ptr("base64")
+		content.Content = github.Ptr(base64.StdEncoding.EncodeToString(c.Content))
+	}
+	return content
+}
+
+func treeNodeToGitHub(n *gogithub.TreeNode) *github.TreeEntry {
+	entry := &github.TreeEntry{
+		Path: github.Ptr(n.Path),
+		Mode: github.Ptr(n.Mode),
+		Type: github.Ptr(n.Type),
+		SHA:  github.Ptr(n.SHA),
+		URL:  github.Ptr(n.URL),
+	}
+	if n.Type == "blob" {
+		entry.Size = github.Ptr(n.Size)
+	}
+	return entry
+}
+
+func contentResponseToGitHub(content *gogithub.FileContent, commit *gogithub.Commit) *github.RepositoryContentResponse {
+	resp := &github.RepositoryContentResponse{Content: fileContentToGitHub(content)}
+	if c := gitCommitToGitHub(commit); c != nil {
+		resp.Commit = *c
+	}
+	return resp
+}
+
+func codeResultToGitHub(c *gogithub.CodeResult) *github.CodeResult {
+	return &github.CodeResult{
+		Name:       github.Ptr(c.Name),
+		Path:       github.Ptr(c.Path),
+		SHA:        github.Ptr(c.SHA),
+		HTMLURL:    github.Ptr(c.HTMLURL),
+		Repository: repositoryToGitHub(c.Repository),
+	}
+}
+
+func eventToGitHub(e *gogithub.Event) *github.Event {
+	event := &github.Event{
+		ID:        github.Ptr(e.ID),
+		Type:      github.Ptr(e.Type),
+		Public:    github.Ptr(e.Public),
+		Actor:     userToGitHub(e.Actor),
+		CreatedAt: timestamp(e.CreatedAt),
+	}
+	if e.Repo != nil {
+		event.Repo = &github.Repository{
+			ID:   github.Ptr(e.Repo.ID),
+			Name: github.Ptr(e.Repo.Name),
+			URL:  github.Ptr(e.Repo.URL),
+		}
+	}
+	return event
+}
+
+func contributorStatsToGitHub(s *gogithub.ContributorStats) *github.ContributorStats {
+	stats := &github.ContributorStats{Total: github.Ptr(s.Total)}
+	if s.Author != nil {
+		stats.Author = &github.Contributor{
+			ID:        github.Ptr(s.Author.ID),
+			Login:     github.Ptr(s.Author.Login),
+			AvatarURL: github.Ptr(s.Author.AvatarURL),
+			HTMLURL:   github.Ptr(s.Author.HTMLURL),
+			Type:      github.Ptr(s.Author.Type),
+		}
+	}
+	for _, w := range s.Weeks {
+		stats.Weeks = append(stats.Weeks, &github.WeeklyStats{
+			Week:      timestamp(w.Week),
+			Additions: github.Ptr(w.Additions),
+			Deletions: github.Ptr(w.Deletions),
+			Commits:   github.Ptr(w.Commits),
+		})
+	}
+	return stats
+}
+
+func branchProtectionToGitHub(p *gogithub.BranchProtection) *github.Protection {
+	protection := &github.Protection{
+		URL:                github.Ptr(p.URL),
+		EnforceAdmins:      &github.AdminEnforcement{Enabled: p.EnforceAdmins},
+		RequiredSignatures: &github.SignaturesProtectedBranch{Enabled: github.Ptr(p.RequireSignedCommits)},
+		AllowForcePushes:   &github.AllowForcePushes{Enabled: p.AllowForcePushes},
+		AllowDeletions:     &github.AllowDeletions{Enabled: p.AllowDeletions},
+	}
+	if rsc := p.RequiredStatusChecks; rsc != nil {
+		protection.RequiredStatusChecks = &github.RequiredStatusChecks{
+			Strict:   rsc.Strict,
+			Contexts: &rsc.Contexts,
+		}
+	}
+	if rpr := p.RequiredPullRequestReviews; rpr != nil {
+		protection.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcement{
+			DismissStaleReviews:          rpr.DismissStaleReviews,
+			RequireCodeOwnerReviews:      rpr.RequireCodeOwnerReviews,
+			RequiredApprovingReviewCount: rpr.RequiredApprovingReviewCount,
+		}
+	}
+	return protection
+}
+
+func workflowToGitHub(w *gogithub.Workflow) *github.Workflow {
+	return &github.Workflow{
+		ID:        github.Ptr(w.ID),
+		Name:      github.Ptr(w.Name),
+		Path:      github.Ptr(w.Path),
+		State:     github.Ptr(w.State),
+		URL:       github.Ptr(w.URL),
+		HTMLURL:   github.Ptr(w.HTMLURL),
+		BadgeURL:  github.Ptr(w.BadgeURL),
+		CreatedAt: timestamp(w.CreatedAt),
+		UpdatedAt: timestamp(w.UpdatedAt),
+	}
+}
+
+func workflowRunToGitHub(r *gogithub.WorkflowRun) *github.WorkflowRun {
+	return &github.WorkflowRun{
+		ID:         github.Ptr(r.ID),
+		Name:       github.Ptr(r.Name),
+		WorkflowID: github.Ptr(r.WorkflowID),
+		RunNumber:  github.Ptr(r.RunNumber),
+		Event:      github.Ptr(r.Event),
+		Status:     github.Ptr(r.Status),
+		Conclusion: github.Ptr(r.Conclusion),
+		HeadBranch: github.Ptr(r.HeadBranch),
+		HeadSHA:    github.Ptr(r.HeadSHA),
+		URL:        github.Ptr(r.URL),
+		HTMLURL:    github.Ptr(r.HTMLURL),
+		CreatedAt:  timestamp(r.CreatedAt),
+		UpdatedAt:  timestamp(r.UpdatedAt),
+	}
+}
+
+// commitAuthorFromGitHub converts the author of a write request.
+func commitAuthorFromGitHub(a *github.CommitAuthor) *clientv1.CommitAuthor {
+	if a == nil {
+		return nil
+	}
+	author := &clientv1.CommitAuthor{Name: a.GetName(), Email: a.GetEmail()}
+	if a.Date != nil {
+		author.Date = &a.Date.Time
+	}
+	return author
+}
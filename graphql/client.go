@@ -9,11 +9,15 @@ import (
 )
 
 // NewClient creates a GitHub GraphQL client authenticated with the given token.
+// To share the transport, middleware and Enterprise URL of a REST client,
+// use clientv1.NewGraphQLClient instead.
 func NewClient(ctx context.Context, token string) *githubv4.Client {
 	return githubv4.NewClient(auth.NewTokenClient(ctx, token))
 }
 
 // NewEnterpriseClient creates a GitHub GraphQL client for GitHub Enterprise.
+// baseURL is the GraphQL endpoint, e.g. "https://github.example.com/api/graphql";
+// clientv1.GraphQLURL derives it from the REST base URL.
 func NewEnterpriseClient(ctx context.Context, token, baseURL string) *githubv4.Client {
 	return githubv4.NewEnterpriseClient(baseURL, auth.NewTokenClient(ctx, token))
 }
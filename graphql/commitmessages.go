@@ -0,0 +1,104 @@
+package graphql
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// CommitMessage is a commit authored by a user, with its full message.
+type CommitMessage struct {
+	Owner         string
+	Repo          string
+	OID           string
+	Message       string
+	CommittedDate time.Time
+}
+
+// commitMessagesQuery fetches the messages of a user's commits to a
+// repository's default branch.
+type commitMessagesQuery struct {
+	RateLimited
+	Repository struct {
+		DefaultBranchRef struct {
+			Target struct {
+				Commit struct {
+					History struct {
+						PageInfo PageInfo
+						Nodes    []struct {
+							OID           githubv4.GitObjectID `graphql:"oid"`
+							Message       githubv4.String
+							CommittedDate githubv4.DateTime
+						}
+					} `graphql:"history(first: 100, after: $cursor, author: {id: $authorId}, since: $since, until: $until)"`
+				} `graphql:"... on Commit"`
+			}
+		}
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// PageInfo implements Query.
+func (q *commitMessagesQuery) PageInfo() PageInfo {
+	return q.Repository.DefaultBranchRef.Target.Commit.History.PageInfo
+}
+
+// GetCommitMessages retrieves the messages of the commits a user authored
+// on the default branches of the repositories they contributed to, oldest
+// first. It traverses the same histories as GetCommitStatsWithOptions, in
+// the same way, so opts, inaccessible repositories and fatal errors are
+// handled alike.
+func GetCommitMessages(ctx context.Context, client *githubv4.Client, username string, from, to time.Time, opts *CommitStatsOptions) ([]CommitMessage, error) {
+	if opts == nil {
+		opts = &CommitStatsOptions{}
+	}
+
+	results, err := traverseRepositories(ctx, client, username, opts, "commit messages",
+		func(ctx context.Context, repo ContributedRepository, authorID githubv4.ID) ([]CommitMessage, error) {
+			return getRepoCommitMessages(ctx, client, repo.Owner, repo.Name, authorID, from, to, opts.Paginate)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []CommitMessage
+	for _, result := range results {
+		messages = append(messages, result...)
+	}
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].CommittedDate.Before(messages[j].CommittedDate)
+	})
+
+	return messages, nil
+}
+
+// getRepoCommitMessages fetches a user's commit messages for a specific
+// repository.
+func getRepoCommitMessages(ctx context.Context, client *githubv4.Client, owner, name string, authorID githubv4.ID, from, to time.Time, popts *PaginateOptions) ([]CommitMessage, error) {
+	variables := map[string]any{
+		"owner":    githubv4.String(owner),
+		"name":     githubv4.String(name),
+		"authorId": authorID,
+		"since":    githubv4.GitTimestamp{Time: from},
+		"until":    githubv4.GitTimestamp{Time: to},
+	}
+
+	var messages []CommitMessage
+	for query, err := range Pages[commitMessagesQuery](ctx, client, variables, popts) {
+		if err != nil {
+			return nil, err
+		}
+		for _, commit := range query.Repository.DefaultBranchRef.Target.Commit.History.Nodes {
+			messages = append(messages, CommitMessage{
+				Owner:         owner,
+				Repo:          name,
+				OID:           string(commit.OID),
+				Message:       string(commit.Message),
+				CommittedDate: commit.CommittedDate.Time,
+			})
+		}
+	}
+
+	return messages, nil
+}
@@ -2,9 +2,14 @@ package graphql
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sort"
+	"sync"
 	"time"
 
+	ghErrors "github.com/grokify/gogithub/errors"
+	"github.com/grokify/gogithub/internal/parallel"
 	"github.com/shurcooL/githubv4"
 )
 
@@ -51,46 +56,63 @@ func (mcs MonthlyCommitStats) YearMonth() string {
 type RepoCommitStats struct {
 	Owner     string
 	Name      string
+	URL       string // web URL, e.g. "https://github.com/octocat/hello"
 	IsPrivate bool
 	Commits   int
 	Additions int
 	Deletions int
+	ByMonth   []MonthlyCommitStats // the repository's commits by month
+
+	// CommitTimes are the timestamps of the repository's commits, one
+	// per commit.
+	CommitTimes []CommitTime
+}
+
+// CommitTime holds a commit's timestamps. AuthoredDate is when the commit
+// was originally written, and CommittedDate when it was last applied,
+// e.g. by a rebase or cherry-pick.
+type CommitTime struct {
+	AuthoredDate  time.Time
+	CommittedDate time.Time
 }
 
 // repositoriesContributedToQuery fetches repositories user has contributed to.
 type repositoriesContributedToQuery struct {
+	RateLimited
 	User struct {
 		ID                        githubv4.ID
 		RepositoriesContributedTo struct {
-			PageInfo struct {
-				HasNextPage bool
-				EndCursor   githubv4.String
-			}
-			Nodes []struct {
+			PageInfo PageInfo
+			Nodes    []struct {
 				Owner struct {
 					Login githubv4.String
 				}
 				Name      githubv4.String
+				URL       githubv4.URI
 				IsPrivate githubv4.Boolean
 			}
 		} `graphql:"repositoriesContributedTo(first: 100, after: $cursor, contributionTypes: COMMIT, includeUserRepositories: true)"`
 	} `graphql:"user(login: $login)"`
 }
 
+// PageInfo implements Query.
+func (q *repositoriesContributedToQuery) PageInfo() PageInfo {
+	return q.User.RepositoriesContributedTo.PageInfo
+}
+
 // commitHistoryQuery fetches commit history for a specific repository.
 type commitHistoryQuery struct {
+	RateLimited
 	Repository struct {
 		DefaultBranchRef struct {
 			Target struct {
 				Commit struct {
 					History struct {
-						PageInfo struct {
-							HasNextPage bool
-							EndCursor   githubv4.String
-						}
-						Nodes []struct {
+						PageInfo PageInfo
+						Nodes    []struct {
 							Additions     githubv4.Int
 							Deletions     githubv4.Int
+							AuthoredDate  githubv4.DateTime
 							CommittedDate githubv4.DateTime
 						}
 					} `graphql:"history(first: 100, after: $cursor, author: {id: $authorId}, since: $since, until: $until)"`
@@ -100,28 +122,84 @@ type commitHistoryQuery struct {
 	} `graphql:"repository(owner: $owner, name: $name)"`
 }
 
+// PageInfo implements Query.
+func (q *commitHistoryQuery) PageInfo() PageInfo {
+	return q.Repository.DefaultBranchRef.Target.Commit.History.PageInfo
+}
+
+// DefaultConcurrency is the number of repositories whose commit histories
+// GetCommitStats fetches at once.
+const DefaultConcurrency = 4
+
 // CommitStatsProgressFunc is called to report progress during commit stats fetching.
 // current is the number of repositories processed, total is the total number of repositories.
 type CommitStatsProgressFunc func(current, total int)
 
+// CommitStatsOptions configures GetCommitStatsWithOptions. A nil
+// *CommitStatsOptions uses the defaults.
+type CommitStatsOptions struct {
+	// Visibility filters which repositories to include. Default: VisibilityAll.
+	Visibility Visibility
+
+	// Concurrency is the number of repositories whose commit histories are
+	// fetched at once. Default: DefaultConcurrency.
+	Concurrency int
+
+	// Paginate controls the pagination of the repository list and of each
+	// repository's commit history, as for Pages. Its MaxCost and
+	// MinRemaining budgets apply to each of these connections separately.
+	// A budget exhausted in any of them stops the traversal with
+	// ErrBudgetExceeded. Nil uses the defaults.
+	Paginate *PaginateOptions
+
+	// Contributor, if set, is the user and repositories to traverse, as
+	// resolved by GetContributor for the same username and Visibility.
+	// Callers fetching several date ranges set it so that the user and
+	// their repositories are looked up once rather than for each range.
+	Contributor *Contributor
+
+	// Progress, if set, is called after each repository is processed. Calls
+	// are made one at a time, with current increasing by one each call.
+	Progress CommitStatsProgressFunc
+}
+
 // GetCommitStats retrieves detailed commit statistics including additions and deletions.
 // This method iterates through all repositories the user has contributed to and aggregates
 // commit data. Use the visibility parameter to filter by public/private repositories.
 func GetCommitStats(ctx context.Context, client *githubv4.Client, username string, from, to time.Time, visibility Visibility) (*CommitStats, error) {
-	return GetCommitStatsWithProgress(ctx, client, username, from, to, visibility, nil)
+	return GetCommitStatsWithOptions(ctx, client, username, from, to, &CommitStatsOptions{Visibility: visibility})
 }
 
 // GetCommitStatsWithProgress is like GetCommitStats but accepts a progress callback.
 // The callback is invoked after each repository is processed.
 func GetCommitStatsWithProgress(ctx context.Context, client *githubv4.Client, username string, from, to time.Time, visibility Visibility, progress CommitStatsProgressFunc) (*CommitStats, error) {
-	// First, get the user's ID for author filtering
-	userID, err := getUserID(ctx, client, username)
-	if err != nil {
-		return nil, err
+	return GetCommitStatsWithOptions(ctx, client, username, from, to, &CommitStatsOptions{Visibility: visibility, Progress: progress})
+}
+
+// GetCommitStatsWithOptions is like GetCommitStats but fetches the commit
+// histories of opts.Concurrency repositories at once. Repositories that
+// cannot be queried, e.g. because access was lost, are skipped. Other
+// failures, such as an exhausted rate limit or a server error that
+// persists after retries, are fatal: the fetches in flight are cancelled
+// and the error is returned.
+func GetCommitStatsWithOptions(ctx context.Context, client *githubv4.Client, username string, from, to time.Time, opts *CommitStatsOptions) (*CommitStats, error) {
+	if opts == nil {
+		opts = &CommitStatsOptions{}
 	}
 
-	// Get all repositories the user has contributed to
-	repos, err := getContributedRepositories(ctx, client, username, visibility)
+	type repoResult struct {
+		stats   *RepoCommitStats
+		byMonth map[string]*MonthlyCommitStats
+	}
+	results, err := traverseRepositories(ctx, client, username, opts, "commit history",
+		func(ctx context.Context, repo ContributedRepository, authorID githubv4.ID) (repoResult, error) {
+			repoStats, monthData, err := getRepoCommitStats(ctx, client, repo, authorID, from, to, opts.Paginate)
+			if err != nil {
+				return repoResult{}, err
+			}
+			repoStats.ByMonth = monthlyMapToSlice(monthData)
+			return repoResult{stats: repoStats, byMonth: monthData}, nil
+		})
 	if err != nil {
 		return nil, err
 	}
@@ -130,66 +208,155 @@ func GetCommitStatsWithProgress(ctx context.Context, client *githubv4.Client, us
 		Username:   username,
 		From:       from,
 		To:         to,
-		Visibility: visibility,
+		Visibility: opts.Visibility,
 		ByMonth:    []MonthlyCommitStats{},
 		ByRepo:     []RepoCommitStats{},
 	}
 
 	monthlyMap := make(map[string]*MonthlyCommitStats)
-	totalRepos := len(repos)
 
-	// Iterate through each repository and get commit history
-	for i, repo := range repos {
-		repoStats, monthData, err := getRepoCommitStats(ctx, client, repo.Owner, repo.Name, repo.IsPrivate, userID, from, to)
-		if err != nil {
-			// Skip repos we can't access (might have lost access)
-			if progress != nil {
-				progress(i+1, totalRepos)
-			}
+	for _, result := range results {
+		repoStats := result.stats
+		if repoStats == nil || repoStats.Commits == 0 {
 			continue
 		}
-
-		if repoStats.Commits > 0 {
-			stats.ByRepo = append(stats.ByRepo, *repoStats)
-			stats.TotalCommits += repoStats.Commits
-			stats.Additions += repoStats.Additions
-			stats.Deletions += repoStats.Deletions
-
-			// Aggregate monthly data
-			for ym, data := range monthData {
-				if existing, ok := monthlyMap[ym]; ok {
-					existing.Commits += data.Commits
-					existing.Additions += data.Additions
-					existing.Deletions += data.Deletions
-				} else {
-					monthlyMap[ym] = &MonthlyCommitStats{
-						Year:      data.Year,
-						Month:     data.Month,
-						Commits:   data.Commits,
-						Additions: data.Additions,
-						Deletions: data.Deletions,
-					}
+		stats.ByRepo = append(stats.ByRepo, *repoStats)
+		stats.TotalCommits += repoStats.Commits
+		stats.Additions += repoStats.Additions
+		stats.Deletions += repoStats.Deletions
+
+		// Aggregate monthly data
+		for ym, data := range result.byMonth {
+			if existing, ok := monthlyMap[ym]; ok {
+				existing.Commits += data.Commits
+				existing.Additions += data.Additions
+				existing.Deletions += data.Deletions
+			} else {
+				monthlyMap[ym] = &MonthlyCommitStats{
+					Year:      data.Year,
+					Month:     data.Month,
+					Commits:   data.Commits,
+					Additions: data.Additions,
+					Deletions: data.Deletions,
 				}
 			}
 		}
-
-		// Report progress after each repo
-		if progress != nil {
-			progress(i+1, totalRepos)
-		}
 	}
 
 	// Convert monthly map to sorted slice
 	stats.ByMonth = monthlyMapToSlice(monthlyMap)
 
-	// Sort repos by commit count descending
-	sort.Slice(stats.ByRepo, func(i, j int) bool {
+	// Sort repos by commit count descending, keeping ties in repository order
+	sort.SliceStable(stats.ByRepo, func(i, j int) bool {
 		return stats.ByRepo[i].Commits > stats.ByRepo[j].Commits
 	})
 
 	return stats, nil
 }
 
+// traverseRepositories calls fetch for each repository the user has
+// contributed to, with the user's node ID for author filtering, fetching
+// opts.Concurrency repositories at once, and returns the results in
+// repository order. It is the traversal shared by GetCommitStatsWithOptions
+// and GetCommitMessages. Repositories whose fetch fails with an error that
+// is not fatal, e.g. because access was lost, are skipped, leaving a zero
+// result. A fatal error, described as fetching what, cancels the fetches in
+// flight and is returned. opts.Progress is called after each repository.
+func traverseRepositories[T any](ctx context.Context, client *githubv4.Client, username string, opts *CommitStatsOptions, what string, fetch func(ctx context.Context, repo ContributedRepository, authorID githubv4.ID) (T, error)) ([]T, error) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	contributor, err := opts.contributor(ctx, client, username)
+	if err != nil {
+		return nil, err
+	}
+	repos := contributor.Repositories
+
+	// Results are stored by index so they merge in repository order.
+	results := make([]T, len(repos))
+	var mu sync.Mutex
+	processed := 0
+	err = parallel.ForEach(ctx, len(repos), concurrency, func(ctx context.Context, i int) error {
+		repo := repos[i]
+		result, err := fetch(ctx, repo, contributor.ID)
+		switch {
+		case err == nil:
+			results[i] = result
+		case isFatal(err):
+			return fmt.Errorf("get %s of %s/%s: %w", what, repo.Owner, repo.Name, err)
+		}
+		// Otherwise skip repos we can't access (might have lost access)
+
+		mu.Lock()
+		defer mu.Unlock()
+		processed++
+		if opts.Progress != nil {
+			opts.Progress(processed, len(repos))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// isFatal reports whether an error fetching one repository's commit
+// history should abort GetCommitStatsWithOptions rather than skip the
+// repository. GraphQL errors about the repository itself are not fatal;
+// cancellation, exhausted budgets and rate limits, and HTTP failures,
+// which affect every repository, are. Pages translates the latter two
+// into *errors.APIError values.
+func isFatal(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrBudgetExceeded) {
+		return true
+	}
+	var apiErr *ghErrors.APIError
+	return errors.As(err, &apiErr)
+}
+
+// Contributor is a user and the repositories they have contributed commits
+// to, which commit history traversals visit.
+type Contributor struct {
+	Username     string
+	ID           githubv4.ID
+	Visibility   Visibility
+	Repositories []ContributedRepository
+}
+
+// GetContributor looks up a user's node ID and the repositories they have
+// contributed commits to, filtered by opts.Visibility and paginated as
+// opts.Paginate controls. See CommitStatsOptions.Contributor.
+func GetContributor(ctx context.Context, client *githubv4.Client, username string, opts *CommitStatsOptions) (*Contributor, error) {
+	if opts == nil {
+		opts = &CommitStatsOptions{}
+	}
+	userID, err := getUserID(ctx, client, username)
+	if err != nil {
+		return nil, err
+	}
+	repos, err := getContributedRepositories(ctx, client, username, opts.Visibility, opts.Paginate)
+	if err != nil {
+		return nil, err
+	}
+	return &Contributor{
+		Username:     username,
+		ID:           userID,
+		Visibility:   opts.Visibility,
+		Repositories: repos,
+	}, nil
+}
+
+// contributor returns opts.Contributor, or else looks the user up.
+func (opts *CommitStatsOptions) contributor(ctx context.Context, client *githubv4.Client, username string) (*Contributor, error) {
+	if opts.Contributor != nil {
+		return opts.Contributor, nil
+	}
+	return GetContributor(ctx, client, username, opts)
+}
+
 // GetCommitStatsByVisibility returns separate stats for public, private, and combined.
 func GetCommitStatsByVisibility(ctx context.Context, client *githubv4.Client, username string, from, to time.Time) (all, public, private *CommitStats, err error) {
 	all, err = GetCommitStats(ctx, client, username, from, to, VisibilityAll)
@@ -231,26 +398,31 @@ func getUserID(ctx context.Context, client *githubv4.Client, username string) (g
 	return query.User.ID, nil
 }
 
-// repoInfo holds basic repository information.
-type repoInfo struct {
+// ContributedRepository is a repository a user has contributed commits to.
+type ContributedRepository struct {
 	Owner     string
 	Name      string
+	URL       string // web URL, e.g. "https://github.com/octocat/hello"
 	IsPrivate bool
 }
 
-// getContributedRepositories fetches all repositories a user has contributed commits to.
-func getContributedRepositories(ctx context.Context, client *githubv4.Client, username string, visibility Visibility) ([]repoInfo, error) {
-	var repos []repoInfo
-	var cursor *githubv4.String
+// GetContributedRepositories fetches all repositories a user has contributed
+// commits to, filtered by visibility. These are the repositories whose
+// commit histories GetCommitStats traverses.
+func GetContributedRepositories(ctx context.Context, client *githubv4.Client, username string, visibility Visibility) ([]ContributedRepository, error) {
+	return getContributedRepositories(ctx, client, username, visibility, nil)
+}
 
-	for {
-		var query repositoriesContributedToQuery
-		variables := map[string]any{
-			"login":  githubv4.String(username),
-			"cursor": cursor,
-		}
+// getContributedRepositories is GetContributedRepositories, paginating as
+// popts controls.
+func getContributedRepositories(ctx context.Context, client *githubv4.Client, username string, visibility Visibility, popts *PaginateOptions) ([]ContributedRepository, error) {
+	var repos []ContributedRepository
+	variables := map[string]any{
+		"login": githubv4.String(username),
+	}
 
-		if err := client.Query(ctx, &query, variables); err != nil {
+	for query, err := range Pages[repositoriesContributedToQuery](ctx, client, variables, popts) {
+		if err != nil {
 			return nil, err
 		}
 
@@ -269,45 +441,41 @@ func getContributedRepositories(ctx context.Context, client *githubv4.Client, us
 				}
 			}
 
-			repos = append(repos, repoInfo{
+			repo := ContributedRepository{
 				Owner:     string(node.Owner.Login),
 				Name:      string(node.Name),
 				IsPrivate: isPrivate,
-			})
-		}
-
-		if !query.User.RepositoriesContributedTo.PageInfo.HasNextPage {
-			break
+			}
+			if node.URL.URL != nil {
+				repo.URL = node.URL.String()
+			}
+			repos = append(repos, repo)
 		}
-		cursor = &query.User.RepositoriesContributedTo.PageInfo.EndCursor
 	}
 
 	return repos, nil
 }
 
 // getRepoCommitStats fetches commit statistics for a specific repository.
-func getRepoCommitStats(ctx context.Context, client *githubv4.Client, owner, name string, isPrivate bool, authorID githubv4.ID, from, to time.Time) (*RepoCommitStats, map[string]*MonthlyCommitStats, error) {
+func getRepoCommitStats(ctx context.Context, client *githubv4.Client, repo ContributedRepository, authorID githubv4.ID, from, to time.Time, popts *PaginateOptions) (*RepoCommitStats, map[string]*MonthlyCommitStats, error) {
 	repoStats := &RepoCommitStats{
-		Owner:     owner,
-		Name:      name,
-		IsPrivate: isPrivate,
+		Owner:     repo.Owner,
+		Name:      repo.Name,
+		URL:       repo.URL,
+		IsPrivate: repo.IsPrivate,
 	}
 	monthlyData := make(map[string]*MonthlyCommitStats)
 
-	var cursor *githubv4.String
-
-	for {
-		var query commitHistoryQuery
-		variables := map[string]any{
-			"owner":    githubv4.String(owner),
-			"name":     githubv4.String(name),
-			"authorId": authorID,
-			"since":    githubv4.GitTimestamp{Time: from},
-			"until":    githubv4.GitTimestamp{Time: to},
-			"cursor":   cursor,
-		}
+	variables := map[string]any{
+		"owner":    githubv4.String(repo.Owner),
+		"name":     githubv4.String(repo.Name),
+		"authorId": authorID,
+		"since":    githubv4.GitTimestamp{Time: from},
+		"until":    githubv4.GitTimestamp{Time: to},
+	}
 
-		if err := client.Query(ctx, &query, variables); err != nil {
+	for query, err := range Pages[commitHistoryQuery](ctx, client, variables, popts) {
+		if err != nil {
 			return nil, nil, err
 		}
 
@@ -321,6 +489,10 @@ func getRepoCommitStats(ctx context.Context, client *githubv4.Client, owner, nam
 			repoStats.Commits++
 			repoStats.Additions += additions
 			repoStats.Deletions += deletions
+			repoStats.CommitTimes = append(repoStats.CommitTimes, CommitTime{
+				AuthoredDate:  commit.AuthoredDate.Time,
+				CommittedDate: committedDate,
+			})
 
 			// Aggregate by month
 			ym := committedDate.Format("2006-01")
@@ -338,11 +510,6 @@ func getRepoCommitStats(ctx context.Context, client *githubv4.Client, owner, nam
 				}
 			}
 		}
-
-		if !history.PageInfo.HasNextPage {
-			break
-		}
-		cursor = &history.PageInfo.EndCursor
 	}
 
 	return repoStats, monthlyData, nil
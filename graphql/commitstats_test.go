@@ -1,8 +1,21 @@
 package graphql
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	ghErrors "github.com/grokify/gogithub/errors"
+	"github.com/shurcooL/githubv4"
 )
 
 func TestVisibilityConstants(t *testing.T) {
@@ -281,8 +294,8 @@ func TestMonthlyMapToSlicePreservesData(t *testing.T) {
 	}
 }
 
-func TestRepoInfoStruct(t *testing.T) {
-	ri := repoInfo{
+func TestContributedRepositoryStruct(t *testing.T) {
+	ri := ContributedRepository{
 		Owner:     "testowner",
 		Name:      "testrepo",
 		IsPrivate: true,
@@ -298,3 +311,190 @@ func TestRepoInfoStruct(t *testing.T) {
 		t.Error("IsPrivate = false, want true")
 	}
 }
+
+// commitStatsServer serves a user who contributed to repos repo0..repoN-1,
+// with i commits of 10 additions each in repo i, one per month from
+// January 2024, authored at 09:30 and committed at midnight on the 15th,
+// with messages "feat: change 0" and so on. respond may
+// answer a history query for a repo instead.
+func commitStatsServer(t *testing.T, repos int, respond func(w http.ResponseWriter, repo string) bool) (*githubv4.Client, *int32) {
+	t.Helper()
+	var histories int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var in struct {
+			Query     string
+			Variables struct{ Name string }
+		}
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		switch {
+		case strings.Contains(in.Query, "history("):
+			atomic.AddInt32(&histories, 1)
+			if respond != nil && respond(w, in.Variables.Name) {
+				return
+			}
+			n, _ := strconv.Atoi(strings.TrimPrefix(in.Variables.Name, "repo"))
+			var nodes []string
+			for i := range n {
+				if strings.Contains(in.Query, "message") {
+					nodes = append(nodes, fmt.Sprintf(`{"oid":"%s%d","message":"feat: change %d","committedDate":"2024-%02d-15T00:00:00Z"}`, in.Variables.Name, i, i, i%12+1))
+					continue
+				}
+				nodes = append(nodes, fmt.Sprintf(`{"additions":10,"deletions":1,"authoredDate":"2024-%02d-14T09:30:00Z","committedDate":"2024-%02d-15T00:00:00Z"}`, i%12+1, i%12+1))
+			}
+			fmt.Fprintf(w, `{"data":{"repository":{"defaultBranchRef":{"target":{"history":{"pageInfo":{"hasNextPage":false},"nodes":[%s]}}}}}}`, strings.Join(nodes, ","))
+		case strings.Contains(in.Query, "repositoriesContributedTo"):
+			var nodes []string
+			for i := range repos {
+				nodes = append(nodes, fmt.Sprintf(`{"owner":{"login":"octocat"},"name":"repo%d","isPrivate":false}`, i))
+			}
+			fmt.Fprintf(w, `{"data":{"user":{"id":"U1","repositoriesContributedTo":{"pageInfo":{"hasNextPage":false},"nodes":[%s]}}}}`, strings.Join(nodes, ","))
+		default:
+			fmt.Fprint(w, `{"data":{"user":{"id":"U1"}}}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return githubv4.NewEnterpriseClient(srv.URL, srv.Client()), &histories
+}
+
+func TestGetCommitStatsWithOptionsConcurrency(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// repo3 cannot be resolved and is skipped.
+	client, _ := commitStatsServer(t, 20, func(w http.ResponseWriter, repo string) bool {
+		if repo != "repo3" {
+			return false
+		}
+		fmt.Fprint(w, `{"data":null,"errors":[{"type":"NOT_FOUND","message":"Could not resolve to a Repository."}]}`)
+		return true
+	})
+
+	var want *CommitStats
+	for _, concurrency := range []int{1, 4, 20} {
+		var calls [][2]int
+		stats, err := GetCommitStatsWithOptions(ctx, client, "octocat", from, to, &CommitStatsOptions{
+			Concurrency: concurrency,
+			Progress:    func(current, total int) { calls = append(calls, [2]int{current, total}) },
+		})
+		if err != nil {
+			t.Fatalf("GetCommitStatsWithOptions(concurrency=%d) error = %v", concurrency, err)
+		}
+		for i, c := range calls {
+			if c != [2]int{i + 1, 20} {
+				t.Fatalf("progress call %d = %v, want [%d 20]", i, c, i+1)
+			}
+		}
+		if len(calls) != 20 {
+			t.Errorf("progress called %d times, want 20", len(calls))
+		}
+		// 0+1+...+19 commits, less repo3's 3
+		if stats.TotalCommits != 187 || stats.Additions != 1870 || len(stats.ByRepo) != 18 || len(stats.ByMonth) != 12 {
+			t.Errorf("concurrency=%d: commits %d, additions %d, %d repos, %d months", concurrency,
+				stats.TotalCommits, stats.Additions, len(stats.ByRepo), len(stats.ByMonth))
+		}
+		if times := stats.ByRepo[0].CommitTimes; len(times) != 19 ||
+			!times[1].AuthoredDate.Equal(time.Date(2024, 2, 14, 9, 30, 0, 0, time.UTC)) ||
+			!times[1].CommittedDate.Equal(time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("concurrency=%d: repo19 CommitTimes = %v", concurrency, times)
+		}
+		if want == nil {
+			want = stats
+		} else if !reflect.DeepEqual(stats, want) {
+			t.Errorf("concurrency=%d: stats differ from sequential fetch", concurrency)
+		}
+	}
+}
+
+func TestGetCommitStatsWithOptionsFatal(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	client, histories := commitStatsServer(t, 50, func(w http.ResponseWriter, repo string) bool {
+		if repo == "repo1" {
+			fmt.Fprint(w, `{"data":null,"errors":[{"type":"RATE_LIMITED","message":"API rate limit exceeded for user ID 1."}]}`)
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+		return false
+	})
+
+	_, err := GetCommitStatsWithOptions(context.Background(), client, "octocat", from, to, &CommitStatsOptions{Concurrency: 2})
+	if !ghErrors.IsRateLimited(err) || !strings.Contains(err.Error(), "repo1") {
+		t.Errorf("GetCommitStatsWithOptions() error = %v, want rate limit error for repo1", err)
+	}
+	if n := atomic.LoadInt32(histories); n > 4 {
+		t.Errorf("fetched %d histories after a fatal error, want it to stop", n)
+	}
+}
+
+func TestGetCommitStatsWithOptionsPaginate(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	// repo1's history never ends, at 10 points a page.
+	client, _ := commitStatsServer(t, 4, func(w http.ResponseWriter, repo string) bool {
+		if repo != "repo1" {
+			return false
+		}
+		fmt.Fprint(w, `{"data":{"rateLimit":{"limit":5000,"cost":10,"remaining":4000,"resetAt":"2030-01-01T00:00:00Z"},"repository":{"defaultBranchRef":{"target":{"history":{"pageInfo":{"hasNextPage":true,"endCursor":"c"},"nodes":[]}}}}}}`)
+		return true
+	})
+
+	opts := &CommitStatsOptions{Paginate: &PaginateOptions{MaxCost: 25}}
+	if _, err := GetCommitStatsWithOptions(context.Background(), client, "octocat", from, to, opts); !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("GetCommitStatsWithOptions() error = %v, want ErrBudgetExceeded", err)
+	}
+	if _, err := GetCommitMessages(context.Background(), client, "octocat", from, to, opts); !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("GetCommitMessages() error = %v, want ErrBudgetExceeded", err)
+	}
+}
+
+func TestIsFatal(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"canceled", fmt.Errorf("query: %w", context.Canceled), true},
+		{"deadline", context.DeadlineExceeded, true},
+		{"budget", fmt.Errorf("%w: spent 10 of 10 points", ErrBudgetExceeded), true},
+		{"rate limited", translateError(errors.New("API rate limit exceeded for user ID 1.")), true},
+		{"unauthorized", translateError(errors.New(`non-200 OK status code: 401 Unauthorized body: ""`)), true},
+		{"server error", translateError(errors.New(`non-200 OK status code: 500 Internal Server Error body: ""`)), true},
+		{"api error", &ghErrors.APIError{StatusCode: http.StatusNotFound, Err: ghErrors.ErrNotFound}, true},
+		{"graphql error", translateError(errors.New("Could not resolve to a Repository with the name 'octocat/gone'.")), false},
+	}
+	for _, tt := range tests {
+		if got := isFatal(tt.err); got != tt.want {
+			t.Errorf("isFatal(%s) = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGetCommitMessages(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	client, histories := commitStatsServer(t, 4, nil)
+
+	messages, err := GetCommitMessages(context.Background(), client, "octocat", from, to, nil)
+	if err != nil {
+		t.Fatalf("GetCommitMessages() error = %v", err)
+	}
+	if n := atomic.LoadInt32(histories); n != 4 {
+		t.Errorf("fetched %d histories, want 4", n)
+	}
+	// 0+1+2+3 commits, oldest first
+	if len(messages) != 6 {
+		t.Fatalf("GetCommitMessages() = %d messages, want 6", len(messages))
+	}
+	for i := 1; i < len(messages); i++ {
+		if messages[i].CommittedDate.Before(messages[i-1].CommittedDate) {
+			t.Errorf("messages not sorted by date at %d", i)
+		}
+	}
+	if m := messages[0]; m.Owner != "octocat" || m.Message != "feat: change 0" || m.CommittedDate.Month() != time.January {
+		t.Errorf("messages[0] = %+v", m)
+	}
+}
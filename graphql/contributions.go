@@ -2,6 +2,8 @@ package graphql
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 	"sort"
 	"time"
 
@@ -25,6 +27,17 @@ type ContributionStats struct {
 	TotalRepositoryContributions int
 	RestrictedContributions      int // All private contributions (not just commits)
 	ContributionsByMonth         []MonthlyContribution
+
+	// ContributionDays are the days of the contribution calendar, oldest
+	// first, as shown on the user's profile.
+	ContributionDays []DailyContribution
+}
+
+// DailyContribution is the contribution count of a day of the contribution
+// calendar.
+type DailyContribution struct {
+	Date  time.Time // midnight UTC
+	Count int
 }
 
 // MonthlyContribution represents contribution counts for a specific month.
@@ -39,26 +52,30 @@ func (mc MonthlyContribution) YearMonth() string {
 	return time.Date(mc.Year, mc.Month, 1, 0, 0, 0, 0, time.UTC).Format("2006-01")
 }
 
+// contributionsCollection is the contributionsCollection of a user
+// fetched by the contribution queries.
+type contributionsCollection struct {
+	TotalCommitContributions            githubv4.Int
+	TotalIssueContributions             githubv4.Int
+	TotalPullRequestContributions       githubv4.Int
+	TotalPullRequestReviewContributions githubv4.Int
+	TotalRepositoryContributions        githubv4.Int
+	RestrictedContributionsCount        githubv4.Int
+	ContributionCalendar                struct {
+		TotalContributions githubv4.Int
+		Weeks              []struct {
+			ContributionDays []struct {
+				ContributionCount githubv4.Int
+				Date              string
+			}
+		}
+	}
+}
+
 // contributionsQuery is the GraphQL query structure for fetching contribution statistics.
 type contributionsQuery struct {
 	User struct {
-		ContributionsCollection struct {
-			TotalCommitContributions            githubv4.Int
-			TotalIssueContributions             githubv4.Int
-			TotalPullRequestContributions       githubv4.Int
-			TotalPullRequestReviewContributions githubv4.Int
-			TotalRepositoryContributions        githubv4.Int
-			RestrictedContributionsCount        githubv4.Int
-			ContributionCalendar                struct {
-				TotalContributions githubv4.Int
-				Weeks              []struct {
-					ContributionDays []struct {
-						ContributionCount githubv4.Int
-						Date              string
-					}
-				}
-			}
-		} `graphql:"contributionsCollection(from: $from, to: $to)"`
+		ContributionsCollection contributionsCollection `graphql:"contributionsCollection(from: $from, to: $to)"`
 	} `graphql:"user(login: $login)"`
 }
 
@@ -76,8 +93,23 @@ func GetContributionStats(ctx context.Context, client *githubv4.Client, username
 		return nil, err
 	}
 
-	cc := query.User.ContributionsCollection
-	stats := &ContributionStats{
+	return newContributionStats(username, from, to, query.User.ContributionsCollection), nil
+}
+
+// newContributionStats returns the ContributionStats of a fetched
+// contributionsCollection.
+func newContributionStats(username string, from, to time.Time, cc contributionsCollection) *ContributionStats {
+	var days []DailyContribution
+	for _, week := range cc.ContributionCalendar.Weeks {
+		for _, day := range week.ContributionDays {
+			t, err := time.Parse("2006-01-02", day.Date)
+			if err != nil {
+				continue
+			}
+			days = append(days, DailyContribution{Date: t, Count: int(day.ContributionCount)})
+		}
+	}
+	return &ContributionStats{
 		Username:                     username,
 		From:                         from,
 		To:                           to,
@@ -87,9 +119,62 @@ func GetContributionStats(ctx context.Context, client *githubv4.Client, username
 		TotalPRReviewContributions:   int(cc.TotalPullRequestReviewContributions),
 		TotalRepositoryContributions: int(cc.TotalRepositoryContributions),
 		RestrictedContributions:      int(cc.RestrictedContributionsCount),
-		ContributionsByMonth:         aggregateByMonth(cc.ContributionCalendar.Weeks),
+		ContributionsByMonth:         aggregateByMonth(days),
+		ContributionDays:             days,
 	}
+}
 
+// GetMonthlyContributionStats retrieves contribution statistics for each
+// calendar month (UTC) of from..to, clipped to the range, oldest first. It
+// makes a single query, requesting an aliased contributionsCollection per
+// month, so the months of a year cost one request rather than twelve. As
+// with GetContributionStats, from..to should span at most a year.
+func GetMonthlyContributionStats(ctx context.Context, client *githubv4.Client, username string, from, to time.Time) ([]*ContributionStats, error) {
+	type period struct{ from, to time.Time }
+	var periods []period
+	for start := from.UTC(); !start.After(to); {
+		next := time.Date(start.Year(), start.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		end := next.Add(-time.Second) // last second of the month
+		if end.After(to) {
+			end = to.UTC()
+		}
+		periods = append(periods, period{start, end})
+		start = next
+	}
+	if len(periods) == 0 {
+		return nil, nil
+	}
+
+	// The query's aliases depend on the number of months, so its type is
+	// built at run time: struct { User struct { M0, M1, ... } }.
+	variables := map[string]any{
+		"login": githubv4.String(username),
+	}
+	fields := make([]reflect.StructField, len(periods))
+	for i, p := range periods {
+		fields[i] = reflect.StructField{
+			Name: fmt.Sprintf("M%d", i),
+			Type: reflect.TypeFor[contributionsCollection](),
+			Tag:  reflect.StructTag(fmt.Sprintf(`graphql:"m%d: contributionsCollection(from: $from%d, to: $to%d)"`, i, i, i)),
+		}
+		variables[fmt.Sprintf("from%d", i)] = githubv4.DateTime{Time: p.from}
+		variables[fmt.Sprintf("to%d", i)] = githubv4.DateTime{Time: p.to}
+	}
+	query := reflect.New(reflect.StructOf([]reflect.StructField{{
+		Name: "User",
+		Type: reflect.StructOf(fields),
+		Tag:  `graphql:"user(login: $login)"`,
+	}}))
+
+	if err := client.Query(ctx, query.Interface(), variables); err != nil {
+		return nil, err
+	}
+
+	user := query.Elem().Field(0)
+	stats := make([]*ContributionStats, len(periods))
+	for i, p := range periods {
+		stats[i] = newContributionStats(username, p.from, p.to, user.Field(i).Interface().(contributionsCollection))
+	}
 	return stats, nil
 }
 
@@ -108,8 +193,6 @@ func GetContributionStatsMultiYear(ctx context.Context, client *githubv4.Client,
 		ContributionsByMonth: []MonthlyContribution{},
 	}
 
-	monthlyMap := make(map[string]int) // "2024-01" -> count
-
 	current := from
 	for current.Before(to) {
 		end := current.AddDate(1, 0, 0)
@@ -129,37 +212,27 @@ func GetContributionStatsMultiYear(ctx context.Context, client *githubv4.Client,
 		stats.TotalRepositoryContributions += yearStats.TotalRepositoryContributions
 		stats.RestrictedContributions += yearStats.RestrictedContributions
 
-		for _, mc := range yearStats.ContributionsByMonth {
-			monthlyMap[mc.YearMonth()] += mc.Count
+		// Consecutive years share the day at their boundary.
+		for _, day := range yearStats.ContributionDays {
+			if n := len(stats.ContributionDays); n == 0 || day.Date.After(stats.ContributionDays[n-1].Date) {
+				stats.ContributionDays = append(stats.ContributionDays, day)
+			}
 		}
 
 		current = end
 	}
 
-	// Convert map to sorted slice
-	stats.ContributionsByMonth = mapToMonthlyContributions(monthlyMap)
+	stats.ContributionsByMonth = aggregateByMonth(stats.ContributionDays)
 
 	return stats, nil
 }
 
 // aggregateByMonth converts daily contribution data to monthly totals.
-func aggregateByMonth(weeks []struct {
-	ContributionDays []struct {
-		ContributionCount githubv4.Int
-		Date              string
-	}
-}) []MonthlyContribution {
+func aggregateByMonth(days []DailyContribution) []MonthlyContribution {
 	monthlyMap := make(map[string]int)
 
-	for _, week := range weeks {
-		for _, day := range week.ContributionDays {
-			t, err := time.Parse("2006-01-02", day.Date)
-			if err != nil {
-				continue
-			}
-			key := t.Format("2006-01")
-			monthlyMap[key] += int(day.ContributionCount)
-		}
+	for _, day := range days {
+		monthlyMap[day.Date.Format("2006-01")] += day.Count
 	}
 
 	return mapToMonthlyContributions(monthlyMap)
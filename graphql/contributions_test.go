@@ -1,8 +1,16 @@
 package graphql
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/shurcooL/githubv4"
 )
 
 func TestMonthlyContributionYearMonth(t *testing.T) {
@@ -208,3 +216,52 @@ func TestMonthlyContributionStruct(t *testing.T) {
 		t.Errorf("Count = %d, want %d", mc.Count, 42)
 	}
 }
+
+func TestGetMonthlyContributionStats(t *testing.T) {
+	var queries int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var in struct {
+			Query     string
+			Variables map[string]string
+		}
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		queries++
+		var months []string
+		for i := 0; in.Variables[fmt.Sprintf("from%d", i)] != ""; i++ {
+			if !strings.Contains(in.Query, fmt.Sprintf("m%d: contributionsCollection(from: $from%d, to: $to%d)", i, i, i)) {
+				t.Errorf("query lacks month %d: %s", i, in.Query)
+			}
+			months = append(months, fmt.Sprintf(`"m%d":{"totalIssueContributions":%d,"contributionCalendar":{"weeks":[{"contributionDays":[{"contributionCount":1,"date":%q}]}]}}`,
+				i, i+1, in.Variables[fmt.Sprintf("from%d", i)][:10]))
+		}
+		fmt.Fprintf(w, `{"data":{"user":{%s}}}`, strings.Join(months, ","))
+	}))
+	defer srv.Close()
+	client := githubv4.NewEnterpriseClient(srv.URL, srv.Client())
+
+	from := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	stats, err := GetMonthlyContributionStats(context.Background(), client, "octocat", from, to)
+	if err != nil {
+		t.Fatalf("GetMonthlyContributionStats() error = %v", err)
+	}
+	if queries != 1 {
+		t.Errorf("made %d queries, want 1", queries)
+	}
+	if len(stats) != 3 {
+		t.Fatalf("got %d months, want 3", len(stats))
+	}
+	if !stats[0].From.Equal(from) || !stats[2].To.Equal(to) {
+		t.Errorf("months not clipped to range: %v .. %v", stats[0].From, stats[2].To)
+	}
+	feb := stats[1]
+	if feb.From != time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC) || feb.To != time.Date(2024, 2, 29, 23, 59, 59, 0, time.UTC) {
+		t.Errorf("February = %v .. %v", feb.From, feb.To)
+	}
+	if feb.TotalIssueContributions != 2 || len(feb.ContributionsByMonth) != 1 || feb.ContributionsByMonth[0].Month != time.February {
+		t.Errorf("February stats = %+v", feb)
+	}
+}
@@ -0,0 +1,281 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v89/github"
+	ghErrors "github.com/grokify/gogithub/errors"
+	"github.com/shurcooL/githubv4"
+)
+
+// Default values for PaginateOptions.
+const (
+	DefaultMaxRetries = 3
+	DefaultRetryDelay = time.Second
+)
+
+// ErrBudgetExceeded is returned by Pages when continuing would spend more
+// rate limit points than PaginateOptions allows.
+var ErrBudgetExceeded = errors.New("graphql: rate limit point budget exceeded")
+
+// PageInfo is the pageInfo of a GraphQL connection.
+type PageInfo struct {
+	HasNextPage bool
+	EndCursor   githubv4.String
+}
+
+// RateLimit is the rateLimit block of a GraphQL query: the points the
+// query cost, the points allowed per hour, and the points left until
+// ResetAt.
+type RateLimit struct {
+	Limit     githubv4.Int
+	Cost      githubv4.Int
+	Remaining githubv4.Int
+	ResetAt   githubv4.DateTime
+}
+
+// GetRateLimit returns the GraphQL rate limit status of client's token.
+func GetRateLimit(ctx context.Context, client *githubv4.Client) (*RateLimit, error) {
+	var q RateLimited
+	if err := client.Query(ctx, &q, nil); err != nil {
+		return nil, err
+	}
+	return &q.RateLimit, nil
+}
+
+// RateLimited is embedded in a query struct to fetch the query's rateLimit
+// block alongside its data, and provides the Rate method of Query.
+type RateLimited struct {
+	RateLimit RateLimit
+}
+
+// Rate returns the query's rateLimit block.
+func (r *RateLimited) Rate() RateLimit {
+	return r.RateLimit
+}
+
+// Query is implemented by pointers to query structs that fetch one page of
+// a connection for Pages. The query must take the page's cursor as a
+// $cursor variable, e.g. `graphql:"history(first: 100, after: $cursor)"`,
+// and usually embeds RateLimited.
+type Query interface {
+	// PageInfo returns the pageInfo of the paginated connection.
+	PageInfo() PageInfo
+
+	// Rate returns the query's rateLimit block, or a zero RateLimit if
+	// it did not request one.
+	Rate() RateLimit
+}
+
+// PaginateOptions controls Pages. A nil *PaginateOptions uses the defaults.
+type PaginateOptions struct {
+	// MaxCost is the number of rate limit points pagination may spend.
+	// Pages stops with ErrBudgetExceeded rather than fetch a page that,
+	// costing as much as the previous one, would exceed it. Zero means no
+	// limit.
+	MaxCost int
+
+	// MinRemaining is the number of rate limit points to leave unspent.
+	// When a page leaves fewer, Pages sleeps until the limit resets before
+	// fetching the next page or, if NoWait is set, stops with
+	// ErrBudgetExceeded. Zero disables the check.
+	MinRemaining int
+
+	// NoWait makes Pages stop instead of sleeping; see MinRemaining.
+	NoWait bool
+
+	// MaxRetries is the number of times a page is retried after a
+	// transient error: a 502, 503 or 504 response, or a query that
+	// exceeded GitHub's resource limits or timed out. Default:
+	// DefaultMaxRetries; negative disables retries.
+	MaxRetries int
+
+	// RetryDelay is the delay before the first retry, doubled for each
+	// further one. Default: DefaultRetryDelay.
+	RetryDelay time.Duration
+}
+
+// Pages returns an iterator over the pages of a connection, fetched with
+// query Q and variables, to which Pages adds $cursor. Pages are fetched
+// lazily, so stopping early saves points. Errors are yielded once, ending
+// the iteration.
+//
+//	for q, err := range graphql.Pages[historyQuery](ctx, client, vars, nil) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    commits = append(commits, q.Repository.History.Nodes...)
+//	}
+func Pages[Q any, PQ interface {
+	*Q
+	Query
+}](ctx context.Context, client *githubv4.Client, variables map[string]any, opts *PaginateOptions) iter.Seq2[*Q, error] {
+	o := PaginateOptions{MaxRetries: DefaultMaxRetries, RetryDelay: DefaultRetryDelay}
+	if opts != nil {
+		o = *opts
+		if o.MaxRetries == 0 {
+			o.MaxRetries = DefaultMaxRetries
+		}
+		if o.RetryDelay <= 0 {
+			o.RetryDelay = DefaultRetryDelay
+		}
+	}
+
+	return func(yield func(*Q, error) bool) {
+		vars := maps.Clone(variables)
+		if vars == nil {
+			vars = make(map[string]any)
+		}
+		if _, ok := vars["cursor"]; !ok {
+			vars["cursor"] = (*githubv4.String)(nil)
+		}
+
+		spent := 0
+		for {
+			q := new(Q)
+			if err := queryWithRetry(ctx, client, q, vars, o); err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(q, nil) {
+				return
+			}
+
+			page, rate := PQ(q).PageInfo(), PQ(q).Rate()
+			if !page.HasNextPage {
+				return
+			}
+			cost := int(rate.Cost)
+			spent += cost
+			if o.MaxCost > 0 && spent+cost > o.MaxCost {
+				yield(nil, fmt.Errorf("%w: spent %d of %d points", ErrBudgetExceeded, spent, o.MaxCost))
+				return
+			}
+			if o.MinRemaining > 0 && !rate.ResetAt.IsZero() && int(rate.Remaining) < o.MinRemaining {
+				if o.NoWait {
+					yield(nil, fmt.Errorf("%w: %d points remaining until %s", ErrBudgetExceeded, rate.Remaining, rate.ResetAt.Format(time.RFC3339)))
+					return
+				}
+				if err := sleep(ctx, time.Until(rate.ResetAt.Time)); err != nil {
+					yield(nil, err)
+					return
+				}
+			}
+			cursor := page.EndCursor
+			vars["cursor"] = &cursor
+		}
+	}
+}
+
+// queryWithRetry runs a query, retrying transient errors as o allows.
+// Errors are translated by translateError.
+func queryWithRetry(ctx context.Context, client *githubv4.Client, q any, vars map[string]any, o PaginateOptions) error {
+	delay := o.RetryDelay
+	for attempt := 0; ; attempt++ {
+		err := translateError(client.Query(ctx, q, vars))
+		if err == nil || attempt >= o.MaxRetries || !isTransient(err) {
+			return err
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+		delay *= 2
+	}
+}
+
+// statusPattern matches the error githubv4 returns for a non-200 response,
+// capturing its status code.
+var statusPattern = regexp.MustCompile(`^non-200 OK status code: (\d{3})`)
+
+// rateLimitErrors are fragments of the messages of GraphQL errors, returned
+// with a 200 response, and of 403 response bodies that report an exhausted
+// primary or secondary rate limit.
+var rateLimitErrors = []string{
+	"API rate limit exceeded",
+	"secondary rate limit",
+}
+
+// translateError converts the errors githubv4 returns for HTTP failures
+// and exhausted rate limits into *errors.APIError values matching the
+// gogithub/errors sentinels, as clientv1 returns for REST requests, so
+// they can be classified by type. githubv4 exposes neither the status code
+// nor the GraphQL error type, so these are recognized here by text, once.
+// Other errors, such as GraphQL errors about the queried objects, are
+// returned unchanged.
+func translateError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var apiErr *ghErrors.APIError
+	if errors.As(err, &apiErr) {
+		return err
+	}
+	msg := err.Error()
+	for _, s := range rateLimitErrors {
+		if strings.Contains(msg, s) {
+			return &ghErrors.APIError{StatusCode: http.StatusTooManyRequests, Message: msg, Err: ghErrors.ErrRateLimited}
+		}
+	}
+	m := statusPattern.FindStringSubmatch(msg)
+	if m == nil {
+		return err
+	}
+	code, _ := strconv.Atoi(m[1])
+	translated := ghErrors.Translate(err, &github.Response{Response: &http.Response{StatusCode: code}})
+	if apiErr, ok := translated.(*ghErrors.APIError); ok {
+		apiErr.Message = msg
+	}
+	return translated
+}
+
+// transientErrors are fragments of the messages of GraphQL errors for
+// queries that may succeed when retried. githubv4 does not expose the
+// GraphQL error type, so they are matched by text.
+var transientErrors = []string{
+	"RESOURCE_LIMITS_EXCEEDED",
+	"Resource limits for this query exceeded",
+	"Something went wrong while executing your query",
+}
+
+// isTransient reports whether err, translated by translateError, is worth
+// retrying: a 502, 503 or 504 response, or a transient GraphQL error.
+func isTransient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	switch ghErrors.StatusCode(err) {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	msg := err.Error()
+	for _, s := range transientErrors {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
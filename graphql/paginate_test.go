@@ -0,0 +1,222 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ghErrors "github.com/grokify/gogithub/errors"
+	"github.com/shurcooL/githubv4"
+)
+
+type reposPageQuery struct {
+	RateLimited
+	Viewer struct {
+		Repositories struct {
+			PageInfo PageInfo
+			Nodes    []struct {
+				Name githubv4.String
+			}
+		} `graphql:"repositories(first: 1, after: $cursor)"`
+	}
+}
+
+func (q *reposPageQuery) PageInfo() PageInfo {
+	return q.Viewer.Repositories.PageInfo
+}
+
+// pagesServer serves a connection of pages repositories, one per page,
+// reporting remaining points. fail, if set, answers request n (from 1)
+// with a failure.
+func pagesServer(t *testing.T, pages, remaining int, fail func(w http.ResponseWriter, n int32) bool) (*githubv4.Client, *int32) {
+	t.Helper()
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		if fail != nil && fail(w, n) {
+			return
+		}
+		var in struct {
+			Query     string
+			Variables struct{ Cursor *string }
+		}
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !strings.Contains(in.Query, "rateLimit{limit,cost,remaining,resetAt}") {
+			t.Errorf("query %q does not request rateLimit", in.Query)
+		}
+		page := 0
+		if in.Variables.Cursor != nil {
+			page, _ = strconv.Atoi(strings.TrimPrefix(*in.Variables.Cursor, "c"))
+		}
+		fmt.Fprintf(w, `{"data":{"rateLimit":{"limit":5000,"cost":2,"remaining":%d,"resetAt":%q},"viewer":{"repositories":{"pageInfo":{"hasNextPage":%t,"endCursor":"c%d"},"nodes":[{"name":"repo%d"}]}}}}`,
+			remaining, time.Now().Add(-time.Second).UTC().Format(time.RFC3339), page+1 < pages, page+1, page)
+	}))
+	t.Cleanup(srv.Close)
+	return githubv4.NewEnterpriseClient(srv.URL, srv.Client()), &requests
+}
+
+func collectNames(ctx context.Context, client *githubv4.Client, opts *PaginateOptions) ([]string, error) {
+	var names []string
+	for q, err := range Pages[reposPageQuery](ctx, client, nil, opts) {
+		if err != nil {
+			return names, err
+		}
+		for _, n := range q.Viewer.Repositories.Nodes {
+			names = append(names, string(n.Name))
+		}
+	}
+	return names, nil
+}
+
+func TestPages(t *testing.T) {
+	ctx := context.Background()
+	client, requests := pagesServer(t, 3, 5000, nil)
+
+	names, err := collectNames(ctx, client, nil)
+	if err != nil {
+		t.Fatalf("Pages() error = %v", err)
+	}
+	if strings.Join(names, ",") != "repo0,repo1,repo2" {
+		t.Errorf("Pages() names = %v, want repo0..repo2", names)
+	}
+	if *requests != 3 {
+		t.Errorf("server saw %d requests, want 3", *requests)
+	}
+
+	// Stopping early fetches no further pages.
+	*requests = 0
+	for range Pages[reposPageQuery](ctx, client, nil, nil) {
+		break
+	}
+	if *requests != 1 {
+		t.Errorf("server saw %d requests after break, want 1", *requests)
+	}
+}
+
+func TestPagesBudget(t *testing.T) {
+	ctx := context.Background()
+
+	client, _ := pagesServer(t, 10, 5000, nil)
+	names, err := collectNames(ctx, client, &PaginateOptions{MaxCost: 5})
+	if !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("MaxCost error = %v, want ErrBudgetExceeded", err)
+	}
+	if len(names) != 2 {
+		t.Errorf("MaxCost fetched %d pages, want 2", len(names))
+	}
+
+	client, _ = pagesServer(t, 10, 50, nil)
+	names, err = collectNames(ctx, client, &PaginateOptions{MinRemaining: 100, NoWait: true})
+	if !errors.Is(err, ErrBudgetExceeded) || len(names) != 1 {
+		t.Errorf("MinRemaining with NoWait = %d pages, %v; want 1, ErrBudgetExceeded", len(names), err)
+	}
+
+	// The limit has already reset, so waiting returns at once.
+	names, err = collectNames(ctx, client, &PaginateOptions{MinRemaining: 100})
+	if err != nil || len(names) != 10 {
+		t.Errorf("MinRemaining = %d pages, %v; want 10, nil", len(names), err)
+	}
+}
+
+func TestPagesRetry(t *testing.T) {
+	ctx := context.Background()
+	opts := &PaginateOptions{RetryDelay: time.Millisecond}
+
+	// The second and third requests fail transiently.
+	client, requests := pagesServer(t, 2, 5000, func(w http.ResponseWriter, n int32) bool {
+		switch n {
+		case 2:
+			http.Error(w, "Bad Gateway", http.StatusBadGateway)
+			return true
+		case 3:
+			fmt.Fprint(w, `{"data":null,"errors":[{"type":"RESOURCE_LIMITS_EXCEEDED","message":"Resource limits for this query exceeded."}]}`)
+			return true
+		}
+		return false
+	})
+	names, err := collectNames(ctx, client, opts)
+	if err != nil || len(names) != 2 {
+		t.Errorf("Pages() after transient errors = %v, %v; want 2 pages", names, err)
+	}
+	if *requests != 4 {
+		t.Errorf("server saw %d requests, want 4", *requests)
+	}
+
+	// Other errors are not retried, and retries are limited.
+	client, requests = pagesServer(t, 2, 5000, func(w http.ResponseWriter, n int32) bool {
+		fmt.Fprint(w, `{"data":null,"errors":[{"type":"NOT_FOUND","message":"Could not resolve to a User."}]}`)
+		return true
+	})
+	if _, err := collectNames(ctx, client, opts); err == nil || *requests != 1 {
+		t.Errorf("Pages() with permanent error = %v after %d requests, want error after 1", err, *requests)
+	}
+	client, requests = pagesServer(t, 2, 5000, func(w http.ResponseWriter, n int32) bool {
+		http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+		return true
+	})
+	_, err = collectNames(ctx, client, &PaginateOptions{MaxRetries: 2, RetryDelay: time.Millisecond})
+	if !ghErrors.IsServerError(err) || *requests != 3 {
+		t.Errorf("Pages() with persistent 503 = %v after %d requests, want server error after 3", err, *requests)
+	}
+}
+
+func TestTranslateError(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		want   error
+	}{
+		{errors.New(`non-200 OK status code: 401 Unauthorized body: "Bad credentials"`), http.StatusUnauthorized, ghErrors.ErrPermissionDenied},
+		{errors.New(`non-200 OK status code: 403 Forbidden body: "You have exceeded a secondary rate limit"`), http.StatusTooManyRequests, ghErrors.ErrRateLimited},
+		{errors.New(`non-200 OK status code: 502 Bad Gateway body: ""`), http.StatusBadGateway, ghErrors.ErrServerError},
+		{errors.New(`non-200 OK status code: 504 Gateway Timeout body: ""`), http.StatusGatewayTimeout, nil},
+		{errors.New("API rate limit exceeded for user ID 1."), http.StatusTooManyRequests, ghErrors.ErrRateLimited},
+	}
+	for _, tt := range tests {
+		err := translateError(tt.err)
+		if got := ghErrors.StatusCode(err); got != tt.status {
+			t.Errorf("translateError(%q) status = %d, want %d", tt.err, got, tt.status)
+		}
+		if tt.want != nil && !errors.Is(err, tt.want) {
+			t.Errorf("translateError(%q) = %v, want %v", tt.err, err, tt.want)
+		}
+		if !strings.Contains(err.Error(), tt.err.Error()) {
+			t.Errorf("translateError(%q) = %q, want the original message", tt.err, err)
+		}
+	}
+
+	// GraphQL errors about the queried objects are not translated.
+	notFound := errors.New("Could not resolve to a Repository with the name 'octocat/gone'.")
+	if err := translateError(notFound); err != notFound {
+		t.Errorf("translateError(not found) = %v, want it unchanged", err)
+	}
+	if err := translateError(nil); err != nil {
+		t.Errorf("translateError(nil) = %v, want nil", err)
+	}
+}
+
+func TestGetRateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"rateLimit":{"limit":5000,"cost":1,"remaining":4321,"resetAt":"2024-01-01T01:00:00Z"}}}`)
+	}))
+	defer srv.Close()
+
+	rate, err := GetRateLimit(context.Background(), githubv4.NewEnterpriseClient(srv.URL, srv.Client()))
+	if err != nil {
+		t.Fatalf("GetRateLimit() error = %v", err)
+	}
+	if rate.Limit != 5000 || rate.Remaining != 4321 || rate.ResetAt.Hour() != 1 {
+		t.Errorf("GetRateLimit() = %+v", rate)
+	}
+}
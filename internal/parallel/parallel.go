@@ -0,0 +1,41 @@
+// Package parallel runs bounded pools of workers over indexed work.
+package parallel
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// ForEach calls fn for each index in [0, n) on up to workers goroutines,
+// in index order of starting. If a call returns an error, the context
+// passed to the other calls is cancelled, no further calls are started,
+// and ForEach returns that error once the calls in flight have returned.
+// It also stops early, returning ctx's error, if ctx is done. workers less
+// than 1 runs one call at a time.
+//
+// fn should store results by index, so callers can merge them in a
+// deterministic order.
+func ForEach(ctx context.Context, n, workers int, fn func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for range min(max(workers, 1), n) {
+		wg.Go(func() {
+			for ctx.Err() == nil {
+				i := int(next.Add(1) - 1)
+				if i >= n {
+					return
+				}
+				if err := fn(ctx, i); err != nil {
+					cancel(err)
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+	return context.Cause(ctx)
+}
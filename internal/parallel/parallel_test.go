@@ -0,0 +1,80 @@
+package parallel
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForEach(t *testing.T) {
+	for _, workers := range []int{0, 1, 4, 100} {
+		var running, peak atomic.Int32
+		results := make([]int, 50)
+		err := ForEach(context.Background(), len(results), workers, func(ctx context.Context, i int) error {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			results[i] = i * i
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("ForEach(workers=%d) error = %v", workers, err)
+		}
+		for i, r := range results {
+			if r != i*i {
+				t.Fatalf("ForEach(workers=%d) results[%d] = %d, want %d", workers, i, r, i*i)
+			}
+		}
+		if want := int32(min(max(workers, 1), len(results))); peak.Load() > want {
+			t.Errorf("ForEach(workers=%d) ran %d calls at once, want at most %d", workers, peak.Load(), want)
+		}
+	}
+}
+
+func TestForEachError(t *testing.T) {
+	errFatal := errors.New("fatal")
+	var started, cancelled atomic.Int32
+	err := ForEach(context.Background(), 100, 4, func(ctx context.Context, i int) error {
+		started.Add(1)
+		if i == 3 {
+			return errFatal
+		}
+		select {
+		case <-ctx.Done():
+			cancelled.Add(1)
+			return ctx.Err()
+		case <-time.After(time.Second):
+			return nil
+		}
+	})
+	if !errors.Is(err, errFatal) {
+		t.Errorf("ForEach() error = %v, want %v", err, errFatal)
+	}
+	if started.Load() >= 100 {
+		t.Errorf("ForEach() started all %d calls after an error", started.Load())
+	}
+	if cancelled.Load() == 0 {
+		t.Error("ForEach() did not cancel the calls in flight")
+	}
+}
+
+func TestForEachContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var calls atomic.Int32
+	err := ForEach(ctx, 10, 2, func(context.Context, int) error {
+		calls.Add(1)
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || calls.Load() != 0 {
+		t.Errorf("ForEach(cancelled) = %v after %d calls, want context.Canceled after 0", err, calls.Load())
+	}
+}
@@ -0,0 +1,470 @@
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+
+	"github.com/grokify/gogithub/graphql"
+)
+
+// maxChunkMonths is the number of uncached months whose commit histories
+// are fetched together. Their files are written once the chunk completes,
+// so it bounds the work an interrupted run loses.
+const maxChunkMonths = 12
+
+// MonthlyContributions are a month's contributionsCollection totals, as
+// cached by GetUserProfile.
+type MonthlyContributions struct {
+	Commits      int `json:"commits"`
+	Issues       int `json:"issues"`
+	PRs          int `json:"prs"`
+	Reviews      int `json:"reviews"`
+	ReposCreated int `json:"reposCreated"`
+	Restricted   int `json:"restricted"`
+	Calendar     int `json:"calendar"` // contributions shown on the calendar
+
+	// Days are the month's calendar days with contributions, oldest
+	// first. Their counts sum to Calendar.
+	Days []CachedContributionDay `json:"days,omitempty"`
+}
+
+// CachedContributionDay is a graphql.DailyContribution, as cached by
+// GetUserProfile.
+type CachedContributionDay struct {
+	Date  time.Time `json:"date"`
+	Count int       `json:"count"`
+}
+
+// MonthlyRepoStats are the commits a user made to one repository in a
+// month, as cached by GetUserProfile.
+type MonthlyRepoStats struct {
+	Owner     string `json:"owner"`
+	Name      string `json:"name"`
+	URL       string `json:"url,omitempty"` // web URL
+	IsPrivate bool   `json:"isPrivate"`
+	Commits   int    `json:"commits"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+
+	// CommitTimes are the timestamps of the commits, one per commit.
+	CommitTimes []CachedCommitTime `json:"commitTimes,omitempty"`
+}
+
+// CachedCommitTime is a graphql.CommitTime, as cached by GetUserProfile.
+type CachedCommitTime struct {
+	Authored  time.Time `json:"authored"`
+	Committed time.Time `json:"committed"`
+}
+
+// monthWindow is one calendar month of a profile's date range, clipped to
+// the range.
+type monthWindow struct {
+	Year  int
+	Month time.Month
+	From  time.Time
+	To    time.Time
+
+	// Cacheable is true if the window covers the whole month and the
+	// month is over, so its data can no longer change.
+	Cacheable bool
+}
+
+// key returns the month as "2024-01".
+func (w monthWindow) key() string {
+	return fmt.Sprintf("%04d-%02d", w.Year, w.Month)
+}
+
+// index numbers months consecutively.
+func (w monthWindow) index() int {
+	return w.Year*12 + int(w.Month)
+}
+
+// monthWindows splits from..to into calendar months (UTC).
+func monthWindows(from, to, now time.Time) []monthWindow {
+	from, to = from.UTC(), to.UTC()
+	var windows []monthWindow
+	start := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for ; !start.After(to); start = start.AddDate(0, 1, 0) {
+		end := start.AddDate(0, 1, 0).Add(-time.Second) // last second of the month
+		w := monthWindow{
+			Year:      start.Year(),
+			Month:     start.Month(),
+			From:      start,
+			To:        end,
+			Cacheable: !from.After(start) && !to.Before(end) && end.Before(now),
+		}
+		if w.From.Before(from) {
+			w.From = from
+		}
+		if w.To.After(to) {
+			w.To = to
+		}
+		windows = append(windows, w)
+	}
+	return windows
+}
+
+// cachedMonth is the data GetUserProfile fetches, and caches, for a month.
+type cachedMonth struct {
+	Contributions MonthlyContributions
+	Repos         []MonthlyRepoStats
+}
+
+// cacheFile returns the path of a month's cache file, named like
+// WriteMonthlyFile's files.
+func cacheFile(dir, username string, w monthWindow) string {
+	return filepath.Join(dir, fmt.Sprintf("%s_github_%s.json", username, w.key()))
+}
+
+// readCachedMonth reads a month cached for username and visibility. It
+// returns nil if the month is not cached: the file is missing, cannot be
+// parsed (e.g. because an earlier run was killed while writing it), was
+// not written by GetUserProfile, was fetched with other options, or lacks
+// the commits' timestamps or calendar days because an older version wrote
+// it.
+func readCachedMonth(dir, username, visibility string, w monthWindow) (*cachedMonth, error) {
+	data, err := os.ReadFile(cacheFile(dir, username, w))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("read cached month: %w", err)
+	}
+	var f MonthlyOutputFile
+	if err := json.Unmarshal(data, &f); err != nil || f.Contributions == nil ||
+		!strings.EqualFold(f.Metadata.Username, username) || f.Metadata.Visibility != visibility ||
+		f.Year != w.Year || f.Month != int(w.Month) {
+		return nil, nil
+	}
+	for _, repo := range f.Repos {
+		if len(repo.CommitTimes) != repo.Commits {
+			return nil, nil
+		}
+	}
+	days := 0
+	for _, day := range f.Contributions.Days {
+		days += day.Count
+	}
+	if days != f.Contributions.Calendar {
+		return nil, nil
+	}
+	return &cachedMonth{Contributions: *f.Contributions, Repos: f.Repos}, nil
+}
+
+// readCachedMonths reads the cached windows, by key, and returns them
+// with the windows that are not cached.
+func readCachedMonths(dir, username, visibility string, windows []monthWindow) (map[string]*cachedMonth, []monthWindow, error) {
+	months := make(map[string]*cachedMonth, len(windows))
+	var uncached []monthWindow
+	for _, w := range windows {
+		if !w.Cacheable {
+			uncached = append(uncached, w)
+			continue
+		}
+		m, err := readCachedMonth(dir, username, visibility, w)
+		if err != nil {
+			return nil, nil, err
+		}
+		if m == nil {
+			uncached = append(uncached, w)
+			continue
+		}
+		months[w.key()] = m
+	}
+	return months, uncached, nil
+}
+
+// writeCachedMonth writes a month to the cache in the MonthlyOutputFile
+// format. The file is written to a temporary file and renamed into place,
+// so an interrupted write leaves no partial file behind.
+func writeCachedMonth(dir, username string, w monthWindow, m *cachedMonth, opts *Options) error {
+	activity := m.activity(w)
+	meta := NewQueryMetadata(username, w.From, w.To, opts)
+	meta.IncludeReleases, meta.ReleaseOrgs = false, nil // releases are not cached
+	output := MonthlyOutputFile{
+		Metadata:      meta,
+		Username:      username,
+		Year:          w.Year,
+		Month:         int(w.Month),
+		MonthName:     w.Month.String(),
+		Stats:         activity.ToMonthlyStats(),
+		Contributions: &m.Contributions,
+		Repos:         m.Repos,
+	}
+
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal cached month: %w", err)
+	}
+
+	fp := cacheFile(dir, username, w)
+	tmp, err := os.CreateTemp(dir, filepath.Base(fp)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write cached month: %w", err)
+	}
+	_, err = tmp.Write(append(data, '\n'))
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), fp)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write cached month: %w", err)
+	}
+	return nil
+}
+
+// activity returns the month's MonthlyActivity, without releases.
+func (m *cachedMonth) activity(w monthWindow) *MonthlyActivity {
+	activity := &MonthlyActivity{
+		Year:          w.Year,
+		Month:         w.Month,
+		Issues:        m.Contributions.Issues,
+		PRs:           m.Contributions.PRs,
+		Reviews:       m.Contributions.Reviews,
+		CommitsByRepo: make(map[string]int),
+	}
+	for _, repo := range m.Repos {
+		activity.Commits += repo.Commits
+		activity.Additions += repo.Additions
+		activity.Deletions += repo.Deletions
+		activity.CommitsByRepo[repo.Owner+"/"+repo.Name] += repo.Commits
+	}
+	return activity
+}
+
+// fetchMonths gets the contribution and commit stats for from..to month by
+// month, reading completed months from opts.CacheDir and caching those it
+// fetches. Uncached months are fetched oldest first, in chunks of up to
+// maxChunkMonths, and cached as each chunk completes, so a run that fails
+// resumes at the chunk it stopped in. Each chunk's contribution stats are
+// fetched in one query, and the user and their repositories are looked up
+// once for all chunks. It reports stages 1 and 2 of GetUserProfile,
+// counting months, and returns the stats together with each month's data
+// by key.
+func fetchMonths(ctx context.Context, client *githubv4.Client, username string, from, to time.Time, opts *Options, report func(stage int, desc string, current, total int, done bool)) (*graphql.ContributionStats, *graphql.CommitStats, map[string]*cachedMonth, error) {
+	if err := os.MkdirAll(opts.CacheDir, 0755); err != nil {
+		return nil, nil, nil, fmt.Errorf("create cache directory: %w", err)
+	}
+	visibility := NewQueryMetadata(username, from, to, opts).Visibility
+
+	windows := monthWindows(from, to, time.Now())
+	months, uncached, err := readCachedMonths(opts.CacheDir, username, visibility, windows)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	chunks := chunkMonths(uncached)
+
+	// Stage 1: contribution stats, one query per chunk of months
+	report(1, "Fetching contribution statistics", 0, len(uncached), false)
+	fetched := 0
+	for _, chunk := range chunks {
+		stats, err := graphql.GetMonthlyContributionStats(ctx, client, username, chunk[0].From, chunk[len(chunk)-1].To)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("get contribution stats from %s: %w", chunk[0].key(), err)
+		}
+		for i, w := range chunk {
+			months[w.key()] = newCachedMonth(w, stats[i])
+		}
+		fetched += len(chunk)
+		report(1, "Fetching contribution statistics", fetched, len(uncached), false)
+	}
+	report(1, "Fetching contribution statistics", len(uncached), len(uncached), true)
+
+	// Stage 2: commit stats, one chunk of months at a time
+	report(2, "Fetching commit details", 0, len(uncached), false)
+	commitOpts := &graphql.CommitStatsOptions{
+		Visibility:  opts.Visibility,
+		Concurrency: opts.Concurrency,
+		Paginate:    opts.Paginate,
+	}
+	if len(chunks) > 0 {
+		commitOpts.Contributor, err = graphql.GetContributor(ctx, client, username, commitOpts)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("get contributed repositories: %w", err)
+		}
+	}
+	fetched = 0
+	for _, chunk := range chunks {
+		stats, err := graphql.GetCommitStatsWithOptions(ctx, client, username, chunk[0].From, chunk[len(chunk)-1].To, commitOpts)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("get commit stats from %s: %w", chunk[0].key(), err)
+		}
+		for _, repo := range stats.ByRepo {
+			times := make(map[string][]CachedCommitTime) // "2024-01" -> the month's commits
+			for _, ct := range repo.CommitTimes {
+				ym := ct.CommittedDate.Format("2006-01")
+				times[ym] = append(times[ym], CachedCommitTime{Authored: ct.AuthoredDate, Committed: ct.CommittedDate})
+			}
+			for _, mcs := range repo.ByMonth {
+				if m, ok := months[mcs.YearMonth()]; ok && inChunk(chunk, mcs) {
+					m.Repos = append(m.Repos, MonthlyRepoStats{
+						Owner:       repo.Owner,
+						Name:        repo.Name,
+						URL:         repo.URL,
+						IsPrivate:   repo.IsPrivate,
+						Commits:     mcs.Commits,
+						Additions:   mcs.Additions,
+						Deletions:   mcs.Deletions,
+						CommitTimes: times[mcs.YearMonth()],
+					})
+				}
+			}
+		}
+		for _, w := range chunk {
+			if !w.Cacheable {
+				continue
+			}
+			if err := writeCachedMonth(opts.CacheDir, username, w, months[w.key()], opts); err != nil {
+				return nil, nil, nil, err
+			}
+		}
+		fetched += len(chunk)
+		report(2, "Fetching commit details", fetched, len(uncached), false)
+	}
+	report(2, "Fetching commit details", len(uncached), len(uncached), true)
+
+	contribStats, commitStats := mergeMonths(username, from, to, opts.Visibility, windows, months)
+	return contribStats, commitStats, months, nil
+}
+
+// newCachedMonth returns a month with the contribution stats fetched for
+// window w.
+func newCachedMonth(w monthWindow, stats *graphql.ContributionStats) *cachedMonth {
+	m := &cachedMonth{Contributions: MonthlyContributions{
+		Commits:      stats.TotalCommitContributions,
+		Issues:       stats.TotalIssueContributions,
+		PRs:          stats.TotalPRContributions,
+		Reviews:      stats.TotalPRReviewContributions,
+		ReposCreated: stats.TotalRepositoryContributions,
+		Restricted:   stats.RestrictedContributions,
+	}}
+	for _, day := range stats.ContributionDays {
+		if day.Count > 0 && day.Date.Year() == w.Year && day.Date.Month() == w.Month {
+			m.Contributions.Calendar += day.Count
+			m.Contributions.Days = append(m.Contributions.Days, CachedContributionDay(day))
+		}
+	}
+	return m
+}
+
+// chunkMonths splits windows into runs of consecutive months, each at
+// most maxChunkMonths long, whose commits can be fetched together.
+func chunkMonths(windows []monthWindow) [][]monthWindow {
+	var chunks [][]monthWindow
+	for i, w := range windows {
+		if i == 0 || len(chunks[len(chunks)-1]) == maxChunkMonths ||
+			windows[i-1].index()+1 != w.index() {
+			chunks = append(chunks, nil)
+		}
+		chunks[len(chunks)-1] = append(chunks[len(chunks)-1], w)
+	}
+	return chunks
+}
+
+// inChunk reports whether mcs falls in one of chunk's months.
+func inChunk(chunk []monthWindow, mcs graphql.MonthlyCommitStats) bool {
+	for _, w := range chunk {
+		if w.Year == mcs.Year && w.Month == mcs.Month {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeMonths combines months into the stats GetUserProfile would have
+// fetched for the whole range.
+func mergeMonths(username string, from, to time.Time, visibility graphql.Visibility, windows []monthWindow, months map[string]*cachedMonth) (*graphql.ContributionStats, *graphql.CommitStats) {
+	contribStats := &graphql.ContributionStats{
+		Username:             username,
+		From:                 from,
+		To:                   to,
+		ContributionsByMonth: []graphql.MonthlyContribution{},
+	}
+	commitStats := &graphql.CommitStats{
+		Username:   username,
+		From:       from,
+		To:         to,
+		Visibility: visibility,
+		ByMonth:    []graphql.MonthlyCommitStats{},
+		ByRepo:     []graphql.RepoCommitStats{},
+	}
+
+	repoIndex := make(map[string]int) // "owner/repo" -> index in ByRepo
+	for _, w := range windows {
+		m := months[w.key()]
+		c := m.Contributions
+		contribStats.TotalCommitContributions += c.Commits
+		contribStats.TotalIssueContributions += c.Issues
+		contribStats.TotalPRContributions += c.PRs
+		contribStats.TotalPRReviewContributions += c.Reviews
+		contribStats.TotalRepositoryContributions += c.ReposCreated
+		contribStats.RestrictedContributions += c.Restricted
+		contribStats.ContributionsByMonth = append(contribStats.ContributionsByMonth, graphql.MonthlyContribution{
+			Year:  w.Year,
+			Month: w.Month,
+			Count: c.Calendar,
+		})
+		for _, day := range c.Days {
+			contribStats.ContributionDays = append(contribStats.ContributionDays, graphql.DailyContribution(day))
+		}
+
+		month := graphql.MonthlyCommitStats{Year: w.Year, Month: w.Month}
+		for _, repo := range m.Repos {
+			month.Commits += repo.Commits
+			month.Additions += repo.Additions
+			month.Deletions += repo.Deletions
+
+			fullName := repo.Owner + "/" + repo.Name
+			i, ok := repoIndex[fullName]
+			if !ok {
+				i = len(commitStats.ByRepo)
+				repoIndex[fullName] = i
+				commitStats.ByRepo = append(commitStats.ByRepo, graphql.RepoCommitStats{
+					Owner:     repo.Owner,
+					Name:      repo.Name,
+					URL:       repo.URL,
+					IsPrivate: repo.IsPrivate,
+				})
+			}
+			r := &commitStats.ByRepo[i]
+			r.Commits += repo.Commits
+			r.Additions += repo.Additions
+			r.Deletions += repo.Deletions
+			for _, ct := range repo.CommitTimes {
+				r.CommitTimes = append(r.CommitTimes, graphql.CommitTime{AuthoredDate: ct.Authored, CommittedDate: ct.Committed})
+			}
+			r.ByMonth = append(r.ByMonth, graphql.MonthlyCommitStats{
+				Year:      w.Year,
+				Month:     w.Month,
+				Commits:   repo.Commits,
+				Additions: repo.Additions,
+				Deletions: repo.Deletions,
+			})
+		}
+		if month.Commits > 0 {
+			commitStats.ByMonth = append(commitStats.ByMonth, month)
+			commitStats.TotalCommits += month.Commits
+			commitStats.Additions += month.Additions
+			commitStats.Deletions += month.Deletions
+		}
+	}
+
+	// Sort repos by commit count descending, keeping ties in first-seen order
+	sort.SliceStable(commitStats.ByRepo, func(i, j int) bool {
+		return commitStats.ByRepo[i].Commits > commitStats.ByRepo[j].Commits
+	})
+
+	return contribStats, commitStats
+}
@@ -0,0 +1,327 @@
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/grokify/gogithub/clientv1"
+	"github.com/grokify/gogithub/graphql"
+)
+
+func TestMonthWindows(t *testing.T) {
+	from := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 31, 23, 59, 59, 0, time.UTC)
+	now := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+
+	windows := monthWindows(from, to, now)
+	if len(windows) != 3 {
+		t.Fatalf("monthWindows() = %d windows, want 3", len(windows))
+	}
+	// January starts mid-month and March is not over, so only February
+	// can be cached.
+	for i, want := range []bool{false, true, false} {
+		if windows[i].Cacheable != want {
+			t.Errorf("%s Cacheable = %t, want %t", windows[i].key(), windows[i].Cacheable, want)
+		}
+	}
+	if !windows[0].From.Equal(from) || !windows[2].To.Equal(to) {
+		t.Errorf("windows not clipped to range: %v .. %v", windows[0].From, windows[2].To)
+	}
+	if feb := windows[1]; feb.From != time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC) || feb.To != time.Date(2024, 2, 29, 23, 59, 59, 0, time.UTC) {
+		t.Errorf("February = %v .. %v", feb.From, feb.To)
+	}
+}
+
+func TestChunkMonths(t *testing.T) {
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	windows := monthWindows(from, from.AddDate(3, 0, -1), time.Now())
+	// Drop 2020-06, leaving runs of 5 and 30 months.
+	windows = append(windows[:5:5], windows[6:]...)
+
+	var sizes []int
+	for _, chunk := range chunkMonths(windows) {
+		sizes = append(sizes, len(chunk))
+	}
+	if fmt.Sprint(sizes) != "[5 12 12 6]" {
+		t.Errorf("chunkMonths() sizes = %v, want [5 12 12 6]", sizes)
+	}
+}
+
+// cacheServer serves GraphQL for a user who, every month, opened an issue
+// and made one commit of 10 additions to octocat/hello, authored at 15:00
+// on the 14th and committed at midnight, with calendar contributions on
+// the 1st and 10th. History queries
+// fail while failHistory is set. It reports rateRemaining GraphQL points
+// left, or 5000, and 4000 REST requests, resetting in an hour.
+type cacheServer struct {
+	contributions, histories atomic.Int32
+	lookups                  atomic.Int32 // repository lists
+	failHistory              atomic.Bool
+	rateRemaining            int
+}
+
+func (s *cacheServer) client(t *testing.T) clientv1.Client {
+	t.Helper()
+	reset := time.Now().Add(time.Hour).UTC()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v3/rate_limit" {
+			fmt.Fprintf(w, `{"resources":{"core":{"limit":5000,"remaining":4000,"reset":%d}}}`, reset.Unix())
+			return
+		}
+		var in struct {
+			Query     string
+			Variables map[string]any
+		}
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		variable := func(name string) time.Time {
+			t, _ := time.Parse(time.RFC3339, fmt.Sprint(in.Variables[name]))
+			return t
+		}
+		switch {
+		case strings.HasPrefix(in.Query, "{rateLimit"):
+			remaining := s.rateRemaining
+			if remaining == 0 {
+				remaining = 5000
+			}
+			fmt.Fprintf(w, `{"data":{"rateLimit":{"limit":5000,"cost":1,"remaining":%d,"resetAt":%q}}}`, remaining, reset.Format(time.RFC3339))
+		case strings.Contains(in.Query, "contributionsCollection"):
+			// A collection for from..to, or one aliased collection, m0,
+			// m1, ..., per month
+			s.contributions.Add(1)
+			collection := func(from time.Time) string {
+				return fmt.Sprintf(`{"totalCommitContributions":1,"totalIssueContributions":1,"contributionCalendar":{"weeks":[{"contributionDays":[{"contributionCount":1,"date":%q},{"contributionCount":1,"date":%q}]}]}}`,
+					from.Format("2006-01-02"), from.AddDate(0, 0, 9).Format("2006-01-02"))
+			}
+			if in.Variables["from"] != nil {
+				fmt.Fprintf(w, `{"data":{"user":{"contributionsCollection":%s}}}`, collection(variable("from")))
+				return
+			}
+			var months []string
+			for i := 0; in.Variables[fmt.Sprintf("from%d", i)] != nil; i++ {
+				months = append(months, fmt.Sprintf(`"m%d":%s`, i, collection(variable(fmt.Sprintf("from%d", i)))))
+			}
+			fmt.Fprintf(w, `{"data":{"user":{%s}}}`, strings.Join(months, ","))
+		case strings.Contains(in.Query, "history("):
+			s.histories.Add(1)
+			if s.failHistory.Load() {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			var nodes []string
+			for m := variable("since").AddDate(0, 0, 14); !m.After(variable("until")); m = m.AddDate(0, 1, 0) {
+				nodes = append(nodes, fmt.Sprintf(`{"additions":10,"deletions":1,"authoredDate":%q,"committedDate":%q}`,
+					m.Add(-9*time.Hour).Format(time.RFC3339), m.Format(time.RFC3339)))
+			}
+			fmt.Fprintf(w, `{"data":{"repository":{"defaultBranchRef":{"target":{"history":{"pageInfo":{"hasNextPage":false},"nodes":[%s]}}}}}}`, strings.Join(nodes, ","))
+		case strings.Contains(in.Query, "repositoriesContributedTo"):
+			s.lookups.Add(1)
+			fmt.Fprint(w, `{"data":{"user":{"repositoriesContributedTo":{"pageInfo":{"hasNextPage":false},"nodes":[{"owner":{"login":"octocat"},"name":"hello","url":"https://github.example.com/octocat/hello","isPrivate":false}]}}}}`)
+		default:
+			fmt.Fprint(w, `{"data":{"user":{"id":"U1"}}}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := clientv1.NewClientWithOptions(context.Background(), clientv1.ClientOptions{
+		Token:     "ghp_test",
+		BaseURL:   srv.URL + "/api/v3/",
+		UploadURL: srv.URL + "/api/uploads/",
+	})
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+	return c
+}
+
+// counts returns and resets the queries served.
+func (s *cacheServer) counts() (contributions, histories int32) {
+	return s.contributions.Swap(0), s.histories.Swap(0)
+}
+
+func TestGetUserProfileCache(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC)
+	dir := t.TempDir()
+	opts := &Options{CacheDir: dir}
+
+	var s cacheServer
+	client := s.client(t)
+
+	p, err := GetUserProfile(ctx, client, "octocat", from, to, opts)
+	if err != nil {
+		t.Fatalf("GetUserProfile() error = %v", err)
+	}
+	// A contribution query and a history fetch per year, and one
+	// repository lookup.
+	if c, h := s.counts(); c != 2 || h != 2 {
+		t.Errorf("first run made %d contribution and %d history queries, want 2 and 2", c, h)
+	}
+	if n := s.lookups.Swap(0); n != 1 {
+		t.Errorf("first run listed repositories %d times, want once", n)
+	}
+	if p.TotalIssues != 24 || p.CommitsDefaultBranch != 24 || p.TotalAdditions != 240 || p.ReposContributedTo != 1 {
+		t.Errorf("profile = %s, %d default branch commits", p.Summary(), p.CommitsDefaultBranch)
+	}
+	if len(p.RepoStats) != 1 || p.RepoStats[0].URL != "https://github.example.com/octocat/hello" {
+		t.Errorf("repo stats = %+v, want octocat/hello with its Enterprise URL", p.RepoStats)
+	}
+	if p.Calendar.TotalContributions != 48 {
+		t.Errorf("calendar total = %d, want 48", p.Calendar.TotalContributions)
+	}
+	if lit := litDays(p.Calendar); lit != 48 {
+		t.Errorf("calendar has %d days with contributions, want 48", lit)
+	}
+	month := p.Activity.GetMonth(2024, time.June)
+	if month == nil || month.Issues != 1 || month.CommitsByRepo["octocat/hello"] != 1 {
+		t.Errorf("2024-06 activity = %+v", month)
+	}
+	if p.Punchcard == nil || p.Punchcard.Total != 24 || p.Punchcard.ByHour()[15] != 24 {
+		t.Errorf("punchcard = %+v, want 24 commits at 15:00", p.Punchcard)
+	}
+
+	// A second run reads every month from the cache.
+	again, err := GetUserProfile(ctx, client, "octocat", from, to, opts)
+	if err != nil {
+		t.Fatalf("GetUserProfile(cached) error = %v", err)
+	}
+	if c, h := s.counts(); c != 0 || h != 0 {
+		t.Errorf("cached run made %d contribution and %d history queries, want none", c, h)
+	}
+	if !reflect.DeepEqual(again, p) {
+		t.Error("cached profile differs from fetched profile")
+	}
+
+	// A missing month is fetched again, alone.
+	if err := os.Remove(filepath.Join(dir, "octocat_github_2024-06.json")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetUserProfile(ctx, client, "octocat", from, to, opts); err != nil {
+		t.Fatalf("GetUserProfile(one month missing) error = %v", err)
+	}
+	if c, h := s.counts(); c != 1 || h != 1 {
+		t.Errorf("refetching one month made %d contribution and %d history queries, want 1 and 1", c, h)
+	}
+
+	// A month cached without commit times is fetched again.
+	fp := filepath.Join(dir, "octocat_github_2024-07.json")
+	data, err := os.ReadFile(fp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var f map[string]any
+	if err := json.Unmarshal(data, &f); err != nil {
+		t.Fatal(err)
+	}
+	for _, repo := range f["repos"].([]any) {
+		delete(repo.(map[string]any), "commitTimes")
+	}
+	if data, err = json.Marshal(f); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fp, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetUserProfile(ctx, client, "octocat", from, to, opts); err != nil {
+		t.Fatalf("GetUserProfile(no commit times) error = %v", err)
+	}
+	if c, h := s.counts(); c != 1 || h != 1 {
+		t.Errorf("refetching a month without commit times made %d contribution and %d history queries, want 1 and 1", c, h)
+	}
+
+	// A month cached without calendar days is fetched again.
+	fp = filepath.Join(dir, "octocat_github_2024-08.json")
+	if data, err = os.ReadFile(fp); err != nil {
+		t.Fatal(err)
+	}
+	f = nil
+	if err := json.Unmarshal(data, &f); err != nil {
+		t.Fatal(err)
+	}
+	delete(f["contributions"].(map[string]any), "days")
+	if data, err = json.Marshal(f); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fp, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetUserProfile(ctx, client, "octocat", from, to, opts); err != nil {
+		t.Fatalf("GetUserProfile(no calendar days) error = %v", err)
+	}
+	if c, h := s.counts(); c != 1 || h != 1 {
+		t.Errorf("refetching a month without calendar days made %d contribution and %d history queries, want 1 and 1", c, h)
+	}
+
+	// Months cached for another visibility are fetched again.
+	if _, err := GetUserProfile(ctx, client, "octocat", from, to, &Options{CacheDir: dir, Visibility: graphql.VisibilityPublic}); err != nil {
+		t.Fatalf("GetUserProfile(public) error = %v", err)
+	}
+	if c, _ := s.counts(); c != 2 {
+		t.Errorf("public run made %d contribution queries, want 2", c)
+	}
+}
+
+func TestGetUserProfileCacheResume(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC)
+	opts := &Options{CacheDir: t.TempDir()}
+
+	var s cacheServer
+	client := s.client(t)
+
+	// The run fails fetching 2024's commits, after caching 2023.
+	var failed bool
+	opts.Progress = func(info ProgressInfo) {
+		if info.Stage == 2 && info.Current == 12 && !failed {
+			failed = true
+			s.failHistory.Store(true)
+		}
+	}
+	if _, err := GetUserProfile(ctx, client, "octocat", from, to, opts); err == nil {
+		t.Fatal("GetUserProfile() error = nil, want error")
+	}
+	files, _ := filepath.Glob(filepath.Join(opts.CacheDir, "*"))
+	if len(files) != 12 {
+		t.Errorf("failed run left %d files, want 12", len(files))
+	}
+	s.counts()
+
+	s.failHistory.Store(false)
+	p, err := GetUserProfile(ctx, client, "octocat", from, to, opts)
+	if err != nil {
+		t.Fatalf("GetUserProfile(resumed) error = %v", err)
+	}
+	if c, h := s.counts(); c != 1 || h != 1 {
+		t.Errorf("resumed run made %d contribution and %d history queries, want 1 and 1", c, h)
+	}
+	if p.CommitsDefaultBranch != 24 {
+		t.Errorf("resumed profile has %d commits, want 24", p.CommitsDefaultBranch)
+	}
+}
+
+// litDays returns the number of days of cal with contributions.
+func litDays(cal *ContributionCalendar) int {
+	n := 0
+	for _, week := range cal.Weeks {
+		for _, day := range week.Days {
+			if day.ContributionCount > 0 {
+				n++
+			}
+		}
+	}
+	return n
+}
@@ -0,0 +1,274 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/grokify/gogithub/clientv1"
+	"github.com/grokify/gogithub/graphql"
+	"github.com/grokify/gogithub/profile/svg/chart"
+)
+
+// CommitClass is the classification of a commit message.
+type CommitClass struct {
+	Type     chart.ConventionalCommitType
+	Scope    string
+	Breaking bool
+
+	// Conventional reports whether the message has a Conventional Commits
+	// header. Otherwise Type was inferred from keywords.
+	Conventional bool
+}
+
+// ChangelogCategory returns the changelog category of the commit:
+// chart.CLBreaking for breaking changes, or else the category of its Type.
+func (c CommitClass) ChangelogCategory() chart.ChangelogCategory {
+	if c.Breaking {
+		return chart.CLBreaking
+	}
+	if cat, ok := chart.CCToChangelogCategory[c.Type]; ok {
+		return cat
+	}
+	return chart.CLOther
+}
+
+// conventionalHeader matches a Conventional Commits header:
+// type(scope)!: description
+var conventionalHeader = regexp.MustCompile(`^([A-Za-z]+)(?:\(([^()]*)\))?(!)?:\s+\S`)
+
+// breakingFooter matches a BREAKING CHANGE footer line.
+var breakingFooter = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE:\s`)
+
+// conventionalTypes maps Conventional Commits types, and common variants,
+// to their type.
+var conventionalTypes = map[string]chart.ConventionalCommitType{
+	"feat":     chart.CCFeat,
+	"feature":  chart.CCFeat,
+	"fix":      chart.CCFix,
+	"bugfix":   chart.CCFix,
+	"hotfix":   chart.CCFix,
+	"docs":     chart.CCDocs,
+	"doc":      chart.CCDocs,
+	"style":    chart.CCStyle,
+	"refactor": chart.CCRefactor,
+	"perf":     chart.CCPerf,
+	"test":     chart.CCTest,
+	"tests":    chart.CCTest,
+	"build":    chart.CCBuild,
+	"ci":       chart.CCCI,
+	"chore":    chart.CCChore,
+	"revert":   chart.CCRevert,
+	"security": chart.CCSecurity,
+	"sec":      chart.CCSecurity,
+	"deps":     chart.CCDeps,
+	"dep":      chart.CCDeps,
+}
+
+// keywordRule infers a type from words in a commit headline. A word ending
+// in "*" matches any word with that prefix.
+type keywordRule struct {
+	Type  chart.ConventionalCommitType
+	Words []string
+}
+
+// topicRules classify non-conventional headlines by what they touch,
+// before leadingVerbRules; fallbackRules classify the rest after.
+var (
+	topicRules = []keywordRule{
+		{chart.CCSecurity, []string{"security", "secure", "vulnerab*", "cve", "xss", "csrf", "injection"}},
+		{chart.CCDeps, []string{"bump*", "dependenc*", "deps", "dependabot", "renovate", "vendor"}},
+		{chart.CCDocs, []string{"doc", "docs", "documentation", "godoc", "readme", "changelog", "typo", "typos"}},
+		{chart.CCTest, []string{"test", "tests", "testing", "testdata", "coverage"}},
+		{chart.CCCI, []string{"ci", "workflow*", "actions", "travis", "circleci"}},
+		{chart.CCBuild, []string{"build", "makefile", "dockerfile", "goreleaser"}},
+	}
+	fallbackRules = []keywordRule{
+		{chart.CCPerf, []string{"perf", "performance", "faster", "optimi*", "speed*"}},
+		{chart.CCFix, []string{"bug*", "fix*", "crash*", "panic*", "broken", "issue"}},
+		{chart.CCStyle, []string{"lint*", "gofmt", "format*", "whitespace"}},
+		{chart.CCRefactor, []string{"refactor*", "cleanup", "rename*", "simplif*"}},
+		{chart.CCChore, []string{"chore", "release", "version"}},
+	}
+)
+
+// leadingVerbRules classify a non-conventional headline by its first
+// word, the imperative verb by Git convention.
+var leadingVerbRules = []keywordRule{
+	{chart.CCFeat, []string{"add", "adds", "added", "implement", "implements", "implemented", "introduce", "introduces", "create", "support", "allow", "enable"}},
+	{chart.CCFix, []string{"fix", "fixes", "fixed", "resolve", "resolves", "correct", "repair", "prevent", "handle"}},
+	{chart.CCRefactor, []string{"refactor", "rename", "move", "extract", "simplify", "restructure", "rework", "clean", "cleanup", "replace", "remove", "delete"}},
+	{chart.CCDocs, []string{"document"}},
+	{chart.CCDeps, []string{"upgrade"}},
+	{chart.CCPerf, []string{"optimize", "speed"}},
+	{chart.CCStyle, []string{"format", "reformat", "lint"}},
+}
+
+// ClassifyCommitMessage classifies a commit message. A Conventional
+// Commits header ("type(scope)!: description") gives the type and scope,
+// with dependency scopes such as "chore(deps)" classified as
+// chart.CCDeps. A "!" before the colon or a BREAKING CHANGE footer marks
+// the commit as breaking. Other messages are classified by keywords in
+// their first line: Git's "Revert" prefix, then words naming security,
+// dependencies, docs, tests, CI or the build, then the leading verb, then
+// words suggesting performance, fixes, style, refactoring or chores.
+// Messages matching none are chart.CCOther.
+func ClassifyCommitMessage(message string) CommitClass {
+	message = strings.TrimSpace(strings.ReplaceAll(message, "\r\n", "\n"))
+	headline, _, _ := strings.Cut(message, "\n")
+	class := CommitClass{
+		Type:     chart.CCOther,
+		Breaking: breakingFooter.MatchString(message),
+	}
+
+	if m := conventionalHeader.FindStringSubmatch(headline); m != nil {
+		if typ, ok := conventionalTypes[strings.ToLower(m[1])]; ok {
+			class.Type = typ
+			class.Scope = strings.TrimSpace(m[2])
+			class.Breaking = class.Breaking || m[3] == "!"
+			class.Conventional = true
+			switch strings.ToLower(class.Scope) {
+			case "deps", "deps-dev", "dependencies":
+				if typ == chart.CCChore || typ == chart.CCBuild || typ == chart.CCFix {
+					class.Type = chart.CCDeps
+				}
+			}
+			return class
+		}
+	}
+
+	if strings.HasPrefix(headline, "Revert ") || strings.HasPrefix(headline, "revert ") {
+		class.Type = chart.CCRevert
+		return class
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(headline), func(r rune) bool {
+		return !('a' <= r && r <= 'z' || '0' <= r && r <= '9')
+	})
+	if typ, ok := matchKeywords(words, topicRules); ok {
+		class.Type = typ
+	} else if typ, ok := matchKeywords(words[:min(len(words), 1)], leadingVerbRules); ok {
+		class.Type = typ
+	} else if typ, ok := matchKeywords(words, fallbackRules); ok {
+		class.Type = typ
+	}
+	return class
+}
+
+// matchKeywords returns the type of the first rule matching any of words.
+func matchKeywords(words []string, rules []keywordRule) (chart.ConventionalCommitType, bool) {
+	for _, rule := range rules {
+		for _, kw := range rule.Words {
+			prefix, isPrefix := strings.CutSuffix(kw, "*")
+			for _, w := range words {
+				if w == kw || isPrefix && strings.HasPrefix(w, prefix) {
+					return rule.Type, true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+// BuildCommitTypeData classifies commits with ClassifyCommitMessage and
+// counts them by month, with a MonthlyCommitTypes for every month from
+// from to to, oldest first. Commits outside that range are ignored.
+func BuildCommitTypeData(username string, from, to time.Time, commits []graphql.CommitMessage) *chart.CommitTypeData {
+	data := &chart.CommitTypeData{
+		Username: username,
+		From:     from.Format("2006-01-02"),
+		To:       to.Format("2006-01-02"),
+		Monthly:  []chart.MonthlyCommitTypes{},
+		Summary: chart.CommitTypeSummary{
+			ByCCType: make(map[string]int),
+			ByCLCat:  make(map[string]int),
+		},
+	}
+
+	index := make(map[string]int) // "2024-01" -> index in Monthly
+	for m := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, from.Location()); !m.After(to); m = m.AddDate(0, 1, 0) {
+		index[m.Format("2006-01")] = len(data.Monthly)
+		data.Monthly = append(data.Monthly, chart.MonthlyCommitTypes{
+			YearMonth: m.Format("2006-01"),
+			Year:      m.Year(),
+			Month:     int(m.Month()),
+			MonthName: m.Format("Jan"),
+			ByCCType:  make(map[string]int),
+			ByCLCat:   make(map[string]int),
+		})
+	}
+
+	for _, c := range commits {
+		date := c.CommittedDate.In(from.Location())
+		if date.Before(from) || date.After(to) {
+			continue
+		}
+		i, ok := index[date.Format("2006-01")]
+		if !ok {
+			continue
+		}
+		class := ClassifyCommitMessage(c.Message)
+		typ, cat := string(class.Type), string(class.ChangelogCategory())
+
+		month := &data.Monthly[i]
+		month.Total++
+		month.ByCCType[typ]++
+		month.ByCLCat[cat]++
+		data.TotalCount++
+		data.Summary.ByCCType[typ]++
+		data.Summary.ByCLCat[cat]++
+	}
+
+	return data
+}
+
+// GetCommitTypes fetches the messages of the commits a user authored in
+// from..to on the default branches of the repositories they contributed
+// to, as GetUserProfile counts them, and classifies them with
+// BuildCommitTypeData. It traverses the commit histories again, so it
+// costs about as much as GetUserProfile's commit details stage. Only
+// opts.Visibility, opts.Concurrency, opts.Paginate, opts.GraphQLClient and
+// opts.Progress are used.
+func GetCommitTypes(ctx context.Context, client clientv1.Client, username string, from, to time.Time, opts *Options) (*chart.CommitTypeData, error) {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+
+	gqlClient, err := opts.graphQLClient(client)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := opts.Progress
+	if progress == nil {
+		progress = func(ProgressInfo) {} // no-op
+	}
+	report := func(current, total int, done bool) {
+		progress(ProgressInfo{
+			Stage:       1,
+			TotalStages: 1,
+			Description: "Fetching commit messages",
+			Current:     current,
+			Total:       total,
+			Done:        done,
+		})
+	}
+
+	report(0, 0, false)
+	commits, err := graphql.GetCommitMessages(ctx, gqlClient, username, from, to, &graphql.CommitStatsOptions{
+		Visibility:  opts.Visibility,
+		Concurrency: opts.Concurrency,
+		Paginate:    opts.Paginate,
+		Progress: func(current, total int) {
+			report(current, total, false)
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get commit messages: %w", err)
+	}
+	report(0, 0, true)
+
+	return BuildCommitTypeData(username, from, to, commits), nil
+}
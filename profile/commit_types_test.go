@@ -0,0 +1,87 @@
+package profile
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grokify/gogithub/graphql"
+	"github.com/grokify/gogithub/profile/svg/chart"
+)
+
+func TestClassifyCommitMessage(t *testing.T) {
+	tests := []struct {
+		message string
+		want    CommitClass
+	}{
+		// Conventional Commits
+		{"feat: add login", CommitClass{Type: chart.CCFeat, Conventional: true}},
+		{"fix(api): handle nil response", CommitClass{Type: chart.CCFix, Scope: "api", Conventional: true}},
+		{"feat(auth)!: drop basic auth", CommitClass{Type: chart.CCFeat, Scope: "auth", Breaking: true, Conventional: true}},
+		{"refactor: split client\n\nBREAKING CHANGE: NewClient takes options", CommitClass{Type: chart.CCRefactor, Breaking: true, Conventional: true}},
+		{"Docs: fix typo", CommitClass{Type: chart.CCDocs, Conventional: true}},
+		{"chore(deps): bump golang.org/x/net to 0.30.0", CommitClass{Type: chart.CCDeps, Scope: "deps", Conventional: true}},
+		{"revert: feat: add login", CommitClass{Type: chart.CCRevert, Conventional: true}},
+
+		// Keyword heuristics
+		{`Revert "Add login"`, CommitClass{Type: chart.CCRevert}},
+		{"Fix XSS vulnerability in templates", CommitClass{Type: chart.CCSecurity}},
+		{"Bump github.com/google/go-github from v88 to v89", CommitClass{Type: chart.CCDeps}},
+		{"Update README", CommitClass{Type: chart.CCDocs}},
+		{"Add tests for parser", CommitClass{Type: chart.CCTest}},
+		{"Add user search", CommitClass{Type: chart.CCFeat}},
+		{"Fixed panic on empty input", CommitClass{Type: chart.CCFix}},
+		{"Rename Foo to Bar", CommitClass{Type: chart.CCRefactor}},
+		{"Update workflow to Go 1.26", CommitClass{Type: chart.CCCI}},
+		{"Make parsing faster", CommitClass{Type: chart.CCPerf}},
+		{"Note: temporary workaround", CommitClass{Type: chart.CCOther}},
+		{"WIP", CommitClass{Type: chart.CCOther}},
+		{"", CommitClass{Type: chart.CCOther}},
+	}
+	for _, tt := range tests {
+		if got := ClassifyCommitMessage(tt.message); got != tt.want {
+			t.Errorf("ClassifyCommitMessage(%q) = %+v, want %+v", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestCommitClassChangelogCategory(t *testing.T) {
+	if got := (CommitClass{Type: chart.CCFeat}).ChangelogCategory(); got != chart.CLAdded {
+		t.Errorf("feat category = %q, want %q", got, chart.CLAdded)
+	}
+	if got := (CommitClass{Type: chart.CCFeat, Breaking: true}).ChangelogCategory(); got != chart.CLBreaking {
+		t.Errorf("breaking feat category = %q, want %q", got, chart.CLBreaking)
+	}
+}
+
+func TestBuildCommitTypeData(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 31, 23, 59, 59, 0, time.UTC)
+	commits := []graphql.CommitMessage{
+		{Message: "feat: one", CommittedDate: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{Message: "fix: two", CommittedDate: time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)},
+		{Message: "feat!: three", CommittedDate: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{Message: "feat: outside", CommittedDate: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	data := BuildCommitTypeData("octocat", from, to, commits)
+
+	if data.Username != "octocat" || data.From != "2024-01-01" || data.To != "2024-03-31" || data.TotalCount != 3 {
+		t.Errorf("data = %s %s..%s, %d commits", data.Username, data.From, data.To, data.TotalCount)
+	}
+	if len(data.Monthly) != 3 {
+		t.Fatalf("Monthly = %d months, want 3", len(data.Monthly))
+	}
+	jan, feb, mar := data.Monthly[0], data.Monthly[1], data.Monthly[2]
+	if jan.YearMonth != "2024-01" || jan.MonthName != "Jan" || jan.Total != 2 || jan.ByCCType["feat"] != 1 || jan.ByCLCat["Fixed"] != 1 {
+		t.Errorf("January = %+v", jan)
+	}
+	if feb.Total != 0 || len(feb.ByCCType) != 0 {
+		t.Errorf("February = %+v, want empty", feb)
+	}
+	if mar.ByCCType["feat"] != 1 || mar.ByCLCat["Breaking"] != 1 {
+		t.Errorf("March = %+v", mar)
+	}
+	if data.Summary.ByCCType["feat"] != 2 || data.Summary.ByCLCat["Added"] != 1 {
+		t.Errorf("Summary = %+v", data.Summary)
+	}
+}
@@ -0,0 +1,204 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/grokify/gogithub"
+	"github.com/grokify/gogithub/clientv1"
+	"github.com/grokify/gogithub/graphql"
+)
+
+// reposPerPage is the page size of the repository and commit history
+// queries GetUserProfile makes.
+const reposPerPage = 100
+
+// Estimate is the projected API cost of a GetUserProfile call, as planned
+// by EstimateUserProfile.
+type Estimate struct {
+	Username string
+	From     time.Time
+	To       time.Time
+
+	// Discovery results
+	Repos        int // repositories with commits by the user, matching Options.Visibility
+	ReleaseRepos int // repositories whose releases would be listed
+	Months       int // months in the range
+	CachedMonths int // months that would be read from Options.CacheDir
+
+	// Projected cost. Each commit history is counted as one page, so these
+	// are lower bounds for users with more than 100 commits to a
+	// repository in the range; likewise for repositories with more than
+	// 100 releases. RESTRequests counts release listings and, with
+	// Options.IncludeLanguages, one languages request per repository.
+	GraphQLQueries int
+	RESTRequests   int
+
+	// GraphQLPoints is a lower bound on the rate limit points the run
+	// would spend: each query is counted at GitHub's minimum of one
+	// point. Queries can cost more; the contribution query for a chunk of
+	// months asks for up to twelve collections at once, and commit
+	// history pages nest connections.
+	GraphQLPoints int
+
+	// Rate limits at the time of the estimate
+	GraphQLRate graphql.RateLimit
+	RESTRate    gogithub.RateLimit
+
+	// WallTime is the projected duration of the run: the time its requests
+	// take at the latency of the discovery queries, or, if they exceed the
+	// remaining rate limits, the time until the limits have reset often
+	// enough to allow them, whichever is longer.
+	WallTime time.Duration
+}
+
+// Fits reports whether the projected cost fits in the remaining rate
+// limits. As GraphQLPoints is a lower bound, a run that fits may still be
+// stopped by the GraphQL rate limit; one that does not fit will be.
+func (e *Estimate) Fits() bool {
+	return e.GraphQLPoints <= int(e.GraphQLRate.Remaining) && e.RESTRequests <= e.RESTRate.Remaining
+}
+
+// Summary returns a brief text summary of the estimate.
+func (e *Estimate) Summary() string {
+	fits := "fits in"
+	if !e.Fits() {
+		fits = "exceeds"
+	}
+	return fmt.Sprintf("%s: at least %d GraphQL points (%d remaining), %d REST requests (%d remaining), ~%s; %s the rate limits",
+		e.Username,
+		e.GraphQLPoints,
+		e.GraphQLRate.Remaining,
+		e.RESTRequests,
+		e.RESTRate.Remaining,
+		e.WallTime.Round(time.Second),
+		fits,
+	)
+}
+
+// EstimateUserProfile plans a GetUserProfile call with the same arguments
+// without making it. It runs only discovery queries: the rate limits, the
+// repositories the user has contributed to and, with opts.CacheDir set,
+// the months already cached. It then projects the requests and points
+// GetUserProfile would spend and how long it would take. Unlike
+// GetUserProfile, it always needs client, to read the REST rate limit.
+func EstimateUserProfile(ctx context.Context, client clientv1.Client, username string, from, to time.Time, opts *Options) (*Estimate, error) {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	if client == nil {
+		return nil, errors.New("EstimateUserProfile needs a REST client")
+	}
+
+	gqlClient, err := opts.graphQLClient(client)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	gqlRate, err := graphql.GetRateLimit(ctx, gqlClient)
+	if err != nil {
+		return nil, fmt.Errorf("get GraphQL rate limit: %w", err)
+	}
+	restRate, err := client.GetRateLimit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	repos, err := graphql.GetContributedRepositories(ctx, gqlClient, username, opts.Visibility)
+	if err != nil {
+		return nil, fmt.Errorf("get contributed repositories: %w", err)
+	}
+	repoPages := max(1, (len(repos)+reposPerPage-1)/reposPerPage)
+	latency := time.Since(start) / time.Duration(2+repoPages)
+
+	est := &Estimate{
+		Username:    username,
+		From:        from,
+		To:          to,
+		Repos:       len(repos),
+		GraphQLRate: *gqlRate,
+		RESTRate:    *restRate,
+	}
+
+	// Contribution queries and commit history fetches
+	contribQueries, fetches := contributionQueries(from, to), 1
+	if opts.CacheDir != "" {
+		windows := monthWindows(from, to, time.Now())
+		visibility := NewQueryMetadata(username, from, to, opts).Visibility
+		months, uncached, err := readCachedMonths(opts.CacheDir, username, visibility, windows)
+		if err != nil {
+			return nil, err
+		}
+		est.Months, est.CachedMonths = len(windows), len(months)
+		fetches = len(chunkMonths(uncached))
+		contribQueries = fetches // one per chunk of months
+	} else {
+		est.Months = len(monthWindows(from, to, time.Now()))
+	}
+
+	// The user and their repositories are looked up once, then each fetch
+	// pages through each repository's history.
+	serial := contribQueries
+	if fetches > 0 {
+		serial += 1 + repoPages
+	}
+	parallel := fetches * len(repos)
+	est.GraphQLQueries = serial + parallel
+	est.GraphQLPoints = est.GraphQLQueries // one point each, at least
+
+	if opts.IncludeReleases {
+		for _, repo := range repos {
+			if len(opts.ReleaseOrgs) > 0 && !slices.Contains(opts.ReleaseOrgs, repo.Owner) {
+				continue
+			}
+			est.ReleaseRepos++
+		}
+		if opts.MaxReleaseFetchRepos > 0 {
+			est.ReleaseRepos = min(est.ReleaseRepos, opts.MaxReleaseFetchRepos)
+		}
+		est.RESTRequests = est.ReleaseRepos
+	}
+	if opts.IncludeLanguages {
+		est.RESTRequests += len(repos)
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = graphql.DefaultConcurrency
+	}
+	rounds := serial + (parallel+concurrency-1)/concurrency + (est.RESTRequests+concurrency-1)/concurrency
+	now := time.Now()
+	est.WallTime = max(
+		latency*time.Duration(rounds),
+		rateLimitWait(est.GraphQLPoints, int(gqlRate.Remaining), int(gqlRate.Limit), gqlRate.ResetAt.Time, now),
+		rateLimitWait(est.RESTRequests, restRate.Remaining, restRate.Limit, restRate.Reset, now),
+	)
+
+	return est, nil
+}
+
+// contributionQueries returns the number of contributionsCollection
+// queries graphql.GetContributionStatsMultiYear makes for from..to.
+func contributionQueries(from, to time.Time) int {
+	if to.Sub(from) <= 365*24*time.Hour {
+		return 1
+	}
+	n := 0
+	for current := from; current.Before(to); current = current.AddDate(1, 0, 0) {
+		n++
+	}
+	return n
+}
+
+// rateLimitWait returns how long until an hourly rate limit, with
+// remaining of limit left until reset, allows needed more requests.
+func rateLimitWait(needed, remaining, limit int, reset, now time.Time) time.Duration {
+	if needed <= remaining || limit <= 0 {
+		return 0
+	}
+	resets := (needed - remaining + limit - 1) / limit
+	return max(reset.Sub(now), 0) + time.Duration(resets-1)*time.Hour
+}
@@ -0,0 +1,147 @@
+package profile
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grokify/gogithub/clientv1"
+	"github.com/grokify/gogithub/clientv1/fake"
+)
+
+func TestEstimateUserProfile(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC)
+
+	var s cacheServer
+	client := s.client(t)
+
+	// Two yearly contribution queries, the user and repository lookups,
+	// and one history page.
+	est, err := EstimateUserProfile(ctx, client, "octocat", from, to, &Options{IncludeReleases: true})
+	if err != nil {
+		t.Fatalf("EstimateUserProfile() error = %v", err)
+	}
+	if c, h := s.counts(); c != 0 || h != 0 {
+		t.Errorf("EstimateUserProfile() made %d contribution and %d history queries, want none", c, h)
+	}
+	if est.Repos != 1 || est.ReleaseRepos != 1 || est.Months != 24 || est.GraphQLPoints != 5 || est.RESTRequests != 1 {
+		t.Errorf("estimate = %+v", est)
+	}
+	if !est.Fits() || est.WallTime > time.Minute {
+		t.Errorf("estimate does not fit: %s", est.Summary())
+	}
+	if !strings.Contains(est.Summary(), "at least 5 GraphQL points") {
+		t.Errorf("Summary() = %q, want the GraphQL points as a lower bound", est.Summary())
+	}
+
+	// Releases of other orgs are not counted.
+	est, err = EstimateUserProfile(ctx, client, "octocat", from, to, &Options{IncludeReleases: true, ReleaseOrgs: []string{"grokify"}})
+	if err != nil || est.RESTRequests != 0 {
+		t.Errorf("EstimateUserProfile(ReleaseOrgs) = %+v, %v; want no REST requests", est, err)
+	}
+
+	// Through an empty cache, a contribution query and history fetch per
+	// year, and one user and repository lookup.
+	opts := &Options{CacheDir: t.TempDir()}
+	est, err = EstimateUserProfile(ctx, client, "octocat", from, to, opts)
+	if err != nil || est.CachedMonths != 0 || est.GraphQLPoints != 6 {
+		t.Errorf("EstimateUserProfile(empty cache) = %+v, %v; want 6 points", est, err)
+	}
+
+	// Cached months are not queried.
+	if _, err := GetUserProfile(ctx, client, "octocat", from, to, opts); err != nil {
+		t.Fatalf("GetUserProfile() error = %v", err)
+	}
+	est, err = EstimateUserProfile(ctx, client, "octocat", from, to, opts)
+	if err != nil || est.CachedMonths != 24 || est.GraphQLPoints != 0 {
+		t.Errorf("EstimateUserProfile(cached) = %+v, %v; want 24 cached months and no points", est, err)
+	}
+}
+
+func TestEstimateUserProfileOverBudget(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC)
+
+	s := cacheServer{rateRemaining: 2}
+	est, err := EstimateUserProfile(context.Background(), s.client(t), "octocat", from, to, nil)
+	if err != nil {
+		t.Fatalf("EstimateUserProfile() error = %v", err)
+	}
+	if est.Fits() {
+		t.Errorf("estimate fits: %s", est.Summary())
+	}
+	if est.WallTime < 59*time.Minute || est.WallTime > time.Hour {
+		t.Errorf("WallTime = %s, want the time until the reset", est.WallTime)
+	}
+}
+
+func TestRateLimitWait(t *testing.T) {
+	now := time.Now()
+	reset := now.Add(10 * time.Minute)
+	tests := []struct {
+		needed, remaining int
+		want              time.Duration
+	}{
+		{100, 100, 0},
+		{101, 100, 10 * time.Minute},
+		{5100, 100, 10 * time.Minute},
+		{5101, 100, 70 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := rateLimitWait(tt.needed, tt.remaining, 5000, reset, now); got != tt.want {
+			t.Errorf("rateLimitWait(%d, %d) = %s, want %s", tt.needed, tt.remaining, got, tt.want)
+		}
+	}
+}
+
+func TestGraphQLClientOption(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC)
+
+	var s cacheServer
+	gql, err := clientv1.NewGraphQLClient(s.client(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A fake has no GraphQL client of its own.
+	client := fake.NewClient()
+	if _, err := GetUserProfile(ctx, client, "octocat", from, to, nil); err == nil {
+		t.Error("GetUserProfile(fake) error = nil, want error")
+	}
+
+	opts := &Options{GraphQLClient: gql}
+	p, err := GetUserProfile(ctx, client, "octocat", from, to, opts)
+	if err != nil {
+		t.Fatalf("GetUserProfile(fake, GraphQLClient) error = %v", err)
+	}
+	if p.CommitsDefaultBranch != 12 {
+		t.Errorf("profile has %d commits, want 12", p.CommitsDefaultBranch)
+	}
+	if _, err := EstimateUserProfile(ctx, client, "octocat", from, to, opts); err != nil {
+		t.Errorf("EstimateUserProfile(fake, GraphQLClient) error = %v", err)
+	}
+
+	// Without REST stages, the REST client is not needed.
+	if _, err := GetUserProfile(ctx, nil, "octocat", from, to, opts); err != nil {
+		t.Errorf("GetUserProfile(nil, GraphQLClient) error = %v", err)
+	}
+	for _, o := range []*Options{
+		{GraphQLClient: gql, IncludeReleases: true},
+		{GraphQLClient: gql, IncludeLanguages: true},
+	} {
+		if _, err := GetUserProfile(ctx, nil, "octocat", from, to, o); err == nil {
+			t.Errorf("GetUserProfile(nil) with IncludeReleases=%v IncludeLanguages=%v error = nil, want error", o.IncludeReleases, o.IncludeLanguages)
+		}
+	}
+	if _, err := GetUserProfile(ctx, nil, "octocat", from, to, nil); err == nil {
+		t.Error("GetUserProfile(nil, nil) error = nil, want error")
+	}
+	if _, err := EstimateUserProfile(ctx, nil, "octocat", from, to, opts); err == nil {
+		t.Error("EstimateUserProfile(nil, GraphQLClient) error = nil, want error")
+	}
+}
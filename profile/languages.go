@@ -0,0 +1,139 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/grokify/gogithub/clientv1"
+	"github.com/grokify/gogithub/graphql"
+	"github.com/grokify/gogithub/internal/parallel"
+)
+
+// LanguageWeighting selects how much each repository's languages count
+// towards a language breakdown.
+type LanguageWeighting int
+
+const (
+	// WeightByCommits weights each repository by the user's commits to it.
+	WeightByCommits LanguageWeighting = iota
+	// WeightByAdditions weights each repository by the lines the user added.
+	WeightByAdditions
+)
+
+// LanguageShare is a language's share of a user's contributions.
+type LanguageShare struct {
+	Name string
+
+	// Weight is the sum, over the repositories using the language, of the
+	// language's fraction of the repository's bytes of code times the
+	// repository's weight: the user's commits or additions to it.
+	Weight float64
+
+	// Percent is Weight as a percentage of the weight of all languages.
+	Percent float64
+}
+
+// BuildLanguageBreakdown splits each repository's weight, its commits or
+// additions, between its languages in proportion to their bytes of code,
+// and sums the results by language. Repositories without Languages are
+// skipped. Shares are sorted by weight, largest first.
+func BuildLanguageBreakdown(repos []RepoContribution, weighting LanguageWeighting) []LanguageShare {
+	weights := make(map[string]float64)
+	var total float64
+	for _, repo := range repos {
+		repoWeight := float64(repo.Commits)
+		if weighting == WeightByAdditions {
+			repoWeight = float64(repo.Additions)
+		}
+		repoBytes := 0
+		for _, b := range repo.Languages {
+			repoBytes += b
+		}
+		if repoWeight <= 0 || repoBytes == 0 {
+			continue
+		}
+		for lang, b := range repo.Languages {
+			w := repoWeight * float64(b) / float64(repoBytes)
+			weights[lang] += w
+			total += w
+		}
+	}
+
+	shares := make([]LanguageShare, 0, len(weights))
+	for lang, w := range weights {
+		shares = append(shares, LanguageShare{
+			Name:    lang,
+			Weight:  w,
+			Percent: 100 * w / total,
+		})
+	}
+	sort.Slice(shares, func(i, j int) bool {
+		if shares[i].Weight != shares[j].Weight {
+			return shares[i].Weight > shares[j].Weight
+		}
+		return shares[i].Name < shares[j].Name
+	})
+	return shares
+}
+
+// TopLanguages returns the n languages with the largest shares.
+// If n <= 0, returns all languages.
+func (p *UserProfile) TopLanguages(n int) []LanguageShare {
+	if n <= 0 || n >= len(p.Languages) {
+		return p.Languages
+	}
+	return p.Languages[:n]
+}
+
+// fetchLanguages fetches the languages of the profile's repositories and
+// builds its language breakdown.
+// Errors fetching individual repos are silently ignored, as for releases.
+func fetchLanguages(ctx context.Context, restClient clientv1.Client, profile *UserProfile, opts *Options, progress ProgressFunc, stage, totalStages int) error {
+	total := len(profile.RepoStats)
+	report := func(current int, done bool) {
+		progress(ProgressInfo{
+			Stage:       stage,
+			TotalStages: totalStages,
+			Description: "Fetching languages",
+			Current:     current,
+			Total:       total,
+			Done:        done,
+		})
+	}
+
+	report(0, false)
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = graphql.DefaultConcurrency
+	}
+
+	var mu sync.Mutex
+	count := 0
+	err := parallel.ForEach(ctx, total, concurrency, func(ctx context.Context, i int) error {
+		repo := &profile.RepoStats[i]
+		langs, err := restClient.ListLanguages(ctx, repo.Owner, repo.Name)
+		switch {
+		case err == nil:
+			repo.Languages = langs
+		case isFatal(err):
+			return fmt.Errorf("list languages of %s/%s: %w", repo.Owner, repo.Name, err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		count++
+		report(count, false)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	profile.Languages = BuildLanguageBreakdown(profile.RepoStats, opts.LanguageWeighting)
+
+	report(count, true)
+	return nil
+}
@@ -0,0 +1,72 @@
+package profile
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/grokify/gogithub/clientv1/fake"
+)
+
+func TestBuildLanguageBreakdown(t *testing.T) {
+	repos := []RepoContribution{
+		{FullName: "a/go", Commits: 3, Additions: 100, Languages: map[string]int{"Go": 900, "Shell": 100}},
+		{FullName: "a/web", Commits: 1, Additions: 900, Languages: map[string]int{"TypeScript": 500, "Shell": 500}},
+		{FullName: "a/none", Commits: 5, Additions: 50},
+	}
+
+	byCommits := BuildLanguageBreakdown(repos, WeightByCommits)
+	want := []LanguageShare{
+		{Name: "Go", Weight: 2.7, Percent: 67.5},
+		{Name: "Shell", Weight: 0.8, Percent: 20},
+		{Name: "TypeScript", Weight: 0.5, Percent: 12.5},
+	}
+	if len(byCommits) != len(want) {
+		t.Fatalf("BuildLanguageBreakdown(commits) = %+v", byCommits)
+	}
+	for i, w := range want {
+		got := byCommits[i]
+		if got.Name != w.Name || math.Abs(got.Weight-w.Weight) > 1e-9 || math.Abs(got.Percent-w.Percent) > 1e-9 {
+			t.Errorf("share %d = %+v, want %+v", i, got, w)
+		}
+	}
+
+	byAdditions := BuildLanguageBreakdown(repos, WeightByAdditions)
+	if byAdditions[0].Name != "Shell" || math.Abs(byAdditions[0].Weight-460) > 1e-9 {
+		t.Errorf("BuildLanguageBreakdown(additions)[0] = %+v, want Shell with 460", byAdditions[0])
+	}
+
+	if got := BuildLanguageBreakdown(nil, WeightByCommits); len(got) != 0 {
+		t.Errorf("BuildLanguageBreakdown(nil) = %+v, want none", got)
+	}
+}
+
+func TestFetchLanguages(t *testing.T) {
+	ctx := context.Background()
+	fc := fake.NewClient()
+	fc.AddRepository("octocat", "go")
+	if err := fc.SetLanguages("octocat", "go", map[string]int{"Go": 1000}); err != nil {
+		t.Fatal(err)
+	}
+	p := &UserProfile{RepoStats: []RepoContribution{
+		{Owner: "octocat", Name: "go", FullName: "octocat/go", Commits: 2},
+		{Owner: "octocat", Name: "gone", FullName: "octocat/gone", Commits: 1}, // not found, so skipped
+	}}
+
+	var last ProgressInfo
+	if err := fetchLanguages(ctx, fc, p, &Options{}, func(info ProgressInfo) { last = info }, 5, 5); err != nil {
+		t.Fatalf("fetchLanguages() error = %v", err)
+	}
+	if p.RepoStats[0].Languages["Go"] != 1000 || p.RepoStats[1].Languages != nil {
+		t.Errorf("repo languages = %v, %v", p.RepoStats[0].Languages, p.RepoStats[1].Languages)
+	}
+	if len(p.Languages) != 1 || p.Languages[0].Name != "Go" || p.Languages[0].Percent != 100 {
+		t.Errorf("Languages = %+v, want Go at 100%%", p.Languages)
+	}
+	if !last.Done || last.Stage != 5 || last.Current != 2 {
+		t.Errorf("last progress = %+v, want stage 5 done at 2", last)
+	}
+	if top := p.TopLanguages(3); len(top) != 1 {
+		t.Errorf("TopLanguages(3) = %+v", top)
+	}
+}
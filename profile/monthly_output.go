@@ -68,6 +68,11 @@ type MonthlyOutputFile struct {
 	Month     int           `json:"month"`
 	MonthName string        `json:"monthName"`
 	Stats     MonthlyStats  `json:"stats"`
+
+	// Contributions and Repos are the raw data of a month cached by
+	// GetUserProfile (see Options.CacheDir). Other writers omit them.
+	Contributions *MonthlyContributions `json:"contributions,omitempty"`
+	Repos         []MonthlyRepoStats    `json:"repos,omitempty"`
 }
 
 // MonthlyOutputMulti is the structure for a combined monthly output file.
@@ -2,12 +2,18 @@ package profile
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"sort"
+	"sync"
 	"time"
 
+	"github.com/grokify/gogithub"
 	"github.com/grokify/gogithub/clientv1"
+	ghErrors "github.com/grokify/gogithub/errors"
 	"github.com/grokify/gogithub/graphql"
+	"github.com/grokify/gogithub/internal/parallel"
 	"github.com/grokify/gogithub/release"
 	"github.com/shurcooL/githubv4"
 )
@@ -43,9 +49,17 @@ type UserProfile struct {
 	ReposContributedTo int
 	RepoStats          []RepoContribution
 
+	// Languages is the language breakdown of RepoStats, largest share
+	// first (optional, nil if not fetched).
+	Languages []LanguageShare
+
 	// Time-series data
 	Calendar *ContributionCalendar
 	Activity *ActivityTimeline
+
+	// Punchcard counts the default branch commits by the weekday and hour
+	// they were authored, in Options.Timezone.
+	Punchcard *Punchcard
 }
 
 // RepoContribution contains contribution statistics for a single repository.
@@ -53,11 +67,16 @@ type RepoContribution struct {
 	Owner     string
 	Name      string
 	FullName  string // "owner/repo"
+	URL       string // web URL, e.g. "https://github.com/owner/repo"
 	IsPrivate bool
 	Commits   int
 	Additions int
 	Deletions int
 	Releases  int // Number of releases (optional, may be 0 if not fetched)
+
+	// Languages maps languages to bytes of code in the repository
+	// (optional, nil if not fetched).
+	Languages map[string]int
 }
 
 // ProgressInfo contains information about the current progress state.
@@ -92,6 +111,44 @@ type Options struct {
 	// these orgs/users are counted (e.g., ["grokify", "plexusone"]).
 	ReleaseOrgs []string
 
+	// IncludeLanguages fetches the languages of each contributed
+	// repository, one REST request per repository, and builds
+	// UserProfile.Languages.
+	IncludeLanguages bool
+
+	// LanguageWeighting selects how repositories are weighted in
+	// UserProfile.Languages. Default: WeightByCommits.
+	LanguageWeighting LanguageWeighting
+
+	// Timezone is the IANA name of the time zone, e.g. "Europe/Berlin",
+	// in which UserProfile.Punchcard counts commits. Default: UTC.
+	Timezone string
+
+	// Concurrency is the number of repositories whose commit histories or
+	// releases are fetched at once. Default: graphql.DefaultConcurrency.
+	Concurrency int
+
+	// Paginate controls the pagination of commit histories, e.g. its
+	// rate limit point budget; see graphql.CommitStatsOptions.Paginate.
+	// Nil uses the defaults.
+	Paginate *graphql.PaginateOptions
+
+	// GraphQLClient, if set, is used for GraphQL queries instead of a
+	// client derived from the REST client with clientv1.NewGraphQLClient,
+	// which requires a go-github backed client or a
+	// clientv1.GraphQLProvider. Set it to use a fake or wrapping REST
+	// client. With it set, the REST client may be nil unless
+	// IncludeReleases or IncludeLanguages is set.
+	GraphQLClient *githubv4.Client
+
+	// CacheDir, if set, is a directory in which GetUserProfile caches each
+	// month's contribution and per-repository commit stats, one
+	// MonthlyOutputFile per month. Completed months found there are not
+	// queried again, so an interrupted run resumes where it stopped. The
+	// directory should not be shared with WriteMonthlyFiles, whose files
+	// have the same names but lack the cached data.
+	CacheDir string
+
 	// Progress is called to report progress during fetching.
 	// If nil, no progress is reported.
 	Progress ProgressFunc
@@ -106,20 +163,64 @@ func DefaultOptions() *Options {
 	}
 }
 
+// graphQLClient returns opts.GraphQLClient, or else a GraphQL client
+// derived from client.
+func (opts *Options) graphQLClient(client clientv1.Client) (*githubv4.Client, error) {
+	if opts.GraphQLClient != nil {
+		return opts.GraphQLClient, nil
+	}
+	if client == nil {
+		return nil, errors.New("no REST client or Options.GraphQLClient")
+	}
+	return clientv1.NewGraphQLClient(client)
+}
+
+// checkRESTClient returns an error if client is nil but opts needs it for
+// release counts or languages.
+func (opts *Options) checkRESTClient(client clientv1.Client) error {
+	if client == nil && (opts.IncludeReleases || opts.IncludeLanguages) {
+		return errors.New("IncludeReleases and IncludeLanguages need a REST client")
+	}
+	return nil
+}
+
 // GetUserProfile fetches comprehensive profile statistics for a GitHub user.
 // This function makes multiple API calls to gather all data:
 //   - GraphQL: contributionsCollection for summary stats and calendar
 //   - GraphQL: commit history for additions/deletions per repo
 //   - REST: release counts (optional)
-func GetUserProfile(ctx context.Context, restClient clientv1.Client, gqlClient *githubv4.Client, username string, from, to time.Time, opts *Options) (*UserProfile, error) {
+//   - REST: repository languages (optional)
+//
+// GraphQL queries are sent through client's transport, as by
+// clientv1.NewGraphQLClient, unless opts.GraphQLClient is set. If
+// opts.CacheDir is set, they are made month by month and completed months
+// are cached; see Options.CacheDir. client may be nil only if
+// opts.GraphQLClient is set and no REST stage is requested.
+func GetUserProfile(ctx context.Context, client clientv1.Client, username string, from, to time.Time, opts *Options) (*UserProfile, error) {
 	if opts == nil {
 		opts = DefaultOptions()
 	}
+	if err := opts.checkRESTClient(client); err != nil {
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(opts.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("load timezone: %w", err)
+	}
+
+	gqlClient, err := opts.graphQLClient(client)
+	if err != nil {
+		return nil, err
+	}
 
 	// Determine total stages
 	totalStages := 4 // contrib stats, commit details, process repos, build timeline
-	if opts.IncludeReleases && restClient != nil {
-		totalStages = 5 // add releases stage
+	if opts.IncludeReleases {
+		totalStages++ // add releases stage
+	}
+	if opts.IncludeLanguages {
+		totalStages++ // add languages stage
 	}
 
 	progress := opts.Progress
@@ -144,11 +245,39 @@ func GetUserProfile(ctx context.Context, restClient clientv1.Client, gqlClient *
 		To:       to,
 	}
 
-	// Stage 1: Fetch contribution stats (commits, issues, PRs, reviews, repos created)
-	report(1, "Fetching contribution statistics", 0, 0, false)
-	contribStats, err := graphql.GetContributionStatsMultiYear(ctx, gqlClient, username, from, to)
-	if err != nil {
-		return nil, fmt.Errorf("get contribution stats: %w", err)
+	var contribStats *graphql.ContributionStats
+	var commitStats *graphql.CommitStats
+	var months map[string]*cachedMonth
+	if opts.CacheDir != "" {
+		// Stages 1 and 2, month by month through the cache
+		contribStats, commitStats, months, err = fetchMonths(ctx, gqlClient, username, from, to, opts, report)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Stage 1: Fetch contribution stats (commits, issues, PRs, reviews, repos created)
+		report(1, "Fetching contribution statistics", 0, 0, false)
+		contribStats, err = graphql.GetContributionStatsMultiYear(ctx, gqlClient, username, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("get contribution stats: %w", err)
+		}
+		report(1, "Fetching contribution statistics", 0, 0, true)
+
+		// Stage 2: Fetch detailed commit stats (additions/deletions per repo)
+		report(2, "Fetching commit details", 0, 0, false)
+		commitStatsProgress := func(current, total int) {
+			report(2, "Fetching commit details", current, total, false)
+		}
+		commitStats, err = graphql.GetCommitStatsWithOptions(ctx, gqlClient, username, from, to, &graphql.CommitStatsOptions{
+			Visibility:  opts.Visibility,
+			Concurrency: opts.Concurrency,
+			Paginate:    opts.Paginate,
+			Progress:    commitStatsProgress,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("get commit stats: %w", err)
+		}
+		report(2, "Fetching commit details", 0, 0, true)
 	}
 
 	profile.TotalCommits = contribStats.TotalCommitContributions
@@ -159,24 +288,12 @@ func GetUserProfile(ctx context.Context, restClient clientv1.Client, gqlClient *
 	profile.RestrictedContributions = contribStats.RestrictedContributions
 
 	// Build contribution calendar from the GraphQL data
-	profile.Calendar = buildCalendarFromContributions(contribStats.ContributionsByMonth, from, to)
-	report(1, "Fetching contribution statistics", 0, 0, true)
-
-	// Stage 2: Fetch detailed commit stats (additions/deletions per repo)
-	report(2, "Fetching commit details", 0, 0, false)
-	commitStatsProgress := func(current, total int) {
-		report(2, "Fetching commit details", current, total, false)
-	}
-	commitStats, err := graphql.GetCommitStatsWithProgress(ctx, gqlClient, username, from, to, opts.Visibility, commitStatsProgress)
-	if err != nil {
-		return nil, fmt.Errorf("get commit stats: %w", err)
-	}
+	profile.Calendar = buildCalendarFromContributions(contribStats.ContributionDays, from, to)
 
 	profile.CommitsDefaultBranch = commitStats.TotalCommits
 	profile.TotalAdditions = commitStats.Additions
 	profile.TotalDeletions = commitStats.Deletions
 	profile.ReposContributedTo = len(commitStats.ByRepo)
-	report(2, "Fetching commit details", 0, 0, true)
 
 	// Stage 3: Process repositories
 	repoCount := len(commitStats.ByRepo)
@@ -188,6 +305,7 @@ func GetUserProfile(ctx context.Context, restClient clientv1.Client, gqlClient *
 			Owner:     repo.Owner,
 			Name:      repo.Name,
 			FullName:  fmt.Sprintf("%s/%s", repo.Owner, repo.Name),
+			URL:       repo.URL,
 			IsPrivate: repo.IsPrivate,
 			Commits:   repo.Commits,
 			Additions: repo.Additions,
@@ -199,36 +317,60 @@ func GetUserProfile(ctx context.Context, restClient clientv1.Client, gqlClient *
 	}
 	report(3, "Processing repositories", repoCount, repoCount, true)
 
-	// Stage 4: Build activity timeline
+	// Stage 4: Build activity timeline and punchcard
 	report(4, "Building activity timeline", 0, 0, false)
 	profile.Activity = buildActivityTimeline(username, from, to, contribStats, commitStats)
+	for i := range profile.Activity.Months {
+		// Months fetched through the cache also have contribution counts
+		month := &profile.Activity.Months[i]
+		if m, ok := months[month.YearMonth()]; ok {
+			month.Issues = m.Contributions.Issues
+			month.PRs = m.Contributions.PRs
+			month.Reviews = m.Contributions.Reviews
+		}
+	}
+	profile.Punchcard = BuildPunchcard(authoredDates(commitStats.ByRepo), loc)
 	report(4, "Building activity timeline", 0, 0, true)
 
 	// Stage 5 (optional): Fetch release counts
-	if opts.IncludeReleases && restClient != nil {
-		fetchReleaseCounts(ctx, restClient, profile, opts, progress, totalStages)
+	if opts.IncludeReleases {
+		if err := fetchReleaseCounts(ctx, client, profile, opts, progress, totalStages); err != nil {
+			return nil, fmt.Errorf("get release counts: %w", err)
+		}
+	}
+
+	// Last stage (optional): Fetch languages
+	if opts.IncludeLanguages {
+		if err := fetchLanguages(ctx, client, profile, opts, progress, totalStages, totalStages); err != nil {
+			return nil, fmt.Errorf("get languages: %w", err)
+		}
 	}
 
 	return profile, nil
 }
 
-// buildCalendarFromContributions creates a ContributionCalendar from monthly contribution data.
-// Note: This provides monthly granularity. For daily granularity, use the GraphQL
-// contributionCalendar field directly.
-func buildCalendarFromContributions(monthly []graphql.MonthlyContribution, from, to time.Time) *ContributionCalendar {
-	// Create a day for the first of each month with that month's contribution count
-	var days []CalendarDay
+// buildCalendarFromContributions creates a ContributionCalendar with a day
+// for each date of from..to, counting the contributions of daily, the
+// contribution calendar's days. Days of daily outside the range are
+// ignored.
+func buildCalendarFromContributions(daily []graphql.DailyContribution, from, to time.Time) *ContributionCalendar {
+	if len(daily) == 0 {
+		return NewCalendarFromDays(nil)
+	}
+	counts := make(map[string]int, len(daily)) // "2024-01-15" -> count
+	for _, d := range daily {
+		counts[d.Date.Format("2006-01-02")] += d.Count
+	}
 
-	for _, m := range monthly {
-		date := time.Date(m.Year, m.Month, 1, 0, 0, 0, 0, time.UTC)
-		if date.Before(from) || date.After(to) {
-			continue
-		}
+	var days []CalendarDay
+	first := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	for date := first; !date.After(to); date = date.AddDate(0, 0, 1) {
+		count := counts[date.Format("2006-01-02")]
 		days = append(days, CalendarDay{
 			Date:              date,
 			Weekday:           date.Weekday(),
-			ContributionCount: m.Count,
-			Level:             CalculateLevel(m.Count),
+			ContributionCount: count,
+			Level:             CalculateLevel(count),
 		})
 	}
 
@@ -279,13 +421,18 @@ func buildActivityTimeline(username string, from, to time.Time, contribStats *gr
 	}
 
 	// Add per-repo commit breakdowns
-	// Note: commitStats.ByRepo doesn't have monthly breakdown, so we distribute
-	// proportionally or leave CommitsByRepo empty for now
-	// A more accurate implementation would need per-month per-repo data from GraphQL
 	for _, repo := range commitStats.ByRepo {
 		fullName := fmt.Sprintf("%s/%s", repo.Owner, repo.Name)
-		// Add to all months proportionally (simplified approach)
-		// In reality, you'd want per-month per-repo data
+		if len(repo.ByMonth) > 0 {
+			for _, mcs := range repo.ByMonth {
+				if activity, ok := activityMap[mcs.YearMonth()]; ok && mcs.Commits > 0 {
+					activity.CommitsByRepo[fullName] += mcs.Commits
+				}
+			}
+			continue
+		}
+		// Without a monthly breakdown, distribute the repo's commits
+		// across months proportionally (simplified approach)
 		for _, activity := range activityMap {
 			if activity.Commits > 0 && repo.Commits > 0 {
 				// Estimate repo's contribution to this month
@@ -310,7 +457,7 @@ func buildActivityTimeline(username string, from, to time.Time, contribStats *gr
 
 // fetchReleaseCounts fetches release counts for repositories and aggregates by month.
 // Errors fetching individual repos are silently ignored (e.g., lost access, deleted repo).
-func fetchReleaseCounts(ctx context.Context, restClient clientv1.Client, profile *UserProfile, opts *Options, progress ProgressFunc, totalStages int) {
+func fetchReleaseCounts(ctx context.Context, restClient clientv1.Client, profile *UserProfile, opts *Options, progress ProgressFunc, totalStages int) error {
 	maxRepos := opts.MaxReleaseFetchRepos
 
 	// Build org filter set for efficient lookup
@@ -330,10 +477,10 @@ func fetchReleaseCounts(ctx context.Context, restClient clientv1.Client, profile
 		eligibleRepos = append(eligibleRepos, repo)
 	}
 
-	total := len(eligibleRepos)
-	if maxRepos > 0 && maxRepos < total {
-		total = maxRepos
+	if maxRepos > 0 && maxRepos < len(eligibleRepos) {
+		eligibleRepos = eligibleRepos[:maxRepos]
 	}
+	total := len(eligibleRepos)
 
 	report := func(current int, done bool) {
 		progress(ProgressInfo{
@@ -348,47 +495,43 @@ func fetchReleaseCounts(ctx context.Context, restClient clientv1.Client, profile
 
 	report(0, false)
 
-	// Track releases by month for aggregation
-	releasesByMonth := make(map[string]int) // "2024-01" -> count
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = graphql.DefaultConcurrency
+	}
 
+	// Fetch each repository's releases, storing counts by index so they
+	// merge in repository order.
+	byMonth := make([]map[string]int, len(eligibleRepos))
+	var mu sync.Mutex
 	count := 0
-	for _, repo := range eligibleRepos {
-		if maxRepos > 0 && count >= maxRepos {
-			break
-		}
-
+	err := parallel.ForEach(ctx, len(eligibleRepos), concurrency, func(ctx context.Context, i int) error {
+		repo := eligibleRepos[i]
 		releases, err := release.ListReleases(ctx, restClient, repo.Owner, repo.Name)
-		if err != nil {
-			// Skip repos we can't access (might have lost access or repo deleted)
-			continue
+		switch {
+		case err == nil:
+			repo.Releases, byMonth[i] = countReleases(releases, profile.From, profile.To)
+		case isFatal(err):
+			return fmt.Errorf("list releases of %s/%s: %w", repo.Owner, repo.Name, err)
 		}
+		// Otherwise skip repos we can't access (might have lost access or repo deleted)
 
-		// Count releases and aggregate by month
-		repoReleaseCount := 0
-		for _, rel := range releases {
-			var relTime time.Time
-			if rel.PublishedAt != nil && !rel.PublishedAt.IsZero() {
-				relTime = *rel.PublishedAt
-			} else if !rel.CreatedAt.IsZero() {
-				relTime = rel.CreatedAt
-			}
-			if relTime.IsZero() {
-				repoReleaseCount++
-				continue
-			}
-
-			// Check if release is within profile date range
-			if relTime.Before(profile.From) || relTime.After(profile.To) {
-				continue
-			}
-
-			repoReleaseCount++
-			key := relTime.Format("2006-01")
-			releasesByMonth[key]++
-		}
-		repo.Releases = repoReleaseCount
+		mu.Lock()
+		defer mu.Unlock()
 		count++
 		report(count, false)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Track releases by month for aggregation
+	releasesByMonth := make(map[string]int) // "2024-01" -> count
+	for _, m := range byMonth {
+		for key, n := range m {
+			releasesByMonth[key] += n
+		}
 	}
 
 	// Update monthly activity with release counts
@@ -401,6 +544,42 @@ func fetchReleaseCounts(ctx context.Context, restClient clientv1.Client, profile
 	}
 
 	report(count, true)
+	return nil
+}
+
+// countReleases returns the number of releases published between from and
+// to, counting undated releases too, and the dated ones by month.
+func countReleases(releases []*gogithub.Release, from, to time.Time) (int, map[string]int) {
+	byMonth := make(map[string]int) // "2024-01" -> count
+	count := 0
+	for _, rel := range releases {
+		var relTime time.Time
+		if rel.PublishedAt != nil && !rel.PublishedAt.IsZero() {
+			relTime = *rel.PublishedAt
+		} else if !rel.CreatedAt.IsZero() {
+			relTime = rel.CreatedAt
+		}
+		if relTime.IsZero() {
+			count++
+			continue
+		}
+
+		// Check if release is within profile date range
+		if relTime.Before(from) || relTime.After(to) {
+			continue
+		}
+
+		count++
+		byMonth[relTime.Format("2006-01")]++
+	}
+	return count, byMonth
+}
+
+// isFatal reports whether an error fetching one repository's releases
+// should abort fetchReleaseCounts rather than skip the repository.
+func isFatal(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) ||
+		ghErrors.IsRateLimitError(err) || ghErrors.StatusCode(err) == http.StatusUnauthorized
 }
 
 // Summary returns a brief text summary of the profile.
@@ -2,24 +2,16 @@ package profile
 
 import (
 	"context"
-	"os"
 	"testing"
 	"time"
 
 	"github.com/grokify/gogithub/clientv1"
+	"github.com/grokify/gogithub/githubtest"
 	"github.com/grokify/gogithub/graphql"
 )
 
 const testUsername = "grokify"
 
-func getTestToken(t *testing.T) string {
-	token := os.Getenv("GITHUB_TOKEN")
-	if token == "" {
-		t.Skip("GITHUB_TOKEN not set, skipping integration test")
-	}
-	return token
-}
-
 func newTestClient(t *testing.T, ctx context.Context, token string) clientv1.Client {
 	client, err := clientv1.NewClient(ctx, token)
 	if err != nil {
@@ -29,17 +21,15 @@ func newTestClient(t *testing.T, ctx context.Context, token string) clientv1.Cli
 }
 
 func TestGetUserProfileIntegration(t *testing.T) {
-	token := getTestToken(t)
-	ctx := context.Background()
+	ctx, token, now := githubtest.Integration(t)
 
-	restClient := newTestClient(t, ctx, token)
-	gqlClient := graphql.NewClient(ctx, token)
+	client := newTestClient(t, ctx, token)
 
 	// Use a 1-month window to minimize API calls
-	to := time.Now()
+	to := now
 	from := to.AddDate(0, -1, 0)
 
-	profile, err := GetUserProfile(ctx, restClient, gqlClient, testUsername, from, to, nil)
+	profile, err := GetUserProfile(ctx, client, testUsername, from, to, nil)
 	if err != nil {
 		t.Fatalf("GetUserProfile failed: %v", err)
 	}
@@ -68,13 +58,11 @@ func TestGetUserProfileIntegration(t *testing.T) {
 }
 
 func TestGetUserProfileWithOptionsIntegration(t *testing.T) {
-	token := getTestToken(t)
-	ctx := context.Background()
+	ctx, token, now := githubtest.Integration(t)
 
-	restClient := newTestClient(t, ctx, token)
-	gqlClient := graphql.NewClient(ctx, token)
+	client := newTestClient(t, ctx, token)
 
-	to := time.Now()
+	to := now
 	from := to.AddDate(0, -1, 0)
 
 	opts := &Options{
@@ -82,7 +70,7 @@ func TestGetUserProfileWithOptionsIntegration(t *testing.T) {
 		IncludeReleases: false, // Skip releases to speed up test
 	}
 
-	profile, err := GetUserProfile(ctx, restClient, gqlClient, testUsername, from, to, opts)
+	profile, err := GetUserProfile(ctx, client, testUsername, from, to, opts)
 	if err != nil {
 		t.Fatalf("GetUserProfile with options failed: %v", err)
 	}
@@ -102,17 +90,15 @@ func TestGetUserProfileWithOptionsIntegration(t *testing.T) {
 }
 
 func TestGetUserProfileCalendarIntegration(t *testing.T) {
-	token := getTestToken(t)
-	ctx := context.Background()
+	ctx, token, now := githubtest.Integration(t)
 
-	restClient := newTestClient(t, ctx, token)
-	gqlClient := graphql.NewClient(ctx, token)
+	client := newTestClient(t, ctx, token)
 
 	// Use a 3-month window to get meaningful calendar data
-	to := time.Now()
+	to := now
 	from := to.AddDate(0, -3, 0)
 
-	profile, err := GetUserProfile(ctx, restClient, gqlClient, testUsername, from, to, nil)
+	profile, err := GetUserProfile(ctx, client, testUsername, from, to, nil)
 	if err != nil {
 		t.Fatalf("GetUserProfile failed: %v", err)
 	}
@@ -137,17 +123,15 @@ func TestGetUserProfileCalendarIntegration(t *testing.T) {
 }
 
 func TestGetUserProfileActivityIntegration(t *testing.T) {
-	token := getTestToken(t)
-	ctx := context.Background()
+	ctx, token, now := githubtest.Integration(t)
 
-	restClient := newTestClient(t, ctx, token)
-	gqlClient := graphql.NewClient(ctx, token)
+	client := newTestClient(t, ctx, token)
 
 	// Use a 3-month window
-	to := time.Now()
+	to := now
 	from := to.AddDate(0, -3, 0)
 
-	profile, err := GetUserProfile(ctx, restClient, gqlClient, testUsername, from, to, nil)
+	profile, err := GetUserProfile(ctx, client, testUsername, from, to, nil)
 	if err != nil {
 		t.Fatalf("GetUserProfile failed: %v", err)
 	}
@@ -182,14 +166,12 @@ func TestGetUserProfileActivityIntegration(t *testing.T) {
 }
 
 func TestGetUserProfileWithReleasesIntegration(t *testing.T) {
-	token := getTestToken(t)
-	ctx := context.Background()
+	ctx, token, now := githubtest.Integration(t)
 
-	restClient := newTestClient(t, ctx, token)
-	gqlClient := graphql.NewClient(ctx, token)
+	client := newTestClient(t, ctx, token)
 
 	// Use a longer window to find repos with releases
-	to := time.Now()
+	to := now
 	from := to.AddDate(-1, 0, 0)
 
 	opts := &Options{
@@ -198,7 +180,7 @@ func TestGetUserProfileWithReleasesIntegration(t *testing.T) {
 		MaxReleaseFetchRepos: 5, // Limit to 5 repos to speed up test
 	}
 
-	profile, err := GetUserProfile(ctx, restClient, gqlClient, testUsername, from, to, opts)
+	profile, err := GetUserProfile(ctx, client, testUsername, from, to, opts)
 	if err != nil {
 		t.Fatalf("GetUserProfile with releases failed: %v", err)
 	}
@@ -218,16 +200,14 @@ func TestGetUserProfileWithReleasesIntegration(t *testing.T) {
 }
 
 func TestGetUserProfileTopReposIntegration(t *testing.T) {
-	token := getTestToken(t)
-	ctx := context.Background()
+	ctx, token, now := githubtest.Integration(t)
 
-	restClient := newTestClient(t, ctx, token)
-	gqlClient := graphql.NewClient(ctx, token)
+	client := newTestClient(t, ctx, token)
 
-	to := time.Now()
+	to := now
 	from := to.AddDate(-1, 0, 0)
 
-	profile, err := GetUserProfile(ctx, restClient, gqlClient, testUsername, from, to, nil)
+	profile, err := GetUserProfile(ctx, client, testUsername, from, to, nil)
 	if err != nil {
 		t.Fatalf("GetUserProfile failed: %v", err)
 	}
@@ -246,16 +226,14 @@ func TestGetUserProfileTopReposIntegration(t *testing.T) {
 }
 
 func TestGetUserProfileSummaryIntegration(t *testing.T) {
-	token := getTestToken(t)
-	ctx := context.Background()
+	ctx, token, now := githubtest.Integration(t)
 
-	restClient := newTestClient(t, ctx, token)
-	gqlClient := graphql.NewClient(ctx, token)
+	client := newTestClient(t, ctx, token)
 
-	to := time.Now()
+	to := now
 	from := to.AddDate(0, -1, 0)
 
-	profile, err := GetUserProfile(ctx, restClient, gqlClient, testUsername, from, to, nil)
+	profile, err := GetUserProfile(ctx, client, testUsername, from, to, nil)
 	if err != nil {
 		t.Fatalf("GetUserProfile failed: %v", err)
 	}
@@ -269,17 +247,15 @@ func TestGetUserProfileSummaryIntegration(t *testing.T) {
 }
 
 func TestGetUserProfileInvalidUserIntegration(t *testing.T) {
-	token := getTestToken(t)
-	ctx := context.Background()
+	ctx, token, now := githubtest.Integration(t)
 
-	restClient := newTestClient(t, ctx, token)
-	gqlClient := graphql.NewClient(ctx, token)
+	client := newTestClient(t, ctx, token)
 
-	to := time.Now()
+	to := now
 	from := to.AddDate(0, -1, 0)
 
 	// Use a username that's very unlikely to exist
-	_, err := GetUserProfile(ctx, restClient, gqlClient, "this-user-definitely-does-not-exist-12345", from, to, nil)
+	_, err := GetUserProfile(ctx, client, "this-user-definitely-does-not-exist-12345", from, to, nil)
 	if err == nil {
 		t.Error("Expected error for non-existent user, got nil")
 	}
@@ -1,9 +1,16 @@
 package profile
 
 import (
+	"context"
+	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
+	"github.com/grokify/gogithub"
+	"github.com/grokify/gogithub/clientv1"
+	"github.com/grokify/gogithub/clientv1/fake"
+	ghErrors "github.com/grokify/gogithub/errors"
 	"github.com/grokify/gogithub/graphql"
 )
 
@@ -216,18 +223,37 @@ func TestBuildCalendarFromContributions(t *testing.T) {
 	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 	to := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
 
-	monthly := []graphql.MonthlyContribution{
-		{Year: 2024, Month: time.January, Count: 50},
-		{Year: 2024, Month: time.February, Count: 30},
-		{Year: 2024, Month: time.March, Count: 40},
-		{Year: 2023, Month: time.December, Count: 100}, // Out of range, should be excluded
+	daily := []graphql.DailyContribution{
+		{Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Count: 50},
+		{Date: time.Date(2024, 2, 14, 0, 0, 0, 0, time.UTC), Count: 30},
+		{Date: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), Count: 40},
+		{Date: time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), Count: 100}, // Out of range, should be excluded
 	}
 
-	cal := buildCalendarFromContributions(monthly, from, to)
+	cal := buildCalendarFromContributions(daily, from, to)
 
 	if cal.TotalContributions != 120 { // 50 + 30 + 40
 		t.Errorf("TotalContributions = %d, want 120", cal.TotalContributions)
 	}
+	// A cell for every day of the range, each on its own date
+	days, lit := 0, 0
+	for _, week := range cal.Weeks {
+		for _, day := range week.Days {
+			if day.Date.IsZero() {
+				continue
+			}
+			days++
+			if day.ContributionCount > 0 {
+				lit++
+			}
+		}
+	}
+	if days != 91 || lit != 3 {
+		t.Errorf("calendar has %d days, %d with contributions; want 91 and 3", days, lit)
+	}
+	if first, last := cal.GetDateRange(); !first.Equal(from) || !last.Equal(to) {
+		t.Errorf("GetDateRange() = %v .. %v, want %v .. %v", first, last, from, to)
+	}
 }
 
 func TestBuildCalendarFromContributionsEmpty(t *testing.T) {
@@ -343,3 +369,69 @@ func TestTopReposByAdditionsDoesNotMutate(t *testing.T) {
 		t.Error("TopReposByAdditions mutated the original RepoStats slice")
 	}
 }
+
+// rateLimitedClient fails ListReleases for one repository with a rate
+// limit error.
+type rateLimitedClient struct {
+	clientv1.Client
+	repo string
+}
+
+func (c rateLimitedClient) ListReleases(ctx context.Context, owner, repo string) ([]*gogithub.Release, error) {
+	if repo == c.repo {
+		return nil, &ghErrors.APIError{StatusCode: http.StatusForbidden, Message: "API rate limit exceeded", Err: ghErrors.ErrRateLimited}
+	}
+	return c.Client.ListReleases(ctx, owner, repo)
+}
+
+func TestFetchReleaseCounts(t *testing.T) {
+	ctx := context.Background()
+	fc := fake.NewClient()
+	now := time.Now().UTC()
+	p := &UserProfile{
+		From:     now.AddDate(0, -1, 0),
+		To:       now.AddDate(0, 0, 1),
+		Activity: &ActivityTimeline{Months: []MonthlyActivity{{Year: now.Year(), Month: now.Month()}}},
+	}
+	for i := range 12 {
+		name := fmt.Sprintf("repo%d", i)
+		p.RepoStats = append(p.RepoStats, RepoContribution{Owner: "octocat", Name: name})
+		if i == 5 {
+			continue // not found, so skipped
+		}
+		fc.AddRepository("octocat", name)
+		for j := range i {
+			if _, err := fc.CreateRelease(ctx, "octocat", name, &clientv1.CreateReleaseInput{TagName: fmt.Sprintf("v0.%d.0", j)}); err != nil {
+				t.Fatalf("CreateRelease() error = %v", err)
+			}
+		}
+	}
+
+	var last ProgressInfo
+	calls := 0
+	opts := &Options{Concurrency: 4, MaxReleaseFetchRepos: 10}
+	err := fetchReleaseCounts(ctx, fc, p, opts, func(info ProgressInfo) { last = info; calls++ }, 5)
+	if err != nil {
+		t.Fatalf("fetchReleaseCounts() error = %v", err)
+	}
+	for i, repo := range p.RepoStats {
+		want := i
+		if i == 5 || i >= 10 {
+			want = 0
+		}
+		if repo.Releases != want {
+			t.Errorf("%s releases = %d, want %d", repo.Name, repo.Releases, want)
+		}
+	}
+	if got := p.Activity.Months[0].Releases; got != 40 {
+		t.Errorf("monthly releases = %d, want 40", got)
+	}
+	if calls != 12 || !last.Done || last.Current != 10 || last.Total != 10 {
+		t.Errorf("progress: %d calls, last %+v; want 12 calls ending at 10/10 done", calls, last)
+	}
+
+	err = fetchReleaseCounts(ctx, rateLimitedClient{Client: fc, repo: "repo2"}, p, opts, func(ProgressInfo) {}, 5)
+	if !ghErrors.IsRateLimited(err) {
+		t.Errorf("fetchReleaseCounts(rate limited) error = %v, want rate limit error", err)
+	}
+}
@@ -0,0 +1,108 @@
+package profile
+
+import (
+	"time"
+
+	"github.com/grokify/gogithub/graphql"
+)
+
+// Punchcard counts commits by day of the week and hour of the day, like
+// GitHub's punch card graph.
+type Punchcard struct {
+	// Timezone is the IANA name of the time zone the commits are counted
+	// in, e.g. "America/New_York".
+	Timezone string
+
+	// Counts holds the number of commits by weekday, indexed by
+	// time.Weekday (Sunday first), and hour, 0 to 23.
+	Counts [7][24]int
+
+	Total int
+}
+
+// BuildPunchcard counts times by their weekday and hour in loc, or UTC if
+// loc is nil.
+func BuildPunchcard(times []time.Time, loc *time.Location) *Punchcard {
+	if loc == nil {
+		loc = time.UTC
+	}
+	p := &Punchcard{Timezone: loc.String()}
+	for _, t := range times {
+		t = t.In(loc)
+		p.Counts[t.Weekday()][t.Hour()]++
+		p.Total++
+	}
+	return p
+}
+
+// ByWeekday returns the number of commits on each weekday, indexed by
+// time.Weekday.
+func (p *Punchcard) ByWeekday() [7]int {
+	var days [7]int
+	for d, hours := range p.Counts {
+		for _, n := range hours {
+			days[d] += n
+		}
+	}
+	return days
+}
+
+// ByHour returns the number of commits in each hour of the day.
+func (p *Punchcard) ByHour() [24]int {
+	var hours [24]int
+	for _, day := range p.Counts {
+		for h, n := range day {
+			hours[h] += n
+		}
+	}
+	return hours
+}
+
+// Peak returns the weekday and hour with the most commits, and their
+// count. Ties go to the earliest in the week.
+func (p *Punchcard) Peak() (time.Weekday, int, int) {
+	var day time.Weekday
+	hour, count := 0, 0
+	for d, hours := range p.Counts {
+		for h, n := range hours {
+			if n > count {
+				day, hour, count = time.Weekday(d), h, n
+			}
+		}
+	}
+	return day, hour, count
+}
+
+// authoredDates returns the author timestamps of the commits in repos.
+func authoredDates(repos []graphql.RepoCommitStats) []time.Time {
+	var times []time.Time
+	for _, repo := range repos {
+		for _, ct := range repo.CommitTimes {
+			times = append(times, ct.AuthoredDate)
+		}
+	}
+	return times
+}
+
+// mergePunchcards sums punchcards in the same time zone. It returns nil
+// if there are none, or if their time zones differ.
+func mergePunchcards(cards []*Punchcard) *Punchcard {
+	var merged *Punchcard
+	for _, c := range cards {
+		if c == nil {
+			continue
+		}
+		if merged == nil {
+			merged = &Punchcard{Timezone: c.Timezone}
+		} else if c.Timezone != merged.Timezone {
+			return nil
+		}
+		for d := range c.Counts {
+			for h, n := range c.Counts[d] {
+				merged.Counts[d][h] += n
+			}
+		}
+		merged.Total += c.Total
+	}
+	return merged
+}
@@ -0,0 +1,79 @@
+package profile
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildPunchcard(t *testing.T) {
+	times := []time.Time{
+		time.Date(2024, 1, 1, 2, 30, 0, 0, time.UTC), // Monday
+		time.Date(2024, 1, 1, 2, 45, 0, 0, time.UTC),
+		time.Date(2024, 1, 6, 23, 0, 0, 0, time.UTC), // Saturday
+	}
+
+	p := BuildPunchcard(times, nil)
+	if p.Timezone != "UTC" || p.Total != 3 || p.Counts[time.Monday][2] != 2 || p.Counts[time.Saturday][23] != 1 {
+		t.Errorf("UTC punchcard = %s, %d commits, Monday 02:00 %d, Saturday 23:00 %d",
+			p.Timezone, p.Total, p.Counts[time.Monday][2], p.Counts[time.Saturday][23])
+	}
+
+	// Five hours behind UTC, the Monday commits were on Sunday evening.
+	p = BuildPunchcard(times, time.FixedZone("EST", -5*60*60))
+	if p.Timezone != "EST" || p.Counts[time.Sunday][21] != 2 || p.Counts[time.Saturday][18] != 1 {
+		t.Errorf("EST punchcard = %s, Sunday 21:00 %d, Saturday 18:00 %d",
+			p.Timezone, p.Counts[time.Sunday][21], p.Counts[time.Saturday][18])
+	}
+	if days := p.ByWeekday(); days[time.Sunday] != 2 || days[time.Saturday] != 1 || days[time.Monday] != 0 {
+		t.Errorf("ByWeekday() = %v", days)
+	}
+	if hours := p.ByHour(); hours[21] != 2 || hours[18] != 1 {
+		t.Errorf("ByHour() = %v", hours)
+	}
+	if day, hour, count := p.Peak(); day != time.Sunday || hour != 21 || count != 2 {
+		t.Errorf("Peak() = %v %d:00, %d commits, want Sunday 21:00, 2 commits", day, hour, count)
+	}
+}
+
+func TestMergePunchcards(t *testing.T) {
+	a := BuildPunchcard([]time.Time{time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)}, nil)
+	b := BuildPunchcard([]time.Time{time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC)}, nil)
+
+	merged := mergePunchcards([]*Punchcard{a, nil, b})
+	if merged == nil || merged.Total != 2 || merged.Counts[time.Monday][9] != 2 {
+		t.Errorf("mergePunchcards() = %+v, want 2 commits on Monday at 09:00", merged)
+	}
+	if a.Counts[time.Monday][9] != 1 {
+		t.Error("mergePunchcards() modified its input")
+	}
+
+	other := BuildPunchcard(nil, time.FixedZone("EST", -5*60*60))
+	if merged := mergePunchcards([]*Punchcard{a, other}); merged != nil {
+		t.Errorf("mergePunchcards(mixed time zones) = %+v, want nil", merged)
+	}
+}
+
+func TestGetUserProfileTimezone(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC)
+	var s cacheServer
+	client := s.client(t)
+
+	// Commits authored at 15:00 UTC were authored at 07:00 in Los Angeles
+	// in winter and 08:00 in summer.
+	p, err := GetUserProfile(ctx, client, "octocat", from, to, &Options{Timezone: "America/Los_Angeles"})
+	if err != nil {
+		t.Fatalf("GetUserProfile() error = %v", err)
+	}
+	hours := p.Punchcard.ByHour()
+	if p.Punchcard.Timezone != "America/Los_Angeles" || hours[7] != 4 || hours[8] != 8 {
+		t.Errorf("punchcard in %s: %d commits at 07:00, %d at 08:00, want 4 and 8", p.Punchcard.Timezone, hours[7], hours[8])
+	}
+
+	if _, err := GetUserProfile(ctx, client, "octocat", from, to, &Options{Timezone: "Mars/Olympus_Mons"}); err == nil || !strings.Contains(err.Error(), "timezone") {
+		t.Errorf("GetUserProfile(unknown timezone) error = %v, want timezone error", err)
+	}
+}
@@ -5,10 +5,12 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"path/filepath"
 	"text/template"
 	"time"
 
 	"github.com/grokify/gogithub/profile"
+	"github.com/grokify/gogithub/profile/svg"
 )
 
 // Config holds static content and display options for README generation.
@@ -33,6 +35,15 @@ type Config struct {
 	ShowHeatmap   bool `json:"show_heatmap"`    // Show contribution heatmap
 	TopReposCount int  `json:"top_repos_count"` // Number of top repos to show (default: 5)
 
+	TopLanguagesCount int `json:"top_languages_count,omitempty"` // Number of languages to show (default: 8)
+
+	// CalendarSVG, if set with ShowHeatmap, is the file name of a
+	// contribution calendar SVG, relative to the README, shown instead of
+	// the ASCII heatmap. GenerateToFile writes it next to the README in
+	// CalendarTheme, an svg theme name.
+	CalendarSVG   string `json:"calendar_svg,omitempty"`
+	CalendarTheme string `json:"calendar_theme,omitempty"`
+
 	// External stats placeholders (to be filled by structured-profile)
 	ExternalStats []ExternalStat `json:"external_stats,omitempty"` // StackOverflow, blog posts, etc.
 }
@@ -89,10 +100,17 @@ func NewGeneratorWithTemplate(tmpl *template.Template) *Generator {
 
 // TemplateData contains all data available to the README template.
 type TemplateData struct {
-	Profile  *profile.UserProfile
-	Config   *Config
-	Heatmap  string // Pre-generated ASCII heatmap
-	TopRepos []profile.RepoContribution
+	Profile *profile.UserProfile
+	Config  *Config
+	Heatmap string // Pre-generated ASCII heatmap
+
+	// CalendarImage is Config.CalendarSVG, if there is calendar data to
+	// draw.
+	CalendarImage string
+	TopRepos      []profile.RepoContribution
+
+	// Languages are the profile's top languages, if ShowLanguages is set.
+	Languages []profile.LanguageShare
 }
 
 // Generate creates README markdown from profile data and config.
@@ -115,6 +133,7 @@ func (g *Generator) Generate(p *profile.UserProfile, cfg *Config) (string, error
 	// Generate heatmap if enabled and calendar data exists
 	if cfg.ShowHeatmap && p.Calendar != nil {
 		data.Heatmap = GenerateHeatmap(p.Calendar)
+		data.CalendarImage = cfg.CalendarSVG
 	}
 
 	// Get top repos if enabled
@@ -122,6 +141,15 @@ func (g *Generator) Generate(p *profile.UserProfile, cfg *Config) (string, error
 		data.TopRepos = p.TopReposByCommits(cfg.TopReposCount)
 	}
 
+	// Get top languages if enabled
+	if cfg.ShowLanguages {
+		n := cfg.TopLanguagesCount
+		if n <= 0 {
+			n = 8
+		}
+		data.Languages = p.TopLanguages(n)
+	}
+
 	var buf bytes.Buffer
 	if err := g.Template.Execute(&buf, data); err != nil {
 		return "", fmt.Errorf("execute template: %w", err)
@@ -130,19 +158,39 @@ func (g *Generator) Generate(p *profile.UserProfile, cfg *Config) (string, error
 	return buf.String(), nil
 }
 
-// GenerateToFile writes README markdown to a file.
+// GenerateToFile writes README markdown to a file and, if the README
+// shows cfg.CalendarSVG, the calendar SVG next to it.
 func (g *Generator) GenerateToFile(p *profile.UserProfile, cfg *Config, path string) error {
 	content, err := g.Generate(p, cfg)
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(path, []byte(content), 0600)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		return err
+	}
+	if fp := CalendarSVGPath(p, cfg, path); fp != "" {
+		calendar := svg.GenerateCalendarSVG(p, cfg.CalendarTheme, "")
+		if err := os.WriteFile(fp, []byte(calendar), 0600); err != nil {
+			return fmt.Errorf("write calendar SVG: %w", err)
+		}
+	}
+	return nil
+}
+
+// CalendarSVGPath returns the path GenerateToFile writes the calendar SVG
+// to for a README at readmePath, or "" if it writes none.
+func CalendarSVGPath(p *profile.UserProfile, cfg *Config, readmePath string) string {
+	if cfg == nil || !cfg.ShowHeatmap || cfg.CalendarSVG == "" || p.Calendar == nil {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(readmePath), cfg.CalendarSVG)
 }
 
 // templateFuncs provides helper functions for templates.
 var templateFuncs = template.FuncMap{
 	"formatNumber":    formatNumber,
 	"formatChange":    formatChange,
+	"formatPercent":   formatPercent,
 	"formatDateRange": formatDateRange,
 	"repoURL":         repoURL,
 	"hasLinks":        hasLinks,
@@ -165,6 +213,11 @@ func formatChange(additions, deletions int) string {
 	return fmt.Sprintf("+%s / -%s", formatNumber(additions), formatNumber(deletions))
 }
 
+// formatPercent formats a percentage with one decimal, like "42.5%".
+func formatPercent(pct float64) string {
+	return fmt.Sprintf("%.1f%%", pct)
+}
+
 // formatDateRange formats a date range for display.
 func formatDateRange(from, to time.Time) string {
 	fromStr := from.Format("Jan 2, 2006")
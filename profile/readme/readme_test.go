@@ -1,6 +1,8 @@
 package readme
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -105,6 +107,85 @@ func TestGenerateWithOrganizations(t *testing.T) {
 	}
 }
 
+func TestGenerateWithLanguages(t *testing.T) {
+	g, err := NewGenerator()
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+
+	p := &profile.UserProfile{
+		Username: "testuser",
+		Languages: []profile.LanguageShare{
+			{Name: "Go", Weight: 75, Percent: 75},
+			{Name: "Shell", Weight: 20, Percent: 20},
+			{Name: "Makefile", Weight: 5, Percent: 5},
+		},
+	}
+
+	readme, err := g.Generate(p, &Config{ShowLanguages: true, TopLanguagesCount: 2})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if !strings.Contains(readme, "## Languages") || !strings.Contains(readme, "| Go | 75.0% |") || !strings.Contains(readme, "| Shell | 20.0% |") {
+		t.Errorf("Generate() output missing languages:\n%s", readme)
+	}
+	if strings.Contains(readme, "Makefile") {
+		t.Error("Generate() output has more than TopLanguagesCount languages")
+	}
+
+	readme, err = g.Generate(p, &Config{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if strings.Contains(readme, "## Languages") {
+		t.Error("Generate() output has languages without ShowLanguages")
+	}
+}
+
+func TestGenerateToFileWithCalendarSVG(t *testing.T) {
+	g, err := NewGenerator()
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+
+	p := &profile.UserProfile{
+		Username: "testuser",
+		Calendar: profile.NewCalendarFromDays([]profile.CalendarDay{
+			{Date: time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC), ContributionCount: 4},
+		}),
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "README.md")
+	cfg := &Config{ShowHeatmap: true, CalendarSVG: "calendar.svg", CalendarTheme: "dark"}
+
+	if err := g.GenerateToFile(p, cfg, path); err != nil {
+		t.Fatalf("GenerateToFile() error = %v", err)
+	}
+	readme, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(readme), "![Contribution calendar](calendar.svg)") || strings.Contains(string(readme), "```") {
+		t.Errorf("README should embed the calendar SVG instead of the heatmap:\n%s", readme)
+	}
+
+	if got := CalendarSVGPath(p, cfg, path); got != filepath.Join(dir, "calendar.svg") {
+		t.Errorf("CalendarSVGPath() = %q", got)
+	}
+	calendar, err := os.ReadFile(filepath.Join(dir, "calendar.svg"))
+	if err != nil {
+		t.Fatalf("calendar SVG not written: %v", err)
+	}
+	if !strings.Contains(string(calendar), "4 contributions on Mon, Jun 3, 2024") {
+		t.Error("calendar SVG missing the day's contributions")
+	}
+
+	// Without CalendarSVG, the README shows the ASCII heatmap.
+	if got := CalendarSVGPath(p, &Config{ShowHeatmap: true}, path); got != "" {
+		t.Errorf("CalendarSVGPath(no CalendarSVG) = %q, want none", got)
+	}
+}
+
 func TestGenerateWithLinks(t *testing.T) {
 	g, err := NewGenerator()
 	if err != nil {
@@ -21,7 +21,12 @@ const DefaultTemplate = `{{- /* Greeting */ -}}
 {{- end }}
 {{ end }}
 {{- /* Contribution Heatmap */ -}}
-{{- if and .Config.ShowHeatmap .Heatmap }}
+{{- if and .Config.ShowHeatmap .CalendarImage }}
+
+## Contribution Activity
+
+![Contribution calendar]({{ .CalendarImage }})
+{{ else if and .Config.ShowHeatmap .Heatmap }}
 
 ## Contribution Activity
 
@@ -58,6 +63,17 @@ _{{ formatDateRange .Profile.From .Profile.To }}_
 | [{{ .FullName }}]({{ repoURL .FullName }}) | {{ formatNumber .Commits }} | {{ formatChange .Additions .Deletions }} |
 {{- end }}
 {{ end }}
+{{- /* Languages */ -}}
+{{- if and .Config.ShowLanguages (gt (len .Languages) 0) }}
+
+## Languages
+
+| Language | Share |
+|----------|-------|
+{{- range .Languages }}
+| {{ .Name }} | {{ formatPercent .Percent }} |
+{{- end }}
+{{ end }}
 {{- /* Organizations */ -}}
 {{- if gt (len .Config.Organizations) 0 }}
 
@@ -0,0 +1,63 @@
+package profile
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/grokify/gocharts/v2/data/table"
+)
+
+// ReposTable returns a table of the profile's repository stats, a row per
+// repository in RepoStats order. The URL column is empty for repositories
+// without a URL, such as those of profiles saved by older versions.
+func (p *UserProfile) ReposTable(name string) *table.Table {
+	tbl := table.NewTable(name)
+	tbl.Columns = []string{
+		"Repository",
+		"URL",
+		"Private",
+		"Commits",
+		"Additions",
+		"Deletions",
+		"Net Additions",
+		"Releases",
+	}
+	tbl.FormatMap = map[int]string{
+		1: table.FormatURL,
+		3: table.FormatInt,
+		4: table.FormatInt,
+		5: table.FormatInt,
+		6: table.FormatInt,
+		7: table.FormatInt,
+	}
+	for _, r := range p.RepoStats {
+		tbl.Rows = append(tbl.Rows, []string{
+			r.FullName,
+			r.URL,
+			strconv.FormatBool(r.IsPrivate),
+			strconv.Itoa(r.Commits),
+			strconv.Itoa(r.Additions),
+			strconv.Itoa(r.Deletions),
+			strconv.Itoa(r.Additions - r.Deletions),
+			strconv.Itoa(r.Releases),
+		})
+	}
+	return &tbl
+}
+
+// WriteReposXLSX writes the profile's repository stats as an Excel
+// workbook.
+func (p *UserProfile) WriteReposXLSX(path string) error {
+	if err := p.ReposTable("Repositories").WriteXLSX(path, "Repositories"); err != nil {
+		return fmt.Errorf("write xlsx: %w", err)
+	}
+	return nil
+}
+
+// WriteReposCSV writes the profile's repository stats as a CSV file.
+func (p *UserProfile) WriteReposCSV(path string) error {
+	if err := p.ReposTable("Repositories").WriteCSV(path); err != nil {
+		return fmt.Errorf("write csv: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grokify/gocharts/v2/data/table"
+)
+
+func TestUserProfileReposTable(t *testing.T) {
+	p := &UserProfile{
+		RepoStats: []RepoContribution{
+			{Owner: "octocat", Name: "hello", FullName: "octocat/hello", URL: "https://github.example.com/octocat/hello", Commits: 12, Additions: 300, Deletions: 100, Releases: 2},
+			{Owner: "octocat", Name: "secret", FullName: "octocat/secret", IsPrivate: true, Commits: 3, Additions: 10, Deletions: 40},
+		},
+	}
+
+	tbl := p.ReposTable("Repos")
+	expected := [][]string{
+		{"octocat/hello", "https://github.example.com/octocat/hello", "false", "12", "300", "100", "200", "2"},
+		{"octocat/secret", "", "true", "3", "10", "40", "-30", "0"}, // no URL
+	}
+	if tbl.Name != "Repos" || len(tbl.Rows) != len(expected) {
+		t.Fatalf("ReposTable() = %s with %d rows, want Repos with %d", tbl.Name, len(tbl.Rows), len(expected))
+	}
+	for i, want := range expected {
+		if got := strings.Join(tbl.Rows[i], ","); got != strings.Join(want, ",") {
+			t.Errorf("row %d = %s, want %s", i, got, strings.Join(want, ","))
+		}
+	}
+
+	dir := t.TempDir()
+	xlsxPath := filepath.Join(dir, "repos.xlsx")
+	if err := p.WriteReposXLSX(xlsxPath); err != nil {
+		t.Fatalf("WriteReposXLSX() error = %v", err)
+	}
+	sheet, err := table.ReadTableXLSXFile(xlsxPath, "Repositories", 1, true)
+	if err != nil {
+		t.Fatalf("ReadTableXLSXFile() error = %v", err)
+	}
+	if len(sheet.Rows) != 2 || sheet.Rows[1][0] != "octocat/secret" {
+		t.Errorf("Repositories sheet rows = %v", sheet.Rows)
+	}
+
+	csvPath := filepath.Join(dir, "repos.csv")
+	if err := p.WriteReposCSV(csvPath); err != nil {
+		t.Fatalf("WriteReposCSV() error = %v", err)
+	}
+	data, err := os.ReadFile(csvPath)
+	if err != nil {
+		t.Fatalf("os.ReadFile() error = %v", err)
+	}
+	if !strings.HasPrefix(string(data), "Repository,URL,Private,Commits,") || !strings.Contains(string(data), "octocat/secret,") {
+		t.Errorf("CSV = %q", data)
+	}
+}
@@ -0,0 +1,213 @@
+package profile
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ComparisonType identifies how the periods of a comparison were chosen.
+type ComparisonType string
+
+const (
+	ComparisonQoQ    ComparisonType = "qoq"    // a quarter against the quarter before it
+	ComparisonYoY    ComparisonType = "yoy"    // a quarter against the same quarter a year earlier
+	ComparisonCustom ComparisonType = "custom" // any period against any other
+)
+
+// PeriodComparison compares the stats of a current period with those of a
+// previous period.
+type PeriodComparison struct {
+	Type     ComparisonType `json:"type"`
+	Current  string         `json:"current"`  // e.g., "Q3 2026" or "2026"
+	Previous string         `json:"previous"` // e.g., "Q2 2026" or "2025"
+	Deltas   StatsDelta     `json:"deltas"`
+}
+
+// StatsDelta holds the change in every AggregateStats field.
+type StatsDelta struct {
+	Commits              Delta `json:"commits"`
+	Issues               Delta `json:"issues"`
+	PRs                  Delta `json:"prs"`
+	Reviews              Delta `json:"reviews"`
+	Releases             Delta `json:"releases"`
+	Additions            Delta `json:"additions"`
+	Deletions            Delta `json:"deletions"`
+	NetAdditions         Delta `json:"netAdditions"`
+	RepoCountContributed Delta `json:"repoCountContributed"`
+	RepoCountCreated     Delta `json:"repoCountCreated"`
+}
+
+// Delta is the change in a single statistic between two periods.
+type Delta struct {
+	Current  int `json:"current"`
+	Previous int `json:"previous"`
+	Change   int `json:"change"` // Current - Previous
+
+	// Percent is Change as a percentage of Previous. It is nil when
+	// Previous is 0, where a percentage is undefined.
+	Percent *float64 `json:"percent,omitempty"`
+}
+
+// NewDelta returns the change from previous to current.
+func NewDelta(current, previous int) Delta {
+	d := Delta{
+		Current:  current,
+		Previous: previous,
+		Change:   current - previous,
+	}
+	if previous != 0 {
+		pct := 100 * float64(d.Change) / float64(abs(previous))
+		d.Percent = &pct
+	}
+	return d
+}
+
+// CompareStats returns the change in each statistic from previous to
+// current.
+func CompareStats(current, previous AggregateStats) StatsDelta {
+	return StatsDelta{
+		Commits:              NewDelta(current.Commits, previous.Commits),
+		Issues:               NewDelta(current.Issues, previous.Issues),
+		PRs:                  NewDelta(current.PRs, previous.PRs),
+		Reviews:              NewDelta(current.Reviews, previous.Reviews),
+		Releases:             NewDelta(current.Releases, previous.Releases),
+		Additions:            NewDelta(current.Additions, previous.Additions),
+		Deletions:            NewDelta(current.Deletions, previous.Deletions),
+		NetAdditions:         NewDelta(current.NetAdditions, previous.NetAdditions),
+		RepoCountContributed: NewDelta(current.RepoCountContributed, previous.RepoCountContributed),
+		RepoCountCreated:     NewDelta(current.RepoCountCreated, previous.RepoCountCreated),
+	}
+}
+
+// namedDelta is a Delta with its metric's display name.
+type namedDelta struct {
+	Name string
+	Delta
+}
+
+// rows returns the deltas in display order, for the renderers.
+func (d StatsDelta) rows() []namedDelta {
+	return []namedDelta{
+		{"Commits", d.Commits},
+		{"Issues", d.Issues},
+		{"Pull Requests", d.PRs},
+		{"Reviews", d.Reviews},
+		{"Releases", d.Releases},
+		{"Additions", d.Additions},
+		{"Deletions", d.Deletions},
+		{"Net Additions", d.NetAdditions},
+		{"Repos Contributed", d.RepoCountContributed},
+		{"Repos Created", d.RepoCountCreated},
+	}
+}
+
+// Title returns a heading for the comparison, e.g.
+// "Q3 2026 vs Q2 2026 (Quarter over Quarter)".
+func (c PeriodComparison) Title() string {
+	title := c.Current + " vs " + c.Previous
+	switch c.Type {
+	case ComparisonQoQ:
+		title += " (Quarter over Quarter)"
+	case ComparisonYoY:
+		title += " (Year over Year)"
+	}
+	return title
+}
+
+// CompareQoQ compares a quarter with the quarter before it.
+func (r *StatsReport) CompareQoQ(year, quarter int) (*PeriodComparison, error) {
+	prevYear, prevQuarter := year, quarter-1
+	if prevQuarter == 0 {
+		prevYear, prevQuarter = year-1, 4
+	}
+	return r.compare(ComparisonQoQ, year, quarter, prevYear, prevQuarter)
+}
+
+// CompareYoY compares a quarter with the same quarter a year earlier.
+func (r *StatsReport) CompareYoY(year, quarter int) (*PeriodComparison, error) {
+	return r.compare(ComparisonYoY, year, quarter, year-1, quarter)
+}
+
+// ComparePeriods compares period current with period previous. A period
+// is a year, e.g. "2026", or a quarter, e.g. "2026-Q3" or "Q3 2026".
+func (r *StatsReport) ComparePeriods(current, previous string) (*PeriodComparison, error) {
+	year, quarter, err := ParsePeriod(current)
+	if err != nil {
+		return nil, err
+	}
+	prevYear, prevQuarter, err := ParsePeriod(previous)
+	if err != nil {
+		return nil, err
+	}
+	return r.compare(ComparisonCustom, year, quarter, prevYear, prevQuarter)
+}
+
+// compare compares two periods of the report, each a year if its quarter
+// is 0.
+func (r *StatsReport) compare(typ ComparisonType, year, quarter, prevYear, prevQuarter int) (*PeriodComparison, error) {
+	current, currentStats, err := r.periodStats(year, quarter)
+	if err != nil {
+		return nil, err
+	}
+	previous, previousStats, err := r.periodStats(prevYear, prevQuarter)
+	if err != nil {
+		return nil, err
+	}
+	return &PeriodComparison{
+		Type:     typ,
+		Current:  current,
+		Previous: previous,
+		Deltas:   CompareStats(currentStats, previousStats),
+	}, nil
+}
+
+// periodStats returns the label and stats of a quarter, or of a year if
+// quarter is 0.
+func (r *StatsReport) periodStats(year, quarter int) (string, AggregateStats, error) {
+	if quarter == 0 {
+		y := r.GetYear(year)
+		if y == nil {
+			return "", AggregateStats{}, fmt.Errorf("year %d not in report", year)
+		}
+		return strconv.Itoa(year), y.Stats, nil
+	}
+	q := r.GetQuarter(year, quarter)
+	if q == nil {
+		return "", AggregateStats{}, fmt.Errorf("Q%d %d not in report", quarter, year)
+	}
+	return q.Label, q.Stats, nil
+}
+
+var (
+	periodYearFirst    = regexp.MustCompile(`^(\d{4})(?:[- ]?Q([1-4]))?$`)
+	periodQuarterFirst = regexp.MustCompile(`^Q([1-4])[- ]?(\d{4})$`)
+)
+
+// ParsePeriod parses a year, e.g. "2026", or a quarter, e.g. "2026-Q3",
+// "2026Q3" or "Q3 2026". The quarter is 0 for a year.
+func ParsePeriod(s string) (year, quarter int, err error) {
+	p := strings.ToUpper(strings.TrimSpace(s))
+	if m := periodYearFirst.FindStringSubmatch(p); m != nil {
+		year, _ = strconv.Atoi(m[1])
+		if m[2] != "" {
+			quarter, _ = strconv.Atoi(m[2])
+		}
+		return year, quarter, nil
+	}
+	if m := periodQuarterFirst.FindStringSubmatch(p); m != nil {
+		quarter, _ = strconv.Atoi(m[1])
+		year, _ = strconv.Atoi(m[2])
+		return year, quarter, nil
+	}
+	return 0, 0, fmt.Errorf("invalid period %q: want a year (2026) or quarter (2026-Q3)", s)
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,182 @@
+package profile
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewDelta(t *testing.T) {
+	tests := []struct {
+		current, previous int
+		change            int
+		percent           float64
+		undefined         bool
+	}{
+		{150, 100, 50, 50, false},
+		{75, 100, -25, -25, false},
+		{100, 100, 0, 0, false},
+		{10, 0, 10, 0, true},
+		{-50, -100, 50, 50, false}, // net additions less negative is up
+	}
+
+	for _, tt := range tests {
+		d := NewDelta(tt.current, tt.previous)
+		if d.Current != tt.current || d.Previous != tt.previous || d.Change != tt.change {
+			t.Errorf("NewDelta(%d, %d) = %+v, want change %d", tt.current, tt.previous, d, tt.change)
+		}
+		if tt.undefined {
+			if d.Percent != nil {
+				t.Errorf("NewDelta(%d, %d).Percent = %v, want nil", tt.current, tt.previous, *d.Percent)
+			}
+		} else if d.Percent == nil || *d.Percent != tt.percent {
+			t.Errorf("NewDelta(%d, %d).Percent = %v, want %v", tt.current, tt.previous, d.Percent, tt.percent)
+		}
+	}
+}
+
+func TestCompareStats(t *testing.T) {
+	current := AggregateStats{Commits: 120, Issues: 4, PRs: 10, Reviews: 6, Releases: 2, Additions: 900, Deletions: 300, NetAdditions: 600, RepoCountContributed: 5, RepoCountCreated: 1}
+	previous := AggregateStats{Commits: 100, Issues: 8, PRs: 10, Reviews: 3, Releases: 0, Additions: 1000, Deletions: 200, NetAdditions: 800, RepoCountContributed: 4, RepoCountCreated: 2}
+
+	d := CompareStats(current, previous)
+	changes := map[string]int{}
+	for _, row := range d.rows() {
+		changes[row.Name] = row.Change
+	}
+	expected := map[string]int{
+		"Commits":           20,
+		"Issues":            -4,
+		"Pull Requests":     0,
+		"Reviews":           3,
+		"Releases":          2,
+		"Additions":         -100,
+		"Deletions":         100,
+		"Net Additions":     -200,
+		"Repos Contributed": 1,
+		"Repos Created":     -1,
+	}
+	if len(changes) != len(expected) {
+		t.Errorf("CompareStats() has %d metrics, want %d", len(changes), len(expected))
+	}
+	for name, want := range expected {
+		if got, ok := changes[name]; !ok || got != want {
+			t.Errorf("CompareStats() %s change = %d, want %d", name, got, want)
+		}
+	}
+	if d.Commits.Percent == nil || *d.Commits.Percent != 20 {
+		t.Errorf("CompareStats() Commits percent = %v, want 20", d.Commits.Percent)
+	}
+}
+
+func TestParsePeriod(t *testing.T) {
+	tests := []struct {
+		s       string
+		year    int
+		quarter int
+		wantErr bool
+	}{
+		{"2026", 2026, 0, false},
+		{"2026-Q3", 2026, 3, false},
+		{"2026q3", 2026, 3, false},
+		{"Q3 2026", 2026, 3, false},
+		{" q1-2025 ", 2025, 1, false},
+		{"2026-Q5", 0, 0, true},
+		{"26-Q1", 0, 0, true},
+		{"", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		year, quarter, err := ParsePeriod(tt.s)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParsePeriod(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+			continue
+		}
+		if year != tt.year || quarter != tt.quarter {
+			t.Errorf("ParsePeriod(%q) = %d, %d, want %d, %d", tt.s, year, quarter, tt.year, tt.quarter)
+		}
+	}
+}
+
+func TestStatsReportCompare(t *testing.T) {
+	report := &StatsReport{
+		Years: []YearStats{
+			{
+				Year:  2025,
+				Stats: AggregateStats{Commits: 100},
+				Quarters: []QuarterStats{
+					{Quarter: 3, Year: 2025, Label: "Q3 2025", Stats: AggregateStats{Commits: 40}},
+					{Quarter: 4, Year: 2025, Label: "Q4 2025", Stats: AggregateStats{Commits: 60}},
+				},
+			},
+			{
+				Year:  2026,
+				Stats: AggregateStats{Commits: 150},
+				Quarters: []QuarterStats{
+					{Quarter: 1, Year: 2026, Label: "Q1 2026", Stats: AggregateStats{Commits: 90}},
+					{Quarter: 2, Year: 2026, Label: "Q2 2026", Stats: AggregateStats{Commits: 0}},
+					{Quarter: 3, Year: 2026, Label: "Q3 2026", Stats: AggregateStats{Commits: 60}},
+				},
+			},
+		},
+	}
+
+	// QoQ across a year boundary
+	c, err := report.CompareQoQ(2026, 1)
+	if err != nil {
+		t.Fatalf("CompareQoQ(2026, 1) error = %v", err)
+	}
+	if c.Type != ComparisonQoQ || c.Current != "Q1 2026" || c.Previous != "Q4 2025" || c.Deltas.Commits.Change != 30 {
+		t.Errorf("CompareQoQ(2026, 1) = %+v", c)
+	}
+	if c.Title() != "Q1 2026 vs Q4 2025 (Quarter over Quarter)" {
+		t.Errorf("Title() = %q", c.Title())
+	}
+
+	// QoQ from zero has no percentage
+	c, err = report.CompareQoQ(2026, 3)
+	if err != nil {
+		t.Fatalf("CompareQoQ(2026, 3) error = %v", err)
+	}
+	if c.Deltas.Commits.Change != 60 || c.Deltas.Commits.Percent != nil {
+		t.Errorf("CompareQoQ(2026, 3) commits = %+v, want +60 with no percent", c.Deltas.Commits)
+	}
+
+	// YoY
+	c, err = report.CompareYoY(2026, 3)
+	if err != nil {
+		t.Fatalf("CompareYoY(2026, 3) error = %v", err)
+	}
+	if c.Type != ComparisonYoY || c.Previous != "Q3 2025" || c.Deltas.Commits.Change != 20 || *c.Deltas.Commits.Percent != 50 {
+		t.Errorf("CompareYoY(2026, 3) = %+v", c)
+	}
+
+	// Custom periods, years and quarters alike
+	c, err = report.ComparePeriods("2026", "2025")
+	if err != nil {
+		t.Fatalf("ComparePeriods(2026, 2025) error = %v", err)
+	}
+	if c.Type != ComparisonCustom || c.Current != "2026" || c.Previous != "2025" || c.Deltas.Commits.Change != 50 {
+		t.Errorf("ComparePeriods(2026, 2025) = %+v", c)
+	}
+	if c.Title() != "2026 vs 2025" {
+		t.Errorf("Title() = %q", c.Title())
+	}
+	c, err = report.ComparePeriods("Q3 2026", "2025-Q4")
+	if err != nil {
+		t.Fatalf("ComparePeriods(Q3 2026, 2025-Q4) error = %v", err)
+	}
+	if c.Deltas.Commits.Change != 0 {
+		t.Errorf("ComparePeriods(Q3 2026, 2025-Q4) commits change = %d, want 0", c.Deltas.Commits.Change)
+	}
+
+	// Periods missing from the report
+	if _, err := report.CompareYoY(2025, 3); err == nil || !strings.Contains(err.Error(), "Q3 2024") {
+		t.Errorf("CompareYoY(2025, 3) error = %v, want Q3 2024 not in report", err)
+	}
+	if _, err := report.ComparePeriods("2026", "2024"); err == nil {
+		t.Error("ComparePeriods(2026, 2024) should fail")
+	}
+	if _, err := report.ComparePeriods("2026", "last year"); err == nil {
+		t.Error("ComparePeriods(2026, last year) should fail")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"text/template"
 	"time"
+	"unicode/utf8"
 )
 
 // RenderFormat specifies the output format for rendering.
@@ -51,6 +52,11 @@ func RenderToMarkdown(report *StatsReport, opts RenderOptions) (string, error) {
 	// Visibility info
 	sb.WriteString(fmt.Sprintf("%s repository contribution statistics.\n\n", capitalize(report.Metadata.Visibility)))
 
+	// Period comparisons
+	for _, c := range report.Comparisons {
+		renderComparisonMarkdown(&sb, c)
+	}
+
 	// Render each year (most recent first for readability)
 	for i := len(report.Years) - 1; i >= 0; i-- {
 		year := report.Years[i]
@@ -65,6 +71,22 @@ func RenderToMarkdown(report *StatsReport, opts RenderOptions) (string, error) {
 	return sb.String(), nil
 }
 
+// renderComparisonMarkdown renders a period comparison table to the string builder.
+func renderComparisonMarkdown(sb *strings.Builder, c PeriodComparison) {
+	sb.WriteString(fmt.Sprintf("## %s\n\n", c.Title()))
+	sb.WriteString(fmt.Sprintf("| Metric | %s | %s | Change | %% Change |\n", c.Current, c.Previous))
+	sb.WriteString("|--------|------:|------:|-------:|---------:|\n")
+	for _, d := range c.Deltas.rows() {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
+			d.Name,
+			formatNumber(d.Current),
+			formatNumber(d.Previous),
+			formatChange(d.Change),
+			formatPercent(d.Percent)))
+	}
+	sb.WriteString("\n")
+}
+
 // renderYearMarkdown renders a single year's stats to the string builder.
 func renderYearMarkdown(sb *strings.Builder, year YearStats, opts RenderOptions) {
 	// Render quarters (most recent first)
@@ -241,11 +263,22 @@ func RenderToHTML(report *StatsReport, opts RenderOptions) (string, error) {
         .footer { margin-top: 40px; padding-top: 20px; border-top: 1px solid #ddd; color: #666; font-size: 0.9em; }
         code { background-color: #f4f4f4; padding: 2px 6px; border-radius: 3px; }
         pre { background-color: #f4f4f4; padding: 15px; border-radius: 5px; overflow-x: auto; }
+        .up { color: #1a7f37; }
+        .down { color: #cf222e; }
     </style>
 </head>
 <body>
     <h1>{{.Title}}</h1>
     <p>{{.Visibility | capitalize}} repository contribution statistics.</p>
+    {{range .Comparisons}}
+    <h2>{{.Title}}</h2>
+    <table>
+        <tr><th>Metric</th><th>{{.Current}}</th><th>{{.Previous}}</th><th>Change</th><th>% Change</th></tr>
+        {{range .Rows}}
+        <tr><td>{{.Name}}</td><td>{{.Current | number}}</td><td>{{.Previous | number}}</td><td class="{{.Change | trend}}">{{.Change | change}}</td><td class="{{.Change | trend}}">{{.Percent | percent}}</td></tr>
+        {{end}}
+    </table>
+    {{end}}
     {{range .Years}}
     {{range .Quarters}}
     <h2>{{.Label}} Summary</h2>
@@ -297,6 +330,9 @@ func RenderToHTML(report *StatsReport, opts RenderOptions) (string, error) {
 		"number":     formatNumber,
 		"signed":     formatSignedNumber,
 		"capitalize": capitalize,
+		"change":     formatChange,
+		"percent":    formatPercent,
+		"trend":      trendClass,
 	}
 
 	tmpl, err := template.New("html").Funcs(funcMap).Parse(htmlTemplate)
@@ -304,15 +340,33 @@ func RenderToHTML(report *StatsReport, opts RenderOptions) (string, error) {
 		return "", fmt.Errorf("parse template: %w", err)
 	}
 
+	type comparison struct {
+		Title    string
+		Current  string
+		Previous string
+		Rows     []namedDelta
+	}
+	var comparisons []comparison
+	for _, c := range report.Comparisons {
+		comparisons = append(comparisons, comparison{
+			Title:    c.Title(),
+			Current:  c.Current,
+			Previous: c.Previous,
+			Rows:     c.Deltas.rows(),
+		})
+	}
+
 	data := struct {
 		Title       string
 		Visibility  string
+		Comparisons []comparison
 		Years       []YearStats
 		GeneratedAt string
 		DataRange   DateRange
 	}{
 		Title:       opts.Title,
 		Visibility:  report.Metadata.Visibility,
+		Comparisons: comparisons,
 		Years:       report.Years,
 		GeneratedAt: report.Metadata.GeneratedAt.Format(time.RFC3339),
 		DataRange:   report.Metadata.DataRange,
@@ -339,6 +393,22 @@ func RenderToText(report *StatsReport, opts RenderOptions) (string, error) {
 	sb.WriteString("\n\n")
 	sb.WriteString(fmt.Sprintf("%s repository contribution statistics.\n\n", capitalize(report.Metadata.Visibility)))
 
+	for _, c := range report.Comparisons {
+		title := c.Title()
+		sb.WriteString(fmt.Sprintf("%s\n", title))
+		sb.WriteString(strings.Repeat("-", utf8.RuneCountInString(title)))
+		sb.WriteString("\n\n")
+		for _, d := range c.Deltas.rows() {
+			sb.WriteString(fmt.Sprintf("  %-19s %10s vs %-10s %10s  %s\n",
+				d.Name+":",
+				formatNumber(d.Current),
+				formatNumber(d.Previous),
+				formatChange(d.Change),
+				formatPercent(d.Percent)))
+		}
+		sb.WriteString("\n")
+	}
+
 	for i := len(report.Years) - 1; i >= 0; i-- {
 		year := report.Years[i]
 		for j := len(year.Quarters) - 1; j >= 0; j-- {
@@ -400,6 +470,38 @@ func formatSignedNumber(n int) string {
 	return formatNumber(n)
 }
 
+// formatChange formats a change with an up or down indicator and thousand
+// separators, e.g. "▲ +1,234" or "▼ -56".
+func formatChange(n int) string {
+	switch {
+	case n > 0:
+		return "▲ " + formatSignedNumber(n)
+	case n < 0:
+		return "▼ " + formatSignedNumber(n)
+	}
+	return "0"
+}
+
+// formatPercent formats a percentage change, e.g. "+12.5%", or "n/a" when
+// it is undefined.
+func formatPercent(p *float64) string {
+	if p == nil {
+		return "n/a"
+	}
+	return fmt.Sprintf("%+.1f%%", *p)
+}
+
+// trendClass returns the HTML class for the direction of a change.
+func trendClass(n int) string {
+	switch {
+	case n > 0:
+		return "up"
+	case n < 0:
+		return "down"
+	}
+	return ""
+}
+
 // capitalize returns the string with the first letter capitalized.
 func capitalize(s string) string {
 	if s == "" {
@@ -204,6 +204,73 @@ func TestRenderToText(t *testing.T) {
 	}
 }
 
+func TestRenderComparisons(t *testing.T) {
+	report := createTestReport()
+	report.Comparisons = []PeriodComparison{{
+		Type:     ComparisonYoY,
+		Current:  "Q1 2026",
+		Previous: "Q1 2025",
+		Deltas: CompareStats(
+			AggregateStats{Commits: 450, Releases: 45, Deletions: 2200, RepoCountCreated: 2},
+			AggregateStats{Commits: 300, Releases: 50, Deletions: 2200},
+		),
+	}}
+
+	md, err := RenderToMarkdown(report, RenderOptions{})
+	if err != nil {
+		t.Fatalf("RenderToMarkdown() error = %v", err)
+	}
+	html, err := RenderToHTML(report, RenderOptions{})
+	if err != nil {
+		t.Fatalf("RenderToHTML() error = %v", err)
+	}
+	text, err := RenderToText(report, RenderOptions{})
+	if err != nil {
+		t.Fatalf("RenderToText() error = %v", err)
+	}
+
+	tests := []struct {
+		format   string
+		output   string
+		expected []string
+	}{
+		{"markdown", md, []string{
+			"## Q1 2026 vs Q1 2025 (Year over Year)",
+			"| Metric | Q1 2026 | Q1 2025 | Change | % Change |",
+			"| Commits | 450 | 300 | ▲ +150 | +50.0% |",
+			"| Releases | 45 | 50 | ▼ -5 | -10.0% |",
+			"| Deletions | 2,200 | 2,200 | 0 | +0.0% |",
+			"| Repos Created | 2 | 0 | ▲ +2 | n/a |",
+		}},
+		{"html", html, []string{
+			"<h2>Q1 2026 vs Q1 2025 (Year over Year)</h2>",
+			"<th>Q1 2026</th><th>Q1 2025</th>",
+			`<td>Commits</td><td>450</td><td>300</td><td class="up">▲ +150</td><td class="up">+50.0%</td>`,
+			`<td class="down">▼ -5</td>`,
+		}},
+		{"text", text, []string{
+			"Q1 2026 vs Q1 2025 (Year over Year)\n-----------------------------------\n",
+			"Commits:",
+			"450 vs 300",
+			"▲ +150  +50.0%",
+			"▼ -5  -10.0%",
+		}},
+	}
+
+	for _, tt := range tests {
+		for _, expected := range tt.expected {
+			if !strings.Contains(tt.output, expected) {
+				t.Errorf("%s comparison missing expected string: %q", tt.format, expected)
+			}
+		}
+	}
+
+	// The comparison comes before the quarterly summaries
+	if strings.Index(md, "Year over Year") > strings.Index(md, "Q1 2026 Summary") {
+		t.Error("RenderToMarkdown() should render comparisons before the summaries")
+	}
+}
+
 func TestRenderToFile(t *testing.T) {
 	tmpDir := t.TempDir()
 	report := createTestReport()
@@ -15,6 +15,10 @@ import (
 type StatsReport struct {
 	Metadata ReportMetadata `json:"metadata"`
 	Years    []YearStats    `json:"years"`
+
+	// Comparisons are period-over-period comparisons to show with the
+	// report, e.g. from CompareQoQ or CompareYoY.
+	Comparisons []PeriodComparison `json:"comparisons,omitempty"`
 }
 
 // ReportMetadata contains information about the report generation.
@@ -0,0 +1,124 @@
+package profile
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/grokify/gocharts/v2/data/table"
+)
+
+// Period levels of the rows of stats report tables.
+const (
+	PeriodLevelYear    = "Year"
+	PeriodLevelQuarter = "Quarter"
+	PeriodLevelMonth   = "Month"
+)
+
+// statsTableColumns are the columns of stats report tables, followed by
+// the AggregateStats columns.
+var statsTableColumns = []string{"Period", "Level", "Year"}
+
+// aggregateStatsColumns are the columns of an AggregateStats, in the order
+// of aggregateStatsRow.
+var aggregateStatsColumns = []string{
+	"Commits",
+	"Issues",
+	"Pull Requests",
+	"Reviews",
+	"Releases",
+	"Additions",
+	"Deletions",
+	"Net Additions",
+	"Repos Contributed",
+	"Repos Created",
+}
+
+// aggregateStatsRow returns the cells of an AggregateStats.
+func aggregateStatsRow(s AggregateStats) []string {
+	return []string{
+		strconv.Itoa(s.Commits),
+		strconv.Itoa(s.Issues),
+		strconv.Itoa(s.PRs),
+		strconv.Itoa(s.Reviews),
+		strconv.Itoa(s.Releases),
+		strconv.Itoa(s.Additions),
+		strconv.Itoa(s.Deletions),
+		strconv.Itoa(s.NetAdditions),
+		strconv.Itoa(s.RepoCountContributed),
+		strconv.Itoa(s.RepoCountCreated),
+	}
+}
+
+// newStatsTable returns an empty stats report table.
+func newStatsTable(name string) *table.Table {
+	tbl := table.NewTable(name)
+	tbl.Columns = append(append([]string{}, statsTableColumns...), aggregateStatsColumns...)
+	tbl.FormatMap[2] = table.FormatInt
+	for i := range aggregateStatsColumns {
+		tbl.FormatMap[len(statsTableColumns)+i] = table.FormatInt
+	}
+	return &tbl
+}
+
+// addYearRows adds a row for each quarter of a year, followed by rows for
+// its months, and a row for the year's total.
+func addYearRows(tbl *table.Table, y YearStats) {
+	year := strconv.Itoa(y.Year)
+	for _, q := range y.Quarters {
+		tbl.Rows = append(tbl.Rows, append([]string{q.Label, PeriodLevelQuarter, year}, aggregateStatsRow(q.Stats)...))
+		for _, m := range q.Months {
+			period := fmt.Sprintf("%s %d", m.MonthName, m.Year)
+			tbl.Rows = append(tbl.Rows, append([]string{period, PeriodLevelMonth, year}, aggregateStatsRow(m.Stats)...))
+		}
+	}
+	tbl.Rows = append(tbl.Rows, append([]string{year, PeriodLevelYear, year}, aggregateStatsRow(y.Stats)...))
+}
+
+// TableSet returns the report as a table set with a table per year, named
+// for the year, so that it is written as a workbook with a sheet per year.
+// Each table has a row per quarter, followed by rows for its months, and a
+// row for the year's total.
+func (r *StatsReport) TableSet() (*table.TableSet, error) {
+	ts := table.NewTableSet(fmt.Sprintf("GitHub Statistics - %s", r.Metadata.Username))
+	for _, y := range r.Years {
+		tbl := newStatsTable(strconv.Itoa(y.Year))
+		addYearRows(tbl, y)
+		if err := ts.Add(tbl); err != nil {
+			return nil, fmt.Errorf("add %d table: %w", y.Year, err)
+		}
+	}
+	return ts, nil
+}
+
+// Table returns the report as a single table with the rows of every year,
+// as in TableSet, for formats without sheets such as CSV.
+func (r *StatsReport) Table(name string) *table.Table {
+	tbl := newStatsTable(name)
+	for _, y := range r.Years {
+		addYearRows(tbl, y)
+	}
+	return tbl
+}
+
+// WriteXLSX writes the report as an Excel workbook with a sheet per year.
+func (r *StatsReport) WriteXLSX(path string) error {
+	if len(r.Years) == 0 {
+		return fmt.Errorf("write xlsx: report has no years")
+	}
+	ts, err := r.TableSet()
+	if err != nil {
+		return err
+	}
+	if err := ts.WriteXLSX(path); err != nil {
+		return fmt.Errorf("write xlsx: %w", err)
+	}
+	return nil
+}
+
+// WriteCSV writes the report as a CSV file of the rows of every year.
+func (r *StatsReport) WriteCSV(path string) error {
+	if err := r.Table("Statistics").WriteCSV(path); err != nil {
+		return fmt.Errorf("write csv: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package profile
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grokify/gocharts/v2/data/table"
+)
+
+func TestStatsReportTableSet(t *testing.T) {
+	report := createTestReport()
+	report.Years = append([]YearStats{{
+		Year:  2025,
+		Stats: AggregateStats{Commits: 80},
+		Quarters: []QuarterStats{{
+			Quarter: 4, Year: 2025, Label: "Q4 2025",
+			Stats:  AggregateStats{Commits: 80},
+			Months: []MonthStats{{Year: 2025, Month: 12, MonthName: "December", Stats: AggregateStats{Commits: 80}}},
+		}},
+	}}, report.Years...)
+
+	ts, err := report.TableSet()
+	if err != nil {
+		t.Fatalf("TableSet() error = %v", err)
+	}
+	if len(ts.Order) != 2 || ts.Order[0] != "2025" || ts.Order[1] != "2026" {
+		t.Fatalf("TableSet() tables = %v, want [2025 2026]", ts.Order)
+	}
+
+	// A quarter row, its three months, then the year total
+	tbl := ts.TableMap["2026"]
+	expected := [][]string{
+		{"Q1 2026", PeriodLevelQuarter, "2026", "450"},
+		{"January 2026", PeriodLevelMonth, "2026", "100"},
+		{"February 2026", PeriodLevelMonth, "2026", "150"},
+		{"March 2026", PeriodLevelMonth, "2026", "200"},
+		{"2026", PeriodLevelYear, "2026", "450"},
+	}
+	if len(tbl.Rows) != len(expected) {
+		t.Fatalf("2026 table has %d rows, want %d", len(tbl.Rows), len(expected))
+	}
+	for i, want := range expected {
+		if got := tbl.Rows[i][:4]; got[0] != want[0] || got[1] != want[1] || got[2] != want[2] || got[3] != want[3] {
+			t.Errorf("2026 row %d = %v, want %v", i, got, want)
+		}
+		if len(tbl.Rows[i]) != len(tbl.Columns) {
+			t.Errorf("2026 row %d has %d cells, want %d", i, len(tbl.Rows[i]), len(tbl.Columns))
+		}
+	}
+
+	if all := report.Table("All"); len(all.Rows) != 3+len(expected) {
+		t.Errorf("Table() has %d rows, want %d", len(all.Rows), 3+len(expected))
+	}
+}
+
+func TestStatsReportWriteXLSXAndCSV(t *testing.T) {
+	report := createTestReport()
+	dir := t.TempDir()
+
+	xlsxPath := filepath.Join(dir, "report.xlsx")
+	if err := report.WriteXLSX(xlsxPath); err != nil {
+		t.Fatalf("WriteXLSX() error = %v", err)
+	}
+	tbl, err := table.ReadTableXLSXFile(xlsxPath, "2026", 1, true)
+	if err != nil {
+		t.Fatalf("ReadTableXLSXFile() error = %v", err)
+	}
+	if len(tbl.Rows) != 5 || tbl.Rows[0][0] != "Q1 2026" || tbl.Rows[0][3] != "450" {
+		t.Errorf("2026 sheet rows = %v", tbl.Rows)
+	}
+
+	csvPath := filepath.Join(dir, "report.csv")
+	if err := report.WriteCSV(csvPath); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	f, err := os.Open(csvPath)
+	if err != nil {
+		t.Fatalf("os.Open() error = %v", err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("csv.ReadAll() error = %v", err)
+	}
+	if len(records) != 6 || records[0][0] != "Period" || records[5][0] != "2026" || records[5][4] != "0" {
+		t.Errorf("CSV records = %v", records)
+	}
+
+	if err := (&StatsReport{}).WriteXLSX(filepath.Join(dir, "empty.xlsx")); err == nil {
+		t.Error("WriteXLSX() should fail for a report without years")
+	}
+}
@@ -0,0 +1,242 @@
+package svg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grokify/gogithub/profile"
+)
+
+// Calendar layout constants, matching the web viewer's calendar.
+const (
+	// CalendarWeeks is the number of weeks, columns, a calendar shows.
+	CalendarWeeks = 53
+
+	// CalendarCellSize is the size of a day's cell.
+	CalendarCellSize = 11
+
+	// CalendarCellGap is the gap between cells.
+	CalendarCellGap = 3
+
+	// CalendarDayLabelWidth is the width of the weekday labels.
+	CalendarDayLabelWidth = 28
+
+	// CalendarMonthLabelHeight is the height of the month labels.
+	CalendarMonthLabelHeight = 15
+)
+
+// Contribution level colors, LevelNone first, as on GitHub.
+var (
+	CalendarColorsLight = [5]string{"#ebedf0", "#9be9a8", "#40c463", "#30a14e", "#216e39"}
+	CalendarColorsDark  = [5]string{"#161b22", "#0e4429", "#006d32", "#26a641", "#39d353"}
+)
+
+// CalendarCard renders a contribution calendar as a GitHub-style grid of
+// days, one column per week, colored by contribution level.
+type CalendarCard struct {
+	*Card
+	calendar *profile.ContributionCalendar
+
+	// Colors are the colors of the contribution levels, LevelNone first.
+	// NewCalendarCard picks CalendarColorsLight or CalendarColorsDark to
+	// suit the theme's background.
+	Colors [5]string
+}
+
+// NewCalendarCard creates a calendar card showing the CalendarWeeks weeks
+// up to the last day of calendar.
+func NewCalendarCard(calendar *profile.ContributionCalendar, theme Theme, title string) *CalendarCard {
+	if title == "" {
+		title = "Contributions"
+	}
+
+	card := NewCard(title, theme)
+	card.Width = 2*DefaultPaddingX + CalendarDayLabelWidth + CalendarWeeks*(CalendarCellSize+CalendarCellGap) - CalendarCellGap
+	card.SetHeight(calendarGridTop + 7*(CalendarCellSize+CalendarCellGap) + 35)
+
+	colors := CalendarColorsLight
+	if isDark(theme.BgColor) {
+		colors = CalendarColorsDark
+	}
+
+	return &CalendarCard{
+		Card:     card,
+		calendar: calendar,
+		Colors:   colors,
+	}
+}
+
+// calendarGridTop is the y of the first row of cells, below the title
+// and month labels.
+const calendarGridTop = 45 + CalendarMonthLabelHeight
+
+// Render generates the complete SVG string.
+func (cc *CalendarCard) Render() string {
+	var sb strings.Builder
+
+	// XML declaration
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8"?>`)
+	sb.WriteString("\n")
+
+	// SVG header
+	sb.WriteString(cc.RenderHeader())
+	sb.WriteString("\n")
+
+	// Title
+	sb.WriteString(cc.RenderTitle())
+	sb.WriteString("\n")
+
+	// Styles
+	fmt.Fprintf(&sb, `  <style>
+    .header { font: 600 18px 'Segoe UI', Ubuntu, 'Helvetica Neue', sans-serif; fill: %s; }
+    .label { font: 400 9px 'Segoe UI', Ubuntu, 'Helvetica Neue', sans-serif; fill: %s; }
+  </style>`,
+		cc.Theme.TitleColor,
+		cc.Theme.TextColor,
+	)
+	sb.WriteString("\n")
+
+	// Background
+	sb.WriteString(cc.RenderBackground())
+	sb.WriteString("\n")
+
+	// Title text
+	sb.WriteString(cc.RenderTitleText())
+	sb.WriteString("\n")
+
+	var counts map[string]int
+	var last time.Time
+	if cc.calendar != nil {
+		counts = make(map[string]int)
+		for _, week := range cc.calendar.Weeks {
+			for _, day := range week.Days {
+				if !day.Date.IsZero() {
+					counts[day.Date.Format("2006-01-02")] = day.ContributionCount
+				}
+			}
+		}
+		_, last = cc.calendar.GetDateRange()
+	}
+
+	if last.IsZero() {
+		fmt.Fprintf(&sb, `  <text class="label" x="%g" y="%d" text-anchor="middle">No data available</text>`,
+			cc.Width/2, calendarGridTop+3*(CalendarCellSize+CalendarCellGap))
+		sb.WriteString("\n")
+		sb.WriteString(cc.RenderFooter())
+		sb.WriteString("\n")
+		return sb.String()
+	}
+
+	gridX := cc.PaddingX + CalendarDayLabelWidth
+	step := float64(CalendarCellSize + CalendarCellGap)
+	last = time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, time.UTC)
+	start := last.AddDate(0, 0, -int(last.Weekday())-7*(CalendarWeeks-1)) // Sunday of the first week
+
+	// Weekday labels
+	for _, d := range []time.Weekday{time.Monday, time.Wednesday, time.Friday} {
+		fmt.Fprintf(&sb, `  <text class="label" x="%g" y="%g">%s</text>`,
+			cc.PaddingX, calendarGridTop+float64(d)*step+CalendarCellSize-2, d.String()[:3])
+		sb.WriteString("\n")
+	}
+
+	// Month labels, above the first week starting in each month. A label
+	// too close to the next is dropped, as on GitHub.
+	for w := range CalendarWeeks {
+		sunday := start.AddDate(0, 0, 7*w)
+		if w > 0 && sunday.Month() == sunday.AddDate(0, 0, -7).Month() {
+			continue
+		}
+		if w == 0 && sunday.AddDate(0, 0, 14).Month() != sunday.Month() {
+			continue
+		}
+		fmt.Fprintf(&sb, `  <text class="label" x="%g" y="%d">%s</text>`,
+			gridX+float64(w)*step, calendarGridTop-5, sunday.Format("Jan"))
+		sb.WriteString("\n")
+	}
+
+	// Day cells
+	total := 0
+	for w := range CalendarWeeks {
+		for d := range 7 {
+			date := start.AddDate(0, 0, 7*w+d)
+			if date.After(last) {
+				break
+			}
+			count := counts[date.Format("2006-01-02")]
+			total += count
+			fmt.Fprintf(&sb, `  <rect x="%g" y="%g" width="%d" height="%d" rx="2" fill="%s"><title>%s</title></rect>`,
+				gridX+float64(w)*step, calendarGridTop+float64(d)*step, CalendarCellSize, CalendarCellSize,
+				cc.Colors[profile.CalculateLevel(count)], dayTooltip(date, count))
+			sb.WriteString("\n")
+		}
+	}
+
+	// Total and legend
+	legendY := calendarGridTop + 7*step + 10
+	fmt.Fprintf(&sb, `  <text class="label" x="%g" y="%g">%s</text>`,
+		gridX, legendY+CalendarCellSize-2, pluralContributions(total))
+	sb.WriteString("\n")
+
+	legendX := cc.Width - cc.PaddingX - 5*step - 25
+	fmt.Fprintf(&sb, `  <text class="label" x="%g" y="%g" text-anchor="end">Less</text>`,
+		legendX-4, legendY+CalendarCellSize-2)
+	sb.WriteString("\n")
+	for i, color := range cc.Colors {
+		fmt.Fprintf(&sb, `  <rect x="%g" y="%g" width="%d" height="%d" rx="2" fill="%s"/>`,
+			legendX+float64(i)*step, legendY, CalendarCellSize, CalendarCellSize, color)
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(&sb, `  <text class="label" x="%g" y="%g">More</text>`,
+		legendX+5*step+1, legendY+CalendarCellSize-2)
+	sb.WriteString("\n")
+
+	// Footer
+	sb.WriteString(cc.RenderFooter())
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// RenderBytes returns the SVG as a byte slice.
+func (cc *CalendarCard) RenderBytes() []byte {
+	return []byte(cc.Render())
+}
+
+// GenerateCalendarSVG is a convenience function to generate a calendar SVG
+// from a profile.
+func GenerateCalendarSVG(p *profile.UserProfile, themeName, title string) string {
+	if title == "" {
+		title = fmt.Sprintf("%s's Contributions", p.Username)
+	}
+	return NewCalendarCard(p.Calendar, GetTheme(themeName), title).Render()
+}
+
+// dayTooltip describes a day's contributions like the web viewer, e.g.
+// "3 contributions on Mon, Jan 2, 2006".
+func dayTooltip(date time.Time, count int) string {
+	day := date.Format("Mon, Jan 2, 2006")
+	if count == 0 {
+		return "No contributions on " + day
+	}
+	return pluralContributions(count) + " on " + day
+}
+
+// pluralContributions returns "1 contribution" or "n contributions".
+func pluralContributions(n int) string {
+	if n == 1 {
+		return "1 contribution"
+	}
+	return strconv.Itoa(n) + " contributions"
+}
+
+// isDark reports whether a "#rrggbb" color is dark, by its luminance.
+func isDark(color string) bool {
+	rgb, err := strconv.ParseUint(strings.TrimPrefix(color, "#"), 16, 32)
+	if err != nil || len(color) != 7 {
+		return false
+	}
+	r, g, b := float64(rgb>>16), float64(rgb>>8&0xff), float64(rgb&0xff)
+	return 0.299*r+0.587*g+0.114*b < 128
+}
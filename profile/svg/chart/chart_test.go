@@ -357,6 +357,125 @@ func TestStackedBarChartToJSON(t *testing.T) {
 	}
 }
 
+func TestDonutChartRender(t *testing.T) {
+	chart := NewDonutChart("Languages", "default").
+		AddSliceWithColor("Go", 75, "#00ADD8").
+		AddSlice("Shell", 25).
+		AddSlice("Empty", 0)
+
+	svg := chart.Render()
+
+	if !strings.HasPrefix(svg, "<?xml") || !strings.Contains(svg, "</svg>") {
+		t.Error("SVG missing XML declaration or closing tag")
+	}
+	if n := strings.Count(svg, "<path "); n != 2 {
+		t.Errorf("SVG has %d slices, want 2", n)
+	}
+	if !strings.Contains(svg, `fill="#00ADD8"`) || !strings.Contains(svg, `fill="`+DefaultSeriesColors[1]+`"`) {
+		t.Error("SVG missing slice colors")
+	}
+	if !strings.Contains(svg, "Go 75.0%") || !strings.Contains(svg, "Shell 25.0%") {
+		t.Error("SVG missing legend")
+	}
+	if strings.Contains(svg, "Empty") {
+		t.Error("SVG shows empty slice")
+	}
+}
+
+func TestDonutChartFullCircle(t *testing.T) {
+	svg := NewDonutChart("One", "default").SetInnerRadius(0).AddSlice("Go", 10).Render()
+
+	if strings.Contains(svg, "<path ") || strings.Count(svg, "<circle ") != 1 {
+		t.Error("single slice pie should be one circle")
+	}
+}
+
+func TestDonutChartEmptyData(t *testing.T) {
+	svg := NewDonutChart("Empty", "default").Render()
+
+	if !strings.Contains(svg, "No data available") {
+		t.Error("Empty chart should show 'No data available'")
+	}
+}
+
+func TestDonutChartToJSON(t *testing.T) {
+	jsonBytes, err := NewDonutChart("Test", "dark").AddSlice("Go", 1).ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON error: %v", err)
+	}
+
+	var parsed map[string]any
+	if err := json.Unmarshal(jsonBytes, &parsed); err != nil {
+		t.Errorf("JSON unmarshal error: %v", err)
+	}
+
+	if parsed["type"] != "donut" {
+		t.Errorf("JSON type = %v, want donut", parsed["type"])
+	}
+}
+
+func TestPunchcardChartRender(t *testing.T) {
+	var counts [7][24]int
+	counts[1][9] = 4  // Monday 09:00
+	counts[5][17] = 1 // Friday 17:00
+	svg := NewPunchcardChart("Commits", "default").SetCounts(counts).SetTimezone("Europe/Berlin").Render()
+
+	if !strings.HasPrefix(svg, "<?xml") || !strings.Contains(svg, "</svg>") {
+		t.Error("SVG missing XML declaration or closing tag")
+	}
+	if n := strings.Count(svg, "<circle "); n != 2 {
+		t.Errorf("SVG has %d circles, want 2", n)
+	}
+	if !strings.Contains(svg, "<title>Mon 09:00: 4</title>") || !strings.Contains(svg, "<title>Fri 17:00: 1</title>") {
+		t.Error("SVG missing circle titles")
+	}
+	for _, label := range []string{">Sun<", ">Sat<", ">12a<", ">3p<", "Europe/Berlin"} {
+		if !strings.Contains(svg, label) {
+			t.Errorf("SVG missing label %q", label)
+		}
+	}
+}
+
+func TestHourLabel(t *testing.T) {
+	for h, want := range map[int]string{0: "12a", 1: "1a", 11: "11a", 12: "12p", 13: "1p", 23: "11p"} {
+		if got := hourLabel(h); got != want {
+			t.Errorf("hourLabel(%d) = %q, want %q", h, got, want)
+		}
+	}
+}
+
+func TestPunchcardChartEmptyData(t *testing.T) {
+	svg := NewPunchcardChart("Empty", "default").Render()
+
+	if !strings.Contains(svg, "No data available") {
+		t.Error("Empty chart should show 'No data available'")
+	}
+}
+
+func TestPunchcardChartToJSON(t *testing.T) {
+	var counts [7][24]int
+	counts[0][0] = 2
+	jsonBytes, err := NewPunchcardChart("Test", "dark").SetCounts(counts).ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON error: %v", err)
+	}
+
+	var parsed struct {
+		Type   string
+		Counts [][]int
+	}
+	if err := json.Unmarshal(jsonBytes, &parsed); err != nil {
+		t.Errorf("JSON unmarshal error: %v", err)
+	}
+
+	if parsed.Type != "punchcard" {
+		t.Errorf("JSON type = %v, want punchcard", parsed.Type)
+	}
+	if len(parsed.Counts) != 7 || len(parsed.Counts[0]) != 24 || parsed.Counts[0][0] != 2 {
+		t.Errorf("JSON counts = %v", parsed.Counts)
+	}
+}
+
 func TestCalculatePercentages(t *testing.T) {
 	series := []Series{
 		{Name: "A", Data: []float64{10, 20}, Color: "#aaa"},
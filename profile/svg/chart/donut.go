@@ -0,0 +1,259 @@
+package chart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// DonutChart renders parts of a whole as a donut chart, or a pie chart
+// with an InnerRadius of 0, with a legend.
+type DonutChart struct {
+	ChartType  ChartType  `json:"type"`
+	Metadata   Metadata   `json:"metadata"`
+	Dimensions Dimensions `json:"dimensions"`
+	Slices     []Slice    `json:"slices"`
+
+	// InnerRadius is the radius of the hole as a fraction of the radius,
+	// from 0 (a pie chart) to below 1.
+	InnerRadius float64 `json:"inner_radius"`
+	theme       Theme
+}
+
+// Slice is one part of a donut chart.
+type Slice struct {
+	Label string  `json:"label"`
+	Value float64 `json:"value"`
+	Color string  `json:"color,omitempty"`
+}
+
+// Donut chart layout constants
+const (
+	DonutPaddingX         = 20
+	DonutPaddingTop       = 35
+	DonutPaddingBottom    = 15
+	DonutLegendRowHeight  = 18
+	DonutDefaultHoleRatio = 0.6
+)
+
+// NewDonutChart creates a new donut chart.
+func NewDonutChart(title string, themeName string) *DonutChart {
+	return &DonutChart{
+		ChartType: TypeDonut,
+		Metadata: Metadata{
+			Title:     title,
+			Generated: time.Now().UTC(),
+			Theme:     themeName,
+		},
+		Dimensions: Dimensions{
+			Width:  400,
+			Height: 200,
+		},
+		Slices:      []Slice{},
+		InnerRadius: DonutDefaultHoleRatio,
+		theme:       GetTheme(themeName),
+	}
+}
+
+// AddSlice adds a slice.
+func (d *DonutChart) AddSlice(label string, value float64) *DonutChart {
+	d.Slices = append(d.Slices, Slice{
+		Label: label,
+		Value: value,
+	})
+	return d
+}
+
+// AddSliceWithColor adds a slice with a custom color.
+func (d *DonutChart) AddSliceWithColor(label string, value float64, color string) *DonutChart {
+	d.Slices = append(d.Slices, Slice{
+		Label: label,
+		Value: value,
+		Color: color,
+	})
+	return d
+}
+
+// SetInnerRadius sets the radius of the hole as a fraction of the radius.
+// 0 renders a pie chart.
+func (d *DonutChart) SetInnerRadius(ratio float64) *DonutChart {
+	d.InnerRadius = math.Min(math.Max(ratio, 0), 0.95)
+	return d
+}
+
+// SetDimensions sets custom dimensions.
+func (d *DonutChart) SetDimensions(width, height int) *DonutChart {
+	d.Dimensions.Width = width
+	d.Dimensions.Height = height
+	return d
+}
+
+// Type returns the chart type.
+func (d *DonutChart) Type() ChartType {
+	return TypeDonut
+}
+
+// ToJSON returns the chart as JSON.
+func (d *DonutChart) ToJSON() ([]byte, error) {
+	return marshalChartJSON(d)
+}
+
+// Render generates the SVG string.
+func (d *DonutChart) Render() string {
+	var sb strings.Builder
+
+	width := d.Dimensions.Width
+	height := d.Dimensions.Height
+
+	// XML declaration
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8"?>`)
+	sb.WriteString("\n")
+
+	// SVG header
+	fmt.Fprintf(&sb, `<svg width="%d" height="%d" viewBox="0 0 %d %d" xmlns="http://www.w3.org/2000/svg">`,
+		width, height, width, height)
+	sb.WriteString("\n")
+
+	// Title element
+	fmt.Fprintf(&sb, `  <title>%s</title>`, escapeXML(d.Metadata.Title))
+	sb.WriteString("\n")
+
+	// Styles
+	fmt.Fprintf(&sb, `  <style>
+    .chart-title { font: 600 14px 'Segoe UI', Ubuntu, sans-serif; fill: %s; }
+    .axis-label { font: 400 10px 'Segoe UI', Ubuntu, sans-serif; fill: %s; }
+    .legend-text { font: 400 11px 'Segoe UI', Ubuntu, sans-serif; fill: %s; }
+  </style>`,
+		d.theme.TitleColor,
+		d.theme.TextColor,
+		d.theme.TextColor,
+	)
+	sb.WriteString("\n")
+
+	// Background
+	fmt.Fprintf(&sb, `  <rect x="0" y="0" width="%d" height="%d" fill="%s" rx="%d"/>`,
+		width, height, d.theme.BackgroundColor, BarBorderRadius)
+	sb.WriteString("\n")
+
+	// Border
+	fmt.Fprintf(&sb, `  <rect x="0.5" y="0.5" width="%d" height="%d" fill="none" stroke="%s" rx="%d"/>`,
+		width-1, height-1, d.theme.BorderColor, BarBorderRadius)
+	sb.WriteString("\n")
+
+	// Title text
+	fmt.Fprintf(&sb, `  <text class="chart-title" x="%d" y="20" text-anchor="middle">%s</text>`,
+		width/2, escapeXML(d.Metadata.Title))
+	sb.WriteString("\n")
+
+	var total float64
+	for _, s := range d.Slices {
+		if s.Value > 0 {
+			total += s.Value
+		}
+	}
+
+	if total == 0 {
+		fmt.Fprintf(&sb, `  <text class="axis-label" x="%d" y="%d" text-anchor="middle">No data available</text>`,
+			width/2, height/2)
+		sb.WriteString("\n")
+		sb.WriteString(`</svg>`)
+		sb.WriteString("\n")
+		return sb.String()
+	}
+
+	// The donut fills the height on the left, the legend the right.
+	r := math.Max(math.Min(float64(height-DonutPaddingTop-DonutPaddingBottom), float64(width)/2)/2, 1)
+	ri := r * d.InnerRadius
+	cx := float64(DonutPaddingX) + r
+	cy := float64(DonutPaddingTop) + r
+
+	angle := 0.0
+	for i, s := range d.Slices {
+		if s.Value <= 0 {
+			continue
+		}
+		color := d.sliceColor(i)
+		sweep := 2 * math.Pi * s.Value / total
+
+		if sweep >= 2*math.Pi-1e-9 {
+			// A full circle cannot be drawn as one arc.
+			fmt.Fprintf(&sb, `  <circle cx="%.2f" cy="%.2f" r="%.2f" fill="%s"/>`, cx, cy, r, color)
+		} else {
+			large := 0
+			if sweep > math.Pi {
+				large = 1
+			}
+			x0, y0 := polar(cx, cy, r, angle)
+			x1, y1 := polar(cx, cy, r, angle+sweep)
+			if ri > 0 {
+				ix0, iy0 := polar(cx, cy, ri, angle)
+				ix1, iy1 := polar(cx, cy, ri, angle+sweep)
+				fmt.Fprintf(&sb, `  <path fill="%s" d="M%.2f,%.2f A%.2f,%.2f 0 %d 1 %.2f,%.2f L%.2f,%.2f A%.2f,%.2f 0 %d 0 %.2f,%.2f Z"/>`,
+					color, x0, y0, r, r, large, x1, y1, ix1, iy1, ri, ri, large, ix0, iy0)
+			} else {
+				fmt.Fprintf(&sb, `  <path fill="%s" d="M%.2f,%.2f L%.2f,%.2f A%.2f,%.2f 0 %d 1 %.2f,%.2f Z"/>`,
+					color, cx, cy, x0, y0, r, r, large, x1, y1)
+			}
+		}
+		sb.WriteString("\n")
+		angle += sweep
+	}
+
+	// Punch out the hole, which a full circle lacks
+	if ri > 0 {
+		fmt.Fprintf(&sb, `  <circle cx="%.2f" cy="%.2f" r="%.2f" fill="%s"/>`, cx, cy, ri, d.theme.BackgroundColor)
+		sb.WriteString("\n")
+	}
+
+	// Legend, centered on the donut
+	rows := 0
+	for _, s := range d.Slices {
+		if s.Value > 0 {
+			rows++
+		}
+	}
+	legendX := cx + r + 2*DonutPaddingX
+	y := cy - float64(rows-1)*DonutLegendRowHeight/2 + 4
+	for i, s := range d.Slices {
+		if s.Value <= 0 {
+			continue
+		}
+
+		// Color box
+		fmt.Fprintf(&sb, `  <rect fill="%s" x="%.2f" y="%.2f" width="10" height="10" rx="2"/>`,
+			d.sliceColor(i), legendX, y-9)
+		sb.WriteString("\n")
+
+		// Label and percentage
+		fmt.Fprintf(&sb, `  <text class="legend-text" x="%.2f" y="%.2f">%s %.1f%%</text>`,
+			legendX+16, y, escapeXML(truncateString(s.Label, 20)), 100*s.Value/total)
+		sb.WriteString("\n")
+		y += DonutLegendRowHeight
+	}
+
+	// Footer
+	sb.WriteString(`</svg>`)
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// RenderBytes returns the SVG as bytes.
+func (d *DonutChart) RenderBytes() []byte {
+	return []byte(d.Render())
+}
+
+// sliceColor returns the color of slice i, or a default series color.
+func (d *DonutChart) sliceColor(i int) string {
+	if c := d.Slices[i].Color; c != "" {
+		return c
+	}
+	return DefaultSeriesColors[i%len(DefaultSeriesColors)]
+}
+
+// polar returns the point at radius r and angle a, in radians clockwise
+// from 12 o'clock, around (cx, cy).
+func polar(cx, cy, r, a float64) (float64, float64) {
+	return cx + r*math.Sin(a), cy - r*math.Cos(a)
+}
@@ -0,0 +1,39 @@
+package chart
+
+// LanguageColors maps common programming languages to the colors GitHub
+// shows for them.
+var LanguageColors = map[string]string{
+	"C":           "#555555",
+	"C#":          "#178600",
+	"C++":         "#f34b7d",
+	"CSS":         "#563d7c",
+	"Dart":        "#00B4AB",
+	"Dockerfile":  "#384d54",
+	"Elixir":      "#6e4a7e",
+	"Go":          "#00ADD8",
+	"HCL":         "#844FBA",
+	"HTML":        "#e34c26",
+	"Java":        "#b07219",
+	"JavaScript":  "#f1e05a",
+	"Jupyter":     "#DA5B0B",
+	"Kotlin":      "#A97BFF",
+	"Lua":         "#000080",
+	"Makefile":    "#427819",
+	"Objective-C": "#438eff",
+	"PHP":         "#4F5D95",
+	"Perl":        "#0298c3",
+	"PowerShell":  "#012456",
+	"Python":      "#3572A5",
+	"R":           "#198CE7",
+	"Ruby":        "#701516",
+	"Rust":        "#dea584",
+	"SCSS":        "#c6538c",
+	"Scala":       "#c22d40",
+	"Shell":       "#89e051",
+	"Swift":       "#F05138",
+	"TypeScript":  "#3178c6",
+	"Vue":         "#41b883",
+}
+
+// LanguageOtherColor is the color of the slice grouping remaining languages.
+const LanguageOtherColor = "#8b949e"
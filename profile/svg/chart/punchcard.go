@@ -0,0 +1,216 @@
+package chart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// PunchcardChart renders counts by day of the week and hour of the day as
+// a grid of circles sized by count, like GitHub's punch card graph.
+type PunchcardChart struct {
+	ChartType  ChartType  `json:"type"`
+	Metadata   Metadata   `json:"metadata"`
+	Dimensions Dimensions `json:"dimensions"`
+
+	// Counts holds the counts by weekday, indexed by time.Weekday
+	// (Sunday first), and hour, 0 to 23.
+	Counts [7][24]int `json:"counts"`
+
+	// Timezone is the name of the time zone the hours are in.
+	Timezone string `json:"timezone,omitempty"`
+	theme    Theme
+}
+
+// Punchcard chart layout constants
+const (
+	PunchcardPaddingLeft   = 45
+	PunchcardPaddingRight  = 15
+	PunchcardPaddingTop    = 35
+	PunchcardPaddingBottom = 40
+)
+
+// NewPunchcardChart creates a new punchcard chart.
+func NewPunchcardChart(title string, themeName string) *PunchcardChart {
+	return &PunchcardChart{
+		ChartType: TypePunchcard,
+		Metadata: Metadata{
+			Title:     title,
+			Generated: time.Now().UTC(),
+			Theme:     themeName,
+		},
+		Dimensions: Dimensions{
+			Width:  640,
+			Height: 250,
+		},
+		theme: GetTheme(themeName),
+	}
+}
+
+// SetCounts sets the counts by weekday and hour.
+func (p *PunchcardChart) SetCounts(counts [7][24]int) *PunchcardChart {
+	p.Counts = counts
+	return p
+}
+
+// SetTimezone sets the name of the time zone the hours are in, shown
+// below the chart.
+func (p *PunchcardChart) SetTimezone(name string) *PunchcardChart {
+	p.Timezone = name
+	return p
+}
+
+// SetDimensions sets custom dimensions.
+func (p *PunchcardChart) SetDimensions(width, height int) *PunchcardChart {
+	p.Dimensions.Width = width
+	p.Dimensions.Height = height
+	return p
+}
+
+// Type returns the chart type.
+func (p *PunchcardChart) Type() ChartType {
+	return TypePunchcard
+}
+
+// ToJSON returns the chart as JSON.
+func (p *PunchcardChart) ToJSON() ([]byte, error) {
+	return marshalChartJSON(p)
+}
+
+// Render generates the SVG string.
+func (p *PunchcardChart) Render() string {
+	var sb strings.Builder
+
+	width := p.Dimensions.Width
+	height := p.Dimensions.Height
+
+	// XML declaration
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8"?>`)
+	sb.WriteString("\n")
+
+	// SVG header
+	fmt.Fprintf(&sb, `<svg width="%d" height="%d" viewBox="0 0 %d %d" xmlns="http://www.w3.org/2000/svg">`,
+		width, height, width, height)
+	sb.WriteString("\n")
+
+	// Title element
+	fmt.Fprintf(&sb, `  <title>%s</title>`, escapeXML(p.Metadata.Title))
+	sb.WriteString("\n")
+
+	// Styles
+	fmt.Fprintf(&sb, `  <style>
+    .chart-title { font: 600 14px 'Segoe UI', Ubuntu, sans-serif; fill: %s; }
+    .axis-label { font: 400 10px 'Segoe UI', Ubuntu, sans-serif; fill: %s; }
+    .grid-line { stroke: %s; stroke-width: 0.5; }
+    .punch { fill: %s; }
+  </style>`,
+		p.theme.TitleColor,
+		p.theme.TextColor,
+		p.theme.GridColor,
+		p.theme.AccentColor,
+	)
+	sb.WriteString("\n")
+
+	// Background
+	fmt.Fprintf(&sb, `  <rect x="0" y="0" width="%d" height="%d" fill="%s" rx="%d"/>`,
+		width, height, p.theme.BackgroundColor, BarBorderRadius)
+	sb.WriteString("\n")
+
+	// Border
+	fmt.Fprintf(&sb, `  <rect x="0.5" y="0.5" width="%d" height="%d" fill="none" stroke="%s" rx="%d"/>`,
+		width-1, height-1, p.theme.BorderColor, BarBorderRadius)
+	sb.WriteString("\n")
+
+	// Title text
+	fmt.Fprintf(&sb, `  <text class="chart-title" x="%d" y="20" text-anchor="middle">%s</text>`,
+		width/2, escapeXML(p.Metadata.Title))
+	sb.WriteString("\n")
+
+	maxCount := 0
+	for _, hours := range p.Counts {
+		for _, n := range hours {
+			maxCount = max(maxCount, n)
+		}
+	}
+
+	if maxCount == 0 {
+		fmt.Fprintf(&sb, `  <text class="axis-label" x="%d" y="%d" text-anchor="middle">No data available</text>`,
+			width/2, height/2)
+		sb.WriteString("\n")
+		sb.WriteString(`</svg>`)
+		sb.WriteString("\n")
+		return sb.String()
+	}
+
+	cellW := float64(width-PunchcardPaddingLeft-PunchcardPaddingRight) / 24
+	cellH := float64(height-PunchcardPaddingTop-PunchcardPaddingBottom) / 7
+	maxRadius := math.Max(math.Min(cellW, cellH)/2-1, 1)
+
+	for d, hours := range p.Counts {
+		y := float64(PunchcardPaddingTop) + (float64(d)+0.5)*cellH
+
+		// Day label and row line
+		fmt.Fprintf(&sb, `  <text class="axis-label" x="%d" y="%.2f" text-anchor="end">%s</text>`,
+			PunchcardPaddingLeft-8, y+3, time.Weekday(d).String()[:3])
+		sb.WriteString("\n")
+		fmt.Fprintf(&sb, `  <line class="grid-line" x1="%d" y1="%.2f" x2="%d" y2="%.2f"/>`,
+			PunchcardPaddingLeft, y, width-PunchcardPaddingRight, y)
+		sb.WriteString("\n")
+
+		// Circles, their area proportional to the count
+		for h, n := range hours {
+			if n <= 0 {
+				continue
+			}
+			x := float64(PunchcardPaddingLeft) + (float64(h)+0.5)*cellW
+			r := maxRadius * math.Sqrt(float64(n)/float64(maxCount))
+			fmt.Fprintf(&sb, `  <circle class="punch" cx="%.2f" cy="%.2f" r="%.2f"><title>%s %02d:00: %d</title></circle>`,
+				x, y, r, time.Weekday(d).String()[:3], h, n)
+			sb.WriteString("\n")
+		}
+	}
+
+	// Hour labels, every hour if there is room, else every third
+	step := 1
+	if cellW < 22 {
+		step = 3
+	}
+	labelY := float64(height-PunchcardPaddingBottom) + 14
+	for h := 0; h < 24; h += step {
+		x := float64(PunchcardPaddingLeft) + (float64(h)+0.5)*cellW
+		fmt.Fprintf(&sb, `  <text class="axis-label" x="%.2f" y="%.2f" text-anchor="middle">%s</text>`,
+			x, labelY, hourLabel(h))
+		sb.WriteString("\n")
+	}
+
+	// Time zone
+	if p.Timezone != "" {
+		fmt.Fprintf(&sb, `  <text class="axis-label" x="%d" y="%d" text-anchor="end">%s</text>`,
+			width-PunchcardPaddingRight, height-8, escapeXML(p.Timezone))
+		sb.WriteString("\n")
+	}
+
+	// Footer
+	sb.WriteString(`</svg>`)
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// RenderBytes returns the SVG as bytes.
+func (p *PunchcardChart) RenderBytes() []byte {
+	return []byte(p.Render())
+}
+
+// hourLabel returns a 12-hour clock label for hour h, e.g. "12a" or "3p".
+func hourLabel(h int) string {
+	suffix := "a"
+	if h >= 12 {
+		suffix = "p"
+	}
+	if h%12 == 0 {
+		return "12" + suffix
+	}
+	return fmt.Sprintf("%d%s", h%12, suffix)
+}
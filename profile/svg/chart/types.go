@@ -10,10 +10,12 @@ import (
 type ChartType string
 
 const (
-	TypeTable   ChartType = "table"
-	TypeBar     ChartType = "bar"
-	TypeLine    ChartType = "line"
-	TypeHeatmap ChartType = "heatmap"
+	TypeTable     ChartType = "table"
+	TypeBar       ChartType = "bar"
+	TypeLine      ChartType = "line"
+	TypeHeatmap   ChartType = "heatmap"
+	TypeDonut     ChartType = "donut"
+	TypePunchcard ChartType = "punchcard"
 )
 
 // Chart is the interface all chart types implement.
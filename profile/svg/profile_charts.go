@@ -223,3 +223,66 @@ func ChangelogCategoriesByMonthChartSVG(data *chart.CommitTypeData, themeName, t
 func ChangelogCategoriesByMonthChartJSON(data *chart.CommitTypeData, themeName, title string) ([]byte, error) {
 	return ChangelogCategoriesByMonthChart(data, themeName, title).ToJSON()
 }
+
+// LanguagesChart creates a donut chart of a profile's language breakdown,
+// showing the top languages and grouping the rest as "Other".
+// If top <= 0, it shows 8 languages.
+func LanguagesChart(p *profile.UserProfile, themeName, title string, top int) *chart.DonutChart {
+	if title == "" {
+		title = fmt.Sprintf("%s's Languages", p.Username)
+	}
+	if top <= 0 {
+		top = 8
+	}
+
+	donut := chart.NewDonutChart(title, themeName)
+
+	var other float64
+	for i, lang := range p.Languages {
+		if i >= top {
+			other += lang.Weight
+			continue
+		}
+		donut.AddSliceWithColor(lang.Name, lang.Weight, chart.LanguageColors[lang.Name])
+	}
+	if other > 0 {
+		donut.AddSliceWithColor("Other", other, chart.LanguageOtherColor)
+	}
+
+	return donut
+}
+
+// LanguagesChartSVG generates an SVG for a profile's language breakdown.
+func LanguagesChartSVG(p *profile.UserProfile, themeName, title string) string {
+	return LanguagesChart(p, themeName, title, 0).Render()
+}
+
+// LanguagesChartJSON generates a JSON IR for a profile's language breakdown.
+func LanguagesChartJSON(p *profile.UserProfile, themeName, title string) ([]byte, error) {
+	return LanguagesChart(p, themeName, title, 0).ToJSON()
+}
+
+// PunchcardChart creates a punchcard chart of when a profile's commits
+// were authored, by weekday and hour in the profile's time zone.
+func PunchcardChart(p *profile.UserProfile, themeName, title string) *chart.PunchcardChart {
+	if title == "" {
+		title = fmt.Sprintf("%s's Commits by Day and Hour", p.Username)
+	}
+
+	punchcard := chart.NewPunchcardChart(title, themeName)
+	if p.Punchcard != nil {
+		punchcard.SetCounts(p.Punchcard.Counts).SetTimezone(p.Punchcard.Timezone)
+	}
+
+	return punchcard
+}
+
+// PunchcardChartSVG generates an SVG for a profile's punchcard.
+func PunchcardChartSVG(p *profile.UserProfile, themeName, title string) string {
+	return PunchcardChart(p, themeName, title).Render()
+}
+
+// PunchcardChartJSON generates a JSON IR for a profile's punchcard.
+func PunchcardChartJSON(p *profile.UserProfile, themeName, title string) ([]byte, error) {
+	return PunchcardChart(p, themeName, title).ToJSON()
+}
@@ -1,9 +1,18 @@
 package svg
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/shurcooL/githubv4"
+
+	"github.com/grokify/gogithub/clientv1/fake"
 	"github.com/grokify/gogithub/profile"
 )
 
@@ -319,3 +328,147 @@ func TestGenerateSVGBytes(t *testing.T) {
 		t.Error("GenerateSVGBytes output should start with XML declaration")
 	}
 }
+
+func TestLanguagesChart(t *testing.T) {
+	p := &profile.UserProfile{
+		Username: "testuser",
+		Languages: []profile.LanguageShare{
+			{Name: "Go", Weight: 60},
+			{Name: "Shell", Weight: 25},
+			{Name: "Makefile", Weight: 10},
+			{Name: "Dockerfile", Weight: 5},
+		},
+	}
+
+	donut := LanguagesChart(p, "default", "", 2)
+
+	if donut.Metadata.Title != "testuser's Languages" {
+		t.Errorf("title = %q", donut.Metadata.Title)
+	}
+	if len(donut.Slices) != 3 || donut.Slices[2].Label != "Other" || donut.Slices[2].Value != 15 {
+		t.Errorf("slices = %+v, want Go, Shell and Other of 15", donut.Slices)
+	}
+	if donut.Slices[0].Color != "#00ADD8" {
+		t.Errorf("Go color = %q, want #00ADD8", donut.Slices[0].Color)
+	}
+}
+
+func TestPunchcardChart(t *testing.T) {
+	p := &profile.UserProfile{
+		Username:  "testuser",
+		Punchcard: profile.BuildPunchcard([]time.Time{time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)}, nil),
+	}
+
+	punchcard := PunchcardChart(p, "default", "")
+
+	if punchcard.Metadata.Title != "testuser's Commits by Day and Hour" {
+		t.Errorf("title = %q", punchcard.Metadata.Title)
+	}
+	if punchcard.Counts[time.Monday][9] != 1 || punchcard.Timezone != "UTC" {
+		t.Errorf("punchcard = %v in %q, want 1 commit on Monday at 09:00 UTC", punchcard.Counts[time.Monday], punchcard.Timezone)
+	}
+
+	// A profile without a punchcard renders as empty.
+	if svg := PunchcardChartSVG(&profile.UserProfile{Username: "empty"}, "default", ""); !strings.Contains(svg, "No data available") {
+		t.Error("empty punchcard should show 'No data available'")
+	}
+}
+
+func TestCalendarCardRender(t *testing.T) {
+	cal := profile.NewCalendarFromDays([]profile.CalendarDay{
+		{Date: time.Date(2024, 12, 2, 0, 0, 0, 0, time.UTC), ContributionCount: 1},
+		{Date: time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), ContributionCount: 12}, // Tuesday
+	})
+
+	svg := NewCalendarCard(cal, GetTheme("default"), "").Render()
+
+	if !strings.HasPrefix(svg, "<?xml") || !strings.Contains(svg, "</svg>") {
+		t.Error("SVG missing XML declaration or closing tag")
+	}
+	// 52 full weeks and Sunday to Tuesday of the last week
+	if n := strings.Count(svg, "<title>") - 1; n != 52*7+3 {
+		t.Errorf("SVG has %d day cells, want %d", n, 52*7+3)
+	}
+	for _, want := range []string{
+		"1 contribution on Mon, Dec 2, 2024",
+		"12 contributions on Tue, Dec 31, 2024",
+		"No contributions on Wed, Jan 3, 2024",
+		`fill="` + CalendarColorsLight[4] + `"`,
+		">Jan<", ">Dec<", ">Mon<", ">Fri<", ">Less<", ">More<",
+		">13 contributions<",
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG missing %q", want)
+		}
+	}
+
+	if dark := NewCalendarCard(cal, GetTheme("dark"), ""); dark.Colors != CalendarColorsDark {
+		t.Errorf("dark theme colors = %v, want CalendarColorsDark", dark.Colors)
+	}
+}
+
+func TestGenerateCalendarSVGFetchedProfile(t *testing.T) {
+	// A user who contributed once on every weekday, and to no repositories
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var in struct {
+			Query     string
+			Variables struct{ From, To time.Time }
+		}
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		switch {
+		case strings.Contains(in.Query, "contributionsCollection"):
+			var days []string
+			for d := in.Variables.From; !d.After(in.Variables.To); d = d.AddDate(0, 0, 1) {
+				count := 1
+				if d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+					count = 0
+				}
+				days = append(days, fmt.Sprintf(`{"contributionCount":%d,"date":%q}`, count, d.Format("2006-01-02")))
+			}
+			fmt.Fprintf(w, `{"data":{"user":{"contributionsCollection":{"contributionCalendar":{"weeks":[{"contributionDays":[%s]}]}}}}}`, strings.Join(days, ","))
+		case strings.Contains(in.Query, "repositoriesContributedTo"):
+			fmt.Fprint(w, `{"data":{"user":{"repositoriesContributedTo":{"pageInfo":{"hasNextPage":false},"nodes":[]}}}}`)
+		default:
+			fmt.Fprint(w, `{"data":{"user":{"id":"U1"}}}`)
+		}
+	}))
+	defer srv.Close()
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC)
+	p, err := profile.GetUserProfile(context.Background(), fake.NewClient(), "octocat", from, to, &profile.Options{
+		GraphQLClient: githubv4.NewEnterpriseClient(srv.URL, srv.Client()),
+	})
+	if err != nil {
+		t.Fatalf("GetUserProfile() error = %v", err)
+	}
+
+	// 2024 has 262 weekdays, each its own lit cell, and the card's 53
+	// weeks, ending Tuesday, Dec 31, start on Sunday, Dec 31, 2023.
+	svg := GenerateCalendarSVG(p, "default", "")
+	if n := strings.Count(svg, ">1 contribution on "); n != 262 {
+		t.Errorf("SVG has %d days with a contribution, want 262", n)
+	}
+	for _, want := range []string{
+		"1 contribution on Mon, Jan 1, 2024",
+		"1 contribution on Fri, Mar 15, 2024",
+		"No contributions on Sat, Mar 16, 2024",
+		"1 contribution on Tue, Dec 31, 2024",
+		">262 contributions<",
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG missing %q", want)
+		}
+	}
+}
+
+func TestCalendarCardEmpty(t *testing.T) {
+	svg := GenerateCalendarSVG(&profile.UserProfile{Username: "testuser"}, "default", "")
+
+	if !strings.Contains(svg, "testuser&apos;s Contributions") || !strings.Contains(svg, "No data available") {
+		t.Error("empty calendar should show its title and 'No data available'")
+	}
+}
@@ -0,0 +1,335 @@
+package profile
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grokify/gogithub/clientv1"
+	"github.com/grokify/gogithub/internal/parallel"
+)
+
+// DefaultTeamConcurrency is the number of member profiles GetTeamProfile
+// fetches at once.
+const DefaultTeamConcurrency = 2
+
+// Team selects the members of a team for GetTeamProfile. Members are the
+// union of Usernames and, if Org is set, the members of Org's team
+// TeamSlug or, without a TeamSlug, of Org itself.
+type Team struct {
+	// Name is the team's name in TeamProfile and its Combined profile,
+	// where it takes the place of a username, e.g. in monthly file names.
+	// Default: TeamSlug, Org, or "team".
+	Name string
+
+	Usernames []string
+	Org       string
+	TeamSlug  string
+}
+
+// TeamOptions configures GetTeamProfile.
+type TeamOptions struct {
+	// Member configures each member's profile, as for GetUserProfile.
+	// Releases are counted and languages fetched once for the team rather
+	// than per member, and Member.Progress is not called.
+	Member *Options
+
+	// Concurrency is the number of member profiles fetched at once.
+	// Each fetches Member.Concurrency repositories at once in turn.
+	// Default: DefaultTeamConcurrency.
+	Concurrency int
+
+	// Progress is called after each member's profile is fetched.
+	// If nil, no progress is reported.
+	Progress func(done, total int)
+}
+
+// TeamProfile contains the contribution statistics of a team: each
+// member's profile and the team's combined profile.
+type TeamProfile struct {
+	Name string
+	From time.Time
+	To   time.Time
+
+	// Members are the members' profiles, sorted by username.
+	Members []*UserProfile
+
+	// Combined rolls the members' profiles up into one, as by
+	// MergeProfiles, with the team's name as its username. Renderers
+	// that take a *UserProfile, such as the readme and svg packages and
+	// WriteMonthlyFiles for stats reports, accept it.
+	Combined *UserProfile
+}
+
+// GetTeamProfile fetches the profiles of a team's members concurrently and
+// combines them. If fetching any member's profile fails, the others are
+// cancelled and the error is returned; with Member.CacheDir set, running
+// it again resumes each member where it stopped.
+func GetTeamProfile(ctx context.Context, client clientv1.Client, team Team, from, to time.Time, opts *TeamOptions) (*TeamProfile, error) {
+	if opts == nil {
+		opts = &TeamOptions{}
+	}
+	memberOpts := DefaultOptions()
+	if opts.Member != nil {
+		o := *opts.Member
+		memberOpts = &o
+	}
+	if err := memberOpts.checkRESTClient(client); err != nil {
+		return nil, err
+	}
+	includeReleases, includeLanguages := memberOpts.IncludeReleases, memberOpts.IncludeLanguages
+	memberOpts.IncludeReleases, memberOpts.IncludeLanguages = false, false
+	memberOpts.Progress = nil
+
+	usernames, err := teamUsernames(ctx, client, team)
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultTeamConcurrency
+	}
+
+	members := make([]*UserProfile, len(usernames))
+	var mu sync.Mutex
+	done := 0
+	err = parallel.ForEach(ctx, len(usernames), concurrency, func(ctx context.Context, i int) error {
+		p, err := GetUserProfile(ctx, client, usernames[i], from, to, memberOpts)
+		if err != nil {
+			return fmt.Errorf("get profile of %s: %w", usernames[i], err)
+		}
+		members[i] = p
+
+		mu.Lock()
+		defer mu.Unlock()
+		done++
+		if opts.Progress != nil {
+			opts.Progress(done, len(usernames))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	name := team.Name
+	if name == "" {
+		name = cmp.Or(team.TeamSlug, team.Org, "team")
+	}
+	tp := &TeamProfile{
+		Name:     name,
+		From:     from,
+		To:       to,
+		Members:  members,
+		Combined: MergeProfiles(name, from, to, members),
+	}
+
+	// Count releases and fetch languages once per repository, not once
+	// per member.
+	noProgress := func(ProgressInfo) {}
+	if includeReleases {
+		if err := fetchReleaseCounts(ctx, client, tp.Combined, memberOpts, noProgress, 5); err != nil {
+			return nil, fmt.Errorf("get release counts: %w", err)
+		}
+	}
+	if includeLanguages {
+		if err := fetchLanguages(ctx, client, tp.Combined, memberOpts, noProgress, 6, 6); err != nil {
+			return nil, fmt.Errorf("get languages: %w", err)
+		}
+		languages := make(map[string]map[string]int)
+		for _, repo := range tp.Combined.RepoStats {
+			languages[strings.ToLower(repo.FullName)] = repo.Languages
+		}
+		for _, m := range members {
+			for i := range m.RepoStats {
+				m.RepoStats[i].Languages = languages[strings.ToLower(m.RepoStats[i].FullName)]
+			}
+			m.Languages = BuildLanguageBreakdown(m.RepoStats, memberOpts.LanguageWeighting)
+		}
+	}
+
+	return tp, nil
+}
+
+// teamUsernames resolves a team's members, de-duplicated case-insensitively
+// and sorted.
+func teamUsernames(ctx context.Context, client clientv1.Client, team Team) ([]string, error) {
+	usernames := slices.Clone(team.Usernames)
+	if team.Org != "" && client == nil {
+		return nil, errors.New("listing organization or team members needs a REST client")
+	}
+	switch {
+	case team.Org != "" && team.TeamSlug != "":
+		users, err := client.ListTeamMembers(ctx, team.Org, team.TeamSlug)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range users {
+			usernames = append(usernames, u.Login)
+		}
+	case team.Org != "":
+		users, err := client.ListOrgMembers(ctx, team.Org)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range users {
+			usernames = append(usernames, u.Login)
+		}
+	}
+
+	seen := make(map[string]bool, len(usernames))
+	unique := usernames[:0]
+	for _, u := range usernames {
+		if key := strings.ToLower(u); u != "" && !seen[key] {
+			seen[key] = true
+			unique = append(unique, u)
+		}
+	}
+	if len(unique) == 0 {
+		return nil, fmt.Errorf("team %q has no members", cmp.Or(team.Name, team.TeamSlug, team.Org))
+	}
+	sort.Slice(unique, func(i, j int) bool {
+		return strings.ToLower(unique[i]) < strings.ToLower(unique[j])
+	})
+	return unique, nil
+}
+
+// MergeProfiles combines user profiles into one profile named username
+// covering from..to. Contribution counts, lines changed, calendar days and
+// monthly activity are summed. Repositories that several profiles
+// contributed to are merged into one RepoContribution, so
+// ReposContributedTo counts each once; as their Releases are the same
+// releases, the largest count is kept. Monthly Releases are summed. If
+// the repositories have Languages, Languages is rebuilt from them,
+// weighted by commits. Punchcards are summed if they share a time zone,
+// and otherwise left nil.
+func MergeProfiles(username string, from, to time.Time, profiles []*UserProfile) *UserProfile {
+	merged := &UserProfile{
+		Username: username,
+		From:     from,
+		To:       to,
+		Activity: &ActivityTimeline{
+			Username: username,
+			From:     from,
+			To:       to,
+			Months:   []MonthlyActivity{},
+		},
+	}
+
+	repoIndex := make(map[string]int) // lowercase "owner/repo" -> index in RepoStats
+	dayCounts := make(map[string]int) // "2024-01-02" -> contributions
+	monthIndex := make(map[string]int)
+	var punchcards []*Punchcard
+	for _, p := range profiles {
+		if p == nil {
+			continue
+		}
+		punchcards = append(punchcards, p.Punchcard)
+		merged.TotalCommits += p.TotalCommits
+		merged.TotalIssues += p.TotalIssues
+		merged.TotalPRs += p.TotalPRs
+		merged.TotalReviews += p.TotalReviews
+		merged.TotalReposCreated += p.TotalReposCreated
+		merged.RestrictedContributions += p.RestrictedContributions
+		merged.CommitsDefaultBranch += p.CommitsDefaultBranch
+		merged.TotalAdditions += p.TotalAdditions
+		merged.TotalDeletions += p.TotalDeletions
+
+		for _, repo := range p.RepoStats {
+			key := strings.ToLower(repo.FullName)
+			i, ok := repoIndex[key]
+			if !ok {
+				repoIndex[key] = len(merged.RepoStats)
+				merged.RepoStats = append(merged.RepoStats, repo)
+				continue
+			}
+			r := &merged.RepoStats[i]
+			r.Commits += repo.Commits
+			r.Additions += repo.Additions
+			r.Deletions += repo.Deletions
+			r.Releases = max(r.Releases, repo.Releases)
+		}
+
+		if p.Calendar != nil {
+			for _, week := range p.Calendar.Weeks {
+				for _, day := range week.Days {
+					if !day.Date.IsZero() {
+						dayCounts[normalizeDate(day.Date).Format("2006-01-02")] += day.ContributionCount
+					}
+				}
+			}
+		}
+
+		if p.Activity != nil {
+			for _, m := range p.Activity.Months {
+				key := m.YearMonth()
+				i, ok := monthIndex[key]
+				if !ok {
+					i = len(merged.Activity.Months)
+					monthIndex[key] = i
+					merged.Activity.Months = append(merged.Activity.Months, MonthlyActivity{
+						Year:          m.Year,
+						Month:         m.Month,
+						CommitsByRepo: make(map[string]int),
+					})
+				}
+				mergeMonth(&merged.Activity.Months[i], m)
+			}
+		}
+	}
+	merged.ReposContributedTo = len(merged.RepoStats)
+	merged.Punchcard = mergePunchcards(punchcards)
+	if slices.ContainsFunc(merged.RepoStats, func(r RepoContribution) bool { return r.Languages != nil }) {
+		merged.Languages = BuildLanguageBreakdown(merged.RepoStats, WeightByCommits)
+	}
+
+	// Sort repos by commit count descending, keeping ties in first-seen order
+	sort.SliceStable(merged.RepoStats, func(i, j int) bool {
+		return merged.RepoStats[i].Commits > merged.RepoStats[j].Commits
+	})
+
+	days := make([]CalendarDay, 0, len(dayCounts))
+	for date, count := range dayCounts {
+		d, _ := time.Parse("2006-01-02", date)
+		days = append(days, CalendarDay{Date: d, Weekday: d.Weekday(), ContributionCount: count})
+	}
+	merged.Calendar = NewCalendarFromDays(days)
+
+	merged.Activity.SortByDateDesc() // Most recent first
+
+	return merged
+}
+
+// mergeMonth adds m's activity to dst.
+func mergeMonth(dst *MonthlyActivity, m MonthlyActivity) {
+	dst.Commits += m.Commits
+	dst.Issues += m.Issues
+	dst.PRs += m.PRs
+	dst.Reviews += m.Reviews
+	dst.Releases += m.Releases
+	dst.Additions += m.Additions
+	dst.Deletions += m.Deletions
+	for repo, n := range m.CommitsByRepo {
+		dst.CommitsByRepo[repo] += n
+	}
+	dst.IssueRepos = appendUnique(dst.IssueRepos, m.IssueRepos...)
+	dst.PRRepos = appendUnique(dst.PRRepos, m.PRRepos...)
+	dst.ReposCreated = appendUnique(dst.ReposCreated, m.ReposCreated...)
+}
+
+// appendUnique appends the values not already in s.
+func appendUnique(s []string, values ...string) []string {
+	for _, v := range values {
+		if !slices.Contains(s, v) {
+			s = append(s, v)
+		}
+	}
+	return s
+}
@@ -0,0 +1,133 @@
+package profile
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/grokify/gogithub/clientv1/fake"
+)
+
+func TestMergeProfiles(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC)
+	day := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+
+	alice := &UserProfile{
+		Username:     "alice",
+		TotalCommits: 10,
+		TotalPRs:     2,
+		RepoStats: []RepoContribution{
+			{Owner: "acme", Name: "api", FullName: "acme/api", Commits: 6, Additions: 60, Releases: 3},
+			{Owner: "acme", Name: "web", FullName: "acme/web", Commits: 4, Additions: 40},
+		},
+		Calendar: NewCalendarFromDays([]CalendarDay{{Date: day, ContributionCount: 2}}),
+		Activity: &ActivityTimeline{Months: []MonthlyActivity{
+			{Year: 2024, Month: time.March, Commits: 10, CommitsByRepo: map[string]int{"acme/api": 6, "acme/web": 4}, PRRepos: []string{"acme/api"}},
+		}},
+	}
+	bob := &UserProfile{
+		Username:     "bob",
+		TotalCommits: 5,
+		TotalReviews: 7,
+		RepoStats: []RepoContribution{
+			{Owner: "acme", Name: "api", FullName: "Acme/API", Commits: 5, Additions: 50, Releases: 3},
+		},
+		Calendar: NewCalendarFromDays([]CalendarDay{{Date: day, ContributionCount: 3}, {Date: day.AddDate(0, 1, 0), ContributionCount: 1}}),
+		Activity: &ActivityTimeline{Months: []MonthlyActivity{
+			{Year: 2024, Month: time.March, Commits: 3, CommitsByRepo: map[string]int{"acme/api": 3}, PRRepos: []string{"acme/api"}},
+			{Year: 2024, Month: time.April, Commits: 2, CommitsByRepo: map[string]int{"acme/api": 2}},
+		}},
+	}
+
+	team := MergeProfiles("core", from, to, []*UserProfile{alice, bob})
+
+	if team.Username != "core" || team.TotalCommits != 15 || team.TotalPRs != 2 || team.TotalReviews != 7 {
+		t.Errorf("merged totals = %s", team.Summary())
+	}
+	if team.ReposContributedTo != 2 || len(team.RepoStats) != 2 {
+		t.Fatalf("ReposContributedTo = %d, RepoStats = %d, want 2 each", team.ReposContributedTo, len(team.RepoStats))
+	}
+	if api := team.RepoStats[0]; api.FullName != "acme/api" || api.Commits != 11 || api.Additions != 110 || api.Releases != 3 {
+		t.Errorf("RepoStats[0] = %+v, want acme/api with 11 commits, 110 additions, 3 releases", api)
+	}
+	if team.Calendar.TotalContributions != 6 {
+		t.Errorf("calendar total = %d, want 6", team.Calendar.TotalContributions)
+	}
+
+	if len(team.Activity.Months) != 2 || team.Activity.Months[0].Month != time.April {
+		t.Fatalf("activity months = %+v, want April then March", team.Activity.Months)
+	}
+	march := team.Activity.GetMonth(2024, time.March)
+	if march.Commits != 13 || march.CommitsByRepo["acme/api"] != 9 || !slices.Equal(march.PRRepos, []string{"acme/api"}) {
+		t.Errorf("March = %+v", march)
+	}
+	if alice.Activity.Months[0].CommitsByRepo["acme/api"] != 6 {
+		t.Error("MergeProfiles modified its input")
+	}
+}
+
+func TestTeamUsernames(t *testing.T) {
+	ctx := context.Background()
+	fc := fake.NewClient()
+	fc.AddTeamMember("acme", "core", "carol")
+	fc.AddTeamMember("acme", "core", "Bob")
+	fc.AddOrgMember("acme", "dave")
+
+	tests := []struct {
+		name string
+		team Team
+		want []string
+	}{
+		{"usernames", Team{Usernames: []string{"bob", "alice", "Bob"}}, []string{"alice", "bob"}},
+		{"team", Team{Org: "acme", TeamSlug: "core", Usernames: []string{"alice", "bob"}}, []string{"alice", "bob", "carol"}},
+		{"org", Team{Org: "acme"}, []string{"Bob", "carol", "dave"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := teamUsernames(ctx, fc, tt.team)
+			if err != nil {
+				t.Fatalf("teamUsernames() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("teamUsernames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := teamUsernames(ctx, fc, Team{Org: "acme", TeamSlug: "missing"}); err == nil {
+		t.Error("teamUsernames(missing team) error = nil, want error")
+	}
+	if _, err := teamUsernames(ctx, fc, Team{}); err == nil {
+		t.Error("teamUsernames(no members) error = nil, want error")
+	}
+}
+
+func TestGetTeamProfile(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC)
+
+	// Every user the stub serves made one commit a month to octocat/hello.
+	var s cacheServer
+	client := s.client(t)
+
+	var progress []int
+	tp, err := GetTeamProfile(context.Background(), client, Team{Usernames: []string{"octocat", "hubot"}}, from, to, &TeamOptions{
+		Member:      &Options{},
+		Concurrency: 1,
+		Progress:    func(done, total int) { progress = append(progress, done) },
+	})
+	if err != nil {
+		t.Fatalf("GetTeamProfile() error = %v", err)
+	}
+	if tp.Name != "team" || len(tp.Members) != 2 || tp.Members[0].Username != "hubot" {
+		t.Errorf("team %q members = %d, first %q", tp.Name, len(tp.Members), tp.Members[0].Username)
+	}
+	if !slices.Equal(progress, []int{1, 2}) {
+		t.Errorf("progress = %v, want [1 2]", progress)
+	}
+	if c := tp.Combined; c.Username != "team" || c.CommitsDefaultBranch != 24 || c.ReposContributedTo != 1 || c.RepoStats[0].Commits != 24 {
+		t.Errorf("combined = %s, %d default branch commits", c.Summary(), c.CommitsDefaultBranch)
+	}
+}
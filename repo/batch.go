@@ -6,7 +6,7 @@ import (
 	"fmt"
 	"sync"
 
-	"github.com/google/go-github/v89/github"
+	"github.com/grokify/gogithub"
 	"github.com/grokify/gogithub/clientv1"
 	"github.com/grokify/gogithub/pathutil"
 )
@@ -73,12 +73,11 @@ var ErrEmptyPath = errors.New("empty path not allowed")
 //	fmt.Printf("Created commit: %s\n", sha)
 type Batch struct {
 	client     clientv1.Client
-	gh         *github.Client // extracted from client for git data API
 	owner      string
 	repo       string
 	branch     string
 	message    string
-	author     *github.CommitAuthor
+	author     *clientv1.CommitAuthor
 	operations []BatchOperation
 	committed  bool
 	mu         sync.Mutex
@@ -90,9 +89,9 @@ type BatchOption func(*Batch)
 // WithCommitAuthor sets the commit author for the batch.
 func WithCommitAuthor(name, email string) BatchOption {
 	return func(b *Batch) {
-		b.author = &github.CommitAuthor{
-			Name:  github.Ptr(name),
-			Email: github.Ptr(email),
+		b.author = &clientv1.CommitAuthor{
+			Name:  name,
+			Email: email,
 		}
 	}
 }
@@ -108,15 +107,8 @@ func NewBatch(ctx context.Context, client clientv1.Client, owner, repo, branch,
 		message = "Batch update"
 	}
 
-	// Extract raw github.Client for git data API operations
-	gh, ok := client.Raw().(*github.Client)
-	if !ok {
-		return nil, errors.New("batch requires a clientv1.Client backed by go-github")
-	}
-
 	b := &Batch{
 		client:     client,
-		gh:         gh,
 		owner:      owner,
 		repo:       repo,
 		branch:     branch,
@@ -239,20 +231,23 @@ func (b *Batch) Commit(ctx context.Context) (string, error) {
 	}
 
 	// Step 1: Get the current branch reference
-	ref, _, err := b.gh.Git.GetRef(ctx, b.owner, b.repo, RefHeadsPrefix+b.branch)
+	ref, err := b.client.GetRef(ctx, b.owner, b.repo, RefHeadsPrefix+b.branch)
 	if err != nil {
 		return "", &BatchError{Op: "get ref", Err: err}
 	}
 
-	currentCommitSHA := ref.Object.GetSHA()
+	currentCommitSHA := ref.SHA
 
 	// Step 2: Get the current commit to find the tree SHA
-	currentCommit, _, err := b.gh.Git.GetCommit(ctx, b.owner, b.repo, currentCommitSHA)
+	currentCommit, err := b.client.GetGitCommit(ctx, b.owner, b.repo, currentCommitSHA)
 	if err != nil {
 		return "", &BatchError{Op: "get commit", Err: err}
 	}
 
-	baseTreeSHA := currentCommit.Tree.GetSHA()
+	if currentCommit.Tree == nil {
+		return "", &BatchError{Op: "get commit", Err: fmt.Errorf("commit %s has no tree", currentCommitSHA)}
+	}
+	baseTreeSHA := currentCommit.Tree.SHA
 
 	// Step 3: Build tree entries for all operations
 	treeEntries, err := b.buildTreeEntries(ctx)
@@ -267,46 +262,34 @@ func (b *Batch) Commit(ctx context.Context) (string, error) {
 	}
 
 	// Step 4: Create the new tree
-	newTree, _, err := b.gh.Git.CreateTree(ctx, b.owner, b.repo, baseTreeSHA, treeEntries)
+	newTreeSHA, err := b.client.CreateTree(ctx, b.owner, b.repo, baseTreeSHA, treeEntries)
 	if err != nil {
 		return "", &BatchError{Op: "create tree", Err: err}
 	}
 
 	// Step 5: Create the new commit
-	commitOpts := github.Commit{
-		Message: github.Ptr(b.message),
-		Tree:    newTree,
-		Parents: []*github.Commit{{SHA: github.Ptr(currentCommitSHA)}},
-	}
-
-	if b.author != nil {
-		commitOpts.Author = b.author
-	}
-
-	newCommit, _, err := b.gh.Git.CreateCommit(ctx, b.owner, b.repo, commitOpts, nil)
+	newCommit, err := b.client.CreateCommit(ctx, b.owner, b.repo, &clientv1.CreateCommitOptions{
+		Message: b.message,
+		Tree:    newTreeSHA,
+		Parents: []string{currentCommitSHA},
+		Author:  b.author,
+	})
 	if err != nil {
 		return "", &BatchError{Op: "create commit", Err: err}
 	}
 
 	// Step 6: Update the branch reference
-	newCommitSHA := newCommit.GetSHA()
-	updateRef := github.UpdateRef{
-		SHA:   newCommitSHA,
-		Force: github.Ptr(false),
-	}
-
-	_, _, err = b.gh.Git.UpdateRef(ctx, b.owner, b.repo, ref.GetRef(), updateRef)
-	if err != nil {
+	if _, err := b.client.UpdateRef(ctx, b.owner, b.repo, ref.Ref, newCommit.SHA, false); err != nil {
 		return "", &BatchError{Op: "update ref", Err: err}
 	}
 
 	b.committed = true
-	return newCommitSHA, nil
+	return newCommit.SHA, nil
 }
 
-// buildTreeEntries creates GitHub tree entries for all operations.
-func (b *Batch) buildTreeEntries(ctx context.Context) ([]*github.TreeEntry, error) {
-	entries := make([]*github.TreeEntry, 0, len(b.operations))
+// buildTreeEntries creates tree entries for all operations.
+func (b *Batch) buildTreeEntries(ctx context.Context) ([]clientv1.TreeEntry, error) {
+	entries := make([]clientv1.TreeEntry, 0, len(b.operations))
 
 	for _, op := range b.operations {
 		if err := ctx.Err(); err != nil {
@@ -316,34 +299,30 @@ func (b *Batch) buildTreeEntries(ctx context.Context) ([]*github.TreeEntry, erro
 		switch op.Type {
 		case BatchOpWrite:
 			// Create a blob for the content
-			blob, _, err := b.gh.Git.CreateBlob(ctx, b.owner, b.repo, github.Blob{
-				Content:  github.Ptr(string(op.Content)),
-				Encoding: github.Ptr("utf-8"),
-			})
+			blobSHA, err := b.client.CreateBlob(ctx, b.owner, b.repo, op.Content, "utf-8")
 			if err != nil {
 				return nil, &BatchError{Op: "create blob", Err: err}
 			}
 
-			entries = append(entries, &github.TreeEntry{
-				Path: github.Ptr(op.Path),
-				Mode: github.Ptr(FileModeRegular),
-				Type: github.Ptr("blob"),
-				SHA:  blob.SHA,
+			entries = append(entries, clientv1.TreeEntry{
+				Path: op.Path,
+				Mode: FileModeRegular,
+				Type: "blob",
+				SHA:  blobSHA,
 			})
 
 		case BatchOpDelete:
 			// Check if the file exists first
-			exists, err := b.fileExists(ctx, op.Path)
+			exists, err := b.client.FileExists(ctx, b.owner, b.repo, op.Path, &gogithub.ContentOptions{Ref: b.branch})
 			if err != nil {
-				return nil, err
+				return nil, &BatchError{Op: "check file exists", Err: err}
 			}
 			if exists {
-				// Use a nil SHA to indicate deletion
-				entries = append(entries, &github.TreeEntry{
-					Path: github.Ptr(op.Path),
-					Mode: github.Ptr(FileModeRegular),
-					Type: github.Ptr("blob"),
-					SHA:  nil, // nil SHA means delete
+				entries = append(entries, clientv1.TreeEntry{
+					Path:   op.Path,
+					Mode:   FileModeRegular,
+					Type:   "blob",
+					Delete: true,
 				})
 			}
 			// If file doesn't exist, skip it (idempotent)
@@ -352,22 +331,3 @@ func (b *Batch) buildTreeEntries(ctx context.Context) ([]*github.TreeEntry, erro
 
 	return entries, nil
 }
-
-// fileExists checks if a file exists in the repository.
-func (b *Batch) fileExists(ctx context.Context, path string) (bool, error) {
-	_, _, resp, err := b.gh.Repositories.GetContents(ctx, b.owner, b.repo, path, &github.RepositoryContentGetOptions{
-		Ref: b.branch,
-	})
-	if err != nil {
-		if resp != nil && resp.StatusCode == 404 {
-			return false, nil
-		}
-		if errResp, ok := err.(*github.ErrorResponse); ok {
-			if errResp.Response != nil && errResp.Response.StatusCode == 404 {
-				return false, nil
-			}
-		}
-		return false, &BatchError{Op: "check file exists", Err: err}
-	}
-	return true, nil
-}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/grokify/gogithub/clientv1"
+	"github.com/grokify/gogithub/clientv1/fake"
 	"github.com/grokify/gogithub/pathutil"
 )
 
@@ -201,11 +202,11 @@ func TestWithCommitAuthor(t *testing.T) {
 	if batch.author == nil {
 		t.Fatal("author is nil")
 	}
-	if batch.author.GetName() != "Test User" {
-		t.Errorf("author.Name = %q, want %q", batch.author.GetName(), "Test User")
+	if batch.author.Name != "Test User" {
+		t.Errorf("author.Name = %q, want %q", batch.author.Name, "Test User")
 	}
-	if batch.author.GetEmail() != "test@example.com" {
-		t.Errorf("author.Email = %q, want %q", batch.author.GetEmail(), "test@example.com")
+	if batch.author.Email != "test@example.com" {
+		t.Errorf("author.Email = %q, want %q", batch.author.Email, "test@example.com")
 	}
 }
 
@@ -261,6 +262,65 @@ func TestNewBatchCanceledContext(t *testing.T) {
 	}
 }
 
+func TestBatchCommit(t *testing.T) {
+	ctx := context.Background()
+	fc := fake.NewClient()
+	fc.AddRepository("owner", "repo")
+
+	if _, err := fc.CreateFile(ctx, "owner", "repo", "old.txt", &clientv1.CreateFileOptions{
+		Content: []byte("old"),
+		Message: "Add old.txt",
+	}); err != nil {
+		t.Fatalf("CreateFile() error = %v", err)
+	}
+
+	batch, err := NewBatch(ctx, fc, "owner", "repo", fake.DefaultBranch, "Update files",
+		WithCommitAuthor("Bot", "bot@example.com"))
+	if err != nil {
+		t.Fatalf("NewBatch() error = %v", err)
+	}
+	if err := batch.Write("docs/new.txt", []byte("new")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := batch.Delete("old.txt"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := batch.Delete("missing.txt"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	sha, err := batch.Commit(ctx)
+	if err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	head, err := GetBranchSHA(ctx, fc, "owner", "repo", fake.DefaultBranch)
+	if err != nil {
+		t.Fatalf("GetBranchSHA() error = %v", err)
+	}
+	if head != sha {
+		t.Errorf("branch head = %q, want %q", head, sha)
+	}
+
+	commit, err := fc.GetGitCommit(ctx, "owner", "repo", sha)
+	if err != nil {
+		t.Fatalf("GetGitCommit() error = %v", err)
+	}
+	if commit.Author == nil || commit.Author.Name != "Bot" {
+		t.Errorf("commit author = %+v, want Bot", commit.Author)
+	}
+
+	got, err := fc.GetFileContentString(ctx, "owner", "repo", "docs/new.txt", nil)
+	if err != nil {
+		t.Fatalf("GetFileContentString() error = %v", err)
+	}
+	if got != "new" {
+		t.Errorf("docs/new.txt = %q, want %q", got, "new")
+	}
+	if exists, _ := fc.FileExists(ctx, "owner", "repo", "old.txt", nil); exists {
+		t.Error("old.txt exists after delete")
+	}
+}
+
 func TestBatchErrorUnwrap(t *testing.T) {
 	innerErr := errors.New("inner error")
 	batchErr := &BatchError{Op: "test", Err: innerErr}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/grokify/gogithub/clientv1"
+	"github.com/grokify/gogithub/errors"
 )
 
 // BranchError indicates a failure to create or update a branch.
@@ -31,7 +32,7 @@ func CreateBranch(ctx context.Context, client clientv1.Client, owner, repo, bran
 	_, err := client.CreateRef(ctx, owner, repo, RefHeadsPrefix+branch, baseSHA)
 	if err != nil {
 		// Check if branch already exists
-		if strings.Contains(err.Error(), ErrAlreadyExists) {
+		if errors.IsValidation(err) && strings.Contains(err.Error(), ErrAlreadyExists) {
 			return nil
 		}
 		return &BranchError{Branch: branch, Err: err}
@@ -49,20 +50,10 @@ func DeleteBranch(ctx context.Context, client clientv1.Client, owner, repo, bran
 func BranchExists(ctx context.Context, client clientv1.Client, owner, repo, branch string) (bool, error) {
 	_, err := client.GetRef(ctx, owner, repo, RefHeadsPrefix+branch)
 	if err != nil {
-		// Check if it's a 404 error
-		if isNotFoundError(err) {
+		if errors.IsNotFound(err) {
 			return false, nil
 		}
 		return false, err
 	}
 	return true, nil
 }
-
-// isNotFoundError checks if an error is a 404 not found error.
-func isNotFoundError(err error) bool {
-	if err == nil {
-		return false
-	}
-	errStr := err.Error()
-	return strings.Contains(errStr, "404") || strings.Contains(errStr, "not found")
-}
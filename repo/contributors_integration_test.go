@@ -2,22 +2,13 @@ package repo
 
 import (
 	"context"
-	"os"
 	"testing"
 
 	"github.com/grokify/gogithub/clientv1"
+	"github.com/grokify/gogithub/githubtest"
 )
 
-func getTestToken(t *testing.T) string {
-	token := os.Getenv("GITHUB_TOKEN")
-	if token == "" {
-		t.Skip("GITHUB_TOKEN not set, skipping integration test")
-	}
-	return token
-}
-
-func newTestClient(t *testing.T, token string) clientv1.Client {
-	ctx := context.Background()
+func newTestClient(t *testing.T, ctx context.Context, token string) clientv1.Client {
 	client, err := clientv1.NewClient(ctx, token)
 	if err != nil {
 		t.Fatalf("failed to create github client: %v", err)
@@ -26,10 +17,9 @@ func newTestClient(t *testing.T, token string) clientv1.Client {
 }
 
 func TestListContributorStatsIntegration(t *testing.T) {
-	token := getTestToken(t)
-	ctx := context.Background()
+	ctx, token, _ := githubtest.Integration(t)
 
-	client := newTestClient(t, token)
+	client := newTestClient(t, ctx, token)
 
 	// Use a well-known public repo
 	stats, err := ListContributorStats(ctx, client, "grokify", "gogithub")
@@ -56,10 +46,9 @@ func TestListContributorStatsIntegration(t *testing.T) {
 }
 
 func TestGetContributorStatsIntegration(t *testing.T) {
-	token := getTestToken(t)
-	ctx := context.Background()
+	ctx, token, _ := githubtest.Integration(t)
 
-	client := newTestClient(t, token)
+	client := newTestClient(t, ctx, token)
 
 	stats, err := GetContributorStats(ctx, client, "grokify", "gogithub", "grokify")
 	if err != nil {
@@ -86,10 +75,9 @@ func TestGetContributorStatsIntegration(t *testing.T) {
 }
 
 func TestGetContributorSummaryIntegration(t *testing.T) {
-	token := getTestToken(t)
-	ctx := context.Background()
+	ctx, token, _ := githubtest.Integration(t)
 
-	client := newTestClient(t, token)
+	client := newTestClient(t, ctx, token)
 
 	summary, err := GetContributorSummary(ctx, client, "grokify", "gogithub", "grokify")
 	if err != nil {
@@ -115,10 +103,9 @@ func TestGetContributorSummaryIntegration(t *testing.T) {
 }
 
 func TestGetContributorStatsNotFoundIntegration(t *testing.T) {
-	token := getTestToken(t)
-	ctx := context.Background()
+	ctx, token, _ := githubtest.Integration(t)
 
-	client := newTestClient(t, token)
+	client := newTestClient(t, ctx, token)
 
 	// Look for a user who is definitely not a contributor
 	stats, err := GetContributorStats(ctx, client, "grokify", "gogithub", "torvalds")
@@ -132,10 +119,9 @@ func TestGetContributorStatsNotFoundIntegration(t *testing.T) {
 }
 
 func TestListContributorStatsNonExistentRepoIntegration(t *testing.T) {
-	token := getTestToken(t)
-	ctx := context.Background()
+	ctx, token, _ := githubtest.Integration(t)
 
-	client := newTestClient(t, token)
+	client := newTestClient(t, ctx, token)
 
 	_, err := ListContributorStats(ctx, client, "grokify", "this-repo-does-not-exist-12345")
 	if err == nil {
@@ -146,10 +132,9 @@ func TestListContributorStatsNonExistentRepoIntegration(t *testing.T) {
 }
 
 func TestListContributorStatsLargeRepoIntegration(t *testing.T) {
-	token := getTestToken(t)
-	ctx := context.Background()
+	ctx, token, _ := githubtest.Integration(t)
 
-	client := newTestClient(t, token)
+	client := newTestClient(t, ctx, token)
 
 	// Test with a larger public repo (go-github itself)
 	stats, err := ListContributorStats(ctx, client, "google", "go-github")
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/grokify/gogithub/clientv1"
+	"github.com/grokify/gogithub/errors"
 )
 
 // ForkError indicates a failure to fork a repository.
@@ -34,7 +35,7 @@ func EnsureFork(ctx context.Context, client clientv1.Client, upstreamOwner, upst
 	}
 
 	// If not a 404, return the error
-	if !isNotFoundError(err) {
+	if !errors.IsNotFound(err) {
 		return "", "", &ForkError{Owner: upstreamOwner, Repo: upstreamRepo, Err: err}
 	}
 
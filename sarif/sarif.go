@@ -6,13 +6,11 @@ import (
 	"compress/gzip"
 	"context"
 	"encoding/base64"
-	"errors"
 	"fmt"
 	"io"
 	"os"
 	"time"
 
-	"github.com/google/go-github/v89/github"
 	"github.com/grokify/gogithub/clientv1"
 )
 
@@ -77,12 +75,6 @@ func Upload(ctx context.Context, client clientv1.Client, owner, repo string, sar
 		return nil, fmt.Errorf("Ref is required")
 	}
 
-	// Extract raw github.Client for CodeScanning API
-	gh, ok := client.Raw().(*github.Client)
-	if !ok {
-		return nil, errors.New("sarif upload requires a clientv1.Client backed by go-github")
-	}
-
 	// Compress with gzip
 	compressed, err := gzipCompress(sarifData)
 	if err != nil {
@@ -92,32 +84,22 @@ func Upload(ctx context.Context, client clientv1.Client, owner, repo string, sar
 	// Base64 encode
 	encoded := base64.StdEncoding.EncodeToString(compressed)
 
-	// Build the upload request
-	analysis := &github.SarifAnalysis{
-		CommitSHA: github.Ptr(opts.CommitSHA),
-		Ref:       github.Ptr(opts.Ref),
-		Sarif:     github.Ptr(encoded),
-	}
-
-	if opts.CheckoutURI != "" {
-		analysis.CheckoutURI = github.Ptr(opts.CheckoutURI)
-	}
-	if opts.ToolName != "" {
-		analysis.ToolName = github.Ptr(opts.ToolName)
-	}
-	if opts.StartedAt != nil {
-		analysis.StartedAt = &github.Timestamp{Time: *opts.StartedAt}
-	}
-
 	// Upload
-	sarifID, _, err := gh.CodeScanning.UploadSarif(ctx, owner, repo, analysis)
+	upload, err := client.UploadSARIF(ctx, owner, repo, &clientv1.UploadSARIFOptions{
+		CommitSHA:   opts.CommitSHA,
+		Ref:         opts.Ref,
+		Sarif:       encoded,
+		CheckoutURI: opts.CheckoutURI,
+		ToolName:    opts.ToolName,
+		StartedAt:   opts.StartedAt,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to upload SARIF: %w", err)
 	}
 
 	result := &UploadResult{
-		SarifID: sarifID.GetID(),
-		URL:     sarifID.GetURL(),
+		SarifID: upload.ID,
+		URL:     upload.URL,
 	}
 
 	return result, nil
@@ -151,20 +133,14 @@ type UploadStatus struct {
 //
 // GitHub API docs: https://docs.github.com/rest/code-scanning/code-scanning#get-information-about-a-sarif-upload
 func GetUploadStatus(ctx context.Context, client clientv1.Client, owner, repo, sarifID string) (*UploadStatus, error) {
-	// Extract raw github.Client for CodeScanning API
-	gh, ok := client.Raw().(*github.Client)
-	if !ok {
-		return nil, errors.New("sarif requires a clientv1.Client backed by go-github")
-	}
-
-	upload, _, err := gh.CodeScanning.GetSARIF(ctx, owner, repo, sarifID)
+	upload, err := client.GetSARIFUpload(ctx, owner, repo, sarifID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get SARIF status: %w", err)
 	}
 
 	return &UploadStatus{
-		Status:      ProcessingStatus(upload.GetProcessingStatus()),
-		AnalysesURL: upload.GetAnalysesURL(),
+		Status:      ProcessingStatus(upload.ProcessingStatus),
+		AnalysesURL: upload.AnalysesURL,
 	}, nil
 }
 
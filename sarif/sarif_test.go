@@ -2,7 +2,10 @@ package sarif
 
 import (
 	"bytes"
+	"context"
 	"testing"
+
+	"github.com/grokify/gogithub/clientv1/fake"
 )
 
 func TestGzipCompressDecompress(t *testing.T) {
@@ -246,3 +249,39 @@ func TestGzipCompressDecompressBinary(t *testing.T) {
 		t.Error("decompressed binary data should match original")
 	}
 }
+
+func TestUploadAndGetStatus(t *testing.T) {
+	ctx := context.Background()
+	fc := fake.NewClient()
+	fc.AddRepository("owner", "repo")
+
+	ref, err := fc.GetRef(ctx, "owner", "repo", "refs/heads/"+fake.DefaultBranch)
+	if err != nil {
+		t.Fatalf("GetRef() error = %v", err)
+	}
+
+	data := []byte(`{"version":"2.1.0","runs":[]}`)
+	result, err := Upload(ctx, fc, "owner", "repo", data, UploadOptions{
+		CommitSHA: ref.SHA,
+		Ref:       ref.Ref,
+		ToolName:  "test",
+	})
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	if result.SarifID == "" {
+		t.Error("SarifID is empty")
+	}
+
+	status, err := GetUploadStatus(ctx, fc, "owner", "repo", result.SarifID)
+	if err != nil {
+		t.Fatalf("GetUploadStatus() error = %v", err)
+	}
+	if status.Status != StatusComplete {
+		t.Errorf("Status = %q, want %q", status.Status, StatusComplete)
+	}
+
+	if _, err := GetUploadStatus(ctx, fc, "owner", "repo", "missing"); err == nil {
+		t.Error("GetUploadStatus(missing) error = nil, want error")
+	}
+}
@@ -54,6 +54,25 @@ func (iss Issues) TableSet() (*table.TableSet, error) {
 	return ts, nil
 }
 
+// WriteXLSX writes the issues as an Excel workbook with the sheets of
+// TableSet.
+func (iss Issues) WriteXLSX(path string) error {
+	ts, err := iss.TableSet()
+	if err != nil {
+		return err
+	}
+	return ts.WriteXLSX(path)
+}
+
+// WriteCSV writes the issues table as a CSV file.
+func (iss Issues) WriteCSV(path string) error {
+	tbl, err := iss.Table("Issues")
+	if err != nil {
+		return err
+	}
+	return tbl.WriteCSV(path)
+}
+
 // TableRepos creates a table of repositories with issue counts.
 func (iss Issues) TableRepos(name string, htmlURLs bool) *table.Table {
 	h := histogram.NewHistogram(name)
@@ -1,9 +1,13 @@
 package search
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/grokify/gocharts/v2/data/table"
 	"github.com/grokify/gogithub"
 )
 
@@ -344,3 +348,46 @@ func TestIssuesTableError(t *testing.T) {
 		t.Error("Table() should return error for issue with zero CreatedAt")
 	}
 }
+
+func TestIssuesWriteXLSXAndCSV(t *testing.T) {
+	issues := Issues{
+		&gogithub.Issue{
+			User:          &gogithub.User{Login: "grokify", ID: 12345},
+			Title:         "Test Issue",
+			HTMLURL:       "https://github.com/owner/repo/issues/1",
+			RepositoryURL: "https://api.github.com/repos/owner/repo",
+			State:         "open",
+			CreatedAt:     time.Now(),
+		},
+	}
+	dir := t.TempDir()
+
+	xlsxPath := filepath.Join(dir, "issues.xlsx")
+	if err := issues.WriteXLSX(xlsxPath); err != nil {
+		t.Fatalf("WriteXLSX() error: %v", err)
+	}
+	ts, err := table.ReadTableSetXLSXFile(xlsxPath, 1, true)
+	if err != nil {
+		t.Fatalf("ReadTableSetXLSXFile() error: %v", err)
+	}
+	if names := ts.TableNames(); len(names) != 2 || names[0] != "Issues" || names[1] != "Repositories" {
+		t.Errorf("sheets = %v, want [Issues Repositories]", names)
+	}
+
+	csvPath := filepath.Join(dir, "issues.csv")
+	if err := issues.WriteCSV(csvPath); err != nil {
+		t.Fatalf("WriteCSV() error: %v", err)
+	}
+	data, err := os.ReadFile(csvPath)
+	if err != nil {
+		t.Fatalf("os.ReadFile() error: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 2 || !strings.HasPrefix(lines[1], "grokify,12345,Test Issue,") {
+		t.Errorf("CSV = %q, want a header and a grokify row", data)
+	}
+
+	bad := Issues{&gogithub.Issue{User: &gogithub.User{Login: "grokify"}}}
+	if err := bad.WriteXLSX(filepath.Join(dir, "bad.xlsx")); err == nil {
+		t.Error("WriteXLSX() should return error for issue with zero CreatedAt")
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/grokify/gogithub"
 	"github.com/grokify/gogithub/clientv1"
+	"github.com/grokify/gogithub/errors"
 )
 
 // ListTags lists all tags for a repository.
@@ -38,8 +39,7 @@ func DeleteTag(ctx context.Context, client clientv1.Client, owner, repo, tagName
 func TagExists(ctx context.Context, client clientv1.Client, owner, repo, tagName string) (bool, error) {
 	_, err := client.GetRef(ctx, owner, repo, tagsPrefix+tagName)
 	if err != nil {
-		// Check if it's a 404 error
-		if isNotFoundError(err) {
+		if errors.IsNotFound(err) {
 			return false, nil
 		}
 		return false, err
@@ -60,27 +60,3 @@ func GetTagNames(ctx context.Context, client clientv1.Client, owner, repo string
 	}
 	return names, nil
 }
-
-// isNotFoundError checks if an error is a 404 not found error.
-func isNotFoundError(err error) bool {
-	if err == nil {
-		return false
-	}
-	// Check for common 404 error patterns
-	errStr := err.Error()
-	return contains(errStr, "404") || contains(errStr, "not found")
-}
-
-// contains is a simple substring check.
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsAt(s, substr))
-}
-
-func containsAt(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
-		}
-	}
-	return false
-}
@@ -1,6 +1,9 @@
 package gogithub
 
-import "time"
+import (
+	"net/http"
+	"time"
+)
 
 // User represents a GitHub user. This is a stable type that won't change
 // when go-github updates its major version.
@@ -439,6 +442,29 @@ type RateLimit struct {
 	Reset     time.Time
 }
 
+// Response describes a GitHub API response returned by clientv1's Do: its
+// status, headers, rate limit and pagination links.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+
+	// Rate is the rate limit reported by the response, or nil if it
+	// reported none.
+	Rate *RateLimit
+
+	// NextPage, PrevPage, FirstPage and LastPage are the page numbers in
+	// the Link header, or zero when absent.
+	NextPage  int
+	PrevPage  int
+	FirstPage int
+	LastPage  int
+
+	// NextURL is the URL of the next page in the Link header, or empty on
+	// the last page. Unlike NextPage, it is also set by cursor-paginated
+	// endpoints.
+	NextURL string
+}
+
 // WorkflowRun represents a run of a GitHub Actions workflow.
 type WorkflowRun struct {
 	ID         int64
@@ -455,3 +481,15 @@ type WorkflowRun struct {
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
 }
+
+// SARIFUpload identifies a SARIF analysis uploaded to Code Scanning.
+type SARIFUpload struct {
+	ID  string
+	URL string // API URL for the upload's processing status
+}
+
+// SARIFUploadStatus represents the processing status of a SARIF upload.
+type SARIFUploadStatus struct {
+	ProcessingStatus string // "pending", "complete", or "failed"
+	AnalysesURL      string // API URL for the analyses; set once complete
+}